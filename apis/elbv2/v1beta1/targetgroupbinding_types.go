@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -118,6 +119,12 @@ type TargetGroupBindingSpec struct {
 	// +kubebuilder:validation:MinLength=1
 	TargetGroupARN string `json:"targetGroupARN"`
 
+	// additionalTargetGroupARNs are the Amazon Resource Names (ARN) for additional TargetGroups that receive the same
+	// targets as targetGroupARN, e.g. weighted TargetGroups of ALB listener backed by the same service.
+	// they must have the same TargetType and VPC as targetGroupARN.
+	// +optional
+	AdditionalTargetGroupARNs []string `json:"additionalTargetGroupARNs,omitempty"`
+
 	// targetType is the TargetType of TargetGroup. If unspecified, it will be automatically inferred.
 	// +optional
 	TargetType *TargetType `json:"targetType,omitempty"`
@@ -132,6 +139,22 @@ type TargetGroupBindingSpec struct {
 	// node selector for instance type target groups to only register certain nodes
 	// +optional
 	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// assumeRoleARN is the ARN of IAM role to assume when managing targets of TargetGroup in another AWS account.
+	// +optional
+	AssumeRoleARN string `json:"assumeRoleARN,omitempty"`
+
+	// healthCheckGracePeriodSeconds is the duration since pod creation, during which unhealthy targets don't fail
+	// the pod readiness gate, which is set True with HealthCheckGracePeriod reason instead.
+	// it's only supported for ip TargetType.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HealthCheckGracePeriodSeconds *int64 `json:"healthCheckGracePeriodSeconds,omitempty"`
+
+	// requiredPodConditionType is the type of pod condition that must be true before pods are registered as targets.
+	// it's only supported for ip TargetType.
+	// +optional
+	RequiredPodConditionType *corev1.PodConditionType `json:"requiredPodConditionType,omitempty"`
 }
 
 // TargetGroupBindingStatus defines the observed state of TargetGroupBinding
@@ -139,6 +162,14 @@ type TargetGroupBindingStatus struct {
 	// The generation observed by the TargetGroupBinding controller.
 	// +optional
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// targetGroupARN is the Amazon Resource Name (ARN) of the TargetGroup targets are registered to.
+	// +optional
+	TargetGroupARN string `json:"targetGroupARN,omitempty"`
+
+	// targetCount is the number of targets registered to the TargetGroup, observed by the last successful reconcile.
+	// +optional
+	TargetCount *int64 `json:"targetCount,omitempty"`
 }
 
 // +kubebuilder:object:root=true
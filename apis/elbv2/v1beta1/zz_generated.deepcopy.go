@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -363,6 +364,11 @@ func (in *TargetGroupBindingNetworking) DeepCopy() *TargetGroupBindingNetworking
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetGroupBindingSpec) DeepCopyInto(out *TargetGroupBindingSpec) {
 	*out = *in
+	if in.AdditionalTargetGroupARNs != nil {
+		in, out := &in.AdditionalTargetGroupARNs, &out.AdditionalTargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TargetType != nil {
 		in, out := &in.TargetType, &out.TargetType
 		*out = new(TargetType)
@@ -379,6 +385,16 @@ func (in *TargetGroupBindingSpec) DeepCopyInto(out *TargetGroupBindingSpec) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HealthCheckGracePeriodSeconds != nil {
+		in, out := &in.HealthCheckGracePeriodSeconds, &out.HealthCheckGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RequiredPodConditionType != nil {
+		in, out := &in.RequiredPodConditionType, &out.RequiredPodConditionType
+		*out = new(corev1.PodConditionType)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingSpec.
@@ -399,6 +415,11 @@ func (in *TargetGroupBindingStatus) DeepCopyInto(out *TargetGroupBindingStatus)
 		*out = new(int64)
 		**out = **in
 	}
+	if in.TargetCount != nil {
+		in, out := &in.TargetCount, &out.TargetCount
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingStatus.
@@ -14,21 +14,25 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"time"
 )
 
 // NewEnqueueRequestsForEndpointsEvent constructs new enqueueRequestsForEndpointsEvent.
-func NewEnqueueRequestsForEndpointsEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+// TargetGroupBindings are enqueued after debounceWindow, so that a burst of events within debounceWindow triggers a single reconcile.
+func NewEnqueueRequestsForEndpointsEvent(k8sClient client.Client, debounceWindow time.Duration, logger logr.Logger) handler.EventHandler {
 	return &enqueueRequestsForEndpointsEvent{
-		k8sClient: k8sClient,
-		logger:    logger,
+		k8sClient:      k8sClient,
+		debounceWindow: debounceWindow,
+		logger:         logger,
 	}
 }
 
 var _ handler.EventHandler = (*enqueueRequestsForEndpointsEvent)(nil)
 
 type enqueueRequestsForEndpointsEvent struct {
-	k8sClient client.Client
-	logger    logr.Logger
+	k8sClient      client.Client
+	debounceWindow time.Duration
+	logger         logr.Logger
 }
 
 // Create is called in response to an create event - e.g. Pod Creation.
@@ -76,11 +80,16 @@ func (h *enqueueRequestsForEndpointsEvent) enqueueImpactedTargetGroupBindings(qu
 			"endpoints", epKey,
 			"targetGroupBinding", k8s.NamespacedName(&tgb),
 		)
-		queue.Add(reconcile.Request{
+		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: tgb.Namespace,
 				Name:      tgb.Name,
 			},
-		})
+		}
+		if h.debounceWindow > 0 {
+			queue.AddAfter(req, h.debounceWindow)
+		} else {
+			queue.Add(req)
+		}
 	}
 }
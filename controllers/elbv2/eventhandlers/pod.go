@@ -0,0 +1,131 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForPodEvent constructs new enqueueRequestsForPodEvent.
+func NewEnqueueRequestsForPodEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForPodEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForPodEvent)(nil)
+
+// enqueueRequestsForPodEvent enqueues IP TargetType TargetGroupBindings once a backend pod starts terminating,
+// so that the pod will be deregistered promptly without waiting for endpoints update.
+// It also enqueues IP TargetType TargetGroupBindings whose RequiredPodConditionType changed on a backend pod,
+// so that the pod will be registered once the condition becomes true.
+type enqueueRequestsForPodEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *enqueueRequestsForPodEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *enqueueRequestsForPodEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	podOld := e.ObjectOld.(*corev1.Pod)
+	podNew := e.ObjectNew.(*corev1.Pod)
+	if podOld.DeletionTimestamp == nil && podNew.DeletionTimestamp != nil {
+		h.enqueueImpactedTargetGroupBindings(queue, podNew, func(tgb *elbv2api.TargetGroupBinding) bool {
+			return true
+		})
+		return
+	}
+
+	changedConditionTypes := computeChangedPodConditionTypes(podOld, podNew)
+	if len(changedConditionTypes) == 0 {
+		return
+	}
+	h.enqueueImpactedTargetGroupBindings(queue, podNew, func(tgb *elbv2api.TargetGroupBinding) bool {
+		return tgb.Spec.RequiredPodConditionType != nil && changedConditionTypes.Has(string(*tgb.Spec.RequiredPodConditionType))
+	})
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *enqueueRequestsForPodEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForPodEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+// enqueueImpactedTargetGroupBindings enqueues IP TargetType TargetGroupBindings for services selecting the pod that matches the tgbFilter.
+func (h *enqueueRequestsForPodEvent) enqueueImpactedTargetGroupBindings(queue workqueue.RateLimitingInterface, pod *corev1.Pod,
+	tgbFilter func(tgb *elbv2api.TargetGroupBinding) bool) {
+	svcList := &corev1.ServiceList{}
+	if err := h.k8sClient.List(context.Background(), svcList, client.InNamespace(pod.Namespace)); err != nil {
+		h.logger.Error(err, "failed to fetch services")
+		return
+	}
+
+	podKey := k8s.NamespacedName(pod)
+	podLabels := labels.Set(pod.Labels)
+	for _, svc := range svcList.Items {
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(podLabels) {
+			continue
+		}
+
+		tgbList := &elbv2api.TargetGroupBindingList{}
+		if err := h.k8sClient.List(context.Background(), tgbList,
+			client.InNamespace(svc.Namespace),
+			client.MatchingFields{targetgroupbinding.IndexKeyServiceRefName: svc.Name}); err != nil {
+			h.logger.Error(err, "failed to fetch targetGroupBindings")
+			return
+		}
+		for _, tgb := range tgbList.Items {
+			if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP || !tgbFilter(&tgb) {
+				continue
+			}
+
+			h.logger.V(1).Info("enqueue targetGroupBinding for pod event",
+				"pod", podKey,
+				"targetGroupBinding", k8s.NamespacedName(&tgb),
+			)
+			queue.Add(reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: tgb.Namespace,
+					Name:      tgb.Name,
+				},
+			})
+		}
+	}
+}
+
+// computeChangedPodConditionTypes computes the condition types whose status changed between podOld and podNew.
+func computeChangedPodConditionTypes(podOld *corev1.Pod, podNew *corev1.Pod) sets.String {
+	oldConditionStatuses := make(map[corev1.PodConditionType]corev1.ConditionStatus, len(podOld.Status.Conditions))
+	for _, condition := range podOld.Status.Conditions {
+		oldConditionStatuses[condition.Type] = condition.Status
+	}
+	changedConditionTypes := sets.NewString()
+	for _, condition := range podNew.Status.Conditions {
+		if oldStatus, exists := oldConditionStatuses[condition.Type]; !exists || oldStatus != condition.Status {
+			changedConditionTypes.Insert(string(condition.Type))
+		}
+		delete(oldConditionStatuses, condition.Type)
+	}
+	for conditionType := range oldConditionStatuses {
+		changedConditionTypes.Insert(string(conditionType))
+	}
+	return changedConditionTypes
+}
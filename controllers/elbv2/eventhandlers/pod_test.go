@@ -0,0 +1,246 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_client "sigs.k8s.io/aws-load-balancer-controller/mocks/controller-runtime/client"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/testutils"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_enqueueRequestsForPodEvent_Update(t *testing.T) {
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	deletionTimestamp := metav1.Now()
+	warmedUp := corev1.PodConditionType("example.com/warmed-up")
+	otherCondType := corev1.PodConditionType("example.com/other")
+
+	type tgbListCall struct {
+		opts []client.ListOption
+		tgbs []*elbv2api.TargetGroupBinding
+	}
+	type fields struct {
+		svcs         []*corev1.Service
+		tgbListCalls []tgbListCall
+	}
+	type args struct {
+		podOld *corev1.Pod
+		podNew *corev1.Pod
+	}
+	podMeta := metav1.ObjectMeta{
+		Namespace: "awesome-ns",
+		Name:      "pod-1",
+		Labels:    map[string]string{"app": "awesome-app"},
+	}
+	terminatingPodMeta := *podMeta.DeepCopy()
+	terminatingPodMeta.DeletionTimestamp = &deletionTimestamp
+	podStatusWithWarmedUp := func(status corev1.ConditionStatus) corev1.PodStatus {
+		return corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: corev1.ConditionTrue,
+				},
+				{
+					Type:   warmedUp,
+					Status: status,
+				},
+			},
+		}
+	}
+	svcs := []*corev1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "awesome-app"}},
+		},
+	}
+	tgbListCallsWithRequiredPodCondition := []tgbListCall{
+		{
+			opts: []client.ListOption{
+				client.InNamespace("awesome-ns"),
+				client.MatchingFields{"spec.serviceRef.name": "awesome-svc"},
+			},
+			tgbs: []*elbv2api.TargetGroupBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "tgb-1"},
+					Spec:       elbv2api.TargetGroupBindingSpec{TargetType: &ipTargetType, RequiredPodConditionType: &warmedUp},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "tgb-2"},
+					Spec:       elbv2api.TargetGroupBindingSpec{TargetType: &ipTargetType, RequiredPodConditionType: &otherCondType},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "tgb-3"},
+					Spec:       elbv2api.TargetGroupBindingSpec{TargetType: &ipTargetType},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "tgb-4"},
+					Spec:       elbv2api.TargetGroupBindingSpec{TargetType: &instanceTargetType, RequiredPodConditionType: &warmedUp},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantRequests []ctrl.Request
+	}{
+		{
+			name: "terminating pod should enqueue impacted ip TargetType TGBs",
+			fields: fields{
+				svcs: []*corev1.Service{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc"},
+						Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "awesome-app"}},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "other-svc"},
+						Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "other-app"}},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "selectorless-svc"},
+					},
+				},
+				tgbListCalls: []tgbListCall{
+					{
+						opts: []client.ListOption{
+							client.InNamespace("awesome-ns"),
+							client.MatchingFields{"spec.serviceRef.name": "awesome-svc"},
+						},
+						tgbs: []*elbv2api.TargetGroupBinding{
+							{
+								ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "tgb-1"},
+								Spec:       elbv2api.TargetGroupBindingSpec{TargetType: &ipTargetType},
+							},
+							{
+								ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "tgb-2"},
+								Spec:       elbv2api.TargetGroupBindingSpec{TargetType: &instanceTargetType},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				podOld: &corev1.Pod{ObjectMeta: podMeta},
+				podNew: &corev1.Pod{ObjectMeta: terminatingPodMeta},
+			},
+			wantRequests: []ctrl.Request{
+				{
+					NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"},
+				},
+			},
+		},
+		{
+			name: "non-terminating pod update should be ignored",
+			args: args{
+				podOld: &corev1.Pod{ObjectMeta: podMeta},
+				podNew: &corev1.Pod{ObjectMeta: podMeta},
+			},
+			wantRequests: nil,
+		},
+		{
+			name: "already terminating pod update should be ignored",
+			args: args{
+				podOld: &corev1.Pod{ObjectMeta: terminatingPodMeta},
+				podNew: &corev1.Pod{ObjectMeta: terminatingPodMeta},
+			},
+			wantRequests: nil,
+		},
+		{
+			name: "required pod condition change should enqueue impacted ip TargetType TGBs requiring the condition",
+			fields: fields{
+				svcs:         svcs,
+				tgbListCalls: tgbListCallsWithRequiredPodCondition,
+			},
+			args: args{
+				podOld: &corev1.Pod{ObjectMeta: podMeta, Status: podStatusWithWarmedUp(corev1.ConditionFalse)},
+				podNew: &corev1.Pod{ObjectMeta: podMeta, Status: podStatusWithWarmedUp(corev1.ConditionTrue)},
+			},
+			wantRequests: []ctrl.Request{
+				{
+					NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"},
+				},
+			},
+		},
+		{
+			name: "required pod condition added should enqueue impacted ip TargetType TGBs requiring the condition",
+			fields: fields{
+				svcs:         svcs,
+				tgbListCalls: tgbListCallsWithRequiredPodCondition,
+			},
+			args: args{
+				podOld: &corev1.Pod{ObjectMeta: podMeta},
+				podNew: &corev1.Pod{ObjectMeta: podMeta, Status: podStatusWithWarmedUp(corev1.ConditionTrue)},
+			},
+			wantRequests: []ctrl.Request{
+				{
+					NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"},
+				},
+			},
+		},
+		{
+			name: "unchanged pod conditions should be ignored",
+			args: args{
+				podOld: &corev1.Pod{ObjectMeta: podMeta, Status: podStatusWithWarmedUp(corev1.ConditionTrue)},
+				podNew: &corev1.Pod{ObjectMeta: podMeta, Status: podStatusWithWarmedUp(corev1.ConditionTrue)},
+			},
+			wantRequests: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			k8sClient := mock_client.NewMockClient(ctrl)
+			if tt.fields.svcs != nil {
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), testutils.NewListOptionEquals(client.InNamespace("awesome-ns"))).DoAndReturn(
+					func(ctx context.Context, svcList *corev1.ServiceList, opts ...client.ListOption) error {
+						for _, svc := range tt.fields.svcs {
+							svcList.Items = append(svcList.Items, *(svc.DeepCopy()))
+						}
+						return nil
+					},
+				)
+			}
+			for _, call := range tt.fields.tgbListCalls {
+				call := call
+				var extraMatchers []interface{}
+				for _, opt := range call.opts {
+					extraMatchers = append(extraMatchers, testutils.NewListOptionEquals(opt))
+				}
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), extraMatchers...).DoAndReturn(
+					func(ctx context.Context, tgbList *elbv2api.TargetGroupBindingList, opts ...client.ListOption) error {
+						for _, tgb := range call.tgbs {
+							tgbList.Items = append(tgbList.Items, *(tgb.DeepCopy()))
+						}
+						return nil
+					},
+				)
+			}
+
+			h := &enqueueRequestsForPodEvent{
+				k8sClient: k8sClient,
+				logger:    &log.NullLogger{},
+			}
+			queue := controllertest.Queue{Interface: workqueue.New()}
+			h.Update(event.UpdateEvent{ObjectOld: tt.args.podOld, ObjectNew: tt.args.podNew}, queue)
+			gotRequests := testutils.ExtractCTRLRequestsFromQueue(queue)
+			assert.True(t, cmp.Equal(tt.wantRequests, gotRequests),
+				"diff", cmp.Diff(tt.wantRequests, gotRequests))
+		})
+	}
+}
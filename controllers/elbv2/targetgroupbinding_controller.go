@@ -24,6 +24,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2/eventhandlers"
@@ -43,7 +45,7 @@ import (
 
 const (
 	targetGroupBindingFinalizer = "elbv2.k8s.aws/resources"
-	controllerName              = "targetGroupBinding"
+	controllerName              = runtime.ControllerNameTargetGroupBinding
 )
 
 // NewTargetGroupBindingReconciler constructs new targetGroupBindingReconciler
@@ -58,8 +60,11 @@ func NewTargetGroupBindingReconciler(k8sClient client.Client, eventRecorder reco
 		tgbResourceManager: tgbResourceManager,
 		logger:             logger,
 
+		reconcileThrottler:         runtime.NewDefaultReconcileThrottler(config.MinReconcileInterval),
 		maxConcurrentReconciles:    config.TargetGroupBindingMaxConcurrentReconciles,
 		maxExponentialBackoffDelay: config.TargetGroupBindingMaxExponentialBackoffDelay,
+		enableEndpointSlices:       config.EnableEndpointSlices,
+		endpointsDebounceWindow:    config.TargetGroupBindingEndpointsDebounceWindow,
 	}
 }
 
@@ -71,8 +76,11 @@ type targetGroupBindingReconciler struct {
 	tgbResourceManager targetgroupbinding.ResourceManager
 	logger             logr.Logger
 
+	reconcileThrottler         runtime.ReconcileThrottler
 	maxConcurrentReconciles    int
 	maxExponentialBackoffDelay time.Duration
+	enableEndpointSlices       bool
+	endpointsDebounceWindow    time.Duration
 }
 
 // +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=get;list;watch;update;patch;create;delete
@@ -81,12 +89,16 @@ type targetGroupBindingReconciler struct {
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *targetGroupBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+	if wait := r.reconcileThrottler.Throttle(req); wait > 0 {
+		return runtime.HandleReconcileError(runtime.NewRequeueNeededAfter("ReconcileThrottled", wait), controllerName, r.logger)
+	}
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), controllerName, r.logger)
 }
 
 func (r *targetGroupBindingReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
@@ -106,13 +118,19 @@ func (r *targetGroupBindingReconciler) reconcileTargetGroupBinding(ctx context.C
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
-	if err := r.tgbResourceManager.Reconcile(ctx, tgb); err != nil {
-		return err
+	tgbOld := tgb.DeepCopy()
+	// targets are observed before requeue is requested, the requeue is deferred until status is updated.
+	reconcileErr := r.tgbResourceManager.Reconcile(ctx, tgb)
+	if reconcileErr != nil && !isRequeueNeededError(reconcileErr) {
+		return reconcileErr
 	}
-	if err := r.updateTargetGroupBindingStatus(ctx, tgb); err != nil {
+	if err := r.updateTargetGroupBindingStatus(ctx, tgb, tgbOld); err != nil {
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 		return err
 	}
+	if reconcileErr != nil {
+		return reconcileErr
+	}
 
 	r.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonSuccessfullyReconciled, "Successfully reconciled")
 	return nil
@@ -132,12 +150,20 @@ func (r *targetGroupBindingReconciler) cleanupTargetGroupBinding(ctx context.Con
 	return nil
 }
 
-func (r *targetGroupBindingReconciler) updateTargetGroupBindingStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	if aws.Int64Value(tgb.Status.ObservedGeneration) == tgb.Generation {
+// isRequeueNeededError checks whether err only requests requeue.
+func isRequeueNeededError(err error) bool {
+	var requeueNeededErr *runtime.RequeueNeeded
+	var requeueNeededAfterErr *runtime.RequeueNeededAfter
+	return errors.As(err, &requeueNeededErr) || errors.As(err, &requeueNeededAfterErr)
+}
+
+// updateTargetGroupBindingStatus patches the status of TargetGroupBinding if it differs from tgbOld,
+// which includes the targets observed by tgbResourceManager during reconcile.
+func (r *targetGroupBindingReconciler) updateTargetGroupBindingStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding, tgbOld *elbv2api.TargetGroupBinding) error {
+	tgb.Status.ObservedGeneration = aws.Int64(tgb.Generation)
+	if equality.Semantic.DeepEqual(tgb.Status, tgbOld.Status) {
 		return nil
 	}
-	tgbOld := tgb.DeepCopy()
-	tgb.Status.ObservedGeneration = aws.Int64(tgb.Generation)
 	if err := r.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
 		return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
 	}
@@ -151,16 +177,26 @@ func (r *targetGroupBindingReconciler) SetupWithManager(ctx context.Context, mgr
 
 	svcEventHandler := eventhandlers.NewEnqueueRequestsForServiceEvent(r.k8sClient,
 		r.logger.WithName("eventHandlers").WithName("service"))
-	epsEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointsEvent(r.k8sClient,
-		r.logger.WithName("eventHandlers").WithName("endpoints"))
 	nodeEventsHandler := eventhandlers.NewEnqueueRequestsForNodeEvent(r.k8sClient,
 		r.logger.WithName("eventHandlers").WithName("node"))
-	return ctrl.NewControllerManagedBy(mgr).
+	podEventsHandler := eventhandlers.NewEnqueueRequestsForPodEvent(r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("pod"))
+	blder := ctrl.NewControllerManagedBy(mgr).
 		For(&elbv2api.TargetGroupBinding{}).
 		Named(controllerName).
-		Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler).
-		Watches(&source.Kind{Type: &corev1.Endpoints{}}, epsEventsHandler).
+		Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler)
+	if r.enableEndpointSlices {
+		epSlicesEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointSlicesEvent(r.k8sClient, r.endpointsDebounceWindow,
+			r.logger.WithName("eventHandlers").WithName("endpointSlices"))
+		blder = blder.Watches(&source.Kind{Type: &discovery.EndpointSlice{}}, epSlicesEventsHandler)
+	} else {
+		epsEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointsEvent(r.k8sClient, r.endpointsDebounceWindow,
+			r.logger.WithName("eventHandlers").WithName("endpoints"))
+		blder = blder.Watches(&source.Kind{Type: &corev1.Endpoints{}}, epsEventsHandler)
+	}
+	return blder.
 		Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventsHandler).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, podEventsHandler).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.maxConcurrentReconciles,
 			RateLimiter:             workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, r.maxExponentialBackoffDelay)}).
@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	ctrlruntime "sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_targetGroupBindingReconciler_updateTargetGroupBindingStatus(t *testing.T) {
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890123456"
+	tests := []struct {
+		name              string
+		tgbStatus         elbv2api.TargetGroupBindingStatus
+		observedStatus    elbv2api.TargetGroupBindingStatus
+		wantStatus        elbv2api.TargetGroupBindingStatus
+		wantStatusPatched bool
+	}{
+		{
+			name:      "status populated with observed targets",
+			tgbStatus: elbv2api.TargetGroupBindingStatus{},
+			observedStatus: elbv2api.TargetGroupBindingStatus{
+				TargetGroupARN: tgARN,
+				TargetCount:    awssdk.Int64(3),
+			},
+			wantStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(3),
+			},
+			wantStatusPatched: true,
+		},
+		{
+			name: "status updated when target count changes",
+			tgbStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(3),
+			},
+			observedStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(0),
+			},
+			wantStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(0),
+			},
+			wantStatusPatched: true,
+		},
+		{
+			name: "status not patched when unchanged",
+			tgbStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(3),
+			},
+			observedStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(3),
+			},
+			wantStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(3),
+			},
+			wantStatusPatched: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "default",
+					Name:       "tgb",
+					Generation: 2,
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: tgARN,
+				},
+				Status: tt.tgbStatus,
+			}
+			assert.NoError(t, k8sClient.Create(ctx, tgb))
+			tgbOld := tgb.DeepCopy()
+			tgb.Status = *tt.observedStatus.DeepCopy()
+
+			r := &targetGroupBindingReconciler{
+				k8sClient: k8sClient,
+				logger:    &log.NullLogger{},
+			}
+			err := r.updateTargetGroupBindingStatus(ctx, tgb, tgbOld)
+			assert.NoError(t, err)
+
+			gotTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), gotTGB))
+			assert.Equal(t, tt.wantStatus, gotTGB.Status)
+			assert.Equal(t, tt.wantStatusPatched, gotTGB.ResourceVersion != tgbOld.ResourceVersion)
+		})
+	}
+}
+
+// stubResourceManager observes targets of TargetGroupBinding and returns reconcileErr.
+type stubResourceManager struct {
+	targetCount  int64
+	reconcileErr error
+}
+
+func (m *stubResourceManager) Reconcile(_ context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	tgb.Status.TargetGroupARN = tgb.Spec.TargetGroupARN
+	tgb.Status.TargetCount = awssdk.Int64(m.targetCount)
+	return m.reconcileErr
+}
+
+func (m *stubResourceManager) Cleanup(_ context.Context, _ *elbv2api.TargetGroupBinding) error {
+	return nil
+}
+
+func Test_targetGroupBindingReconciler_reconcileTargetGroupBinding(t *testing.T) {
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890123456"
+	tests := []struct {
+		name         string
+		reconcileErr error
+		wantErr      error
+		wantStatus   elbv2api.TargetGroupBindingStatus
+	}{
+		{
+			name: "status updated when reconciled",
+			wantStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(3),
+			},
+		},
+		{
+			name:         "status updated before requeue after duration",
+			reconcileErr: ctrlruntime.NewRequeueNeededAfter("monitor targetHealth", 15*time.Second),
+			wantErr:      ctrlruntime.NewRequeueNeededAfter("monitor targetHealth", 15*time.Second),
+			wantStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(3),
+			},
+		},
+		{
+			name:         "status updated before requeue",
+			reconcileErr: ctrlruntime.NewRequeueNeeded("monitor potential ready endpoints"),
+			wantErr:      ctrlruntime.NewRequeueNeeded("monitor potential ready endpoints"),
+			wantStatus: elbv2api.TargetGroupBindingStatus{
+				ObservedGeneration: awssdk.Int64(2),
+				TargetGroupARN:     tgARN,
+				TargetCount:        awssdk.Int64(3),
+			},
+		},
+		{
+			name:         "status not updated when reconcile failed",
+			reconcileErr: errors.New("some error"),
+			wantErr:      errors.New("some error"),
+			wantStatus:   elbv2api.TargetGroupBindingStatus{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			finalizerManager := k8s.NewMockFinalizerManager(ctrl)
+			finalizerManager.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), targetGroupBindingFinalizer).Return(nil)
+			ctx := context.Background()
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "default",
+					Name:       "tgb",
+					Generation: 2,
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: tgARN,
+				},
+			}
+			assert.NoError(t, k8sClient.Create(ctx, tgb))
+
+			r := &targetGroupBindingReconciler{
+				k8sClient:        k8sClient,
+				eventRecorder:    record.NewFakeRecorder(10),
+				finalizerManager: finalizerManager,
+				tgbResourceManager: &stubResourceManager{
+					targetCount:  3,
+					reconcileErr: tt.reconcileErr,
+				},
+				logger: &log.NullLogger{},
+			}
+			err := r.reconcileTargetGroupBinding(ctx, tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			gotTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), gotTGB))
+			assert.Equal(t, tt.wantStatus, gotTGB.Status)
+		})
+	}
+}
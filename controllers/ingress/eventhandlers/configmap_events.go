@@ -0,0 +1,71 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForConfigMapEvent constructs new enqueueRequestsForConfigMapEvent.
+func NewEnqueueRequestsForConfigMapEvent(ingEventChan chan<- event.GenericEvent,
+	k8sClient client.Client, logger logr.Logger) *enqueueRequestsForConfigMapEvent {
+	return &enqueueRequestsForConfigMapEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForConfigMapEvent)(nil)
+
+type enqueueRequestsForConfigMapEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForConfigMapEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	// configMap events are delivered as generic events by ConfigMapsManager.
+}
+
+func (h *enqueueRequestsForConfigMapEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	// configMap events are delivered as generic events by ConfigMapsManager.
+}
+
+func (h *enqueueRequestsForConfigMapEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	// configMap events are delivered as generic events by ConfigMapsManager.
+}
+
+func (h *enqueueRequestsForConfigMapEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	configMap := e.Object.(*corev1.ConfigMap)
+	h.enqueueImpactedIngresses(configMap)
+}
+
+func (h *enqueueRequestsForConfigMapEvent) enqueueImpactedIngresses(configMap *corev1.ConfigMap) {
+	configMapKey := k8s.NamespacedName(configMap)
+
+	ingList := &networking.IngressList{}
+	if err := h.k8sClient.List(context.Background(), ingList,
+		client.InNamespace(configMap.GetNamespace()),
+		client.MatchingFields{ingress.IndexKeyConfigMapRefName: configMap.GetName()}); err != nil {
+		h.logger.Error(err, "failed to fetch ingresses")
+		return
+	}
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+
+		h.logger.V(1).Info("enqueue ingress for configMap event",
+			"configMap", configMapKey,
+			"ingress", k8s.NamespacedName(ing))
+		h.ingEventChan <- event.GenericEvent{
+			Object: ing,
+		}
+	}
+}
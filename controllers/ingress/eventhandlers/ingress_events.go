@@ -41,9 +41,10 @@ func (h *enqueueRequestsForIngressEvent) Update(e event.UpdateEvent, queue workq
 	ingNew := e.ObjectNew.(*networking.Ingress)
 
 	// we only care below update event:
-	//	1. Ingress annotation updates
+	//	1. Ingress annotation updates, including bumps of the reconcile-trigger annotation
 	//	2. Ingress spec updates
 	//	3. Ingress deletion
+	// status updates made by the controller itself are ignored to avoid update loops.
 	if equality.Semantic.DeepEqual(ingOld.Annotations, ingNew.Annotations) &&
 		equality.Semantic.DeepEqual(ingOld.Spec, ingNew.Spec) &&
 		equality.Semantic.DeepEqual(ingOld.DeletionTimestamp.IsZero(), ingNew.DeletionTimestamp.IsZero()) {
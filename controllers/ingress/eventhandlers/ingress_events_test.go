@@ -0,0 +1,115 @@
+package eventhandlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/testutils"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// implicitGroupLoader is a GroupLoader that places every Ingress into its own implicit group.
+type implicitGroupLoader struct{}
+
+func (l *implicitGroupLoader) Load(_ context.Context, groupID ingress.GroupID) (ingress.Group, error) {
+	return ingress.Group{ID: groupID}, nil
+}
+
+func (l *implicitGroupLoader) LoadGroupIDIfAny(_ context.Context, ing *networking.Ingress) (*ingress.GroupID, error) {
+	groupID := ingress.NewGroupIDForImplicitGroup(k8s.NamespacedName(ing))
+	return &groupID, nil
+}
+
+func (l *implicitGroupLoader) LoadGroupIDsPendingFinalization(_ context.Context, _ *networking.Ingress) []ingress.GroupID {
+	return nil
+}
+
+func Test_enqueueRequestsForIngressEvent_Update(t *testing.T) {
+	tests := []struct {
+		name         string
+		ingOld       *networking.Ingress
+		ingNew       *networking.Ingress
+		wantRequests []ctrl.Request
+	}{
+		{
+			name: "reconcile-trigger annotation bump should be enqueued",
+			ingOld: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"aws-load-balancer-controller.k8s.aws/reconcile-trigger": "1",
+					},
+				},
+			},
+			ingNew: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"aws-load-balancer-controller.k8s.aws/reconcile-trigger": "2",
+					},
+				},
+			},
+			wantRequests: []ctrl.Request{
+				{
+					NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "awesome-ing"},
+				},
+			},
+		},
+		{
+			name: "status update should be ignored",
+			ingOld: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"aws-load-balancer-controller.k8s.aws/reconcile-trigger": "1",
+					},
+				},
+			},
+			ingNew: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"aws-load-balancer-controller.k8s.aws/reconcile-trigger": "1",
+					},
+				},
+				Status: networking.IngressStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{
+							{
+								Hostname: "awesome-alb.us-west-2.elb.amazonaws.com",
+							},
+						},
+					},
+				},
+			},
+			wantRequests: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewEnqueueRequestsForIngressEvent(&implicitGroupLoader{}, record.NewFakeRecorder(10), &log.NullLogger{})
+			queue := controllertest.Queue{Interface: workqueue.New()}
+			h.Update(event.UpdateEvent{ObjectOld: tt.ingOld, ObjectNew: tt.ingNew}, queue)
+			gotRequests := testutils.ExtractCTRLRequestsFromQueue(queue)
+			assert.True(t, cmp.Equal(tt.wantRequests, gotRequests),
+				"diff", cmp.Diff(tt.wantRequests, gotRequests))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
@@ -16,6 +17,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	ec2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
 	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
@@ -29,15 +31,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 )
 
 const (
 	ingressTagPrefix = "ingress.k8s.aws"
-	controllerName   = "ingress"
+	controllerName   = runtime.ControllerNameIngress
 
 	// the groupVersion of used Ingress & IngressClass resource.
 	ingressResourcesGroupVersion = "networking.k8s.io/v1beta1"
 	ingressClassKind             = "IngressClass"
+
+	// the interval to re-evaluate target health of actions with fixed response on no healthy targets.
+	noHealthyTargetsRequeueInterval = 30 * time.Second
+	// the interval to check newly created load balancers that are still provisioning.
+	lbProvisioningRequeueInterval = 15 * time.Second
 )
 
 // NewGroupReconciler constructs new GroupReconciler
@@ -48,24 +56,30 @@ func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder
 
 	annotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
 	authConfigBuilder := ingress.NewDefaultAuthConfigBuilder(annotationParser)
-	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, authConfigBuilder)
-	referenceIndexer := ingress.NewDefaultReferenceIndexer(enhancedBackendBuilder, authConfigBuilder, logger)
+	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(k8sClient, eventRecorder, annotationParser, authConfigBuilder,
+		config.IngressConfig.SkipMissingBackends)
+	referenceIndexer := ingress.NewDefaultReferenceIndexer(enhancedBackendBuilder, authConfigBuilder, annotationParser, logger)
 	trackingProvider := tracking.NewDefaultProvider(ingressTagPrefix, config.ClusterName)
 	elbv2TaggingManager := elbv2deploy.NewDefaultTaggingManager(cloud.ELBV2(), logger)
+	namespaceTagsResolver := k8s.NewDefaultNamespaceTagsResolver(k8sClient, config.NamespaceLabelTagPrefixes, logger)
 	modelBuilder := ingress.NewDefaultModelBuilder(k8sClient, eventRecorder,
 		cloud.EC2(), cloud.ACM(),
 		annotationParser, subnetsResolver,
-		authConfigBuilder, enhancedBackendBuilder, trackingProvider, elbv2TaggingManager,
+		authConfigBuilder, enhancedBackendBuilder, trackingProvider, elbv2TaggingManager, namespaceTagsResolver,
 		cloud.VpcID(), config.ClusterName, config.DefaultTags, config.ExternalManagedTags,
-		config.DefaultSSLPolicy, logger)
+		config.DefaultSSLPolicy, config.IngressConfig.MaxListenerRules, config.IngressConfig.DuplicateRulePolicy, logger)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler,
 		config, ingressTagPrefix, logger)
 	classLoader := ingress.NewDefaultClassLoader(k8sClient)
 	classAnnotationMatcher := ingress.NewDefaultClassAnnotationMatcher(config.IngressConfig.IngressClass)
-	manageIngressesWithoutIngressClass := config.IngressConfig.IngressClass == ""
+	manageIngressesWithoutIngressClass := config.IngressConfig.ManageIngressesWithoutIngressClass()
 	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, eventRecorder, annotationParser, classLoader, classAnnotationMatcher, manageIngressesWithoutIngressClass)
 	groupFinalizerManager := ingress.NewDefaultFinalizerManager(finalizerManager)
+	var lbProvisioningTracker elbv2deploy.LoadBalancerProvisioningTracker
+	if config.LoadBalancerProvisioningTimeout > 0 {
+		lbProvisioningTracker = elbv2deploy.NewDefaultLoadBalancerProvisioningTracker(config.LoadBalancerProvisioningTimeout, logger)
+	}
 
 	return &groupReconciler{
 		k8sClient:        k8sClient,
@@ -75,10 +89,12 @@ func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder
 		stackMarshaller:  stackMarshaller,
 		stackDeployer:    stackDeployer,
 
+		lbProvisioningTracker: lbProvisioningTracker,
 		groupLoader:           groupLoader,
 		groupFinalizerManager: groupFinalizerManager,
 		logger:                logger,
 
+		reconcileThrottler:      runtime.NewDefaultReconcileThrottler(config.MinReconcileInterval),
 		maxConcurrentReconciles: config.IngressConfig.MaxConcurrentReconciles,
 	}
 }
@@ -92,10 +108,13 @@ type groupReconciler struct {
 	stackMarshaller  deploy.StackMarshaller
 	stackDeployer    deploy.StackDeployer
 
+	lbProvisioningTracker elbv2deploy.LoadBalancerProvisioningTracker
+	configMapsManager     k8s.ConfigMapsManager
 	groupLoader           ingress.GroupLoader
 	groupFinalizerManager ingress.FinalizerManager
 	logger                logr.Logger
 
+	reconcileThrottler      runtime.ReconcileThrottler
 	maxConcurrentReconciles int
 }
 
@@ -107,10 +126,14 @@ type groupReconciler struct {
 // +kubebuilder:rbac:groups=extensions,resources=ingresses/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *groupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+	if wait := r.reconcileThrottler.Throttle(req); wait > 0 {
+		return runtime.HandleReconcileError(runtime.NewRequeueNeededAfter("ReconcileThrottled", wait), controllerName, r.logger)
+	}
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), controllerName, r.logger)
 }
 
 func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
@@ -120,17 +143,26 @@ func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error
 		return err
 	}
 
+	r.configMapsManager.MonitorConfigMaps(ingGroupID.String(), r.buildConfigMapRefs(ctx, ingGroup))
+
 	if err := r.groupFinalizerManager.AddGroupFinalizer(ctx, ingGroupID, ingGroup.Members); err != nil {
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
 
-	_, lb, err := r.buildAndDeployModel(ctx, ingGroup)
-	if err != nil {
+	stack, lb, err := r.buildAndDeployModel(ctx, ingGroup)
+	// the stack is deployed when requeue is requested, the requeue is deferred until the reconcile finishes.
+	var deployRequeueErr *runtime.RequeueNeededAfter
+	if err != nil && !errors.As(err, &deployRequeueErr) {
 		return err
 	}
 
+	lbProvisioning := false
 	if len(ingGroup.Members) > 0 && lb != nil {
+		lbProvisioning, err = r.checkLoadBalancerProvisioning(ctx, ingGroup, lb)
+		if err != nil {
+			return err
+		}
 		lbDNS, err := lb.DNSName().Resolve(ctx)
 		if err != nil {
 			return err
@@ -149,12 +181,58 @@ func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error
 	}
 
 	r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	if deployRequeueErr != nil {
+		return deployRequeueErr
+	}
+	if lbProvisioning {
+		return runtime.NewRequeueNeededAfter("LoadBalancerProvisioning", lbProvisioningRequeueInterval)
+	}
+	// target health changes don't trigger reconcile, so actions depending on target health are re-evaluated periodically.
+	if stack != nil && hasNoHealthyTargetsFixedResponse(stack) {
+		return runtime.NewRequeueNeededAfter("NoHealthyTargetsFixedResponse", noHealthyTargetsRequeueInterval)
+	}
 	return nil
 }
 
+// buildConfigMapRefs returns the ConfigMaps referenced by active members of ingGroup.
+func (r *groupReconciler) buildConfigMapRefs(ctx context.Context, ingGroup ingress.Group) []types.NamespacedName {
+	var configMapRefs []types.NamespacedName
+	for _, member := range ingGroup.Members {
+		for _, configMapName := range r.referenceIndexer.BuildConfigMapRefIndexes(ctx, member.Ing) {
+			configMapRefs = append(configMapRefs, types.NamespacedName{Namespace: member.Ing.Namespace, Name: configMapName})
+		}
+	}
+	return configMapRefs
+}
+
+// hasNoHealthyTargetsFixedResponse checks whether any forward action in stack serves fixed response on no healthy targets.
+func hasNoHealthyTargetsFixedResponse(stack core.Stack) bool {
+	var actions []elbv2model.Action
+	var resLSs []*elbv2model.Listener
+	stack.ListResources(&resLSs)
+	for _, ls := range resLSs {
+		actions = append(actions, ls.Spec.DefaultActions...)
+	}
+	var resLRs []*elbv2model.ListenerRule
+	stack.ListResources(&resLRs)
+	for _, lr := range resLRs {
+		actions = append(actions, lr.Spec.Actions...)
+	}
+	for _, action := range actions {
+		if action.ForwardConfig != nil && action.ForwardConfig.NoHealthyTargetsFixedResponseConfig != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingress.Group) (core.Stack, *elbv2model.LoadBalancer, error) {
 	stack, lb, err := r.modelBuilder.Build(ctx, ingGroup)
 	if err != nil {
+		var insufficientSubnetsErr *networkingpkg.InsufficientSubnetsError
+		if errors.As(err, &insufficientSubnetsErr) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonInsufficientSubnets, insufficientSubnetsErr.Error())
+		}
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return nil, nil, err
 	}
@@ -165,12 +243,57 @@ func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingr
 	}
 	r.logger.Info("successfully built model", "model", stackJSON)
 
-	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
+	// deployment requests requeue once the stack is deployed when cleanup is pending, e.g. targets deregistration of orphaned targetGroups.
+	var deployRequeueErr *runtime.RequeueNeededAfter
+	if err := r.stackDeployer.Deploy(ctx, stack); err != nil && !errors.As(err, &deployRequeueErr) {
+		// securityGroup deletion is retried later when it's still referenced by other resources.
+		// the event is recorded on inactive members as well, since they are the ones waiting for finalizer removal.
+		var sgDeletionDelayedErr *ec2deploy.SecurityGroupDeletionDelayedError
+		if errors.As(err, &sgDeletionDelayedErr) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonSecurityGroupDeletionDelayed, sgDeletionDelayedErr.Error())
+			for _, inactiveMember := range ingGroup.InactiveMembers {
+				r.eventRecorder.Event(inactiveMember, corev1.EventTypeWarning, k8s.IngressEventReasonSecurityGroupDeletionDelayed, sgDeletionDelayedErr.Error())
+			}
+			return nil, nil, runtime.NewRequeueNeededAfter("SecurityGroupDeletionDelayed", sgDeletionDelayedErr.Delay)
+		}
+		// changes to immutable fields cannot be applied in place, the dedicated event explains recreation is required.
+		var immutableFieldChangeErr *elbv2deploy.ImmutableFieldChangeError
+		if errors.As(err, &immutableFieldChangeErr) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonImmutableFieldChange, immutableFieldChangeErr.Error())
+		}
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
 		return nil, nil, err
 	}
 	r.logger.Info("successfully deployed model", "ingressGroup", ingGroup.ID)
-	return stack, lb, err
+	var resLSs []*elbv2model.Listener
+	stack.ListResources(&resLSs)
+	for _, ls := range resLSs {
+		if ls.Status != nil && ls.Status.Recreated {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonListenerRecreated,
+				fmt.Sprintf("Listener on port %v is recreated since the changes cannot be applied in place, arn=%v", ls.Spec.Port, ls.Status.ListenerARN))
+		}
+	}
+	if deployRequeueErr != nil {
+		return stack, lb, deployRequeueErr
+	}
+	return stack, lb, nil
+}
+
+// checkLoadBalancerProvisioning checks whether newly created LoadBalancer is still provisioning, with progress events emitted.
+// reconcile isn't blocked by provisioning, instead requeue is requested while the LoadBalancer is still provisioning.
+func (r *groupReconciler) checkLoadBalancerProvisioning(ctx context.Context, ingGroup ingress.Group, lb *elbv2model.LoadBalancer) (bool, error) {
+	if r.lbProvisioningTracker == nil || lb.Status == nil {
+		return false, nil
+	}
+	provisioning, err := r.lbProvisioningTracker.TrackState(lb.Status.LoadBalancerARN, lb.Status.State)
+	if err != nil {
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedProvisionLoadBalancer, fmt.Sprintf("Failed provision load balancer due to %v", err))
+		return false, err
+	}
+	if provisioning {
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonLoadBalancerProvisioning, fmt.Sprintf("LB provisioning, state=%v", lb.Status.State))
+	}
+	return provisioning, nil
 }
 
 func (r *groupReconciler) recordIngressGroupEvent(_ context.Context, ingGroup ingress.Group, eventType string, reason string, message string) {
@@ -222,7 +345,7 @@ func (r *groupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager
 	if err := r.setupIndexes(ctx, mgr.GetFieldIndexer(), ingressClassResourceAvailable); err != nil {
 		return err
 	}
-	if err := r.setupWatches(ctx, c, ingressClassResourceAvailable); err != nil {
+	if err := r.setupWatches(ctx, c, clientSet, ingressClassResourceAvailable); err != nil {
 		return err
 	}
 	return nil
@@ -250,6 +373,13 @@ func (r *groupReconciler) setupIndexes(ctx context.Context, fieldIndexer client.
 	); err != nil {
 		return err
 	}
+	if err := fieldIndexer.IndexField(ctx, &networking.Ingress{}, ingress.IndexKeyConfigMapRefName,
+		func(obj client.Object) []string {
+			return r.referenceIndexer.BuildConfigMapRefIndexes(context.Background(), obj.(*networking.Ingress))
+		},
+	); err != nil {
+		return err
+	}
 	if ingressClassResourceAvailable {
 		if err := fieldIndexer.IndexField(ctx, &networking.IngressClass{}, ingress.IndexKeyIngressClassParamsRefName,
 			func(obj client.Object) []string {
@@ -269,7 +399,7 @@ func (r *groupReconciler) setupIndexes(ctx context.Context, fieldIndexer client.
 	return nil
 }
 
-func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controller, ingressClassResourceAvailable bool) error {
+func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controller, clientSet kubernetes.Interface, ingressClassResourceAvailable bool) error {
 	ingEventChan := make(chan event.GenericEvent)
 	svcEventChan := make(chan event.GenericEvent)
 	ingEventHandler := eventhandlers.NewEnqueueRequestsForIngressEvent(r.groupLoader, r.eventRecorder,
@@ -278,6 +408,10 @@ func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controlle
 		r.logger.WithName("eventHandlers").WithName("service"))
 	secretEventHandler := eventhandlers.NewEnqueueRequestsForSecretEvent(ingEventChan, svcEventChan, r.k8sClient, r.eventRecorder,
 		r.logger.WithName("eventHandlers").WithName("secret"))
+	configMapEventChan := make(chan event.GenericEvent)
+	configMapEventHandler := eventhandlers.NewEnqueueRequestsForConfigMapEvent(ingEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("configMap"))
+	r.configMapsManager = k8s.NewDefaultConfigMapsManager(clientSet, configMapEventChan, r.logger.WithName("configMapsManager"))
 	if err := c.Watch(&source.Channel{Source: ingEventChan}, ingEventHandler); err != nil {
 		return err
 	}
@@ -293,6 +427,9 @@ func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controlle
 	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, secretEventHandler); err != nil {
 		return err
 	}
+	if err := c.Watch(&source.Channel{Source: configMapEventChan}, configMapEventHandler); err != nil {
+		return err
+	}
 
 	if ingressClassResourceAvailable {
 		ingClassEventChan := make(chan event.GenericEvent)
@@ -24,13 +24,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strings"
+	"time"
 )
 
 const (
-	serviceFinalizer        = "service.k8s.aws/resources"
+	serviceFinalizer        = service.ServiceFinalizer
 	serviceTagPrefix        = "service.k8s.aws"
 	serviceAnnotationPrefix = "service.beta.kubernetes.io"
-	controllerName          = "service"
+	controllerName          = runtime.ControllerNameService
+
+	// serviceAnnotationZonalDNSNames is the annotation that surfaces the comma-separated zonal DNS names of the load balancer.
+	serviceAnnotationZonalDNSNames = "service.k8s.aws/zonal-dns-names"
+
+	// the interval to check newly created load balancers that are still provisioning.
+	lbProvisioningRequeueInterval = 15 * time.Second
 )
 
 func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder,
@@ -41,10 +49,16 @@ func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorde
 	annotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
 	trackingProvider := tracking.NewDefaultProvider(serviceTagPrefix, config.ClusterName)
 	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(cloud.ELBV2(), logger)
-	modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, vpcResolver, trackingProvider,
-		elbv2TaggingManager, config.ClusterName, config.DefaultTags, config.ExternalManagedTags, config.DefaultSSLPolicy)
+	namespaceTagsResolver := k8s.NewDefaultNamespaceTagsResolver(k8sClient, config.NamespaceLabelTagPrefixes, logger)
+	modelBuilder := service.NewDefaultModelBuilder(annotationParser, eventRecorder, subnetsResolver, vpcResolver, trackingProvider,
+		elbv2TaggingManager, namespaceTagsResolver, config.ClusterName, config.DefaultTags, config.ExternalManagedTags, config.DefaultSSLPolicy,
+		config.ServiceDefaultHealthCheckPath, logger)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler, config, serviceTagPrefix, logger)
+	var lbProvisioningTracker elbv2.LoadBalancerProvisioningTracker
+	if config.LoadBalancerProvisioningTimeout > 0 {
+		lbProvisioningTracker = elbv2.NewDefaultLoadBalancerProvisioningTracker(config.LoadBalancerProvisioningTimeout, logger)
+	}
 	return &serviceReconciler{
 		k8sClient:        k8sClient,
 		eventRecorder:    eventRecorder,
@@ -56,6 +70,9 @@ func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorde
 		stackDeployer:   stackDeployer,
 		logger:          logger,
 
+		lbProvisioningTracker: lbProvisioningTracker,
+
+		reconcileThrottler:      runtime.NewDefaultReconcileThrottler(config.MinReconcileInterval),
 		maxConcurrentReconciles: config.ServiceMaxConcurrentReconciles,
 	}
 }
@@ -71,6 +88,9 @@ type serviceReconciler struct {
 	stackDeployer   deploy.StackDeployer
 	logger          logr.Logger
 
+	lbProvisioningTracker elbv2.LoadBalancerProvisioningTracker
+
+	reconcileThrottler      runtime.ReconcileThrottler
 	maxConcurrentReconciles int
 }
 
@@ -79,7 +99,10 @@ type serviceReconciler struct {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *serviceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+	if wait := r.reconcileThrottler.Throttle(req); wait > 0 {
+		return runtime.HandleReconcileError(runtime.NewRequeueNeededAfter("ReconcileThrottled", wait), controllerName, r.logger)
+	}
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), controllerName, r.logger)
 }
 
 func (r *serviceReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
@@ -87,6 +110,18 @@ func (r *serviceReconciler) reconcile(ctx context.Context, req ctrl.Request) err
 	if err := r.k8sClient.Get(ctx, req.NamespacedName, svc); err != nil {
 		return client.IgnoreNotFound(err)
 	}
+	if service.IsServiceIgnored(svc) {
+		r.logger.V(1).Info("ignoring service", "service", k8s.NamespacedName(svc))
+		// AWS resources are left untouched, only the finalizer is removed so that it doesn't block deletion of the service.
+		if !k8s.HasFinalizer(svc, serviceFinalizer) {
+			return nil
+		}
+		if err := r.finalizerManager.RemoveFinalizers(ctx, svc, serviceFinalizer); err != nil {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
+			return err
+		}
+		return nil
+	}
 	if !svc.DeletionTimestamp.IsZero() {
 		return r.cleanupLoadBalancerResources(ctx, svc)
 	}
@@ -96,6 +131,14 @@ func (r *serviceReconciler) reconcile(ctx context.Context, req ctrl.Request) err
 func (r *serviceReconciler) buildAndDeployModel(ctx context.Context, svc *corev1.Service) (core.Stack, *elbv2model.LoadBalancer, error) {
 	stack, lb, err := r.modelBuilder.Build(ctx, svc)
 	if err != nil {
+		var insufficientSubnetsErr *networking.InsufficientSubnetsError
+		if errors.As(err, &insufficientSubnetsErr) {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonInsufficientSubnets, insufficientSubnetsErr.Error())
+		}
+		var noServicePortsErr *service.NoServicePortsError
+		if errors.As(err, &noServicePortsErr) {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonNoServicePorts, noServicePortsErr.Error())
+		}
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return nil, nil, err
 	}
@@ -106,12 +149,30 @@ func (r *serviceReconciler) buildAndDeployModel(ctx context.Context, svc *corev1
 	}
 	r.logger.Info("successfully built model", "model", stackJSON)
 
-	if err = r.stackDeployer.Deploy(ctx, stack); err != nil {
+	// deployment requests requeue once the stack is deployed when cleanup is pending, e.g. targets deregistration of orphaned targetGroups.
+	var deployRequeueErr *runtime.RequeueNeededAfter
+	if err = r.stackDeployer.Deploy(ctx, stack); err != nil && !errors.As(err, &deployRequeueErr) {
+		// changes to immutable fields cannot be applied in place, the dedicated event explains recreation is required.
+		var immutableFieldChangeErr *elbv2.ImmutableFieldChangeError
+		if errors.As(err, &immutableFieldChangeErr) {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonImmutableFieldChange, immutableFieldChangeErr.Error())
+		}
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
 		return nil, nil, err
 	}
 	r.logger.Info("successfully deployed model", "service", k8s.NamespacedName(svc))
+	var resLSs []*elbv2model.Listener
+	stack.ListResources(&resLSs)
+	for _, ls := range resLSs {
+		if ls.Status != nil && ls.Status.Recreated {
+			r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonListenerRecreated,
+				fmt.Sprintf("Listener on port %v is recreated since the changes cannot be applied in place, arn=%v", ls.Spec.Port, ls.Status.ListenerARN))
+		}
+	}
 
+	if deployRequeueErr != nil {
+		return stack, lb, deployRequeueErr
+	}
 	return stack, lb, nil
 }
 
@@ -121,6 +182,16 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 		return err
 	}
 	_, lb, err := r.buildAndDeployModel(ctx, svc)
+	// the stack is deployed when requeue is requested, the requeue is deferred until the reconcile finishes.
+	var deployRequeueErr *runtime.RequeueNeededAfter
+	if err != nil && !errors.As(err, &deployRequeueErr) {
+		return err
+	}
+	if lb.Status != nil && lb.Status.CapacityReservationNotSupported {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonCapacityReservationNotSupported,
+			"Minimum load balancer capacity is ignored since capacity reservation isn't supported for the load balancer")
+	}
+	lbProvisioning, err := r.checkLoadBalancerProvisioning(ctx, svc, lb)
 	if err != nil {
 		return err
 	}
@@ -129,14 +200,45 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 		return err
 	}
 
+	var zonalDNSNames []string
+	if lb.Status != nil {
+		zonalDNSNames = lb.Status.ZonalDNSNames
+	}
+	if err = r.updateServiceZonalDNSNames(ctx, zonalDNSNames, svc); err != nil {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
 	if err = r.updateServiceStatus(ctx, lbDNS, svc); err != nil {
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 		return err
 	}
 	r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	if deployRequeueErr != nil {
+		return deployRequeueErr
+	}
+	if lbProvisioning {
+		return runtime.NewRequeueNeededAfter("LoadBalancerProvisioning", lbProvisioningRequeueInterval)
+	}
 	return nil
 }
 
+// checkLoadBalancerProvisioning checks whether newly created LoadBalancer is still provisioning, with progress events emitted.
+// reconcile isn't blocked by provisioning, instead requeue is requested while the LoadBalancer is still provisioning.
+func (r *serviceReconciler) checkLoadBalancerProvisioning(_ context.Context, svc *corev1.Service, lb *elbv2model.LoadBalancer) (bool, error) {
+	if r.lbProvisioningTracker == nil || lb.Status == nil {
+		return false, nil
+	}
+	provisioning, err := r.lbProvisioningTracker.TrackState(lb.Status.LoadBalancerARN, lb.Status.State)
+	if err != nil {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedProvisionLoadBalancer, fmt.Sprintf("Failed provision load balancer due to %v", err))
+		return false, err
+	}
+	if provisioning {
+		r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonLoadBalancerProvisioning, fmt.Sprintf("LB provisioning, state=%v", lb.Status.State))
+	}
+	return provisioning, nil
+}
+
 func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, svc *corev1.Service) error {
 	if k8s.HasFinalizer(svc, serviceFinalizer) {
 		_, _, err := r.buildAndDeployModel(ctx, svc)
@@ -168,6 +270,28 @@ func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbDNS strin
 	return nil
 }
 
+// updateServiceZonalDNSNames surfaces the zonal DNS names of load balancer via annotation, the annotation is only patched when changed.
+func (r *serviceReconciler) updateServiceZonalDNSNames(ctx context.Context, zonalDNSNames []string, svc *corev1.Service) error {
+	desiredValue := strings.Join(zonalDNSNames, ",")
+	currentValue, exists := svc.Annotations[serviceAnnotationZonalDNSNames]
+	if currentValue == desiredValue && (exists || desiredValue == "") {
+		return nil
+	}
+	svcOld := svc.DeepCopy()
+	if desiredValue == "" {
+		delete(svc.Annotations, serviceAnnotationZonalDNSNames)
+	} else {
+		if svc.Annotations == nil {
+			svc.Annotations = make(map[string]string)
+		}
+		svc.Annotations[serviceAnnotationZonalDNSNames] = desiredValue
+	}
+	if err := r.k8sClient.Patch(ctx, svc, client.MergeFrom(svcOld)); err != nil {
+		return errors.Wrapf(err, "failed to update service zonal DNS names: %v", k8s.NamespacedName(svc))
+	}
+	return nil
+}
+
 func (r *serviceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
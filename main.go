@@ -17,13 +17,17 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	zapraw "go.uber.org/zap"
+	discovery "k8s.io/api/discovery/v1beta1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/record"
 	"os"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	elbv2controller "sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2"
@@ -74,11 +78,25 @@ func main() {
 	}
 	ctrl.SetLogger(getLoggerWithLogLevel(controllerCFG.LogLevel))
 
-	cloud, err := aws.NewCloud(controllerCFG.AWSConfig, metrics.Registry)
+	cloud, err := aws.NewCloud(controllerCFG.AWSConfig, controllerCFG.ClusterName, metrics.Registry, ctrl.Log.WithName("aws"))
 	if err != nil {
 		setupLog.Error(err, "unable to initialize AWS cloud")
 		os.Exit(1)
 	}
+	if controllerCFG.ValidatePermissions {
+		permissionsValidator := aws.NewDefaultPermissionsValidator(cloud, aws.PermissionsValidatorOptions{
+			WAFEnabled:    controllerCFG.AddonsConfig.WAFEnabled,
+			WAFV2Enabled:  controllerCFG.AddonsConfig.WAFV2Enabled,
+			ShieldEnabled: controllerCFG.AddonsConfig.ShieldEnabled,
+		}, ctrl.Log.WithName("permissions-validator"))
+		if missingPermissions := permissionsValidator.Validate(context.Background()); len(missingPermissions) != 0 {
+			setupLog.Info("missing IAM permissions", "permissions", missingPermissions)
+			if controllerCFG.ValidatePermissionsFailFast {
+				setupLog.Error(errors.New("missing IAM permissions"), "IAM permissions validation failed")
+				os.Exit(1)
+			}
+		}
+	}
 	restCFG, err := config.BuildRestConfig(controllerCFG.RuntimeConfig)
 	if err != nil {
 		setupLog.Error(err, "unable to build REST config")
@@ -96,6 +114,16 @@ func main() {
 		setupLog.Error(err, "unable to obtain clientSet")
 		os.Exit(1)
 	}
+	if controllerCFG.EnableEndpointSlices {
+		if _, err := clientSet.Discovery().ServerResourcesForGroupVersion(discovery.SchemeGroupVersion.String()); err != nil {
+			setupLog.Info("EndpointSlices isn't supported by cluster, falling back to Endpoints", "error", err.Error())
+			controllerCFG.EnableEndpointSlices = false
+		}
+	}
+
+	ingEventRecorder := buildEventRecorder(mgr, "ingress", controllerCFG)
+	svcEventRecorder := buildEventRecorder(mgr, "service", controllerCFG)
+	tgbEventRecorder := buildEventRecorder(mgr, "targetGroupBinding", controllerCFG)
 
 	podInfoRepo := k8s.NewDefaultPodInfoRepo(clientSet.CoreV1().RESTClient(), rtOpts.Namespace, ctrl.Log)
 	finalizerManager := k8s.NewDefaultFinalizerManager(mgr.GetClient(), ctrl.Log)
@@ -104,17 +132,20 @@ func main() {
 	sgManager := networking.NewDefaultSecurityGroupManager(cloud.EC2(), ctrl.Log)
 	sgReconciler := networking.NewDefaultSecurityGroupReconciler(sgManager, ctrl.Log)
 	azInfoProvider := networking.NewDefaultAZInfoProvider(cloud.EC2(), ctrl.Log.WithName("az-info-provider"))
-	subnetResolver := networking.NewDefaultSubnetsResolver(azInfoProvider, cloud.EC2(), cloud.VpcID(), controllerCFG.ClusterName, ctrl.Log.WithName("subnets-resolver"))
-	vpcResolver := networking.NewDefaultVPCResolver(cloud.EC2(), cloud.VpcID(), ctrl.Log.WithName("vpc-resolver"))
-	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(),
-		podInfoRepo, podENIResolver, nodeENIResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, mgr.GetEventRecorderFor("targetGroupBinding"), ctrl.Log)
-	ingGroupReconciler := ingress.NewGroupReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("ingress"),
+	subnetResolver := networking.NewDefaultSubnetsResolver(azInfoProvider, cloud.EC2(), cloud.VpcID(), controllerCFG.ClusterName,
+		controllerCFG.DisableSubnetAutoDiscovery, controllerCFG.MaxSubnetsPerLB, ctrl.Log.WithName("subnets-resolver"))
+	vpcResolver := networking.NewDefaultVPCResolver(cloud.EC2(), cloud.VpcID(), controllerCFG.AWSConfig.VpcCacheDuration, ctrl.Log.WithName("vpc-resolver"))
+	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(), cloud,
+		podInfoRepo, podENIResolver, nodeENIResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, controllerCFG.EnableEndpointSlices,
+		controllerCFG.TargetGroupBindingDeferSGRuleCleanup, controllerCFG.TargetGroupBindingTargetHealthRequeueInterval,
+		controllerCFG.TargetGroupBindingTargetHealthRequeueMaxInterval, tgbEventRecorder, ctrl.Log)
+	ingGroupReconciler := ingress.NewGroupReconciler(cloud, mgr.GetClient(), ingEventRecorder,
 		finalizerManager, sgManager, sgReconciler, subnetResolver,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("ingress"))
-	svcReconciler := service.NewServiceReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("service"),
+	svcReconciler := service.NewServiceReconciler(cloud, mgr.GetClient(), svcEventRecorder,
 		finalizerManager, sgManager, sgReconciler, subnetResolver, vpcResolver,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("service"))
-	tgbReconciler := elbv2controller.NewTargetGroupBindingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("targetGroupBinding"),
+	tgbReconciler := elbv2controller.NewTargetGroupBindingReconciler(mgr.GetClient(), tgbEventRecorder,
 		finalizerManager, tgbResManager,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("targetGroupBinding"))
 
@@ -139,11 +170,16 @@ func main() {
 		setupLog.Error(err, "unable add a health check")
 		os.Exit(1)
 	}
+	// Add readiness probe for AWS credentials
+	if err := mgr.AddReadyzCheck("aws-credentials", cloud.CheckCredentials); err != nil {
+		setupLog.Error(err, "unable add a readiness check")
+		os.Exit(1)
+	}
 
 	podReadinessGateInjector := inject.NewPodReadinessGate(controllerCFG.PodWebhookConfig,
 		mgr.GetClient(), ctrl.Log.WithName("pod-readiness-gate-injector"))
 	corewebhook.NewPodMutator(podReadinessGateInjector).SetupWithManager(mgr)
-	elbv2webhook.NewTargetGroupBindingMutator(cloud.ELBV2(), ctrl.Log).SetupWithManager(mgr)
+	elbv2webhook.NewTargetGroupBindingMutator(cloud.ELBV2(), cloud.VpcID(), ctrl.Log).SetupWithManager(mgr)
 	elbv2webhook.NewTargetGroupBindingValidator(mgr.GetClient(), ctrl.Log).SetupWithManager(mgr)
 	networkingwebhook.NewIngressValidator(mgr.GetClient(), controllerCFG.IngressConfig, ctrl.Log).SetupWithManager(mgr)
 	//+kubebuilder:scaffold:builder
@@ -167,9 +203,8 @@ func main() {
 
 // loadControllerConfig loads the controller configuration.
 func loadControllerConfig() (config.ControllerConfig, error) {
-	defaultAWSThrottleCFG := throttle.NewDefaultServiceOperationsThrottleConfig()
 	controllerCFG := config.ControllerConfig{
-		AWSConfig: aws.CloudConfig{ThrottleConfig: defaultAWSThrottleCFG},
+		AWSConfig: aws.CloudConfig{ThrottleConfig: &throttle.ServiceOperationsThrottleConfig{}},
 	}
 
 	fs := pflag.NewFlagSet("", pflag.ExitOnError)
@@ -185,6 +220,15 @@ func loadControllerConfig() (config.ControllerConfig, error) {
 	return controllerCFG, nil
 }
 
+// buildEventRecorder builds the event recorder for name, with events rate limited per object if enabled.
+func buildEventRecorder(mgr ctrl.Manager, name string, controllerCFG config.ControllerConfig) record.EventRecorder {
+	eventRecorder := mgr.GetEventRecorderFor(name)
+	if controllerCFG.EventRateLimitQPS > 0 {
+		return k8s.NewRateLimitedEventRecorder(eventRecorder, controllerCFG.EventRateLimitQPS, controllerCFG.EventRateLimitBurst)
+	}
+	return eventRecorder
+}
+
 // getLoggerWithLogLevel returns logger with specific log level.
 func getLoggerWithLogLevel(logLevel string) logr.Logger {
 	var zapLevel zapraw.AtomicLevel
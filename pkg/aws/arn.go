@@ -0,0 +1,22 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
+)
+
+// ValidateARNInPartitionAndAccount validates the ARN belongs to the specified AWS partition(e.g. aws, aws-cn, aws-us-gov, aws-iso) and account.
+// ARNs from other partitions can never be accessed with credentials of the specified partition, even if the accountID matches.
+func ValidateARNInPartitionAndAccount(resourceARN string, partition string, accountID string) error {
+	parsedARN, err := arn.Parse(resourceARN)
+	if err != nil {
+		return errors.Wrapf(err, "invalid ARN %v", resourceARN)
+	}
+	if parsedARN.Partition != partition {
+		return errors.Errorf("%v doesn't belong to partition %v", resourceARN, partition)
+	}
+	if parsedARN.AccountID != accountID {
+		return errors.Errorf("%v doesn't belong to account %v", resourceARN, accountID)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateARNInPartitionAndAccount(t *testing.T) {
+	type args struct {
+		resourceARN string
+		partition   string
+		accountID   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "targetGroup in aws partition",
+			args: args{
+				resourceARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+				partition:   "aws",
+				accountID:   "123456789012",
+			},
+		},
+		{
+			name: "targetGroup in aws-cn partition",
+			args: args{
+				resourceARN: "arn:aws-cn:elasticloadbalancing:cn-north-1:123456789012:targetgroup/my-tg/1234567890abcdef",
+				partition:   "aws-cn",
+				accountID:   "123456789012",
+			},
+		},
+		{
+			name: "targetGroup in aws-us-gov partition",
+			args: args{
+				resourceARN: "arn:aws-us-gov:elasticloadbalancing:us-gov-west-1:123456789012:targetgroup/my-tg/1234567890abcdef",
+				partition:   "aws-us-gov",
+				accountID:   "123456789012",
+			},
+		},
+		{
+			name: "targetGroup in aws-iso partition",
+			args: args{
+				resourceARN: "arn:aws-iso:elasticloadbalancing:us-iso-east-1:123456789012:targetgroup/my-tg/1234567890abcdef",
+				partition:   "aws-iso",
+				accountID:   "123456789012",
+			},
+		},
+		{
+			name: "targetGroup in other partition",
+			args: args{
+				resourceARN: "arn:aws-cn:elasticloadbalancing:cn-north-1:123456789012:targetgroup/my-tg/1234567890abcdef",
+				partition:   "aws",
+				accountID:   "123456789012",
+			},
+			wantErr: errors.New("arn:aws-cn:elasticloadbalancing:cn-north-1:123456789012:targetgroup/my-tg/1234567890abcdef doesn't belong to partition aws"),
+		},
+		{
+			name: "targetGroup in other account",
+			args: args{
+				resourceARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+				partition:   "aws",
+				accountID:   "222222222222",
+			},
+			wantErr: errors.New("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef doesn't belong to account 222222222222"),
+		},
+		{
+			name: "invalid ARN",
+			args: args{
+				resourceARN: "my-tg",
+				partition:   "aws",
+				accountID:   "123456789012",
+			},
+			wantErr: errors.New("invalid ARN my-tg: arn: invalid prefix"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateARNInPartitionAndAccount(tt.args.resourceARN, tt.args.partition, tt.args.accountID)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,11 +1,17 @@
 package aws
 
 import (
+	"net/http"
+	"sync"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/metrics"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
@@ -33,15 +39,26 @@ type Cloud interface {
 	// RGT provides API to AWS RGT
 	RGT() services.RGT
 
+	// AssumeRoleELBV2 provides API to AWS ELBV2 with credentials of specified IAM role assumed.
+	AssumeRoleELBV2(roleARN string) (services.ELBV2, error)
+
 	// Region for the kubernetes cluster
 	Region() string
 
 	// VPC ID for the the kubernetes cluster
 	VpcID() string
+
+	// CheckCredentials reports error if AWS credentials remain expired after refresh.
+	// It's intended to be used as a readiness check.
+	CheckCredentials(req *http.Request) error
 }
 
 // NewCloud constructs new Cloud implementation.
-func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud, error) {
+func NewCloud(cfg CloudConfig, clusterName string, metricsRegisterer prometheus.Registerer, logger logr.Logger) (Cloud, error) {
+	if cfg.vpcCacheDurationInMinutes {
+		logger.Info("specifying aws-vpc-cache-duration as bare integer of minutes is deprecated, use duration such as 10m instead",
+			"vpcCacheDuration", cfg.VpcCacheDuration.String())
+	}
 	metadataSess := session.Must(session.NewSession(aws.NewConfig()))
 	metadata := services.NewEC2Metadata(metadataSess)
 	if len(cfg.Region) == 0 {
@@ -59,16 +76,41 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		}
 		cfg.VpcID = vpcId
 	}
+	if _, err := resolvePartition(cfg.Region); err != nil {
+		return nil, err
+	}
 
-	awsCFG := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint).WithMaxRetries(cfg.MaxRetries)
+	if cfg.RequestTimeout < 0 {
+		return nil, errors.Errorf("invalid %v: %v, must be non-negative", flagAWSRequestTimeout, cfg.RequestTimeout)
+	}
+	if cfg.TotalTimeout < 0 {
+		return nil, errors.Errorf("invalid %v: %v, must be non-negative", flagAWSTotalTimeout, cfg.TotalTimeout)
+	}
+	httpClient, err := buildHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	awsCFG := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint).WithMaxRetries(cfg.MaxRetries).
+		WithHTTPClient(httpClient)
+	if len(cfg.AWSEndpoints) != 0 {
+		awsCFG = awsCFG.WithEndpointResolver(newEndpointsResolver(cfg.AWSEndpoints))
+	}
 	sess := session.Must(session.NewSession(awsCFG))
-	injectUserAgent(&sess.Handlers)
-
-	if cfg.ThrottleConfig != nil {
-		throttler := throttle.NewThrottler(cfg.ThrottleConfig)
-		throttler.InjectHandlers(&sess.Handlers)
+	injectUserAgent(&sess.Handlers, clusterName, cfg.UserAgentSuffix)
+	injectTotalTimeout(&sess.Handlers, cfg.TotalTimeout)
+	credentialsHealthChecker := newCredentialsHealthChecker(sess.Config.Credentials, logger)
+	credentialsHealthChecker.InjectHandlers(&sess.Handlers)
+
+	throttleCFG, err := buildThrottleConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
+	throttler := throttle.NewThrottler(throttleCFG)
+	throttler.InjectHandlers(&sess.Handlers)
 	if metricsRegisterer != nil {
+		if err := throttler.RegisterMetrics(metricsRegisterer); err != nil {
+			return nil, errors.Wrapf(err, "failed to initialize sdk throttle metrics")
+		}
 		metricsCollector, err := metrics.NewCollector(metricsRegisterer)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to initialize sdk metrics collector")
@@ -78,6 +120,7 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 
 	return &defaultCloud{
 		cfg:         cfg,
+		sess:        sess,
 		ec2:         services.NewEC2(sess),
 		elbv2:       services.NewELBV2(sess),
 		acm:         services.NewACM(sess),
@@ -85,13 +128,19 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		wafRegional: services.NewWAFRegional(sess, cfg.Region),
 		shield:      services.NewShield(sess),
 		rgt:         services.NewRGT(sess),
+
+		credentialsHealthChecker: credentialsHealthChecker,
+
+		allowedAssumeRoleARNs: sets.NewString(cfg.AllowedAssumeRoleARNs...),
+		assumeRoleELBV2ByARN:  make(map[string]services.ELBV2),
 	}, nil
 }
 
 var _ Cloud = &defaultCloud{}
 
 type defaultCloud struct {
-	cfg CloudConfig
+	cfg  CloudConfig
+	sess *session.Session
 
 	ec2   services.EC2
 	elbv2 services.ELBV2
@@ -101,6 +150,14 @@ type defaultCloud struct {
 	wafRegional services.WAFRegional
 	shield      services.Shield
 	rgt         services.RGT
+
+	credentialsHealthChecker *credentialsHealthChecker
+
+	allowedAssumeRoleARNs sets.String
+	// assumeRoleELBV2ByARN caches ELBV2 clients by assumed roleARN.
+	assumeRoleELBV2ByARN map[string]services.ELBV2
+	// assumeRoleELBV2Mutex protects assumeRoleELBV2ByARN
+	assumeRoleELBV2Mutex sync.Mutex
 }
 
 func (c *defaultCloud) EC2() services.EC2 {
@@ -131,6 +188,23 @@ func (c *defaultCloud) RGT() services.RGT {
 	return c.rgt
 }
 
+func (c *defaultCloud) AssumeRoleELBV2(roleARN string) (services.ELBV2, error) {
+	if !c.allowedAssumeRoleARNs.Has(roleARN) {
+		return nil, errors.Errorf("assume role %v is not allowed, allowed roles can be specified via --%v", roleARN, flagAWSAllowedAssumeRoleARNs)
+	}
+	c.assumeRoleELBV2Mutex.Lock()
+	defer c.assumeRoleELBV2Mutex.Unlock()
+	if elbv2Client, exists := c.assumeRoleELBV2ByARN[roleARN]; exists {
+		return elbv2Client, nil
+	}
+	assumeRoleSess := c.sess.Copy(&aws.Config{
+		Credentials: stscreds.NewCredentials(c.sess, roleARN),
+	})
+	elbv2Client := services.NewELBV2(assumeRoleSess)
+	c.assumeRoleELBV2ByARN[roleARN] = elbv2Client
+	return elbv2Client, nil
+}
+
 func (c *defaultCloud) Region() string {
 	return c.cfg.Region
 }
@@ -138,3 +212,28 @@ func (c *defaultCloud) Region() string {
 func (c *defaultCloud) VpcID() string {
 	return c.cfg.VpcID
 }
+
+func (c *defaultCloud) CheckCredentials(req *http.Request) error {
+	return c.credentialsHealthChecker.Check(req)
+}
+
+// buildThrottleConfig builds the throttle config from throttle profile, with ThrottleConfig merged on top per operation pattern.
+// ThrottleDescribeBurst is applied last to the throttles of Describe* operations.
+func buildThrottleConfig(cfg CloudConfig) (*throttle.ServiceOperationsThrottleConfig, error) {
+	if cfg.ThrottleDescribeBurst < 0 {
+		return nil, errors.Errorf("invalid %v flag: %v, must be non-negative", flagAWSAPIThrottleDescribeBurst, cfg.ThrottleDescribeBurst)
+	}
+	throttleProfile := cfg.ThrottleProfile
+	if len(throttleProfile) == 0 {
+		throttleProfile = throttle.ProfileDefault
+	}
+	profileThrottleCFG, err := throttle.NewServiceOperationsThrottleConfigForProfile(throttleProfile)
+	if err != nil {
+		return nil, err
+	}
+	throttleCFG := profileThrottleCFG.MergeWith(cfg.ThrottleConfig)
+	if cfg.ThrottleDescribeBurst > 0 {
+		throttleCFG = throttleCFG.WithDescribeBurst(cfg.ThrottleDescribeBurst)
+	}
+	return throttleCFG, nil
+}
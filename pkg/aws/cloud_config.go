@@ -1,37 +1,138 @@
 package aws
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/spf13/pflag"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
 )
 
 const (
-	flagAWSRegion        = "aws-region"
-	flagAWSAPIThrottle   = "aws-api-throttle"
-	flagAWSVpcID         = "aws-vpc-id"
-	flagAWSMaxRetries    = "aws-max-retries"
-	defaultVpcID         = ""
-	defaultRegion        = ""
-	defaultAPIMaxRetries = 10
+	flagAWSRegion                   = "aws-region"
+	flagAWSAPIThrottle              = "aws-api-throttle"
+	flagAWSAPIThrottleProfile       = "aws-api-throttle-profile"
+	flagAWSAPIThrottleDescribeBurst = "aws-api-throttle-describe-burst"
+	flagAWSVpcID                    = "aws-vpc-id"
+	flagAWSVpcCacheDuration         = "aws-vpc-cache-duration"
+	flagAWSMaxRetries               = "aws-max-retries"
+	flagAWSRequestTimeout           = "aws-request-timeout"
+	flagAWSTotalTimeout             = "aws-total-timeout"
+	flagAWSEndpoint                 = "aws-endpoint"
+	flagAWSAllowedAssumeRoleARNs    = "aws-allowed-assume-role-arns"
+	flagAWSUserAgentSuffix          = "aws-user-agent-suffix"
+	flagAWSHTTPSProxy               = "aws-https-proxy"
+	flagAWSHTTPMaxIdleConnsPerHost  = "aws-http-max-idle-conns-per-host"
+	flagAWSHTTPKeepAlive            = "aws-http-keep-alive"
+	flagAWSHTTPDisableKeepAlives    = "aws-http-disable-keep-alives"
+	flagAWSHTTPIdleConnTimeout      = "aws-http-idle-conn-timeout"
+	flagAWSHTTPTLSHandshakeTimeout  = "aws-http-tls-handshake-timeout"
+	flagAWSHTTPTLSMinVersion        = "aws-http-tls-min-version"
+	defaultVpcID                    = ""
+	defaultRegion                   = ""
+	defaultAPIMaxRetries            = 10
+	defaultRequestTimeout           = 30 * time.Second
+	defaultVpcCacheDuration         = 0
+	defaultHTTPKeepAlive            = 30 * time.Second
+	defaultHTTPIdleConnTimeout      = 90 * time.Second
+	defaultHTTPTLSHandshakeTimeout  = 10 * time.Second
 )
 
 type CloudConfig struct {
 	// AWS Region for the kubernetes cluster
 	Region string
 
-	// Throttle settings for AWS APIs
+	// Throttle settings for AWS APIs, which overrides the settings from ThrottleProfile per service.
 	ThrottleConfig *throttle.ServiceOperationsThrottleConfig
 
+	// Name of the built-in throttle profile for AWS APIs, defaults to throttle.ProfileDefault if empty.
+	ThrottleProfile string
+
+	// Burst of throttles for Describe* operations across services, 0 keeps the burst from other throttle settings.
+	ThrottleDescribeBurst int
+
 	// VPC ID of the Kubernetes cluster
 	VpcID string
 
+	// Duration to cache VPC information such as CIDRs, 0 disables the cache.
+	VpcCacheDuration time.Duration
+
+	// whether VpcCacheDuration is specified as bare integer of minutes, which is deprecated.
+	vpcCacheDurationInMinutes bool
+
 	// Max retries configuration for AWS APIs
 	MaxRetries int
+
+	// Timeout of each attempt of AWS API calls, 0 disables the timeout.
+	RequestTimeout time.Duration
+
+	// Timeout of AWS API calls including all retries, 0 disables the timeout.
+	TotalTimeout time.Duration
+
+	// Custom endpoint URLs for AWS APIs, keyed by endpointsID(e.g. "elasticloadbalancing", "ec2", "tagging").
+	AWSEndpoints map[string]string
+
+	// IAM roles that are allowed to be assumed for cross-account AWS API calls.
+	AllowedAssumeRoleARNs []string
+
+	// Suffix appended to the user-agent of AWS API calls
+	UserAgentSuffix string
+
+	// Proxy URL for AWS API calls, which overrides the proxy settings from HTTPS_PROXY environment variable.
+	HTTPSProxy string
+
+	// Maximum idle connections to keep per AWS API endpoint.
+	HTTPMaxIdleConnsPerHost int
+
+	// Interval between TCP keep-alive probes for connections to AWS API endpoints.
+	HTTPKeepAlive time.Duration
+
+	// Whether to disable reusing connections to AWS API endpoints.
+	HTTPDisableKeepAlives bool
+
+	// Maximum duration an idle connection to AWS API endpoints is kept.
+	HTTPIdleConnTimeout time.Duration
+
+	// Maximum duration to wait for TLS handshake with AWS API endpoints.
+	HTTPTLSHandshakeTimeout time.Duration
+
+	// Minimum TLS version for AWS API calls, one of TLS1.0, TLS1.1, TLS1.2, TLS1.3.
+	HTTPTLSMinVersion string
 }
 
 func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.Region, flagAWSRegion, defaultRegion, "AWS Region for the kubernetes cluster")
 	fs.Var(cfg.ThrottleConfig, flagAWSAPIThrottle, "throttle settings for AWS APIs, format: serviceID1:operationRegex1=rate:burst,serviceID2:operationRegex2=rate:burst")
+	fs.StringVar(&cfg.ThrottleProfile, flagAWSAPIThrottleProfile, throttle.ProfileDefault,
+		"built-in throttle profile for AWS APIs, one of default, conservative, aggressive. Settings from aws-api-throttle take precedence per service")
+	fs.IntVar(&cfg.ThrottleDescribeBurst, flagAWSAPIThrottleDescribeBurst, 0,
+		"burst for throttles of Describe* operations across services, applied on top of aws-api-throttle-profile and aws-api-throttle. 0 keeps the configured burst")
 	fs.StringVar(&cfg.VpcID, flagAWSVpcID, defaultVpcID, "AWS VPC ID for the Kubernetes cluster")
+	cfg.VpcCacheDuration = defaultVpcCacheDuration
+	fs.Var(newVPCCacheDurationValue(&cfg.VpcCacheDuration, &cfg.vpcCacheDurationInMinutes), flagAWSVpcCacheDuration,
+		"Duration to cache VPC information such as CIDRs, e.g. 5m, 1h30s. 0 disables the cache, otherwise VPC CIDR changes are picked up with delay up to the duration. Bare integer is treated as minutes, which is deprecated")
 	fs.IntVar(&cfg.MaxRetries, flagAWSMaxRetries, defaultAPIMaxRetries, "Maximum retries for AWS APIs")
+	fs.DurationVar(&cfg.RequestTimeout, flagAWSRequestTimeout, defaultRequestTimeout,
+		"Timeout of each attempt of AWS API calls, timed out attempts are retried up to aws-max-retries. 0 disables the timeout")
+	fs.DurationVar(&cfg.TotalTimeout, flagAWSTotalTimeout, 0,
+		"Timeout of AWS API calls including all retries. 0 disables the timeout")
+	fs.Var(newAWSEndpointsValue(&cfg.AWSEndpoints), flagAWSEndpoint,
+		"custom endpoint URLs for AWS APIs, format: endpointsID1=url1,endpointsID2=url2, e.g. elasticloadbalancing=http://localhost:4566")
+	fs.StringSliceVar(&cfg.AllowedAssumeRoleARNs, flagAWSAllowedAssumeRoleARNs, nil,
+		"IAM role ARNs that are allowed to be assumed for cross-account AWS API calls")
+	fs.StringVar(&cfg.UserAgentSuffix, flagAWSUserAgentSuffix, "", "Suffix appended to the user-agent of AWS API calls")
+	fs.StringVar(&cfg.HTTPSProxy, flagAWSHTTPSProxy, "",
+		"Proxy URL for AWS API calls, overrides the HTTPS_PROXY environment variable")
+	fs.IntVar(&cfg.HTTPMaxIdleConnsPerHost, flagAWSHTTPMaxIdleConnsPerHost, http.DefaultMaxIdleConnsPerHost,
+		"Maximum idle connections to keep per AWS API endpoint")
+	fs.DurationVar(&cfg.HTTPKeepAlive, flagAWSHTTPKeepAlive, defaultHTTPKeepAlive,
+		"Interval between TCP keep-alive probes for connections to AWS API endpoints")
+	fs.BoolVar(&cfg.HTTPDisableKeepAlives, flagAWSHTTPDisableKeepAlives, false,
+		"Disable reusing connections to AWS API endpoints")
+	fs.DurationVar(&cfg.HTTPIdleConnTimeout, flagAWSHTTPIdleConnTimeout, defaultHTTPIdleConnTimeout,
+		"Maximum duration an idle connection to AWS API endpoints is kept")
+	fs.DurationVar(&cfg.HTTPTLSHandshakeTimeout, flagAWSHTTPTLSHandshakeTimeout, defaultHTTPTLSHandshakeTimeout,
+		"Maximum duration to wait for TLS handshake with AWS API endpoints")
+	fs.StringVar(&cfg.HTTPTLSMinVersion, flagAWSHTTPTLSMinVersion, "",
+		"Minimum TLS version for AWS API calls, one of TLS1.0, TLS1.1, TLS1.2, TLS1.3")
 }
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"testing"
+)
+
+func Test_buildThrottleConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "default profile is applied when flags are unset",
+			args: nil,
+			want: "EC2:^Describe=20:40,EC2:^AuthorizeSecurityGroupIngress|RevokeSecurityGroupIngress=5:10," +
+				"Elastic Load Balancing v2:^Describe=10:20,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=5:10," +
+				"WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+		{
+			name: "throttle flag is merged with profile",
+			args: []string{
+				"--aws-api-throttle-profile=conservative",
+				"--aws-api-throttle=Elastic Load Balancing v2:^DescribeTargetHealth=4.2:5",
+			},
+			want: "EC2:^Describe=10:20,EC2:^AuthorizeSecurityGroupIngress|RevokeSecurityGroupIngress=2.5:5," +
+				"Elastic Load Balancing v2:^Describe=5:10,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=2.5:5," +
+				"Elastic Load Balancing v2:^DescribeTargetHealth=4.2:5," +
+				"WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+		{
+			name: "throttle flag overrides profile throttle of same operation pattern",
+			args: []string{
+				"--aws-api-throttle=Elastic Load Balancing v2:^Describe=4.2:5",
+			},
+			want: "EC2:^Describe=20:40,EC2:^AuthorizeSecurityGroupIngress|RevokeSecurityGroupIngress=5:10," +
+				"Elastic Load Balancing v2:^Describe=4.2:5,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=5:10," +
+				"WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+		{
+			name: "throttle flag is merged with profile regardless of flag order",
+			args: []string{
+				"--aws-api-throttle=Elastic Load Balancing v2:^DescribeTargetHealth=4.2:5",
+				"--aws-api-throttle-profile=aggressive",
+			},
+			want: "Elastic Load Balancing v2:^DescribeTargetHealth=4.2:5," +
+				"WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+		{
+			name: "describe burst is applied to Describe throttles from profile and throttle flag",
+			args: []string{
+				"--aws-api-throttle=Elastic Load Balancing v2:^DescribeTargetHealth=4.2:5",
+				"--aws-api-throttle-describe-burst=100",
+			},
+			want: "EC2:^Describe=20:100,EC2:^AuthorizeSecurityGroupIngress|RevokeSecurityGroupIngress=5:10," +
+				"Elastic Load Balancing v2:^Describe=10:100,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=5:10," +
+				"Elastic Load Balancing v2:^DescribeTargetHealth=4.2:100," +
+				"WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+		{
+			name: "negative describe burst",
+			args: []string{
+				"--aws-api-throttle-describe-burst=-1",
+			},
+			wantErr: errors.New("invalid aws-api-throttle-describe-burst flag: -1, must be non-negative"),
+		},
+		{
+			name: "unknown profile",
+			args: []string{
+				"--aws-api-throttle-profile=fast",
+			},
+			wantErr: errors.New("unknown throttle profile fast, must be one of [default conservative aggressive]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := CloudConfig{ThrottleConfig: &throttle.ServiceOperationsThrottleConfig{}}
+			fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+			cfg.BindFlags(fs)
+			assert.NoError(t, fs.Parse(tt.args))
+
+			got, err := buildThrottleConfig(cfg)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
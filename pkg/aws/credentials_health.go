@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// defaultExpiredCredentialsErrorThreshold is the number of consecutive expired credentials errors
+	// before we force a credentials refresh.
+	defaultExpiredCredentialsErrorThreshold = 3
+)
+
+// expiredCredentialsErrorCodes are AWS error codes that indicates the credentials used are expired or no longer valid.
+var expiredCredentialsErrorCodes = sets.NewString(
+	"ExpiredToken",
+	"ExpiredTokenException",
+	"RequestExpired",
+)
+
+// newCredentialsHealthChecker constructs new credentialsHealthChecker.
+func newCredentialsHealthChecker(creds *credentials.Credentials, logger logr.Logger) *credentialsHealthChecker {
+	return &credentialsHealthChecker{
+		credentials:    creds,
+		errorThreshold: defaultExpiredCredentialsErrorThreshold,
+		logger:         logger,
+	}
+}
+
+// credentialsHealthChecker watches AWS API responses for expired credentials errors.
+// Once consecutive expired credentials errors reaches the threshold, it forces the credentials to be refreshed
+// upon next API call. If the errors persists after refresh, the credentials is reported as unhealthy.
+type credentialsHealthChecker struct {
+	credentials    *credentials.Credentials
+	errorThreshold int
+	logger         logr.Logger
+
+	// mutex protects below fields
+	mutex sync.Mutex
+	// consecutiveErrors is the number of consecutive expired credentials errors observed.
+	consecutiveErrors int
+	// refreshed indicates whether credentials refresh have been forced since last successful API call.
+	refreshed bool
+}
+
+// InjectHandlers will inject credentialsHealthChecker's handlers into awsSDK.
+func (c *credentialsHealthChecker) InjectHandlers(handlers *request.Handlers) {
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "credentialsHealthChecker",
+		Fn:   c.afterComplete,
+	})
+}
+
+// Check reports error if expired credentials errors persists after credentials refresh.
+// It's intended to be used as a readiness check.
+func (c *credentialsHealthChecker) Check(_ *http.Request) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.refreshed && c.consecutiveErrors >= c.errorThreshold {
+		return errors.Errorf("AWS credentials are expired, %v consecutive expired credentials errors after refresh", c.consecutiveErrors)
+	}
+	return nil
+}
+
+func (c *credentialsHealthChecker) afterComplete(r *request.Request) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if r.Error == nil {
+		if c.refreshed {
+			c.logger.Info("AWS credentials recovered after refresh")
+		}
+		c.consecutiveErrors = 0
+		c.refreshed = false
+		return
+	}
+	if !isExpiredCredentialsError(r.Error) {
+		return
+	}
+
+	c.consecutiveErrors++
+	if c.consecutiveErrors < c.errorThreshold || c.refreshed {
+		return
+	}
+	c.logger.Info("refreshing AWS credentials due to expired credentials errors",
+		"consecutiveErrors", c.consecutiveErrors,
+		"lastError", r.Error.Error())
+	if c.credentials != nil {
+		c.credentials.Expire()
+	}
+	c.consecutiveErrors = 0
+	c.refreshed = true
+}
+
+// isExpiredCredentialsError checks whether error indicates the credentials are expired.
+func isExpiredCredentialsError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return expiredCredentialsErrorCodes.Has(awsErr.Code())
+	}
+	return false
+}
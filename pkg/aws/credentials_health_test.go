@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeCredentialsProvider struct {
+	retrieveCalls int
+}
+
+func (p *fakeCredentialsProvider) Retrieve() (credentials.Value, error) {
+	p.retrieveCalls++
+	return credentials.Value{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+}
+
+func (p *fakeCredentialsProvider) IsExpired() bool {
+	return false
+}
+
+func Test_credentialsHealthChecker(t *testing.T) {
+	expiredErr := awserr.New("ExpiredToken", "The security token included in the request is expired", nil)
+	type step struct {
+		err               error
+		wantRetrieveCalls int
+		wantCheckErr      error
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "credentials expired then recovered after refresh",
+			steps: []step{
+				{err: nil, wantRetrieveCalls: 1},
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: nil, wantRetrieveCalls: 2},
+			},
+		},
+		{
+			name: "credentials remain expired after refresh",
+			steps: []step{
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: expiredErr, wantRetrieveCalls: 2},
+				{err: expiredErr, wantRetrieveCalls: 2},
+				{
+					err:               expiredErr,
+					wantRetrieveCalls: 2,
+					wantCheckErr:      errors.New("AWS credentials are expired, 3 consecutive expired credentials errors after refresh"),
+				},
+				{err: nil, wantRetrieveCalls: 2},
+			},
+		},
+		{
+			name: "non-credentials errors are ignored",
+			steps: []step{
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: awserr.New("Throttling", "rate exceeded", nil), wantRetrieveCalls: 1},
+				{err: errors.Wrap(awserr.New("UnauthorizedOperation", "", nil), "failed to describe subnets"), wantRetrieveCalls: 1},
+				{err: expiredErr, wantRetrieveCalls: 1},
+				{err: nil, wantRetrieveCalls: 2},
+			},
+		},
+		{
+			name: "permission denials don't mark credentials as expired",
+			steps: []step{
+				{err: awserr.New("UnauthorizedOperation", "", nil), wantRetrieveCalls: 1},
+				{err: awserr.New("UnauthorizedOperation", "", nil), wantRetrieveCalls: 1},
+				{err: awserr.New("UnauthorizedOperation", "", nil), wantRetrieveCalls: 1},
+				{err: awserr.New("UnauthorizedOperation", "", nil), wantRetrieveCalls: 1},
+				{err: awserr.New("UnauthorizedOperation", "", nil), wantRetrieveCalls: 1},
+				{err: awserr.New("UnauthorizedOperation", "", nil), wantRetrieveCalls: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeCredentialsProvider{}
+			creds := credentials.NewCredentials(provider)
+			checker := newCredentialsHealthChecker(creds, &log.NullLogger{})
+			for _, s := range tt.steps {
+				// simulates an API call, which retrieves credentials before sending request.
+				_, err := creds.Get()
+				assert.NoError(t, err)
+				checker.afterComplete(&request.Request{Error: s.err})
+				assert.Equal(t, s.wantRetrieveCalls, provider.retrieveCalls)
+
+				err = checker.Check(nil)
+				if s.wantCheckErr != nil {
+					assert.EqualError(t, err, s.wantCheckErr.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package aws
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/pkg/errors"
+)
+
+// newEndpointsResolver constructs an endpoints resolver that resolves to the custom endpoint URL for services
+// in awsEndpoints(keyed by endpointsID, e.g. "elasticloadbalancing", "ec2"), and to the default endpoint otherwise.
+func newEndpointsResolver(awsEndpoints map[string]string) endpoints.Resolver {
+	defaultResolver := endpoints.DefaultResolver()
+	return endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		if endpointURL, ok := awsEndpoints[service]; ok {
+			return endpoints.ResolvedEndpoint{
+				URL:           endpointURL,
+				SigningRegion: region,
+			}, nil
+		}
+		return defaultResolver.EndpointFor(service, region, opts...)
+	})
+}
+
+// awsEndpointsValue is the flag value for custom AWS endpoints, in the form of endpointsID1=url1,endpointsID2=url2.
+type awsEndpointsValue struct {
+	value *map[string]string
+}
+
+// newAWSEndpointsValue constructs new awsEndpointsValue that parses into awsEndpoints.
+func newAWSEndpointsValue(awsEndpoints *map[string]string) *awsEndpointsValue {
+	return &awsEndpointsValue{value: awsEndpoints}
+}
+
+func (v *awsEndpointsValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+	var endpointsIDs []string
+	for endpointsID := range *v.value {
+		endpointsIDs = append(endpointsIDs, endpointsID)
+	}
+	sort.Strings(endpointsIDs)
+	pairs := make([]string, 0, len(endpointsIDs))
+	for _, endpointsID := range endpointsIDs {
+		pairs = append(pairs, endpointsID+"="+(*v.value)[endpointsID])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (v *awsEndpointsValue) Set(val string) error {
+	awsEndpoints := make(map[string]string)
+	for _, pair := range strings.Split(val, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return errors.Errorf("%s must be formatted as endpointsID=url", pair)
+		}
+		endpointsID := strings.TrimSpace(kv[0])
+		endpointURL := strings.TrimSpace(kv[1])
+		if len(endpointsID) == 0 {
+			return errors.Errorf("%s must specify endpointsID, e.g. elasticloadbalancing", pair)
+		}
+		parsedURL, err := url.Parse(endpointURL)
+		if err != nil || len(parsedURL.Scheme) == 0 || len(parsedURL.Host) == 0 {
+			return errors.Errorf("%s must be absolute URL with scheme and host, e.g. http://localhost:4566", endpointURL)
+		}
+		awsEndpoints[endpointsID] = endpointURL
+	}
+	*v.value = awsEndpoints
+	return nil
+}
+
+func (v *awsEndpointsValue) Type() string {
+	return "awsEndpoints"
+}
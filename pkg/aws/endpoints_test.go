@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newEndpointsResolver(t *testing.T) {
+	type args struct {
+		awsEndpoints map[string]string
+		service      string
+		region       string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantURL string
+	}{
+		{
+			name: "custom endpoint",
+			args: args{
+				awsEndpoints: map[string]string{"elasticloadbalancing": "https://elbv2.example.com"},
+				service:      "elasticloadbalancing",
+				region:       "us-west-2",
+			},
+			wantURL: "https://elbv2.example.com",
+		},
+		{
+			name: "elbv2 endpoint in china region",
+			args: args{
+				awsEndpoints: map[string]string{"ec2": "https://ec2.example.com"},
+				service:      "elasticloadbalancing",
+				region:       "cn-north-1",
+			},
+			wantURL: "https://elasticloadbalancing.cn-north-1.amazonaws.com.cn",
+		},
+		{
+			name: "ec2 endpoint in iso region",
+			args: args{
+				awsEndpoints: map[string]string{"elasticloadbalancing": "https://elbv2.example.com"},
+				service:      "ec2",
+				region:       "us-iso-east-1",
+			},
+			wantURL: "https://ec2.us-iso-east-1.c2s.ic.gov",
+		},
+		{
+			name: "acm endpoint in iso region",
+			args: args{
+				awsEndpoints: map[string]string{"elasticloadbalancing": "https://elbv2.example.com"},
+				service:      "acm",
+				region:       "us-iso-east-1",
+			},
+			wantURL: "https://acm.us-iso-east-1.c2s.ic.gov",
+		},
+		{
+			name: "custom tagging endpoint",
+			args: args{
+				awsEndpoints: map[string]string{"tagging": "https://tagging.example.com"},
+				service:      "tagging",
+				region:       "us-west-2",
+			},
+			wantURL: "https://tagging.example.com",
+		},
+		{
+			name: "tagging endpoint in china region",
+			args: args{
+				awsEndpoints: map[string]string{"elasticloadbalancing": "https://elbv2.example.com"},
+				service:      "tagging",
+				region:       "cn-north-1",
+			},
+			wantURL: "https://tagging.cn-north-1.amazonaws.com.cn",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := newEndpointsResolver(tt.args.awsEndpoints)
+			got, err := resolver.EndpointFor(tt.args.service, tt.args.region, endpoints.ResolveUnknownServiceOption)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantURL, got.URL)
+		})
+	}
+}
+
+func Test_awsEndpointsValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		want       map[string]string
+		wantString string
+		wantErr    error
+	}{
+		{
+			name: "flag not specified",
+			args: nil,
+			want: nil,
+		},
+		{
+			name: "multiple endpoints",
+			args: []string{"--aws-endpoint=elasticloadbalancing=http://localhost:4566, ec2=http://localhost:4566"},
+			want: map[string]string{
+				"elasticloadbalancing": "http://localhost:4566",
+				"ec2":                  "http://localhost:4566",
+			},
+			wantString: "ec2=http://localhost:4566,elasticloadbalancing=http://localhost:4566",
+		},
+		{
+			name:    "missing URL",
+			args:    []string{"--aws-endpoint=elasticloadbalancing"},
+			wantErr: errors.New(`invalid argument "elasticloadbalancing" for "--aws-endpoint" flag: elasticloadbalancing must be formatted as endpointsID=url`),
+		},
+		{
+			name:    "missing endpointsID",
+			args:    []string{"--aws-endpoint==http://localhost:4566"},
+			wantErr: errors.New(`invalid argument "=http://localhost:4566" for "--aws-endpoint" flag: =http://localhost:4566 must specify endpointsID, e.g. elasticloadbalancing`),
+		},
+		{
+			name:    "URL without scheme",
+			args:    []string{"--aws-endpoint=ec2=localhost:4566"},
+			wantErr: errors.New(`invalid argument "ec2=localhost:4566" for "--aws-endpoint" flag: localhost:4566 must be absolute URL with scheme and host, e.g. http://localhost:4566`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := CloudConfig{}
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			cfg.BindFlags(fs)
+			err := fs.Parse(tt.args)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, cfg.AWSEndpoints)
+				assert.Equal(t, tt.wantString, fs.Lookup("aws-endpoint").Value.String())
+			}
+		})
+	}
+}
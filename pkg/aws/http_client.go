@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+)
+
+var tlsVersionByName = map[string]uint16{
+	"TLS1.0": tls.VersionTLS10,
+	"TLS1.1": tls.VersionTLS11,
+	"TLS1.2": tls.VersionTLS12,
+	"TLS1.3": tls.VersionTLS13,
+}
+
+// buildHTTPClient builds the http client for AWS API calls from cloud configuration.
+func buildHTTPClient(cfg CloudConfig) (*http.Client, error) {
+	proxy, err := buildHTTPProxy(cfg.HTTPSProxy)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{}
+	if len(cfg.HTTPTLSMinVersion) != 0 {
+		tlsMinVersion, ok := tlsVersionByName[cfg.HTTPTLSMinVersion]
+		if !ok {
+			return nil, errors.Errorf("unsupported TLS version: %v", cfg.HTTPTLSMinVersion)
+		}
+		tlsConfig.MinVersion = tlsMinVersion
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: cfg.HTTPKeepAlive,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   cfg.HTTPMaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.HTTPIdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.HTTPTLSHandshakeTimeout,
+		TLSClientConfig:       tlsConfig,
+		ExpectContinueTimeout: 1 * time.Second,
+		DisableKeepAlives:     cfg.HTTPDisableKeepAlives,
+	}
+	return &http.Client{Transport: transport, Timeout: cfg.RequestTimeout}, nil
+}
+
+// buildHTTPProxy builds the proxy function for http transport.
+// httpsProxy takes precedence over HTTPS_PROXY from environment variables, which will be validated as well.
+// NO_PROXY from environment variables is honored in either case, e.g. for instance metadata or VPC endpoints.
+func buildHTTPProxy(httpsProxy string) (func(*http.Request) (*url.URL, error), error) {
+	proxyCfg := httpproxy.FromEnvironment()
+	if len(httpsProxy) != 0 {
+		proxyURL, err := parseProxyURL(httpsProxy)
+		if err != nil {
+			return nil, err
+		}
+		proxyCfg.HTTPSProxy = proxyURL.String()
+	} else if len(proxyCfg.HTTPSProxy) != 0 {
+		if _, err := parseProxyURL(proxyCfg.HTTPSProxy); err != nil {
+			return nil, errors.Wrap(err, "invalid HTTPS_PROXY environment variable")
+		}
+	}
+	proxyFunc := proxyCfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// parseProxyURL parses and validates proxy URL.
+// proxy URL without scheme defaults to http, consistent with proxy settings from environment variables in net/http.
+func parseProxyURL(rawProxyURL string) (*url.URL, error) {
+	proxyURLWithScheme := rawProxyURL
+	if !strings.Contains(rawProxyURL, "://") {
+		proxyURLWithScheme = "http://" + rawProxyURL
+	}
+	proxyURL, err := url.Parse(proxyURLWithScheme)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse proxy URL: %v", rawProxyURL)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.Errorf("unsupported proxy URL scheme, must be one of http, https or socks5: %v", rawProxyURL)
+	}
+	if len(proxyURL.Host) == 0 {
+		return nil, errors.Errorf("proxy URL must contain host: %v", rawProxyURL)
+	}
+	return proxyURL, nil
+}
@@ -0,0 +1,179 @@
+package aws
+
+import (
+	"crypto/tls"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+)
+
+func Test_buildHTTPClient(t *testing.T) {
+	type wantTransport struct {
+		maxIdleConnsPerHost int
+		disableKeepAlives   bool
+		idleConnTimeout     time.Duration
+		tlsHandshakeTimeout time.Duration
+		tlsMinVersion       uint16
+		// the proxy of requests to AWS API endpoint, empty if requests bypass the proxy.
+		proxyURL string
+	}
+	tests := []struct {
+		name          string
+		args          []string
+		env           map[string]string
+		wantTransport wantTransport
+		wantErr       error
+	}{
+		{
+			name: "default flags",
+			args: []string{},
+			wantTransport: wantTransport{
+				maxIdleConnsPerHost: http.DefaultMaxIdleConnsPerHost,
+				idleConnTimeout:     90 * time.Second,
+				tlsHandshakeTimeout: 10 * time.Second,
+			},
+		},
+		{
+			name: "custom flags",
+			args: []string{
+				"--aws-http-max-idle-conns-per-host=50",
+				"--aws-http-disable-keep-alives",
+				"--aws-http-idle-conn-timeout=30s",
+				"--aws-http-tls-handshake-timeout=5s",
+				"--aws-http-tls-min-version=TLS1.2",
+				"--aws-https-proxy=http://proxy.example.com:3128",
+			},
+			wantTransport: wantTransport{
+				maxIdleConnsPerHost: 50,
+				disableKeepAlives:   true,
+				idleConnTimeout:     30 * time.Second,
+				tlsHandshakeTimeout: 5 * time.Second,
+				tlsMinVersion:       tls.VersionTLS12,
+				proxyURL:            "http://proxy.example.com:3128",
+			},
+		},
+		{
+			name: "proxy flag overrides environment variable",
+			args: []string{
+				"--aws-https-proxy=http://proxy.example.com:3128",
+			},
+			env: map[string]string{
+				"HTTPS_PROXY": "not-a-proxy",
+			},
+			wantTransport: wantTransport{
+				maxIdleConnsPerHost: http.DefaultMaxIdleConnsPerHost,
+				idleConnTimeout:     90 * time.Second,
+				tlsHandshakeTimeout: 10 * time.Second,
+				proxyURL:            "http://proxy.example.com:3128",
+			},
+		},
+		{
+			name: "proxy without scheme defaults to http",
+			args: []string{
+				"--aws-https-proxy=proxy.example.com:3128",
+			},
+			wantTransport: wantTransport{
+				maxIdleConnsPerHost: http.DefaultMaxIdleConnsPerHost,
+				idleConnTimeout:     90 * time.Second,
+				tlsHandshakeTimeout: 10 * time.Second,
+				proxyURL:            "http://proxy.example.com:3128",
+			},
+		},
+		{
+			name: "proxy without scheme from environment variable defaults to http",
+			args: []string{},
+			env: map[string]string{
+				"HTTPS_PROXY": "proxy:3128",
+			},
+			wantTransport: wantTransport{
+				maxIdleConnsPerHost: http.DefaultMaxIdleConnsPerHost,
+				idleConnTimeout:     90 * time.Second,
+				tlsHandshakeTimeout: 10 * time.Second,
+				proxyURL:            "http://proxy:3128",
+			},
+		},
+		{
+			name: "proxy flag honors NO_PROXY environment variable",
+			args: []string{
+				"--aws-https-proxy=http://proxy.example.com:3128",
+			},
+			env: map[string]string{
+				"NO_PROXY": ".amazonaws.com",
+			},
+			wantTransport: wantTransport{
+				maxIdleConnsPerHost: http.DefaultMaxIdleConnsPerHost,
+				idleConnTimeout:     90 * time.Second,
+				tlsHandshakeTimeout: 10 * time.Second,
+			},
+		},
+		{
+			name: "proxy without host",
+			args: []string{
+				"--aws-https-proxy=http://",
+			},
+			wantErr: errors.New("proxy URL must contain host: http://"),
+		},
+		{
+			name: "invalid proxy from environment variable",
+			args: []string{},
+			env: map[string]string{
+				"HTTPS_PROXY": "ftp://proxy.example.com",
+			},
+			wantErr: errors.New("invalid HTTPS_PROXY environment variable: unsupported proxy URL scheme, must be one of http, https or socks5: ftp://proxy.example.com"),
+		},
+		{
+			name: "unsupported TLS version",
+			args: []string{
+				"--aws-http-tls-min-version=SSL3.0",
+			},
+			wantErr: errors.New("unsupported TLS version: SSL3.0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, env := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy"} {
+				if value, ok := os.LookupEnv(env); ok {
+					defer os.Setenv(env, value)
+				} else {
+					defer os.Unsetenv(env)
+				}
+				os.Unsetenv(env)
+			}
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+
+			cfg := CloudConfig{ThrottleConfig: &throttle.ServiceOperationsThrottleConfig{}}
+			fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+			cfg.BindFlags(fs)
+			assert.NoError(t, fs.Parse(tt.args))
+
+			got, err := buildHTTPClient(cfg)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			transport := got.Transport.(*http.Transport)
+			assert.Equal(t, tt.wantTransport.maxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+			assert.Equal(t, tt.wantTransport.disableKeepAlives, transport.DisableKeepAlives)
+			assert.Equal(t, tt.wantTransport.idleConnTimeout, transport.IdleConnTimeout)
+			assert.Equal(t, tt.wantTransport.tlsHandshakeTimeout, transport.TLSHandshakeTimeout)
+			assert.Equal(t, tt.wantTransport.tlsMinVersion, transport.TLSClientConfig.MinVersion)
+			req, _ := http.NewRequest(http.MethodGet, "https://elasticloadbalancing.us-west-2.amazonaws.com", nil)
+			proxyURL, err := transport.Proxy(req)
+			assert.NoError(t, err)
+			if len(tt.wantTransport.proxyURL) == 0 {
+				assert.Nil(t, proxyURL)
+			} else {
+				assert.Equal(t, tt.wantTransport.proxyURL, proxyURL.String())
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/prometheus/client_golang/prometheus"
@@ -11,6 +12,7 @@ import (
 const (
 	sdkHandlerCollectAPICallMetric    = "collectAPICallMetric"
 	sdkHandlerCollectAPIRequestMetric = "collectAPIRequestMetric"
+	sdkHandlerCollectAPIRetryMetric   = "collectAPIRetryMetric"
 )
 
 type collector struct {
@@ -36,6 +38,10 @@ func (c *collector) InjectHandlers(handlers *request.Handlers) {
 		Name: sdkHandlerCollectAPICallMetric,
 		Fn:   c.collectAPICallMetric,
 	})
+	handlers.AfterRetry.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerCollectAPIRetryMetric,
+		Fn:   c.collectAPIRetryMetric,
+	})
 }
 
 func (c *collector) collectAPIRequestMetric(r *request.Request) {
@@ -80,6 +86,30 @@ func (c *collector) collectAPICallMetric(r *request.Request) {
 	}).Observe(float64(r.RetryCount))
 }
 
+// collectAPIRetryMetric is added to the front of AfterRetry chain, where the error of failed attempt is still available.
+func (c *collector) collectAPIRetryMetric(r *request.Request) {
+	if !willRetryRequest(r) {
+		return
+	}
+	c.instruments.apiRetriesTotal.With(map[string]string{
+		labelService:   r.ClientInfo.ServiceID,
+		labelOperation: operationForRequest(r),
+		labelErrorCode: errorCodeForRequest(r),
+	}).Inc()
+}
+
+// willRetryRequest returns whether the failed attempt of request will be retried by SDK.
+// it mirrors the decision of SDK's AfterRetry handler, which runs after this one.
+func willRetryRequest(r *request.Request) bool {
+	if r.Error == nil || r.RetryCount >= r.MaxRetries() {
+		return false
+	}
+	if r.Retryable != nil && !awssdk.BoolValue(r.Config.EnforceShouldRetryCheck) {
+		return awssdk.BoolValue(r.Retryable)
+	}
+	return r.ShouldRetry(r)
+}
+
 // statusCodeForRequest returns the http status code for request.
 // if there is no http response, returns "0".
 func statusCodeForRequest(r *request.Request) string {
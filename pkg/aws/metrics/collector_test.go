@@ -2,8 +2,13 @@ package metrics
 
 import (
 	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
 	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"testing"
@@ -123,3 +128,76 @@ func Test_operationForRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_collector_collectAPIRetryMetric(t *testing.T) {
+	tests := []struct {
+		name        string
+		retryCount  int
+		retryable   *bool
+		err         error
+		wantRetries float64
+	}{
+		{
+			name:        "throttled request will be retried",
+			err:         awserr.New("Throttling", "Rate exceeded", nil),
+			wantRetries: 1,
+		},
+		{
+			name:        "request marked as retryable will be retried",
+			retryable:   awssdk.Bool(true),
+			err:         awserr.New("Throttling", "Rate exceeded", nil),
+			wantRetries: 1,
+		},
+		{
+			name:        "request marked as not retryable won't be retried",
+			retryable:   awssdk.Bool(false),
+			err:         awserr.New("Throttling", "Rate exceeded", nil),
+			wantRetries: 0,
+		},
+		{
+			name:        "request exhausted max retries won't be retried",
+			retryCount:  3,
+			err:         awserr.New("Throttling", "Rate exceeded", nil),
+			wantRetries: 0,
+		},
+		{
+			name:        "request with non-retryable error won't be retried",
+			err:         awserr.New("ValidationError", "invalid request", nil),
+			wantRetries: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			c, err := NewCollector(registry)
+			assert.NoError(t, err)
+
+			r := &request.Request{
+				ClientInfo: metadata.ClientInfo{ServiceID: "Elastic Load Balancing v2"},
+				Operation:  &request.Operation{Name: "DescribeLoadBalancers"},
+				Retryer:    client.DefaultRetryer{NumMaxRetries: 3},
+				RetryCount: tt.retryCount,
+				Retryable:  tt.retryable,
+				Error:      tt.err,
+			}
+			c.collectAPIRetryMetric(r)
+
+			labels := map[string]string{
+				labelService:   "Elastic Load Balancing v2",
+				labelOperation: "DescribeLoadBalancers",
+				labelErrorCode: errorCodeForRequest(r),
+			}
+			assert.Equal(t, tt.wantRetries, testutil.ToFloat64(c.instruments.apiRetriesTotal.With(labels)))
+		})
+	}
+}
+
+func Test_collector_InjectHandlers(t *testing.T) {
+	c, err := NewCollector(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	handlers := request.Handlers{}
+	c.InjectHandlers(&handlers)
+	assert.Equal(t, 1, handlers.CompleteAttempt.Len())
+	assert.Equal(t, 1, handlers.Complete.Len())
+	assert.Equal(t, 1, handlers.AfterRetry.Len())
+}
@@ -10,6 +10,7 @@ const (
 	metricAPICallsTotal          = "api_calls_total"
 	metricAPICallDurationSeconds = "api_call_duration_seconds"
 	metricAPICallRetries         = "api_call_retries"
+	metricAPIRetriesTotal        = "api_retries_total"
 
 	metricAPIRequestsTotal          = "api_requests_total"
 	metricAPIRequestDurationSeconds = "api_request_duration_seconds"
@@ -26,6 +27,7 @@ type instruments struct {
 	apiCallsTotal            *prometheus.CounterVec
 	apiCallDurationSeconds   *prometheus.HistogramVec
 	apiCallRetries           *prometheus.HistogramVec
+	apiRetriesTotal          *prometheus.CounterVec
 	apiRequestsTotal         *prometheus.CounterVec
 	apiRequestDurationSecond *prometheus.HistogramVec
 }
@@ -48,6 +50,11 @@ func newInstruments(registerer prometheus.Registerer) (*instruments, error) {
 		Help:      "Number of times the SDK retried requests to AWS services for SDK API calls",
 		Buckets:   []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
 	}, []string{labelService, labelOperation})
+	apiRetriesTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemAWS,
+		Name:      metricAPIRetriesTotal,
+		Help:      "Total number of HTTP requests that the SDK retried, labeled by the error code of the retried request",
+	}, []string{labelService, labelOperation, labelErrorCode})
 
 	apiRequestsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Subsystem: metricSubsystemAWS,
@@ -69,6 +76,9 @@ func newInstruments(registerer prometheus.Registerer) (*instruments, error) {
 	if err := registerer.Register(apiCallRetries); err != nil {
 		return nil, err
 	}
+	if err := registerer.Register(apiRetriesTotal); err != nil {
+		return nil, err
+	}
 	if err := registerer.Register(apiRequestsTotal); err != nil {
 		return nil, err
 	}
@@ -79,6 +89,7 @@ func newInstruments(registerer prometheus.Registerer) (*instruments, error) {
 		apiCallsTotal:            apiCallsTotal,
 		apiCallDurationSeconds:   apiCallDurationSeconds,
 		apiCallRetries:           apiCallRetries,
+		apiRetriesTotal:          apiRetriesTotal,
 		apiRequestsTotal:         apiRequestsTotal,
 		apiRequestDurationSecond: apiRequestDurationSecond,
 	}, nil
@@ -0,0 +1,16 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/pkg/errors"
+)
+
+// resolvePartition resolves the AWS partition(e.g. aws, aws-cn, aws-us-gov, aws-iso, aws-iso-b) that region belongs to.
+// Endpoints and ARNs for AWS resources must be built according to the partition.
+func resolvePartition(region string) (endpoints.Partition, error) {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return endpoints.Partition{}, errors.Errorf("unrecognized AWS partition for region %v", region)
+	}
+	return partition, nil
+}
@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resolvePartition(t *testing.T) {
+	tests := []struct {
+		name          string
+		region        string
+		wantPartition string
+		wantDNSSuffix string
+		wantErr       error
+	}{
+		{
+			name:          "standard region",
+			region:        "us-west-2",
+			wantPartition: "aws",
+			wantDNSSuffix: "amazonaws.com",
+		},
+		{
+			name:          "china region",
+			region:        "cn-north-1",
+			wantPartition: "aws-cn",
+			wantDNSSuffix: "amazonaws.com.cn",
+		},
+		{
+			name:          "govcloud region",
+			region:        "us-gov-west-1",
+			wantPartition: "aws-us-gov",
+			wantDNSSuffix: "amazonaws.com",
+		},
+		{
+			name:          "iso region",
+			region:        "us-iso-east-1",
+			wantPartition: "aws-iso",
+			wantDNSSuffix: "c2s.ic.gov",
+		},
+		{
+			name:          "iso-b region",
+			region:        "us-isob-east-1",
+			wantPartition: "aws-iso-b",
+			wantDNSSuffix: "sc2s.sgov.gov",
+		},
+		{
+			name:    "unrecognized region",
+			region:  "mars-east-1",
+			wantErr: errors.New("unrecognized AWS partition for region mars-east-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePartition(tt.region)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPartition, got.ID())
+				assert.Equal(t, tt.wantDNSSuffix, got.DNSSuffix())
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package aws
+
+import (
+	"context"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// accessDeniedErrorCodes are AWS error codes that indicates the IAM permission of API call is missing.
+var accessDeniedErrorCodes = sets.NewString(
+	"AccessDenied",
+	"AccessDeniedException",
+	"UnauthorizedOperation",
+)
+
+// PermissionsValidatorOptions contains the features that affects the IAM permissions to validate.
+type PermissionsValidatorOptions struct {
+	// whether WAF addon is enabled.
+	WAFEnabled bool
+	// whether WAFv2 addon is enabled.
+	WAFV2Enabled bool
+	// whether Shield addon is enabled.
+	ShieldEnabled bool
+}
+
+// PermissionsValidator validates the IAM permissions of controller with harmless describe calls.
+type PermissionsValidator interface {
+	// Validate returns the IAM permissions that are missing, e.g. elasticloadbalancing:DescribeLoadBalancers.
+	// API calls failed for other reasons are logged, since the permission cannot be determined.
+	Validate(ctx context.Context) []string
+}
+
+// NewDefaultPermissionsValidator constructs new defaultPermissionsValidator.
+func NewDefaultPermissionsValidator(cloud Cloud, opts PermissionsValidatorOptions, logger logr.Logger) *defaultPermissionsValidator {
+	return &defaultPermissionsValidator{
+		cloud:  cloud,
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+var _ PermissionsValidator = &defaultPermissionsValidator{}
+
+// default implementation for PermissionsValidator.
+type defaultPermissionsValidator struct {
+	cloud  Cloud
+	opts   PermissionsValidatorOptions
+	logger logr.Logger
+}
+
+// permissionCheck checks an IAM permission by invoking the API it grants.
+type permissionCheck struct {
+	// the IAM permission checked.
+	permission string
+	// invoke the API granted by permission.
+	invoke func(ctx context.Context) error
+}
+
+func (v *defaultPermissionsValidator) Validate(ctx context.Context) []string {
+	var missingPermissions []string
+	for _, check := range v.buildPermissionChecks() {
+		err := check.invoke(ctx)
+		if err == nil {
+			continue
+		}
+		if isAccessDeniedError(err) {
+			missingPermissions = append(missingPermissions, check.permission)
+			continue
+		}
+		v.logger.Error(err, "failed to validate IAM permission",
+			"permission", check.permission)
+	}
+	return missingPermissions
+}
+
+// buildPermissionChecks builds the checks for IAM permissions required by enabled features.
+func (v *defaultPermissionsValidator) buildPermissionChecks() []permissionCheck {
+	vpcIDFilter := []*ec2.Filter{
+		{
+			Name:   awssdk.String("vpc-id"),
+			Values: awssdk.StringSlice([]string{v.cloud.VpcID()}),
+		},
+	}
+	checks := []permissionCheck{
+		{
+			permission: "elasticloadbalancing:DescribeLoadBalancers",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.ELBV2().DescribeLoadBalancersWithContext(ctx, &elbv2.DescribeLoadBalancersInput{
+					PageSize: awssdk.Int64(1),
+				})
+				return err
+			},
+		},
+		{
+			permission: "elasticloadbalancing:DescribeTargetGroups",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.ELBV2().DescribeTargetGroupsWithContext(ctx, &elbv2.DescribeTargetGroupsInput{
+					PageSize: awssdk.Int64(1),
+				})
+				return err
+			},
+		},
+		{
+			permission: "ec2:DescribeVpcs",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.EC2().DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{
+					VpcIds: awssdk.StringSlice([]string{v.cloud.VpcID()}),
+				})
+				return err
+			},
+		},
+		{
+			permission: "ec2:DescribeSubnets",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.EC2().DescribeSubnetsWithContext(ctx, &ec2.DescribeSubnetsInput{
+					Filters:    vpcIDFilter,
+					MaxResults: awssdk.Int64(5),
+				})
+				return err
+			},
+		},
+		{
+			permission: "ec2:DescribeSecurityGroups",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.EC2().DescribeSecurityGroupsWithContext(ctx, &ec2.DescribeSecurityGroupsInput{
+					Filters:    vpcIDFilter,
+					MaxResults: awssdk.Int64(5),
+				})
+				return err
+			},
+		},
+		{
+			permission: "ec2:DescribeNetworkInterfaces",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.EC2().DescribeNetworkInterfacesWithContext(ctx, &ec2.DescribeNetworkInterfacesInput{
+					Filters:    vpcIDFilter,
+					MaxResults: awssdk.Int64(5),
+				})
+				return err
+			},
+		},
+		{
+			permission: "acm:ListCertificates",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.ACM().ListCertificatesWithContext(ctx, &acm.ListCertificatesInput{
+					MaxItems: awssdk.Int64(1),
+				})
+				return err
+			},
+		},
+	}
+	if v.opts.WAFEnabled && v.cloud.WAFRegional().Available() {
+		checks = append(checks, permissionCheck{
+			permission: "waf-regional:ListWebACLs",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.WAFRegional().ListWebACLsWithContext(ctx, &waf.ListWebACLsInput{
+					Limit: awssdk.Int64(1),
+				})
+				return err
+			},
+		})
+	}
+	if v.opts.WAFV2Enabled {
+		checks = append(checks, permissionCheck{
+			permission: "wafv2:ListWebACLs",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.WAFv2().ListWebACLsWithContext(ctx, &wafv2.ListWebACLsInput{
+					Scope: awssdk.String(wafv2.ScopeRegional),
+					Limit: awssdk.Int64(1),
+				})
+				return err
+			},
+		})
+	}
+	if v.opts.ShieldEnabled {
+		checks = append(checks, permissionCheck{
+			permission: "shield:GetSubscriptionState",
+			invoke: func(ctx context.Context) error {
+				_, err := v.cloud.Shield().GetSubscriptionStateWithContext(ctx, &shield.GetSubscriptionStateInput{})
+				return err
+			},
+		})
+	}
+	return checks
+}
+
+// isAccessDeniedError checks whether err indicates the IAM permission of API call is missing.
+func isAccessDeniedError(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return accessDeniedErrorCodes.Has(awsErr.Code())
+	}
+	return false
+}
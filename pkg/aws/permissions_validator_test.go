@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/awstesting/unit"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// newFakeCloud constructs a cloud whose API calls succeed with empty responses, except calls of deniedPermissions
+// which fail with deniedErr. The permission of API call is in the form of signingName:operationName.
+func newFakeCloud(deniedPermissions sets.String, deniedErr error) *defaultCloud {
+	sess := unit.Session.Copy()
+	sess.Handlers.Send.Clear()
+	sess.Handlers.Send.PushBack(func(r *request.Request) {
+		permission := r.ClientInfo.SigningName + ":" + r.Operation.Name
+		if deniedPermissions.Has(permission) {
+			r.Error = deniedErr
+			return
+		}
+		r.HTTPResponse = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}
+	})
+	return &defaultCloud{
+		cfg:         CloudConfig{Region: "us-west-2", VpcID: "vpc-xxxxxxxx"},
+		sess:        sess,
+		ec2:         services.NewEC2(sess),
+		elbv2:       services.NewELBV2(sess),
+		acm:         services.NewACM(sess),
+		wafv2:       services.NewWAFv2(sess),
+		wafRegional: services.NewWAFRegional(sess, "us-west-2"),
+		shield:      services.NewShield(sess),
+	}
+}
+
+func Test_defaultPermissionsValidator_Validate(t *testing.T) {
+	accessDeniedErr := awserr.New("AccessDenied", "User is not authorized to perform this operation", nil)
+	tests := []struct {
+		name              string
+		opts              PermissionsValidatorOptions
+		deniedPermissions sets.String
+		deniedErr         error
+		want              []string
+	}{
+		{
+			name:              "all permissions granted",
+			opts:              PermissionsValidatorOptions{WAFEnabled: true, WAFV2Enabled: true, ShieldEnabled: true},
+			deniedPermissions: sets.NewString(),
+			want:              nil,
+		},
+		{
+			name: "subset of permissions denied",
+			opts: PermissionsValidatorOptions{WAFEnabled: true, WAFV2Enabled: true, ShieldEnabled: true},
+			deniedPermissions: sets.NewString(
+				"elasticloadbalancing:DescribeTargetGroups",
+				"ec2:DescribeSecurityGroups",
+				"wafv2:ListWebACLs",
+				"shield:GetSubscriptionState",
+			),
+			deniedErr: accessDeniedErr,
+			want: []string{
+				"elasticloadbalancing:DescribeTargetGroups",
+				"ec2:DescribeSecurityGroups",
+				"wafv2:ListWebACLs",
+				"shield:GetSubscriptionState",
+			},
+		},
+		{
+			name: "permissions of disabled addons are not validated",
+			opts: PermissionsValidatorOptions{WAFEnabled: false, WAFV2Enabled: false, ShieldEnabled: false},
+			deniedPermissions: sets.NewString(
+				"acm:ListCertificates",
+				"waf-regional:ListWebACLs",
+				"wafv2:ListWebACLs",
+				"shield:GetSubscriptionState",
+			),
+			deniedErr: accessDeniedErr,
+			want: []string{
+				"acm:ListCertificates",
+			},
+		},
+		{
+			name: "ec2 UnauthorizedOperation is reported as missing permission",
+			opts: PermissionsValidatorOptions{},
+			deniedPermissions: sets.NewString(
+				"ec2:DescribeNetworkInterfaces",
+			),
+			deniedErr: awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil),
+			want: []string{
+				"ec2:DescribeNetworkInterfaces",
+			},
+		},
+		{
+			name: "failures other than access denied are not reported as missing permission",
+			opts: PermissionsValidatorOptions{},
+			deniedPermissions: sets.NewString(
+				"ec2:DescribeVpcs",
+			),
+			deniedErr: errors.New("connection reset by peer"),
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cloud := newFakeCloud(tt.deniedPermissions, tt.deniedErr)
+			v := NewDefaultPermissionsValidator(cloud, tt.opts, &log.NullLogger{})
+			got := v.Validate(context.Background())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,868 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services (interfaces: ACM)
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request "github.com/aws/aws-sdk-go/aws/request"
+	acm "github.com/aws/aws-sdk-go/service/acm"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockACM is a mock of ACM interface.
+type MockACM struct {
+	ctrl     *gomock.Controller
+	recorder *MockACMMockRecorder
+}
+
+// MockACMMockRecorder is the mock recorder for MockACM.
+type MockACMMockRecorder struct {
+	mock *MockACM
+}
+
+// NewMockACM creates a new mock instance.
+func NewMockACM(ctrl *gomock.Controller) *MockACM {
+	mock := &MockACM{ctrl: ctrl}
+	mock.recorder = &MockACMMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockACM) EXPECT() *MockACMMockRecorder {
+	return m.recorder
+}
+
+// AddTagsToCertificate mocks base method.
+func (m *MockACM) AddTagsToCertificate(arg0 *acm.AddTagsToCertificateInput) (*acm.AddTagsToCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTagsToCertificate", arg0)
+	ret0, _ := ret[0].(*acm.AddTagsToCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTagsToCertificate indicates an expected call of AddTagsToCertificate.
+func (mr *MockACMMockRecorder) AddTagsToCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagsToCertificate", reflect.TypeOf((*MockACM)(nil).AddTagsToCertificate), arg0)
+}
+
+// AddTagsToCertificateRequest mocks base method.
+func (m *MockACM) AddTagsToCertificateRequest(arg0 *acm.AddTagsToCertificateInput) (*request.Request, *acm.AddTagsToCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTagsToCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.AddTagsToCertificateOutput)
+	return ret0, ret1
+}
+
+// AddTagsToCertificateRequest indicates an expected call of AddTagsToCertificateRequest.
+func (mr *MockACMMockRecorder) AddTagsToCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagsToCertificateRequest", reflect.TypeOf((*MockACM)(nil).AddTagsToCertificateRequest), arg0)
+}
+
+// AddTagsToCertificateWithContext mocks base method.
+func (m *MockACM) AddTagsToCertificateWithContext(arg0 context.Context, arg1 *acm.AddTagsToCertificateInput, arg2 ...request.Option) (*acm.AddTagsToCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddTagsToCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.AddTagsToCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTagsToCertificateWithContext indicates an expected call of AddTagsToCertificateWithContext.
+func (mr *MockACMMockRecorder) AddTagsToCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagsToCertificateWithContext", reflect.TypeOf((*MockACM)(nil).AddTagsToCertificateWithContext), varargs...)
+}
+
+// DeleteCertificate mocks base method.
+func (m *MockACM) DeleteCertificate(arg0 *acm.DeleteCertificateInput) (*acm.DeleteCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCertificate", arg0)
+	ret0, _ := ret[0].(*acm.DeleteCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCertificate indicates an expected call of DeleteCertificate.
+func (mr *MockACMMockRecorder) DeleteCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCertificate", reflect.TypeOf((*MockACM)(nil).DeleteCertificate), arg0)
+}
+
+// DeleteCertificateRequest mocks base method.
+func (m *MockACM) DeleteCertificateRequest(arg0 *acm.DeleteCertificateInput) (*request.Request, *acm.DeleteCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.DeleteCertificateOutput)
+	return ret0, ret1
+}
+
+// DeleteCertificateRequest indicates an expected call of DeleteCertificateRequest.
+func (mr *MockACMMockRecorder) DeleteCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCertificateRequest", reflect.TypeOf((*MockACM)(nil).DeleteCertificateRequest), arg0)
+}
+
+// DeleteCertificateWithContext mocks base method.
+func (m *MockACM) DeleteCertificateWithContext(arg0 context.Context, arg1 *acm.DeleteCertificateInput, arg2 ...request.Option) (*acm.DeleteCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.DeleteCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCertificateWithContext indicates an expected call of DeleteCertificateWithContext.
+func (mr *MockACMMockRecorder) DeleteCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCertificateWithContext", reflect.TypeOf((*MockACM)(nil).DeleteCertificateWithContext), varargs...)
+}
+
+// DescribeCertificate mocks base method.
+func (m *MockACM) DescribeCertificate(arg0 *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeCertificate", arg0)
+	ret0, _ := ret[0].(*acm.DescribeCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeCertificate indicates an expected call of DescribeCertificate.
+func (mr *MockACMMockRecorder) DescribeCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCertificate", reflect.TypeOf((*MockACM)(nil).DescribeCertificate), arg0)
+}
+
+// DescribeCertificateRequest mocks base method.
+func (m *MockACM) DescribeCertificateRequest(arg0 *acm.DescribeCertificateInput) (*request.Request, *acm.DescribeCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.DescribeCertificateOutput)
+	return ret0, ret1
+}
+
+// DescribeCertificateRequest indicates an expected call of DescribeCertificateRequest.
+func (mr *MockACMMockRecorder) DescribeCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCertificateRequest", reflect.TypeOf((*MockACM)(nil).DescribeCertificateRequest), arg0)
+}
+
+// DescribeCertificateWithContext mocks base method.
+func (m *MockACM) DescribeCertificateWithContext(arg0 context.Context, arg1 *acm.DescribeCertificateInput, arg2 ...request.Option) (*acm.DescribeCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.DescribeCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeCertificateWithContext indicates an expected call of DescribeCertificateWithContext.
+func (mr *MockACMMockRecorder) DescribeCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCertificateWithContext", reflect.TypeOf((*MockACM)(nil).DescribeCertificateWithContext), varargs...)
+}
+
+// ExportCertificate mocks base method.
+func (m *MockACM) ExportCertificate(arg0 *acm.ExportCertificateInput) (*acm.ExportCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCertificate", arg0)
+	ret0, _ := ret[0].(*acm.ExportCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCertificate indicates an expected call of ExportCertificate.
+func (mr *MockACMMockRecorder) ExportCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCertificate", reflect.TypeOf((*MockACM)(nil).ExportCertificate), arg0)
+}
+
+// ExportCertificateRequest mocks base method.
+func (m *MockACM) ExportCertificateRequest(arg0 *acm.ExportCertificateInput) (*request.Request, *acm.ExportCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.ExportCertificateOutput)
+	return ret0, ret1
+}
+
+// ExportCertificateRequest indicates an expected call of ExportCertificateRequest.
+func (mr *MockACMMockRecorder) ExportCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCertificateRequest", reflect.TypeOf((*MockACM)(nil).ExportCertificateRequest), arg0)
+}
+
+// ExportCertificateWithContext mocks base method.
+func (m *MockACM) ExportCertificateWithContext(arg0 context.Context, arg1 *acm.ExportCertificateInput, arg2 ...request.Option) (*acm.ExportCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExportCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.ExportCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCertificateWithContext indicates an expected call of ExportCertificateWithContext.
+func (mr *MockACMMockRecorder) ExportCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCertificateWithContext", reflect.TypeOf((*MockACM)(nil).ExportCertificateWithContext), varargs...)
+}
+
+// GetAccountConfiguration mocks base method.
+func (m *MockACM) GetAccountConfiguration(arg0 *acm.GetAccountConfigurationInput) (*acm.GetAccountConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountConfiguration", arg0)
+	ret0, _ := ret[0].(*acm.GetAccountConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountConfiguration indicates an expected call of GetAccountConfiguration.
+func (mr *MockACMMockRecorder) GetAccountConfiguration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountConfiguration", reflect.TypeOf((*MockACM)(nil).GetAccountConfiguration), arg0)
+}
+
+// GetAccountConfigurationRequest mocks base method.
+func (m *MockACM) GetAccountConfigurationRequest(arg0 *acm.GetAccountConfigurationInput) (*request.Request, *acm.GetAccountConfigurationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountConfigurationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.GetAccountConfigurationOutput)
+	return ret0, ret1
+}
+
+// GetAccountConfigurationRequest indicates an expected call of GetAccountConfigurationRequest.
+func (mr *MockACMMockRecorder) GetAccountConfigurationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountConfigurationRequest", reflect.TypeOf((*MockACM)(nil).GetAccountConfigurationRequest), arg0)
+}
+
+// GetAccountConfigurationWithContext mocks base method.
+func (m *MockACM) GetAccountConfigurationWithContext(arg0 context.Context, arg1 *acm.GetAccountConfigurationInput, arg2 ...request.Option) (*acm.GetAccountConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAccountConfigurationWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.GetAccountConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountConfigurationWithContext indicates an expected call of GetAccountConfigurationWithContext.
+func (mr *MockACMMockRecorder) GetAccountConfigurationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountConfigurationWithContext", reflect.TypeOf((*MockACM)(nil).GetAccountConfigurationWithContext), varargs...)
+}
+
+// GetCertificate mocks base method.
+func (m *MockACM) GetCertificate(arg0 *acm.GetCertificateInput) (*acm.GetCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificate", arg0)
+	ret0, _ := ret[0].(*acm.GetCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificate indicates an expected call of GetCertificate.
+func (mr *MockACMMockRecorder) GetCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificate", reflect.TypeOf((*MockACM)(nil).GetCertificate), arg0)
+}
+
+// GetCertificateRequest mocks base method.
+func (m *MockACM) GetCertificateRequest(arg0 *acm.GetCertificateInput) (*request.Request, *acm.GetCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.GetCertificateOutput)
+	return ret0, ret1
+}
+
+// GetCertificateRequest indicates an expected call of GetCertificateRequest.
+func (mr *MockACMMockRecorder) GetCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificateRequest", reflect.TypeOf((*MockACM)(nil).GetCertificateRequest), arg0)
+}
+
+// GetCertificateWithContext mocks base method.
+func (m *MockACM) GetCertificateWithContext(arg0 context.Context, arg1 *acm.GetCertificateInput, arg2 ...request.Option) (*acm.GetCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.GetCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificateWithContext indicates an expected call of GetCertificateWithContext.
+func (mr *MockACMMockRecorder) GetCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificateWithContext", reflect.TypeOf((*MockACM)(nil).GetCertificateWithContext), varargs...)
+}
+
+// ImportCertificate mocks base method.
+func (m *MockACM) ImportCertificate(arg0 *acm.ImportCertificateInput) (*acm.ImportCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCertificate", arg0)
+	ret0, _ := ret[0].(*acm.ImportCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCertificate indicates an expected call of ImportCertificate.
+func (mr *MockACMMockRecorder) ImportCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCertificate", reflect.TypeOf((*MockACM)(nil).ImportCertificate), arg0)
+}
+
+// ImportCertificateRequest mocks base method.
+func (m *MockACM) ImportCertificateRequest(arg0 *acm.ImportCertificateInput) (*request.Request, *acm.ImportCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.ImportCertificateOutput)
+	return ret0, ret1
+}
+
+// ImportCertificateRequest indicates an expected call of ImportCertificateRequest.
+func (mr *MockACMMockRecorder) ImportCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCertificateRequest", reflect.TypeOf((*MockACM)(nil).ImportCertificateRequest), arg0)
+}
+
+// ImportCertificateWithContext mocks base method.
+func (m *MockACM) ImportCertificateWithContext(arg0 context.Context, arg1 *acm.ImportCertificateInput, arg2 ...request.Option) (*acm.ImportCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.ImportCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCertificateWithContext indicates an expected call of ImportCertificateWithContext.
+func (mr *MockACMMockRecorder) ImportCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCertificateWithContext", reflect.TypeOf((*MockACM)(nil).ImportCertificateWithContext), varargs...)
+}
+
+// ListCertificates mocks base method.
+func (m *MockACM) ListCertificates(arg0 *acm.ListCertificatesInput) (*acm.ListCertificatesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCertificates", arg0)
+	ret0, _ := ret[0].(*acm.ListCertificatesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCertificates indicates an expected call of ListCertificates.
+func (mr *MockACMMockRecorder) ListCertificates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificates", reflect.TypeOf((*MockACM)(nil).ListCertificates), arg0)
+}
+
+// ListCertificatesAsList mocks base method.
+func (m *MockACM) ListCertificatesAsList(arg0 context.Context, arg1 *acm.ListCertificatesInput) ([]*acm.CertificateSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCertificatesAsList", arg0, arg1)
+	ret0, _ := ret[0].([]*acm.CertificateSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCertificatesAsList indicates an expected call of ListCertificatesAsList.
+func (mr *MockACMMockRecorder) ListCertificatesAsList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificatesAsList", reflect.TypeOf((*MockACM)(nil).ListCertificatesAsList), arg0, arg1)
+}
+
+// ListCertificatesPages mocks base method.
+func (m *MockACM) ListCertificatesPages(arg0 *acm.ListCertificatesInput, arg1 func(*acm.ListCertificatesOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCertificatesPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListCertificatesPages indicates an expected call of ListCertificatesPages.
+func (mr *MockACMMockRecorder) ListCertificatesPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificatesPages", reflect.TypeOf((*MockACM)(nil).ListCertificatesPages), arg0, arg1)
+}
+
+// ListCertificatesPagesWithContext mocks base method.
+func (m *MockACM) ListCertificatesPagesWithContext(arg0 context.Context, arg1 *acm.ListCertificatesInput, arg2 func(*acm.ListCertificatesOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCertificatesPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListCertificatesPagesWithContext indicates an expected call of ListCertificatesPagesWithContext.
+func (mr *MockACMMockRecorder) ListCertificatesPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificatesPagesWithContext", reflect.TypeOf((*MockACM)(nil).ListCertificatesPagesWithContext), varargs...)
+}
+
+// ListCertificatesRequest mocks base method.
+func (m *MockACM) ListCertificatesRequest(arg0 *acm.ListCertificatesInput) (*request.Request, *acm.ListCertificatesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCertificatesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.ListCertificatesOutput)
+	return ret0, ret1
+}
+
+// ListCertificatesRequest indicates an expected call of ListCertificatesRequest.
+func (mr *MockACMMockRecorder) ListCertificatesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificatesRequest", reflect.TypeOf((*MockACM)(nil).ListCertificatesRequest), arg0)
+}
+
+// ListCertificatesWithContext mocks base method.
+func (m *MockACM) ListCertificatesWithContext(arg0 context.Context, arg1 *acm.ListCertificatesInput, arg2 ...request.Option) (*acm.ListCertificatesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCertificatesWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.ListCertificatesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCertificatesWithContext indicates an expected call of ListCertificatesWithContext.
+func (mr *MockACMMockRecorder) ListCertificatesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificatesWithContext", reflect.TypeOf((*MockACM)(nil).ListCertificatesWithContext), varargs...)
+}
+
+// ListTagsForCertificate mocks base method.
+func (m *MockACM) ListTagsForCertificate(arg0 *acm.ListTagsForCertificateInput) (*acm.ListTagsForCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForCertificate", arg0)
+	ret0, _ := ret[0].(*acm.ListTagsForCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForCertificate indicates an expected call of ListTagsForCertificate.
+func (mr *MockACMMockRecorder) ListTagsForCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForCertificate", reflect.TypeOf((*MockACM)(nil).ListTagsForCertificate), arg0)
+}
+
+// ListTagsForCertificateRequest mocks base method.
+func (m *MockACM) ListTagsForCertificateRequest(arg0 *acm.ListTagsForCertificateInput) (*request.Request, *acm.ListTagsForCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.ListTagsForCertificateOutput)
+	return ret0, ret1
+}
+
+// ListTagsForCertificateRequest indicates an expected call of ListTagsForCertificateRequest.
+func (mr *MockACMMockRecorder) ListTagsForCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForCertificateRequest", reflect.TypeOf((*MockACM)(nil).ListTagsForCertificateRequest), arg0)
+}
+
+// ListTagsForCertificateWithContext mocks base method.
+func (m *MockACM) ListTagsForCertificateWithContext(arg0 context.Context, arg1 *acm.ListTagsForCertificateInput, arg2 ...request.Option) (*acm.ListTagsForCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsForCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.ListTagsForCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForCertificateWithContext indicates an expected call of ListTagsForCertificateWithContext.
+func (mr *MockACMMockRecorder) ListTagsForCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForCertificateWithContext", reflect.TypeOf((*MockACM)(nil).ListTagsForCertificateWithContext), varargs...)
+}
+
+// PutAccountConfiguration mocks base method.
+func (m *MockACM) PutAccountConfiguration(arg0 *acm.PutAccountConfigurationInput) (*acm.PutAccountConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutAccountConfiguration", arg0)
+	ret0, _ := ret[0].(*acm.PutAccountConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutAccountConfiguration indicates an expected call of PutAccountConfiguration.
+func (mr *MockACMMockRecorder) PutAccountConfiguration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAccountConfiguration", reflect.TypeOf((*MockACM)(nil).PutAccountConfiguration), arg0)
+}
+
+// PutAccountConfigurationRequest mocks base method.
+func (m *MockACM) PutAccountConfigurationRequest(arg0 *acm.PutAccountConfigurationInput) (*request.Request, *acm.PutAccountConfigurationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutAccountConfigurationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.PutAccountConfigurationOutput)
+	return ret0, ret1
+}
+
+// PutAccountConfigurationRequest indicates an expected call of PutAccountConfigurationRequest.
+func (mr *MockACMMockRecorder) PutAccountConfigurationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAccountConfigurationRequest", reflect.TypeOf((*MockACM)(nil).PutAccountConfigurationRequest), arg0)
+}
+
+// PutAccountConfigurationWithContext mocks base method.
+func (m *MockACM) PutAccountConfigurationWithContext(arg0 context.Context, arg1 *acm.PutAccountConfigurationInput, arg2 ...request.Option) (*acm.PutAccountConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutAccountConfigurationWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.PutAccountConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutAccountConfigurationWithContext indicates an expected call of PutAccountConfigurationWithContext.
+func (mr *MockACMMockRecorder) PutAccountConfigurationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAccountConfigurationWithContext", reflect.TypeOf((*MockACM)(nil).PutAccountConfigurationWithContext), varargs...)
+}
+
+// RemoveTagsFromCertificate mocks base method.
+func (m *MockACM) RemoveTagsFromCertificate(arg0 *acm.RemoveTagsFromCertificateInput) (*acm.RemoveTagsFromCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTagsFromCertificate", arg0)
+	ret0, _ := ret[0].(*acm.RemoveTagsFromCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTagsFromCertificate indicates an expected call of RemoveTagsFromCertificate.
+func (mr *MockACMMockRecorder) RemoveTagsFromCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagsFromCertificate", reflect.TypeOf((*MockACM)(nil).RemoveTagsFromCertificate), arg0)
+}
+
+// RemoveTagsFromCertificateRequest mocks base method.
+func (m *MockACM) RemoveTagsFromCertificateRequest(arg0 *acm.RemoveTagsFromCertificateInput) (*request.Request, *acm.RemoveTagsFromCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTagsFromCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.RemoveTagsFromCertificateOutput)
+	return ret0, ret1
+}
+
+// RemoveTagsFromCertificateRequest indicates an expected call of RemoveTagsFromCertificateRequest.
+func (mr *MockACMMockRecorder) RemoveTagsFromCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagsFromCertificateRequest", reflect.TypeOf((*MockACM)(nil).RemoveTagsFromCertificateRequest), arg0)
+}
+
+// RemoveTagsFromCertificateWithContext mocks base method.
+func (m *MockACM) RemoveTagsFromCertificateWithContext(arg0 context.Context, arg1 *acm.RemoveTagsFromCertificateInput, arg2 ...request.Option) (*acm.RemoveTagsFromCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveTagsFromCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.RemoveTagsFromCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTagsFromCertificateWithContext indicates an expected call of RemoveTagsFromCertificateWithContext.
+func (mr *MockACMMockRecorder) RemoveTagsFromCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagsFromCertificateWithContext", reflect.TypeOf((*MockACM)(nil).RemoveTagsFromCertificateWithContext), varargs...)
+}
+
+// RenewCertificate mocks base method.
+func (m *MockACM) RenewCertificate(arg0 *acm.RenewCertificateInput) (*acm.RenewCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewCertificate", arg0)
+	ret0, _ := ret[0].(*acm.RenewCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewCertificate indicates an expected call of RenewCertificate.
+func (mr *MockACMMockRecorder) RenewCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewCertificate", reflect.TypeOf((*MockACM)(nil).RenewCertificate), arg0)
+}
+
+// RenewCertificateRequest mocks base method.
+func (m *MockACM) RenewCertificateRequest(arg0 *acm.RenewCertificateInput) (*request.Request, *acm.RenewCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.RenewCertificateOutput)
+	return ret0, ret1
+}
+
+// RenewCertificateRequest indicates an expected call of RenewCertificateRequest.
+func (mr *MockACMMockRecorder) RenewCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewCertificateRequest", reflect.TypeOf((*MockACM)(nil).RenewCertificateRequest), arg0)
+}
+
+// RenewCertificateWithContext mocks base method.
+func (m *MockACM) RenewCertificateWithContext(arg0 context.Context, arg1 *acm.RenewCertificateInput, arg2 ...request.Option) (*acm.RenewCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RenewCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.RenewCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewCertificateWithContext indicates an expected call of RenewCertificateWithContext.
+func (mr *MockACMMockRecorder) RenewCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewCertificateWithContext", reflect.TypeOf((*MockACM)(nil).RenewCertificateWithContext), varargs...)
+}
+
+// RequestCertificate mocks base method.
+func (m *MockACM) RequestCertificate(arg0 *acm.RequestCertificateInput) (*acm.RequestCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCertificate", arg0)
+	ret0, _ := ret[0].(*acm.RequestCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestCertificate indicates an expected call of RequestCertificate.
+func (mr *MockACMMockRecorder) RequestCertificate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCertificate", reflect.TypeOf((*MockACM)(nil).RequestCertificate), arg0)
+}
+
+// RequestCertificateRequest mocks base method.
+func (m *MockACM) RequestCertificateRequest(arg0 *acm.RequestCertificateInput) (*request.Request, *acm.RequestCertificateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCertificateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.RequestCertificateOutput)
+	return ret0, ret1
+}
+
+// RequestCertificateRequest indicates an expected call of RequestCertificateRequest.
+func (mr *MockACMMockRecorder) RequestCertificateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCertificateRequest", reflect.TypeOf((*MockACM)(nil).RequestCertificateRequest), arg0)
+}
+
+// RequestCertificateWithContext mocks base method.
+func (m *MockACM) RequestCertificateWithContext(arg0 context.Context, arg1 *acm.RequestCertificateInput, arg2 ...request.Option) (*acm.RequestCertificateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestCertificateWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.RequestCertificateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestCertificateWithContext indicates an expected call of RequestCertificateWithContext.
+func (mr *MockACMMockRecorder) RequestCertificateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCertificateWithContext", reflect.TypeOf((*MockACM)(nil).RequestCertificateWithContext), varargs...)
+}
+
+// ResendValidationEmail mocks base method.
+func (m *MockACM) ResendValidationEmail(arg0 *acm.ResendValidationEmailInput) (*acm.ResendValidationEmailOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendValidationEmail", arg0)
+	ret0, _ := ret[0].(*acm.ResendValidationEmailOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendValidationEmail indicates an expected call of ResendValidationEmail.
+func (mr *MockACMMockRecorder) ResendValidationEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendValidationEmail", reflect.TypeOf((*MockACM)(nil).ResendValidationEmail), arg0)
+}
+
+// ResendValidationEmailRequest mocks base method.
+func (m *MockACM) ResendValidationEmailRequest(arg0 *acm.ResendValidationEmailInput) (*request.Request, *acm.ResendValidationEmailOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendValidationEmailRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.ResendValidationEmailOutput)
+	return ret0, ret1
+}
+
+// ResendValidationEmailRequest indicates an expected call of ResendValidationEmailRequest.
+func (mr *MockACMMockRecorder) ResendValidationEmailRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendValidationEmailRequest", reflect.TypeOf((*MockACM)(nil).ResendValidationEmailRequest), arg0)
+}
+
+// ResendValidationEmailWithContext mocks base method.
+func (m *MockACM) ResendValidationEmailWithContext(arg0 context.Context, arg1 *acm.ResendValidationEmailInput, arg2 ...request.Option) (*acm.ResendValidationEmailOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResendValidationEmailWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.ResendValidationEmailOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendValidationEmailWithContext indicates an expected call of ResendValidationEmailWithContext.
+func (mr *MockACMMockRecorder) ResendValidationEmailWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendValidationEmailWithContext", reflect.TypeOf((*MockACM)(nil).ResendValidationEmailWithContext), varargs...)
+}
+
+// UpdateCertificateOptions mocks base method.
+func (m *MockACM) UpdateCertificateOptions(arg0 *acm.UpdateCertificateOptionsInput) (*acm.UpdateCertificateOptionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCertificateOptions", arg0)
+	ret0, _ := ret[0].(*acm.UpdateCertificateOptionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCertificateOptions indicates an expected call of UpdateCertificateOptions.
+func (mr *MockACMMockRecorder) UpdateCertificateOptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCertificateOptions", reflect.TypeOf((*MockACM)(nil).UpdateCertificateOptions), arg0)
+}
+
+// UpdateCertificateOptionsRequest mocks base method.
+func (m *MockACM) UpdateCertificateOptionsRequest(arg0 *acm.UpdateCertificateOptionsInput) (*request.Request, *acm.UpdateCertificateOptionsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCertificateOptionsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*acm.UpdateCertificateOptionsOutput)
+	return ret0, ret1
+}
+
+// UpdateCertificateOptionsRequest indicates an expected call of UpdateCertificateOptionsRequest.
+func (mr *MockACMMockRecorder) UpdateCertificateOptionsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCertificateOptionsRequest", reflect.TypeOf((*MockACM)(nil).UpdateCertificateOptionsRequest), arg0)
+}
+
+// UpdateCertificateOptionsWithContext mocks base method.
+func (m *MockACM) UpdateCertificateOptionsWithContext(arg0 context.Context, arg1 *acm.UpdateCertificateOptionsInput, arg2 ...request.Option) (*acm.UpdateCertificateOptionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateCertificateOptionsWithContext", varargs...)
+	ret0, _ := ret[0].(*acm.UpdateCertificateOptionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCertificateOptionsWithContext indicates an expected call of UpdateCertificateOptionsWithContext.
+func (mr *MockACMMockRecorder) UpdateCertificateOptionsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCertificateOptionsWithContext", reflect.TypeOf((*MockACM)(nil).UpdateCertificateOptionsWithContext), varargs...)
+}
+
+// WaitUntilCertificateValidated mocks base method.
+func (m *MockACM) WaitUntilCertificateValidated(arg0 *acm.DescribeCertificateInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilCertificateValidated", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilCertificateValidated indicates an expected call of WaitUntilCertificateValidated.
+func (mr *MockACMMockRecorder) WaitUntilCertificateValidated(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilCertificateValidated", reflect.TypeOf((*MockACM)(nil).WaitUntilCertificateValidated), arg0)
+}
+
+// WaitUntilCertificateValidatedWithContext mocks base method.
+func (m *MockACM) WaitUntilCertificateValidatedWithContext(arg0 context.Context, arg1 *acm.DescribeCertificateInput, arg2 ...request.WaiterOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitUntilCertificateValidatedWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilCertificateValidatedWithContext indicates an expected call of WaitUntilCertificateValidatedWithContext.
+func (mr *MockACMMockRecorder) WaitUntilCertificateValidatedWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilCertificateValidatedWithContext", reflect.TypeOf((*MockACM)(nil).WaitUntilCertificateValidatedWithContext), varargs...)
+}
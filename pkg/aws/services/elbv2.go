@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -25,18 +26,36 @@ type ELBV2 interface {
 
 	// wrapper to DescribeRulesWithContext API, which aggregates paged results into list.
 	DescribeRulesAsList(ctx context.Context, input *elbv2.DescribeRulesInput) ([]*elbv2.Rule, error)
+
+	// DescribeCapacityReservationWithContext describes the capacity reservation of loadBalancer.
+	DescribeCapacityReservationWithContext(ctx context.Context, input *DescribeCapacityReservationInput) (*DescribeCapacityReservationOutput, error)
+
+	// ModifyCapacityReservationWithContext modifies the capacity reservation of loadBalancer.
+	ModifyCapacityReservationWithContext(ctx context.Context, input *ModifyCapacityReservationInput) (*ModifyCapacityReservationOutput, error)
+}
+
+// markerPaginator is the paginator for ELBV2 APIs paginated by Marker/NextMarker,
+// AWS SDK doesn't define paginators for some of these APIs(e.g. DescribeRules), thus only first page will be returned without it.
+var markerPaginator = &request.Paginator{
+	InputTokens:  []string{"Marker"},
+	OutputTokens: []string{"NextMarker"},
 }
 
 // NewELBV2 constructs new ELBV2 implementation.
 func NewELBV2(session *session.Session) ELBV2 {
+	elbv2Client := elbv2.New(session)
 	return &defaultELBV2{
-		ELBV2API: elbv2.New(session),
+		ELBV2API:  elbv2Client,
+		sdkClient: elbv2Client.Client,
 	}
 }
 
 // default implementation for ELBV2.
 type defaultELBV2 struct {
 	elbv2iface.ELBV2API
+
+	// sdkClient is the generic client used to invoke APIs not supported by AWS SDK yet.
+	sdkClient *client.Client
 }
 
 func (c *defaultELBV2) DescribeLoadBalancersAsList(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) ([]*elbv2.LoadBalancer, error) {
@@ -78,6 +97,7 @@ func (c *defaultELBV2) DescribeListenerCertificatesAsList(ctx context.Context, i
 		EndPageOnSameToken: true,
 		NewRequest: func() (*request.Request, error) {
 			req, _ := c.DescribeListenerCertificatesRequest(input)
+			req.Operation.Paginator = markerPaginator
 			req.SetContext(ctx)
 			return req, nil
 		},
@@ -95,6 +115,7 @@ func (c *defaultELBV2) DescribeRulesAsList(ctx context.Context, input *elbv2.Des
 		EndPageOnSameToken: true,
 		NewRequest: func() (*request.Request, error) {
 			req, _ := c.DescribeRulesRequest(input)
+			req.Operation.Paginator = markerPaginator
 			req.SetContext(ctx)
 			return req, nil
 		},
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// The capacity reservation APIs for ELBV2 are newer than the AWS SDK we depend on,
+// thus they are invoked via the generic request interface of the ELBV2 client.
+const (
+	opDescribeCapacityReservation = "DescribeCapacityReservation"
+	opModifyCapacityReservation   = "ModifyCapacityReservation"
+)
+
+// MinimumLoadBalancerCapacity is the minimum capacity reserved for a loadBalancer.
+type MinimumLoadBalancerCapacity struct {
+	_ struct{} `type:"structure"`
+
+	// The number of capacity units.
+	CapacityUnits *int64 `type:"integer"`
+}
+
+type DescribeCapacityReservationInput struct {
+	_ struct{} `type:"structure"`
+
+	// The Amazon Resource Name (ARN) of the load balancer.
+	LoadBalancerArn *string `type:"string" required:"true"`
+}
+
+type DescribeCapacityReservationOutput struct {
+	_ struct{} `type:"structure"`
+
+	// The amount of daily capacity decreases remaining.
+	DecreaseRequestsRemaining *int64 `type:"integer"`
+
+	// The requested minimum capacity reservation for the load balancer.
+	MinimumLoadBalancerCapacity *MinimumLoadBalancerCapacity `type:"structure"`
+}
+
+type ModifyCapacityReservationInput struct {
+	_ struct{} `type:"structure"`
+
+	// The Amazon Resource Name (ARN) of the load balancer.
+	LoadBalancerArn *string `type:"string" required:"true"`
+
+	// The minimum load balancer capacity reserved.
+	MinimumLoadBalancerCapacity *MinimumLoadBalancerCapacity `type:"structure"`
+
+	// Resets the capacity reservation.
+	ResetCapacityReservation *bool `type:"boolean"`
+}
+
+type ModifyCapacityReservationOutput struct {
+	_ struct{} `type:"structure"`
+
+	// The amount of daily capacity decreases remaining.
+	DecreaseRequestsRemaining *int64 `type:"integer"`
+
+	// The requested minimum capacity reservation for the load balancer.
+	MinimumLoadBalancerCapacity *MinimumLoadBalancerCapacity `type:"structure"`
+}
+
+func (c *defaultELBV2) DescribeCapacityReservationWithContext(ctx context.Context, input *DescribeCapacityReservationInput) (*DescribeCapacityReservationOutput, error) {
+	output := &DescribeCapacityReservationOutput{}
+	req := newCapacityReservationRequest(c.sdkClient, opDescribeCapacityReservation, input, output)
+	req.SetContext(ctx)
+	return output, req.Send()
+}
+
+func (c *defaultELBV2) ModifyCapacityReservationWithContext(ctx context.Context, input *ModifyCapacityReservationInput) (*ModifyCapacityReservationOutput, error) {
+	output := &ModifyCapacityReservationOutput{}
+	req := newCapacityReservationRequest(c.sdkClient, opModifyCapacityReservation, input, output)
+	req.SetContext(ctx)
+	return output, req.Send()
+}
+
+// newCapacityReservationRequest constructs a request for capacity reservation APIs,
+// the request is marshaled and unmarshaled by the query protocol handlers of ELBV2 client.
+func newCapacityReservationRequest(sdkClient *client.Client, opName string, input interface{}, output interface{}) *request.Request {
+	op := &request.Operation{
+		Name:       opName,
+		HTTPMethod: http.MethodPost,
+		HTTPPath:   "/",
+	}
+	return sdkClient.NewRequest(op, input, output)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/awstesting/unit"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeELBV2 constructs an ELBV2 client whose responses are served with respBody, and the requests are recorded into reqParams.
+func newFakeELBV2(respBody string, reqParams *url.Values) *defaultELBV2 {
+	client := elbv2.New(unit.Session)
+	client.Handlers.Send.Clear()
+	client.Handlers.Send.PushBack(func(r *request.Request) {
+		body, _ := ioutil.ReadAll(r.GetBody())
+		*reqParams, _ = url.ParseQuery(string(body))
+		r.HTTPResponse = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(respBody)),
+		}
+	})
+	return &defaultELBV2{ELBV2API: client, sdkClient: client.Client}
+}
+
+func Test_defaultELBV2_DescribeCapacityReservationWithContext(t *testing.T) {
+	var reqParams url.Values
+	c := newFakeELBV2(`<DescribeCapacityReservationResponse>
+  <DescribeCapacityReservationResult>
+    <DecreaseRequestsRemaining>3</DecreaseRequestsRemaining>
+    <MinimumLoadBalancerCapacity>
+      <CapacityUnits>1500</CapacityUnits>
+    </MinimumLoadBalancerCapacity>
+  </DescribeCapacityReservationResult>
+</DescribeCapacityReservationResponse>`, &reqParams)
+	got, err := c.DescribeCapacityReservationWithContext(context.Background(), &DescribeCapacityReservationInput{
+		LoadBalancerArn: awssdk.String("my-arn"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &DescribeCapacityReservationOutput{
+		DecreaseRequestsRemaining: awssdk.Int64(3),
+		MinimumLoadBalancerCapacity: &MinimumLoadBalancerCapacity{
+			CapacityUnits: awssdk.Int64(1500),
+		},
+	}, got)
+	assert.Equal(t, url.Values{
+		"Action":          []string{"DescribeCapacityReservation"},
+		"Version":         []string{"2015-12-01"},
+		"LoadBalancerArn": []string{"my-arn"},
+	}, reqParams)
+}
+
+func Test_defaultELBV2_ModifyCapacityReservationWithContext(t *testing.T) {
+	var reqParams url.Values
+	c := newFakeELBV2(`<ModifyCapacityReservationResponse>
+  <ModifyCapacityReservationResult>
+    <DecreaseRequestsRemaining>3</DecreaseRequestsRemaining>
+    <MinimumLoadBalancerCapacity>
+      <CapacityUnits>2000</CapacityUnits>
+    </MinimumLoadBalancerCapacity>
+  </ModifyCapacityReservationResult>
+</ModifyCapacityReservationResponse>`, &reqParams)
+	got, err := c.ModifyCapacityReservationWithContext(context.Background(), &ModifyCapacityReservationInput{
+		LoadBalancerArn: awssdk.String("my-arn"),
+		MinimumLoadBalancerCapacity: &MinimumLoadBalancerCapacity{
+			CapacityUnits: awssdk.Int64(2000),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &ModifyCapacityReservationOutput{
+		DecreaseRequestsRemaining: awssdk.Int64(3),
+		MinimumLoadBalancerCapacity: &MinimumLoadBalancerCapacity{
+			CapacityUnits: awssdk.Int64(2000),
+		},
+	}, got)
+	assert.Equal(t, url.Values{
+		"Action":          []string{"ModifyCapacityReservation"},
+		"Version":         []string{"2015-12-01"},
+		"LoadBalancerArn": []string{"my-arn"},
+		"MinimumLoadBalancerCapacity.CapacityUnits": []string{"2000"},
+	}, reqParams)
+}
@@ -651,6 +651,21 @@ func (mr *MockELBV2MockRecorder) DescribeListenerCertificates(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeListenerCertificates", reflect.TypeOf((*MockELBV2)(nil).DescribeListenerCertificates), arg0)
 }
 
+// DescribeCapacityReservationWithContext mocks base method.
+func (m *MockELBV2) DescribeCapacityReservationWithContext(arg0 context.Context, arg1 *DescribeCapacityReservationInput) (*DescribeCapacityReservationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeCapacityReservationWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*DescribeCapacityReservationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeCapacityReservationWithContext indicates an expected call of DescribeCapacityReservationWithContext.
+func (mr *MockELBV2MockRecorder) DescribeCapacityReservationWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCapacityReservationWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeCapacityReservationWithContext), arg0, arg1)
+}
+
 // DescribeListenerCertificatesAsList mocks base method.
 func (m *MockELBV2) DescribeListenerCertificatesAsList(arg0 context.Context, arg1 *elbv2.DescribeListenerCertificatesInput) ([]*elbv2.Certificate, error) {
 	m.ctrl.T.Helper()
@@ -1310,6 +1325,21 @@ func (mr *MockELBV2MockRecorder) DescribeTargetHealthWithContext(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetHealthWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeTargetHealthWithContext), varargs...)
 }
 
+// ModifyCapacityReservationWithContext mocks base method.
+func (m *MockELBV2) ModifyCapacityReservationWithContext(arg0 context.Context, arg1 *ModifyCapacityReservationInput) (*ModifyCapacityReservationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyCapacityReservationWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*ModifyCapacityReservationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyCapacityReservationWithContext indicates an expected call of ModifyCapacityReservationWithContext.
+func (mr *MockELBV2MockRecorder) ModifyCapacityReservationWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyCapacityReservationWithContext", reflect.TypeOf((*MockELBV2)(nil).ModifyCapacityReservationWithContext), arg0, arg1)
+}
+
 // ModifyListener mocks base method.
 func (m *MockELBV2) ModifyListener(arg0 *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
 	m.ctrl.T.Helper()
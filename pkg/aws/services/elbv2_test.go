@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/awstesting/unit"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPagedELBV2 constructs an ELBV2 client whose responses are served from pages indexed by request Marker.
+// the first page is indexed by empty Marker.
+func newPagedELBV2(pageByMarker func(r *request.Request, marker string)) *defaultELBV2 {
+	client := elbv2.New(unit.Session)
+	client.Handlers.Send.Clear()
+	client.Handlers.Send.PushBack(func(r *request.Request) {
+		r.HTTPResponse = &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	})
+	client.Handlers.Unmarshal.Clear()
+	client.Handlers.Unmarshal.PushBack(func(r *request.Request) {
+		var marker string
+		switch params := r.Params.(type) {
+		case *elbv2.DescribeRulesInput:
+			marker = awssdk.StringValue(params.Marker)
+		case *elbv2.DescribeListenerCertificatesInput:
+			marker = awssdk.StringValue(params.Marker)
+		case *elbv2.DescribeLoadBalancersInput:
+			marker = awssdk.StringValue(params.Marker)
+		}
+		pageByMarker(r, marker)
+	})
+	client.Handlers.UnmarshalMeta.Clear()
+	client.Handlers.ValidateResponse.Clear()
+	return &defaultELBV2{ELBV2API: client}
+}
+
+func Test_defaultELBV2_DescribeRulesAsList(t *testing.T) {
+	c := newPagedELBV2(func(r *request.Request, marker string) {
+		output := r.Data.(*elbv2.DescribeRulesOutput)
+		switch marker {
+		case "":
+			output.Rules = []*elbv2.Rule{{RuleArn: awssdk.String("rule-1")}, {RuleArn: awssdk.String("rule-2")}}
+			output.NextMarker = awssdk.String("marker-1")
+		case "marker-1":
+			output.Rules = []*elbv2.Rule{{RuleArn: awssdk.String("rule-3")}}
+			output.NextMarker = awssdk.String("marker-2")
+		case "marker-2":
+			output.Rules = []*elbv2.Rule{{RuleArn: awssdk.String("rule-4")}}
+		}
+	})
+	got, err := c.DescribeRulesAsList(context.Background(), &elbv2.DescribeRulesInput{
+		ListenerArn: awssdk.String("listener-1"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.Rule{
+		{RuleArn: awssdk.String("rule-1")},
+		{RuleArn: awssdk.String("rule-2")},
+		{RuleArn: awssdk.String("rule-3")},
+		{RuleArn: awssdk.String("rule-4")},
+	}, got)
+}
+
+func Test_defaultELBV2_DescribeListenerCertificatesAsList(t *testing.T) {
+	c := newPagedELBV2(func(r *request.Request, marker string) {
+		output := r.Data.(*elbv2.DescribeListenerCertificatesOutput)
+		switch marker {
+		case "":
+			output.Certificates = []*elbv2.Certificate{{CertificateArn: awssdk.String("cert-1")}}
+			output.NextMarker = awssdk.String("marker-1")
+		case "marker-1":
+			output.Certificates = []*elbv2.Certificate{{CertificateArn: awssdk.String("cert-2")}}
+		}
+	})
+	got, err := c.DescribeListenerCertificatesAsList(context.Background(), &elbv2.DescribeListenerCertificatesInput{
+		ListenerArn: awssdk.String("listener-1"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.Certificate{
+		{CertificateArn: awssdk.String("cert-1")},
+		{CertificateArn: awssdk.String("cert-2")},
+	}, got)
+}
+
+func Test_defaultELBV2_DescribeLoadBalancersAsList(t *testing.T) {
+	c := newPagedELBV2(func(r *request.Request, marker string) {
+		output := r.Data.(*elbv2.DescribeLoadBalancersOutput)
+		switch marker {
+		case "":
+			output.LoadBalancers = []*elbv2.LoadBalancer{{LoadBalancerArn: awssdk.String("lb-1")}}
+			output.NextMarker = awssdk.String("marker-1")
+		case "marker-1":
+			output.LoadBalancers = []*elbv2.LoadBalancer{{LoadBalancerArn: awssdk.String("lb-2")}}
+		}
+	})
+	got, err := c.DescribeLoadBalancersAsList(context.Background(), &elbv2.DescribeLoadBalancersInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.LoadBalancer{
+		{LoadBalancerArn: awssdk.String("lb-1")},
+		{LoadBalancerArn: awssdk.String("lb-2")},
+	}, got)
+}
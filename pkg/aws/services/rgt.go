@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
@@ -8,9 +9,13 @@ import (
 
 type RGT interface {
 	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+
+	// wrapper to GetResourcesPagesWithContext API, which aggregates paged results into list.
+	GetResourcesAsList(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) ([]*resourcegroupstaggingapi.ResourceTagMapping, error)
 }
 
 // NewRGT constructs new RGT implementation.
+// the endpoint is resolved by session, thus custom endpoints and partitions are honored.
 func NewRGT(session *session.Session) RGT {
 	return &defaultRGT{
 		ResourceGroupsTaggingAPIAPI: resourcegroupstaggingapi.New(session),
@@ -20,3 +25,14 @@ func NewRGT(session *session.Session) RGT {
 type defaultRGT struct {
 	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 }
+
+func (c *defaultRGT) GetResourcesAsList(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) ([]*resourcegroupstaggingapi.ResourceTagMapping, error) {
+	var result []*resourcegroupstaggingapi.ResourceTagMapping
+	if err := c.GetResourcesPagesWithContext(ctx, input, func(output *resourcegroupstaggingapi.GetResourcesOutput, _ bool) bool {
+		result = append(result, output.ResourceTagMappingList...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
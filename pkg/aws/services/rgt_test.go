@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/awstesting/unit"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_defaultRGT_GetResourcesAsList(t *testing.T) {
+	var gotEndpoints []string
+	rgtClient := resourcegroupstaggingapi.New(unit.Session, awssdk.NewConfig().WithEndpoint("https://tagging.example.com"))
+	rgtClient.Handlers.Send.Clear()
+	rgtClient.Handlers.Send.PushBack(func(r *request.Request) {
+		gotEndpoints = append(gotEndpoints, r.HTTPRequest.URL.Host)
+		r.HTTPResponse = &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	})
+	rgtClient.Handlers.Unmarshal.Clear()
+	rgtClient.Handlers.Unmarshal.PushBack(func(r *request.Request) {
+		output := r.Data.(*resourcegroupstaggingapi.GetResourcesOutput)
+		switch awssdk.StringValue(r.Params.(*resourcegroupstaggingapi.GetResourcesInput).PaginationToken) {
+		case "":
+			output.ResourceTagMappingList = []*resourcegroupstaggingapi.ResourceTagMapping{{ResourceARN: awssdk.String("arn-1")}, {ResourceARN: awssdk.String("arn-2")}}
+			output.PaginationToken = awssdk.String("token-1")
+		case "token-1":
+			output.ResourceTagMappingList = []*resourcegroupstaggingapi.ResourceTagMapping{{ResourceARN: awssdk.String("arn-3")}}
+			output.PaginationToken = awssdk.String("token-2")
+		case "token-2":
+			output.ResourceTagMappingList = []*resourcegroupstaggingapi.ResourceTagMapping{{ResourceARN: awssdk.String("arn-4")}}
+		}
+	})
+	rgtClient.Handlers.UnmarshalMeta.Clear()
+	rgtClient.Handlers.ValidateResponse.Clear()
+	c := &defaultRGT{ResourceGroupsTaggingAPIAPI: rgtClient}
+
+	got, err := c.GetResourcesAsList(context.Background(), &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: awssdk.StringSlice([]string{"elasticloadbalancing:loadbalancer"}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*resourcegroupstaggingapi.ResourceTagMapping{
+		{ResourceARN: awssdk.String("arn-1")},
+		{ResourceARN: awssdk.String("arn-2")},
+		{ResourceARN: awssdk.String("arn-3")},
+		{ResourceARN: awssdk.String("arn-4")},
+	}, got)
+	assert.Equal(t, []string{"tagging.example.com", "tagging.example.com", "tagging.example.com"}, gotEndpoints)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/pflag"
 	"golang.org/x/time/rate"
 	"regexp"
+	"regexp/syntax"
 	"sort"
 	"strconv"
 	"strings"
@@ -97,6 +98,93 @@ func (c *ServiceOperationsThrottleConfig) Set(val string) error {
 	return nil
 }
 
+// WithOverrides returns a new ServiceOperationsThrottleConfig with overrides applied.
+// Note: throttle for each service will be replaced if any override is set for that service.
+func (c *ServiceOperationsThrottleConfig) WithOverrides(overrides *ServiceOperationsThrottleConfig) *ServiceOperationsThrottleConfig {
+	value := make(map[string][]throttleConfig)
+	for k, v := range c.value {
+		value[k] = v
+	}
+	if overrides != nil {
+		for k, v := range overrides.value {
+			value[k] = v
+		}
+	}
+	return &ServiceOperationsThrottleConfig{value: value}
+}
+
+// MergeWith returns a new ServiceOperationsThrottleConfig with other layered on top of c.
+// Unlike WithOverrides, throttles are merged per operation pattern: throttle from other takes precedence for the same
+// service and operation pattern, while other throttles from both configs are retained.
+func (c *ServiceOperationsThrottleConfig) MergeWith(other *ServiceOperationsThrottleConfig) *ServiceOperationsThrottleConfig {
+	value := make(map[string][]throttleConfig)
+	if c != nil {
+		for k, v := range c.value {
+			value[k] = append([]throttleConfig(nil), v...)
+		}
+	}
+	if other == nil {
+		return &ServiceOperationsThrottleConfig{value: value}
+	}
+	for serviceID, otherConfigs := range other.value {
+		configs := value[serviceID]
+		for _, otherConfig := range otherConfigs {
+			merged := false
+			for i := range configs {
+				if configs[i].operationPtn.String() == otherConfig.operationPtn.String() {
+					configs[i] = otherConfig
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				configs = append(configs, otherConfig)
+			}
+		}
+		value[serviceID] = configs
+	}
+	return &ServiceOperationsThrottleConfig{value: value}
+}
+
+// WithDescribeBurst returns a new ServiceOperationsThrottleConfig with burst of throttles for Describe* operations
+// set to burst across all services, the rates of these throttles are unchanged.
+func (c *ServiceOperationsThrottleConfig) WithDescribeBurst(burst int) *ServiceOperationsThrottleConfig {
+	overrides := &ServiceOperationsThrottleConfig{value: make(map[string][]throttleConfig)}
+	if c != nil {
+		for serviceID, configs := range c.value {
+			for _, config := range configs {
+				if !isDescribeOperationPattern(config.operationPtn) {
+					continue
+				}
+				overrides.value[serviceID] = append(overrides.value[serviceID], throttleConfig{
+					operationPtn: config.operationPtn,
+					r:            config.r,
+					burst:        burst,
+				})
+			}
+		}
+	}
+	return c.MergeWith(overrides)
+}
+
+// isDescribeOperationPattern checks whether operationPtn only matches Describe* operations,
+// i.e. every operation matched by it starts with "Describe".
+func isDescribeOperationPattern(operationPtn *regexp.Regexp) bool {
+	re, err := syntax.Parse(operationPtn.String(), syntax.Perl)
+	if err != nil {
+		return false
+	}
+	re = re.Simplify()
+	if re.Op != syntax.OpConcat || len(re.Sub) < 2 {
+		return false
+	}
+	anchor, literal := re.Sub[0], re.Sub[1]
+	if anchor.Op != syntax.OpBeginText || literal.Op != syntax.OpLiteral || literal.Flags&syntax.FoldCase != 0 {
+		return false
+	}
+	return strings.HasPrefix(string(literal.Rune), "Describe")
+}
+
 func (c *ServiceOperationsThrottleConfig) Type() string {
 	return "serviceOperationsThrottleConfig"
 }
@@ -252,6 +252,163 @@ func TestServiceOperationsThrottleConfig_Set(t *testing.T) {
 	}
 }
 
+func TestServiceOperationsThrottleConfig_MergeWith(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  *ServiceOperationsThrottleConfig
+		other *ServiceOperationsThrottleConfig
+		want  string
+	}{
+		{
+			name: "throttle of other takes precedence for same operation pattern",
+			base: &ServiceOperationsThrottleConfig{
+				value: map[string][]throttleConfig{
+					elbv2.ServiceID: {
+						{operationPtn: regexp.MustCompile("^Describe"), r: 10, burst: 20},
+						{operationPtn: regexp.MustCompile("^RegisterTargets|DeregisterTargets"), r: 5, burst: 10},
+					},
+				},
+			},
+			other: &ServiceOperationsThrottleConfig{
+				value: map[string][]throttleConfig{
+					elbv2.ServiceID: {
+						{operationPtn: regexp.MustCompile("^Describe"), r: 10, burst: 50},
+					},
+				},
+			},
+			want: "Elastic Load Balancing v2:^Describe=10:50,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=5:10",
+		},
+		{
+			name: "throttles of different operation patterns and services are retained",
+			base: &ServiceOperationsThrottleConfig{
+				value: map[string][]throttleConfig{
+					elbv2.ServiceID: {
+						{operationPtn: regexp.MustCompile("^Describe"), r: 10, burst: 20},
+					},
+				},
+			},
+			other: &ServiceOperationsThrottleConfig{
+				value: map[string][]throttleConfig{
+					elbv2.ServiceID: {
+						{operationPtn: regexp.MustCompile("^DescribeTargetHealth"), r: 4.2, burst: 5},
+					},
+					appmesh.ServiceID: {
+						{operationPtn: regexp.MustCompile("^Describe"), r: 1, burst: 2},
+					},
+				},
+			},
+			want: "App Mesh:^Describe=1:2,Elastic Load Balancing v2:^Describe=10:20,Elastic Load Balancing v2:^DescribeTargetHealth=4.2:5",
+		},
+		{
+			name: "nil other",
+			base: &ServiceOperationsThrottleConfig{
+				value: map[string][]throttleConfig{
+					elbv2.ServiceID: {
+						{operationPtn: regexp.MustCompile("^Describe"), r: 10, burst: 20},
+					},
+				},
+			},
+			other: nil,
+			want:  "Elastic Load Balancing v2:^Describe=10:20",
+		},
+		{
+			name: "nil base",
+			base: nil,
+			other: &ServiceOperationsThrottleConfig{
+				value: map[string][]throttleConfig{
+					elbv2.ServiceID: {
+						{operationPtn: regexp.MustCompile("^Describe"), r: 10, burst: 20},
+					},
+				},
+			},
+			want: "Elastic Load Balancing v2:^Describe=10:20",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseStr := tt.base.String()
+			got := tt.base.MergeWith(tt.other)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, baseStr, tt.base.String())
+		})
+	}
+}
+
+func TestServiceOperationsThrottleConfig_WithDescribeBurst(t *testing.T) {
+	base := &ServiceOperationsThrottleConfig{
+		value: map[string][]throttleConfig{
+			elbv2.ServiceID: {
+				{operationPtn: regexp.MustCompile("^Describe"), r: 10, burst: 20},
+				{operationPtn: regexp.MustCompile("^RegisterTargets|DeregisterTargets"), r: 5, burst: 10},
+			},
+			appmesh.ServiceID: {
+				{operationPtn: regexp.MustCompile("^DescribeMesh"), r: 1, burst: 2},
+				{operationPtn: regexp.MustCompile("CreateMesh"), r: 1, burst: 2},
+			},
+		},
+	}
+	got := base.WithDescribeBurst(100)
+	assert.Equal(t, "App Mesh:^DescribeMesh=1:100,App Mesh:CreateMesh=1:2,"+
+		"Elastic Load Balancing v2:^Describe=10:100,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=5:10", got.String())
+	assert.Equal(t, "App Mesh:^DescribeMesh=1:2,App Mesh:CreateMesh=1:2,"+
+		"Elastic Load Balancing v2:^Describe=10:20,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=5:10", base.String())
+}
+
+func Test_isDescribeOperationPattern(t *testing.T) {
+	tests := []struct {
+		name         string
+		operationPtn string
+		want         bool
+	}{
+		{
+			name:         "all Describe operations",
+			operationPtn: "^Describe",
+			want:         true,
+		},
+		{
+			name:         "specific Describe operation",
+			operationPtn: "^DescribeTargetHealth",
+			want:         true,
+		},
+		{
+			name:         "Describe operations with wildcard",
+			operationPtn: "^Describe.*Groups",
+			want:         true,
+		},
+		{
+			name:         "unanchored Describe pattern may match other operations",
+			operationPtn: "Describe",
+			want:         false,
+		},
+		{
+			name:         "alternation of Describe and other operations",
+			operationPtn: "^DescribeTargetHealth|RegisterTargets",
+			want:         false,
+		},
+		{
+			name:         "non Describe operations",
+			operationPtn: "^RegisterTargets|DeregisterTargets",
+			want:         false,
+		},
+		{
+			name:         "case insensitive Describe pattern",
+			operationPtn: "(?i)^describe",
+			want:         false,
+		},
+		{
+			name:         "operations sharing prefix with Describe",
+			operationPtn: "^Desc",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isDescribeOperationPattern(regexp.MustCompile(tt.operationPtn))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestServiceOperationsThrottleConfig_Type(t *testing.T) {
 	c := &ServiceOperationsThrottleConfig{}
 	got := c.Type()
@@ -1,40 +1,103 @@
 package throttle
 
 import (
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/wafregional"
 	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/pkg/errors"
 	"golang.org/x/time/rate"
 	"regexp"
 )
 
+const (
+	// ProfileDefault throttles the chattiest ELBV2/EC2 operations with rates suitable for most clusters.
+	ProfileDefault = "default"
+	// ProfileConservative throttles the chattiest ELBV2/EC2 operations with half of the default rates,
+	// which is suitable for large clusters or AWS accounts shared with other API consumers.
+	ProfileConservative = "conservative"
+	// ProfileAggressive don't throttle ELBV2/EC2 operations, and relies on SDK retries upon throttling errors.
+	ProfileAggressive = "aggressive"
+)
+
 // NewDefaultServiceOperationsThrottleConfig returns a ServiceOperationsThrottleConfig with default settings.
 func NewDefaultServiceOperationsThrottleConfig() *ServiceOperationsThrottleConfig {
-	return &ServiceOperationsThrottleConfig{
-		value: map[string][]throttleConfig{
-			wafregional.ServiceID: {
-				{
-					operationPtn: regexp.MustCompile("^AssociateWebACL|DisassociateWebACL"),
-					r:            rate.Limit(0.5),
-					burst:        1,
-				},
-				{
-					operationPtn: regexp.MustCompile("^GetWebACLForResource|ListResourcesForWebACL"),
-					r:            rate.Limit(1),
-					burst:        1,
-				},
+	config, _ := NewServiceOperationsThrottleConfigForProfile(ProfileDefault)
+	return config
+}
+
+// NewServiceOperationsThrottleConfigForProfile returns a ServiceOperationsThrottleConfig with settings of specified profile.
+func NewServiceOperationsThrottleConfigForProfile(profile string) (*ServiceOperationsThrottleConfig, error) {
+	value := map[string][]throttleConfig{
+		wafregional.ServiceID: {
+			{
+				operationPtn: regexp.MustCompile("^AssociateWebACL|DisassociateWebACL"),
+				r:            rate.Limit(0.5),
+				burst:        1,
+			},
+			{
+				operationPtn: regexp.MustCompile("^GetWebACLForResource|ListResourcesForWebACL"),
+				r:            rate.Limit(1),
+				burst:        1,
 			},
-			wafv2.ServiceID: {
-				{
-					operationPtn: regexp.MustCompile("^AssociateWebACL|DisassociateWebACL"),
-					r:            rate.Limit(0.5),
-					burst:        1,
-				},
-				{
-					operationPtn: regexp.MustCompile("^GetWebACLForResource|ListResourcesForWebACL"),
-					r:            rate.Limit(1),
-					burst:        1,
-				},
+		},
+		wafv2.ServiceID: {
+			{
+				operationPtn: regexp.MustCompile("^AssociateWebACL|DisassociateWebACL"),
+				r:            rate.Limit(0.5),
+				burst:        1,
+			},
+			{
+				operationPtn: regexp.MustCompile("^GetWebACLForResource|ListResourcesForWebACL"),
+				r:            rate.Limit(1),
+				burst:        1,
 			},
 		},
 	}
+
+	switch profile {
+	case ProfileDefault:
+		value[elbv2.ServiceID] = buildELBV2ThrottleConfigs(1)
+		value[ec2.ServiceID] = buildEC2ThrottleConfigs(1)
+	case ProfileConservative:
+		value[elbv2.ServiceID] = buildELBV2ThrottleConfigs(0.5)
+		value[ec2.ServiceID] = buildEC2ThrottleConfigs(0.5)
+	case ProfileAggressive:
+	default:
+		return nil, errors.Errorf("unknown throttle profile %v, must be one of %v", profile,
+			[]string{ProfileDefault, ProfileConservative, ProfileAggressive})
+	}
+	return &ServiceOperationsThrottleConfig{value: value}, nil
+}
+
+// buildELBV2ThrottleConfigs builds throttle configs for chattiest ELBV2 operations, with rates scaled by factor.
+func buildELBV2ThrottleConfigs(factor float64) []throttleConfig {
+	return []throttleConfig{
+		{
+			operationPtn: regexp.MustCompile("^Describe"),
+			r:            rate.Limit(10 * factor),
+			burst:        int(20 * factor),
+		},
+		{
+			operationPtn: regexp.MustCompile("^RegisterTargets|DeregisterTargets"),
+			r:            rate.Limit(5 * factor),
+			burst:        int(10 * factor),
+		},
+	}
+}
+
+// buildEC2ThrottleConfigs builds throttle configs for chattiest EC2 operations, with rates scaled by factor.
+func buildEC2ThrottleConfigs(factor float64) []throttleConfig {
+	return []throttleConfig{
+		{
+			operationPtn: regexp.MustCompile("^Describe"),
+			r:            rate.Limit(20 * factor),
+			burst:        int(40 * factor),
+		},
+		{
+			operationPtn: regexp.MustCompile("^AuthorizeSecurityGroupIngress|RevokeSecurityGroupIngress"),
+			r:            rate.Limit(5 * factor),
+			burst:        int(10 * factor),
+		},
+	}
 }
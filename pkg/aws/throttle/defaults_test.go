@@ -0,0 +1,96 @@
+package throttle
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewServiceOperationsThrottleConfigForProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "default profile",
+			profile: ProfileDefault,
+			want: "EC2:^Describe=20:40,EC2:^AuthorizeSecurityGroupIngress|RevokeSecurityGroupIngress=5:10," +
+				"Elastic Load Balancing v2:^Describe=10:20,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=5:10," +
+				"WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+		{
+			name:    "conservative profile",
+			profile: ProfileConservative,
+			want: "EC2:^Describe=10:20,EC2:^AuthorizeSecurityGroupIngress|RevokeSecurityGroupIngress=2.5:5," +
+				"Elastic Load Balancing v2:^Describe=5:10,Elastic Load Balancing v2:^RegisterTargets|DeregisterTargets=2.5:5," +
+				"WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+		{
+			name:    "aggressive profile",
+			profile: ProfileAggressive,
+			want: "WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+		{
+			name:    "unknown profile",
+			profile: "fast",
+			wantErr: errors.New("unknown throttle profile fast, must be one of [default conservative aggressive]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewServiceOperationsThrottleConfigForProfile(tt.profile)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestServiceOperationsThrottleConfig_WithOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   string
+		overrides string
+		want      string
+	}{
+		{
+			name:      "overrides should replace throttle of overridden services only",
+			profile:   ProfileAggressive,
+			overrides: "WAFV2:^AssociateWebACL=1:2,Elastic Load Balancing v2:^DescribeTargetHealth=4.2:5",
+			want: "Elastic Load Balancing v2:^DescribeTargetHealth=4.2:5," +
+				"WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL=1:2",
+		},
+		{
+			name:      "no overrides",
+			profile:   ProfileAggressive,
+			overrides: "",
+			want: "WAF Regional:^AssociateWebACL|DisassociateWebACL=0.5:1,WAF Regional:^GetWebACLForResource|ListResourcesForWebACL=1:1," +
+				"WAFV2:^AssociateWebACL|DisassociateWebACL=0.5:1,WAFV2:^GetWebACLForResource|ListResourcesForWebACL=1:1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewServiceOperationsThrottleConfigForProfile(tt.profile)
+			assert.NoError(t, err)
+			overrides := &ServiceOperationsThrottleConfig{}
+			if tt.overrides != "" {
+				assert.NoError(t, overrides.Set(tt.overrides))
+			}
+			got := c.WithOverrides(overrides)
+			assert.Equal(t, tt.want, got.String())
+
+			// the profile config itself shouldn't be changed by overrides.
+			unchanged, _ := NewServiceOperationsThrottleConfigForProfile(tt.profile)
+			assert.Equal(t, unchanged.String(), c.String())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package throttle
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricSubsystemAWS = "aws"
+
+	metricAPIThrottleWaitsTotal          = "api_throttle_waits_total"
+	metricAPIThrottleWaitDurationSeconds = "api_throttle_wait_duration_seconds"
+	metricAPIThrottleRuleWaitsTotal      = "api_throttle_rule_waits_total"
+)
+
+const (
+	labelService   = "service"
+	labelOperation = "operation"
+	labelRule      = "rule"
+)
+
+type instruments struct {
+	apiThrottleWaitsTotal          *prometheus.CounterVec
+	apiThrottleWaitDurationSeconds *prometheus.HistogramVec
+	apiThrottleRuleWaitsTotal      *prometheus.CounterVec
+}
+
+// newInstruments allocates and register new metrics to registerer
+func newInstruments(registerer prometheus.Registerer) (*instruments, error) {
+	apiThrottleWaitsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemAWS,
+		Name:      metricAPIThrottleWaitsTotal,
+		Help:      "Total number of SDK API requests that waited for client-side throttle",
+	}, []string{labelService, labelOperation})
+	apiThrottleWaitDurationSeconds := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricSubsystemAWS,
+		Name:      metricAPIThrottleWaitDurationSeconds,
+		Help:      "Latency of SDK API requests waiting for client-side throttle",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+	}, []string{labelService, labelOperation})
+	apiThrottleRuleWaitsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemAWS,
+		Name:      metricAPIThrottleRuleWaitsTotal,
+		Help:      "Total number of SDK API requests that waited for client-side throttle, per throttle rule",
+	}, []string{labelRule})
+
+	if err := registerer.Register(apiThrottleWaitsTotal); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(apiThrottleWaitDurationSeconds); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(apiThrottleRuleWaitsTotal); err != nil {
+		return nil, err
+	}
+	return &instruments{
+		apiThrottleWaitsTotal:          apiThrottleWaitsTotal,
+		apiThrottleWaitDurationSeconds: apiThrottleWaitDurationSeconds,
+		apiThrottleRuleWaitsTotal:      apiThrottleRuleWaitsTotal,
+	}, nil
+}
@@ -2,19 +2,25 @@ package throttle
 
 import (
 	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/time/rate"
 	"regexp"
+	"time"
 )
 
 const sdkHandlerRequestThrottle = "requestThrottle"
 
 type conditionLimiter struct {
+	// rule identifies the throttle in metrics, e.g. "Elastic Load Balancing v2:^Describe".
+	rule      string
 	condition Condition
 	limiter   *rate.Limiter
 }
 
 type throttler struct {
 	conditionLimiters []conditionLimiter
+	// instruments to collect throttle metrics, metrics won't be collected if nil.
+	instruments *instruments
 }
 
 // NewThrottler constructs new request throttler instance.
@@ -33,24 +39,40 @@ func NewThrottler(config *ServiceOperationsThrottleConfig) *throttler {
 }
 
 func (t *throttler) WithConditionThrottle(condition Condition, r rate.Limit, burst int) *throttler {
-	limiter := rate.NewLimiter(r, burst)
-	t.conditionLimiters = append(t.conditionLimiters, conditionLimiter{
-		condition: condition,
-		limiter:   limiter,
-	})
-	return t
+	return t.withRuleThrottle("", condition, r, burst)
 }
 
 func (t *throttler) WithServiceThrottle(serviceID string, r rate.Limit, burst int) *throttler {
-	return t.WithConditionThrottle(matchService(serviceID), r, burst)
+	return t.withRuleThrottle(serviceID, matchService(serviceID), r, burst)
 }
 
 func (t *throttler) WithOperationThrottle(serviceID string, operation string, r rate.Limit, burst int) *throttler {
-	return t.WithConditionThrottle(matchServiceOperation(serviceID, operation), r, burst)
+	return t.withRuleThrottle(serviceID+":"+operation, matchServiceOperation(serviceID, operation), r, burst)
 }
 
 func (t *throttler) WithOperationPatternThrottle(serviceID string, operationPtn *regexp.Regexp, r rate.Limit, burst int) *throttler {
-	return t.WithConditionThrottle(matchServiceOperationPattern(serviceID, operationPtn), r, burst)
+	return t.withRuleThrottle(serviceID+":"+operationPtn.String(), matchServiceOperationPattern(serviceID, operationPtn), r, burst)
+}
+
+// withRuleThrottle adds throttle for requests matching condition, the rule identifies the throttle in metrics.
+func (t *throttler) withRuleThrottle(rule string, condition Condition, r rate.Limit, burst int) *throttler {
+	limiter := rate.NewLimiter(r, burst)
+	t.conditionLimiters = append(t.conditionLimiters, conditionLimiter{
+		rule:      rule,
+		condition: condition,
+		limiter:   limiter,
+	})
+	return t
+}
+
+// RegisterMetrics registers throttle metrics to registerer, and collects them for throttled requests.
+func (t *throttler) RegisterMetrics(registerer prometheus.Registerer) error {
+	instruments, err := newInstruments(registerer)
+	if err != nil {
+		return err
+	}
+	t.instruments = instruments
+	return nil
 }
 
 func (t *throttler) InjectHandlers(handlers *request.Handlers) {
@@ -63,8 +85,36 @@ func (t *throttler) InjectHandlers(handlers *request.Handlers) {
 // beforeSign is added to the Sign chain; called before each request
 func (t *throttler) beforeSign(r *request.Request) {
 	for _, conditionLimiter := range t.conditionLimiters {
-		if conditionLimiter.condition(r) {
+		if !conditionLimiter.condition(r) {
+			continue
+		}
+		if t.instruments == nil {
 			conditionLimiter.limiter.Wait(r.Context())
+			continue
+		}
+		if conditionLimiter.limiter.Allow() {
+			continue
 		}
+		waitStart := time.Now()
+		conditionLimiter.limiter.Wait(r.Context())
+		t.collectThrottleWaitMetric(r, conditionLimiter.rule, time.Since(waitStart))
+	}
+}
+
+func (t *throttler) collectThrottleWaitMetric(r *request.Request, rule string, waitDuration time.Duration) {
+	labels := map[string]string{
+		labelService:   r.ClientInfo.ServiceID,
+		labelOperation: operationForRequest(r),
+	}
+	t.instruments.apiThrottleWaitsTotal.With(labels).Inc()
+	t.instruments.apiThrottleWaitDurationSeconds.With(labels).Observe(waitDuration.Seconds())
+	t.instruments.apiThrottleRuleWaitsTotal.With(map[string]string{labelRule: rule}).Inc()
+}
+
+// operationForRequest returns the operation for request.
+func operationForRequest(r *request.Request) string {
+	if r.Operation != nil {
+		return r.Operation.Name
 	}
+	return "?"
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/appmesh"
 	"github.com/aws/aws-sdk-go/service/servicediscovery"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/time/rate"
 	"net/http"
@@ -53,6 +55,7 @@ func Test_throttler_WithConditionThrottle(t *testing.T) {
 
 	cl := throttler.conditionLimiters[0]
 	assert.True(t, cl.condition(&request.Request{ClientInfo: metadata.ClientInfo{ServiceID: appmesh.ServiceID}}))
+	assert.Equal(t, "", cl.rule)
 	assert.Equal(t, rate.NewLimiter(5.0, 10), cl.limiter)
 }
 
@@ -64,6 +67,7 @@ func Test_throttler_WithServiceThrottle(t *testing.T) {
 
 	cl := throttler.conditionLimiters[0]
 	assert.True(t, cl.condition(&request.Request{ClientInfo: metadata.ClientInfo{ServiceID: appmesh.ServiceID}}))
+	assert.Equal(t, appmesh.ServiceID, cl.rule)
 	assert.Equal(t, rate.NewLimiter(5.0, 10), cl.limiter)
 }
 
@@ -78,6 +82,7 @@ func Test_throttler_WithOperationThrottle(t *testing.T) {
 		ClientInfo: metadata.ClientInfo{ServiceID: appmesh.ServiceID},
 		Operation:  &request.Operation{Name: "CreateMesh"},
 	}))
+	assert.Equal(t, "App Mesh:CreateMesh", cl.rule)
 	assert.Equal(t, rate.NewLimiter(5.0, 10), cl.limiter)
 }
 
@@ -92,6 +97,7 @@ func Test_throttler_WithOperationPatternThrottle(t *testing.T) {
 		ClientInfo: metadata.ClientInfo{ServiceID: appmesh.ServiceID},
 		Operation:  &request.Operation{Name: "CreateMesh"},
 	}))
+	assert.Equal(t, "App Mesh:^Create", cl.rule)
 	assert.Equal(t, rate.NewLimiter(5.0, 10), cl.limiter)
 }
 
@@ -284,3 +290,61 @@ func Test_throttler_beforeSign(t *testing.T) {
 		})
 	}
 }
+
+func Test_throttler_beforeSign_metrics(t *testing.T) {
+	tests := []struct {
+		name            string
+		limiter         *rate.Limiter
+		callsCount      int
+		wantWaitsCount  float64
+		wantMinWaitTime time.Duration
+	}{
+		{
+			name:            "limiter blocks requests exceeding burst",
+			limiter:         rate.NewLimiter(rate.Every(50*time.Millisecond), 1),
+			callsCount:      3,
+			wantWaitsCount:  2,
+			wantMinWaitTime: 80 * time.Millisecond,
+		},
+		{
+			name:            "limiter don't block requests within burst",
+			limiter:         rate.NewLimiter(rate.Every(50*time.Millisecond), 3),
+			callsCount:      3,
+			wantWaitsCount:  0,
+			wantMinWaitTime: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttler := &throttler{}
+			throttler.WithOperationPatternThrottle(appmesh.ServiceID, regexp.MustCompile("^Describe"), tt.limiter.Limit(), tt.limiter.Burst())
+			registry := prometheus.NewRegistry()
+			assert.NoError(t, throttler.RegisterMetrics(registry))
+
+			r := &request.Request{
+				ClientInfo:  metadata.ClientInfo{ServiceID: appmesh.ServiceID},
+				Operation:   &request.Operation{Name: "DescribeMesh"},
+				HTTPRequest: &http.Request{},
+			}
+			for i := 0; i < tt.callsCount; i++ {
+				throttler.beforeSign(r)
+			}
+
+			labels := map[string]string{labelService: appmesh.ServiceID, labelOperation: "DescribeMesh"}
+			assert.Equal(t, tt.wantWaitsCount, testutil.ToFloat64(throttler.instruments.apiThrottleWaitsTotal.With(labels)))
+			ruleLabels := map[string]string{labelRule: "App Mesh:^Describe"}
+			assert.Equal(t, tt.wantWaitsCount, testutil.ToFloat64(throttler.instruments.apiThrottleRuleWaitsTotal.With(ruleLabels)))
+
+			metricFamilies, err := registry.Gather()
+			assert.NoError(t, err)
+			for _, mf := range metricFamilies {
+				if mf.GetName() != "aws_api_throttle_wait_duration_seconds" {
+					continue
+				}
+				histogram := mf.GetMetric()[0].GetHistogram()
+				assert.Equal(t, uint64(tt.wantWaitsCount), histogram.GetSampleCount())
+				assert.GreaterOrEqual(t, histogram.GetSampleSum(), tt.wantMinWaitTime.Seconds())
+			}
+		})
+	}
+}
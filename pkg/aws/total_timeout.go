@@ -0,0 +1,27 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// injectTotalTimeout will inject a deadline into awsSDK requests, which bounds the API call including all retries.
+// the timeout of each attempt is bounded by the http client timeout instead.
+func injectTotalTimeout(handlers *request.Handlers, totalTimeout time.Duration) {
+	if totalTimeout <= 0 {
+		return
+	}
+	handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: fmt.Sprintf("%s/total-timeout", appName),
+		Fn: func(r *request.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), totalTimeout)
+			r.SetContext(ctx)
+			r.Handlers.Complete.PushBack(func(_ *request.Request) {
+				cancel()
+			})
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_requestTimeoutAndTotalTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		responseDelay  time.Duration
+		requestTimeout time.Duration
+		totalTimeout   time.Duration
+		maxRetries     int
+		wantErr        bool
+		// attempts are bounded since the retry delay is randomized.
+		wantMinAttempts int32
+		wantMaxAttempts int32
+	}{
+		{
+			name:            "response within request timeout",
+			responseDelay:   0,
+			requestTimeout:  500 * time.Millisecond,
+			maxRetries:      2,
+			wantErr:         false,
+			wantMinAttempts: 1,
+			wantMaxAttempts: 1,
+		},
+		{
+			name:            "request timeout bounds each attempt, which is retried",
+			responseDelay:   500 * time.Millisecond,
+			requestTimeout:  50 * time.Millisecond,
+			maxRetries:      2,
+			wantErr:         true,
+			wantMinAttempts: 3,
+			wantMaxAttempts: 3,
+		},
+		{
+			name:            "total timeout bounds all retries",
+			responseDelay:   500 * time.Millisecond,
+			requestTimeout:  50 * time.Millisecond,
+			totalTimeout:    200 * time.Millisecond,
+			maxRetries:      10,
+			wantErr:         true,
+			wantMinAttempts: 2,
+			wantMaxAttempts: 4,
+		},
+		{
+			name:            "total timeout without request timeout",
+			responseDelay:   500 * time.Millisecond,
+			totalTimeout:    50 * time.Millisecond,
+			maxRetries:      10,
+			wantErr:         true,
+			wantMinAttempts: 1,
+			wantMaxAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				select {
+				case <-time.After(tt.responseDelay):
+				case <-r.Context().Done():
+					return
+				}
+				w.Header().Set("Content-Type", "text/xml")
+				_, _ = w.Write([]byte(`<DescribeLoadBalancersResponse><DescribeLoadBalancersResult><LoadBalancers/></DescribeLoadBalancersResult></DescribeLoadBalancersResponse>`))
+			}))
+			defer server.Close()
+
+			httpClient, err := buildHTTPClient(CloudConfig{RequestTimeout: tt.requestTimeout})
+			require.NoError(t, err)
+			sess := session.Must(session.NewSession(awssdk.NewConfig().
+				WithRegion("us-west-2").
+				WithEndpoint(server.URL).
+				WithCredentials(credentials.NewStaticCredentials("AKID", "SECRET", "")).
+				WithMaxRetries(tt.maxRetries).
+				WithHTTPClient(httpClient)))
+			injectTotalTimeout(&sess.Handlers, tt.totalTimeout)
+
+			_, err = elbv2sdk.New(sess).DescribeLoadBalancersWithContext(context.Background(), &elbv2sdk.DescribeLoadBalancersInput{})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			gotAttempts := atomic.LoadInt32(&attempts)
+			assert.GreaterOrEqual(t, gotAttempts, tt.wantMinAttempts)
+			assert.LessOrEqual(t, gotAttempts, tt.wantMaxAttempts)
+		})
+	}
+}
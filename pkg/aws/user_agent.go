@@ -8,10 +8,18 @@ import (
 
 const appName = "elbv2.k8s.aws"
 
-// injectUserAgent will inject app specific user-agent into awsSDK
-func injectUserAgent(handlers *request.Handlers) {
+// injectUserAgent will inject app specific user-agent into awsSDK.
+// the user-agent carries controller's version and clusterName, with optional userAgentSuffix appended.
+func injectUserAgent(handlers *request.Handlers, clusterName string, userAgentSuffix string) {
+	addAppUserAgent := request.MakeAddToUserAgentHandler(appName, version.GitVersion, fmt.Sprintf("cluster/%s", clusterName))
+	addSuffixUserAgent := request.MakeAddToUserAgentFreeFormHandler(userAgentSuffix)
 	handlers.Build.PushFrontNamed(request.NamedHandler{
 		Name: fmt.Sprintf("%s/user-agent", appName),
-		Fn:   request.MakeAddToUserAgentHandler(appName, version.GitVersion),
+		Fn: func(r *request.Request) {
+			addAppUserAgent(r)
+			if len(userAgentSuffix) != 0 {
+				addSuffixUserAgent(r)
+			}
+		},
 	})
 }
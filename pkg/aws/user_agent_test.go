@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/version"
+)
+
+func Test_injectUserAgent(t *testing.T) {
+	type args struct {
+		clusterName     string
+		userAgentSuffix string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "without userAgentSuffix",
+			args: args{
+				clusterName: "my-cluster",
+			},
+			want: "aws-sdk-go/1.0 elbv2.k8s.aws/" + version.GitVersion + " (cluster/my-cluster)",
+		},
+		{
+			name: "with userAgentSuffix",
+			args: args{
+				clusterName:     "my-cluster",
+				userAgentSuffix: "team/awesome",
+			},
+			want: "aws-sdk-go/1.0 elbv2.k8s.aws/" + version.GitVersion + " (cluster/my-cluster) team/awesome",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := request.Handlers{}
+			injectUserAgent(&handlers, tt.args.clusterName, tt.args.userAgentSuffix)
+			r := &request.Request{
+				HTTPRequest: &http.Request{Header: http.Header{}},
+			}
+			r.HTTPRequest.Header.Set("User-Agent", "aws-sdk-go/1.0")
+			handlers.Build.Run(r)
+			assert.Equal(t, tt.want, r.HTTPRequest.Header.Get("User-Agent"))
+		})
+	}
+}
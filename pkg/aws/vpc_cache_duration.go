@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// vpcCacheDurationValue is the flag value for VPC cache duration, in the form of time.Duration(e.g. 5m, 1h30s).
+// a bare integer is accepted as minutes for backwards compatibility, which is deprecated.
+type vpcCacheDurationValue struct {
+	value *time.Duration
+	// whether the value is specified as bare integer of minutes.
+	inMinutes *bool
+}
+
+// newVPCCacheDurationValue constructs new vpcCacheDurationValue that parses into duration.
+func newVPCCacheDurationValue(duration *time.Duration, inMinutes *bool) *vpcCacheDurationValue {
+	return &vpcCacheDurationValue{value: duration, inMinutes: inMinutes}
+}
+
+func (v *vpcCacheDurationValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+	return v.value.String()
+}
+
+func (v *vpcCacheDurationValue) Set(val string) error {
+	if minutes, err := strconv.Atoi(val); err == nil {
+		if minutes < 0 {
+			return errors.Errorf("%s must be non-negative", val)
+		}
+		*v.value = time.Duration(minutes) * time.Minute
+		*v.inMinutes = true
+		return nil
+	}
+	duration, err := time.ParseDuration(val)
+	if err != nil {
+		return errors.Errorf("%s must be valid duration, e.g. 5m, 1h30s", val)
+	}
+	if duration < 0 {
+		return errors.Errorf("%s must be non-negative", val)
+	}
+	*v.value = duration
+	*v.inMinutes = false
+	return nil
+}
+
+func (v *vpcCacheDurationValue) Type() string {
+	return "duration"
+}
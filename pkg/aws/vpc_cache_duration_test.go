@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_vpcCacheDurationValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		want          time.Duration
+		wantInMinutes bool
+		wantErr       error
+	}{
+		{
+			name: "flag not specified",
+			args: nil,
+			want: 0,
+		},
+		{
+			name: "duration in minutes",
+			args: []string{"--aws-vpc-cache-duration=5m"},
+			want: 5 * time.Minute,
+		},
+		{
+			name: "duration with hours and seconds",
+			args: []string{"--aws-vpc-cache-duration=1h30s"},
+			want: time.Hour + 30*time.Second,
+		},
+		{
+			name: "sub-minute duration",
+			args: []string{"--aws-vpc-cache-duration=30s"},
+			want: 30 * time.Second,
+		},
+		{
+			name:          "legacy bare integer as minutes",
+			args:          []string{"--aws-vpc-cache-duration=15"},
+			want:          15 * time.Minute,
+			wantInMinutes: true,
+		},
+		{
+			name:          "legacy zero disables cache",
+			args:          []string{"--aws-vpc-cache-duration=0"},
+			want:          0,
+			wantInMinutes: true,
+		},
+		{
+			name:    "invalid duration",
+			args:    []string{"--aws-vpc-cache-duration=5 minutes"},
+			wantErr: errors.New(`invalid argument "5 minutes" for "--aws-vpc-cache-duration" flag: 5 minutes must be valid duration, e.g. 5m, 1h30s`),
+		},
+		{
+			name:    "negative duration",
+			args:    []string{"--aws-vpc-cache-duration=-5m"},
+			wantErr: errors.New(`invalid argument "-5m" for "--aws-vpc-cache-duration" flag: -5m must be non-negative`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := CloudConfig{}
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			cfg.BindFlags(fs)
+			err := fs.Parse(tt.args)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, cfg.VpcCacheDuration)
+				assert.Equal(t, tt.wantInMinutes, cfg.vpcCacheDurationInMinutes)
+			}
+		})
+	}
+}
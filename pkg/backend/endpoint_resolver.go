@@ -3,12 +3,15 @@ package backend
 import (
 	"context"
 	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -33,11 +36,12 @@ type EndpointResolver interface {
 }
 
 // NewDefaultEndpointResolver constructs new defaultEndpointResolver
-func NewDefaultEndpointResolver(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) *defaultEndpointResolver {
+func NewDefaultEndpointResolver(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, endpointSliceEnabled bool, logger logr.Logger) *defaultEndpointResolver {
 	return &defaultEndpointResolver{
-		k8sClient:   k8sClient,
-		podInfoRepo: podInfoRepo,
-		logger:      logger,
+		k8sClient:            k8sClient,
+		podInfoRepo:          podInfoRepo,
+		endpointSliceEnabled: endpointSliceEnabled,
+		logger:               logger,
 	}
 }
 
@@ -47,7 +51,9 @@ var _ EndpointResolver = &defaultEndpointResolver{}
 type defaultEndpointResolver struct {
 	k8sClient   client.Client
 	podInfoRepo k8s.PodInfoRepo
-	logger      logr.Logger
+	// whether to resolve pod endpoints from EndpointSlices instead of Endpoints.
+	endpointSliceEnabled bool
+	logger               logr.Logger
 }
 
 func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString,
@@ -60,18 +66,16 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 		return nil, false, err
 	}
 
-	epsKey := k8s.NamespacedName(svc) // k8s Endpoints have same name as k8s Service
-	eps := &corev1.Endpoints{}
-	if err := r.k8sClient.Get(ctx, epsKey, eps); err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil, false, fmt.Errorf("%w: %v", ErrNotFound, err.Error())
-		}
+	epSubsets, err := r.fetchEndpointSubsets(ctx, svc)
+	if err != nil {
 		return nil, false, err
 	}
 
 	containsPotentialReadyEndpoints := false
 	var endpoints []PodEndpoint
-	for _, epSubset := range eps.Subsets {
+	// the same endpoint may temporarily present in multiple EndpointSlices, we only need to include it once.
+	endpointKeys := sets.NewString()
+	for _, epSubset := range epSubsets {
 		for _, epPort := range epSubset.Ports {
 			// servicePort.Name is optional if there is only one port
 			if svcPort.Name != "" && svcPort.Name != epPort.Name {
@@ -89,7 +93,20 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 				if !exists {
 					return nil, false, errors.New("couldn't find podInfo for ready endpoint")
 				}
-				endpoints = append(endpoints, buildPodEndpoint(pod, epAddr, epPort))
+				// terminating pods are excluded ahead of endpoints update to start draining sooner.
+				if pod.IsTerminating() {
+					continue
+				}
+				if !pod.HasAllPodConditionsTrue(resolveOpts.PodRequiredConditions) {
+					containsPotentialReadyEndpoints = true
+					continue
+				}
+				endpoint := buildPodEndpoint(pod, epAddr, epPort)
+				if endpointKeys.Has(podEndpointKey(endpoint)) {
+					continue
+				}
+				endpointKeys.Insert(podEndpointKey(endpoint))
+				endpoints = append(endpoints, endpoint)
 			}
 
 			if len(resolveOpts.PodReadinessGates) != 0 {
@@ -105,14 +122,19 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 						containsPotentialReadyEndpoints = true
 						continue
 					}
-					if !pod.HasAnyOfReadinessGates(resolveOpts.PodReadinessGates) {
+					if pod.IsTerminating() || !pod.HasAnyOfReadinessGates(resolveOpts.PodReadinessGates) {
 						continue
 					}
-					if !pod.IsContainersReady() {
+					if !pod.IsContainersReady() || !pod.HasAllPodConditionsTrue(resolveOpts.PodRequiredConditions) {
 						containsPotentialReadyEndpoints = true
 						continue
 					}
-					endpoints = append(endpoints, buildPodEndpoint(pod, epAddr, epPort))
+					endpoint := buildPodEndpoint(pod, epAddr, epPort)
+					if endpointKeys.Has(podEndpointKey(endpoint)) {
+						continue
+					}
+					endpointKeys.Insert(podEndpointKey(endpoint))
+					endpoints = append(endpoints, endpoint)
 				}
 			}
 		}
@@ -138,6 +160,9 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 		return nil, err
 	}
 
+	// nodes are registered regardless of whether they run endpoints of service, including services with externalTrafficPolicy Local.
+	// for such services, the healthCheckNodePort health check gates traffic to nodes running endpoints,
+	// which avoids re-registering nodes whenever pods are rescheduled.
 	var endpoints []NodePortEndpoint
 	for i := range nodeList.Items {
 		node := &nodeList.Items[i]
@@ -170,6 +195,29 @@ func (r *defaultEndpointResolver) findServiceAndServicePort(ctx context.Context,
 	return svc, svcPort, nil
 }
 
+// fetchEndpointSubsets fetches the endpoint subsets for service, from either Endpoints or EndpointSlices.
+func (r *defaultEndpointResolver) fetchEndpointSubsets(ctx context.Context, svc *corev1.Service) ([]corev1.EndpointSubset, error) {
+	if r.endpointSliceEnabled {
+		epSliceList := &discovery.EndpointSliceList{}
+		if err := r.k8sClient.List(ctx, epSliceList,
+			client.InNamespace(svc.Namespace),
+			client.MatchingLabels{discovery.LabelServiceName: svc.Name}); err != nil {
+			return nil, err
+		}
+		return buildEndpointSubsetsFromEndpointSlices(epSliceList.Items), nil
+	}
+
+	epsKey := k8s.NamespacedName(svc) // k8s Endpoints have same name as k8s Service
+	eps := &corev1.Endpoints{}
+	if err := r.k8sClient.Get(ctx, epsKey, eps); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %v", ErrNotFound, err.Error())
+		}
+		return nil, err
+	}
+	return eps.Subsets, nil
+}
+
 func (r *defaultEndpointResolver) findPodByReference(ctx context.Context, namespace string, podRef corev1.ObjectReference) (k8s.PodInfo, bool, error) {
 	podKey := types.NamespacedName{Namespace: namespace, Name: podRef.Name}
 	return r.podInfoRepo.Get(ctx, podKey)
@@ -183,6 +231,53 @@ func buildPodEndpoint(pod k8s.PodInfo, epAddr corev1.EndpointAddress, epPort cor
 	}
 }
 
+// podEndpointKey returns an unique key for podEndpoint.
+func podEndpointKey(endpoint PodEndpoint) string {
+	return fmt.Sprintf("%s:%d", endpoint.IP, endpoint.Port)
+}
+
+// buildEndpointSubsetsFromEndpointSlices aggregates EndpointSlices into endpoint subsets.
+// each EndpointSlice is converted into one subset, endpoints without ready condition are considered as ready.
+func buildEndpointSubsetsFromEndpointSlices(epSlices []discovery.EndpointSlice) []corev1.EndpointSubset {
+	var epSubsets []corev1.EndpointSubset
+	for _, epSlice := range epSlices {
+		if epSlice.AddressType != discovery.AddressTypeIPv4 && epSlice.AddressType != discovery.AddressTypeIPv6 {
+			continue
+		}
+		var epSubset corev1.EndpointSubset
+		for _, port := range epSlice.Ports {
+			if port.Port == nil {
+				continue
+			}
+			epPort := corev1.EndpointPort{
+				Name: awssdk.StringValue(port.Name),
+				Port: *port.Port,
+			}
+			if port.Protocol != nil {
+				epPort.Protocol = *port.Protocol
+			}
+			epSubset.Ports = append(epSubset.Ports, epPort)
+		}
+		for _, ep := range epSlice.Endpoints {
+			ready := ep.Conditions.Ready == nil || *ep.Conditions.Ready
+			for _, addr := range ep.Addresses {
+				epAddr := corev1.EndpointAddress{
+					IP:        addr,
+					TargetRef: ep.TargetRef,
+					NodeName:  ep.NodeName,
+				}
+				if ready {
+					epSubset.Addresses = append(epSubset.Addresses, epAddr)
+				} else {
+					epSubset.NotReadyAddresses = append(epSubset.NotReadyAddresses, epAddr)
+				}
+			}
+		}
+		epSubsets = append(epSubsets, epSubset)
+	}
+	return epSubsets
+}
+
 func buildNodePortEndpoint(node *corev1.Node, instanceID string, nodePort int32) NodePortEndpoint {
 	return NodePortEndpoint{
 		InstanceID: instanceID,
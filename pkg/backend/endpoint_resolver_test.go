@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -57,6 +59,22 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 		},
 		PodIP: "192.168.1.2",
 	}
+	deletionTimestamp := metav1.Now()
+	pod2Terminating := pod2
+	pod2Terminating.DeletionTimestamp = &deletionTimestamp
+	warmedUpCondType := corev1.PodConditionType("example.com/warmed-up")
+	buildPodWithWarmedUpCondition := func(pod k8s.PodInfo, status corev1.ConditionStatus) k8s.PodInfo {
+		podWithCond := pod
+		podWithCond.Conditions = append([]corev1.PodCondition{}, pod.Conditions...)
+		podWithCond.Conditions = append(podWithCond.Conditions, corev1.PodCondition{
+			Type:   warmedUpCondType,
+			Status: status,
+		})
+		return podWithCond
+	}
+	pod1WarmedUp := buildPodWithWarmedUpCondition(pod1, corev1.ConditionTrue)
+	pod2WarmedUp := buildPodWithWarmedUpCondition(pod2, corev1.ConditionTrue)
+	pod2NotWarmedUp := buildPodWithWarmedUpCondition(pod2, corev1.ConditionFalse)
 	pod3 := k8s.PodInfo{
 		Key: types.NamespacedName{Namespace: testNS, Name: "pod-3"},
 		UID: "pod-uuid-3",
@@ -379,6 +397,50 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 		},
 	}
 
+	svcHeadless := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-headless",
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromString("http-port"),
+				},
+			},
+		},
+	}
+	epHeadless := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-headless",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Ports: []corev1.EndpointPort{
+					{
+						Name: "http",
+						Port: 8080,
+					},
+				},
+				Addresses: []corev1.EndpointAddress{
+					{
+						IP: pod1.PodIP,
+						TargetRef: &corev1.ObjectReference{
+							Kind:      "Pod",
+							Namespace: pod1.Key.Namespace,
+							Name:      pod1.Key.Name,
+						},
+					},
+				},
+			},
+		},
+	}
+
 	type podInfoRepoGetCall struct {
 		key    types.NamespacedName
 		pod    k8s.PodInfo
@@ -719,6 +781,141 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 			},
 			wantContainsPotentialReadyEndpoints: true,
 		},
+		{
+			name: "pods will only be included if required condition is true - condition is false for some pods",
+			env: env{
+				services:      []*corev1.Service{svc1},
+				endpointsList: []*corev1.Endpoints{ep1A},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1WarmedUp,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2NotWarmedUp,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithPodRequiredCondition(warmedUpCondType)},
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1WarmedUp,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: true,
+		},
+		{
+			name: "pods will only be included if required condition is true - condition flipped to true for all pods",
+			env: env{
+				services:      []*corev1.Service{svc1},
+				endpointsList: []*corev1.Endpoints{ep1A},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1WarmedUp,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2WarmedUp,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithPodRequiredCondition(warmedUpCondType)},
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1WarmedUp,
+				},
+				{
+					IP:   "192.168.1.2",
+					Port: 8080,
+					Pod:  pod2WarmedUp,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "pods without required condition will not be included",
+			env: env{
+				services:      []*corev1.Service{svc1},
+				endpointsList: []*corev1.Endpoints{ep1A},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithPodRequiredCondition(warmedUpCondType)},
+			},
+			want:                                nil,
+			wantContainsPotentialReadyEndpoints: true,
+		},
+		{
+			name: "terminating pods will not be included",
+			env: env{
+				services:      []*corev1.Service{svc1},
+				endpointsList: []*corev1.Endpoints{ep1A},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2Terminating,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   nil,
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
 		{
 			name: "service not found",
 			env: env{
@@ -753,6 +950,119 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 			},
 			wantErr: fmt.Errorf("%w: %v", ErrNotFound, "unable to find port http on service test-ns/svc-1"),
 		},
+		{
+			name: "clusterIP service resolved by numeric service port",
+			env: env{
+				services:      []*corev1.Service{svc1},
+				endpointsList: []*corev1.Endpoints{ep1A},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromInt(80),
+				opts:   nil,
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+				{
+					IP:   "192.168.1.2",
+					Port: 8080,
+					Pod:  pod2,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "headless service resolved by named service port",
+			env: env{
+				services:      []*corev1.Service{svcHeadless},
+				endpointsList: []*corev1.Endpoints{epHeadless},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svcHeadless),
+				port:   intstr.FromString("http"),
+				opts:   nil,
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "headless service resolved by numeric service port",
+			env: env{
+				services:      []*corev1.Service{svcHeadless},
+				endpointsList: []*corev1.Endpoints{epHeadless},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svcHeadless),
+				port:   intstr.FromInt(80),
+				opts:   nil,
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "headless service port not found",
+			env: env{
+				services:      []*corev1.Service{svcHeadless},
+				endpointsList: []*corev1.Endpoints{epHeadless},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svcHeadless),
+				port:   intstr.FromInt(8080),
+				opts:   nil,
+			},
+			wantErr: fmt.Errorf("%w: %v", ErrNotFound, "unable to find port 8080 on service test-ns/svc-headless"),
+		},
 		{
 			name: "endpoints not found",
 			env: env{
@@ -816,11 +1126,364 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 	}
 }
 
-func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
+func Test_defaultEndpointResolver_ResolvePodEndpoints_EndpointSlices(t *testing.T) {
 	testNS := "test-ns"
-	node1 := &corev1.Node{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "node-1",
+	pod1 := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: testNS, Name: "pod-1"},
+		UID: "pod-uuid-1",
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionTrue,
+			},
+			{
+				Type:   corev1.ContainersReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		PodIP: "192.168.1.1",
+	}
+	pod2 := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: testNS, Name: "pod-2"},
+		UID: "pod-uuid-2",
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionTrue,
+			},
+			{
+				Type:   corev1.ContainersReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		PodIP: "192.168.1.2",
+	}
+	pod3 := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: testNS, Name: "pod-3"},
+		UID: "pod-uuid-3",
+		ReadinessGates: []corev1.PodReadinessGate{
+			{
+				ConditionType: "custom-condition",
+			},
+		},
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionFalse,
+			},
+			{
+				Type:   corev1.ContainersReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		PodIP: "192.168.1.3",
+	}
+	svc1 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1",
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name: "http",
+					Port: 80,
+				},
+				{
+					Name: "https",
+					Port: 443,
+				},
+			},
+		},
+	}
+	buildPodRef := func(pod k8s.PodInfo) *corev1.ObjectReference {
+		return &corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: pod.Key.Namespace,
+			Name:      pod.Key.Name,
+		}
+	}
+	epSlice1A := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1-a",
+			Labels:    map[string]string{discovery.LabelServiceName: "svc-1"},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{
+			{
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+			{
+				Name: awssdk.String("https"),
+				Port: awssdk.Int32(8443),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses:  []string{pod1.PodIP},
+				Conditions: discovery.EndpointConditions{Ready: awssdk.Bool(true)},
+				TargetRef:  buildPodRef(pod1),
+			},
+			{
+				Addresses: []string{pod2.PodIP},
+				TargetRef: buildPodRef(pod2),
+			},
+		},
+	}
+	epSlice1B := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1-b",
+			Labels:    map[string]string{discovery.LabelServiceName: "svc-1"},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{
+			{
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses:  []string{pod2.PodIP},
+				Conditions: discovery.EndpointConditions{Ready: awssdk.Bool(true)},
+				TargetRef:  buildPodRef(pod2),
+			},
+			{
+				Addresses:  []string{pod3.PodIP},
+				Conditions: discovery.EndpointConditions{Ready: awssdk.Bool(false)},
+				TargetRef:  buildPodRef(pod3),
+			},
+		},
+	}
+	epSlice1FQDN := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1-fqdn",
+			Labels:    map[string]string{discovery.LabelServiceName: "svc-1"},
+		},
+		AddressType: discovery.AddressTypeFQDN,
+		Ports: []discovery.EndpointPort{
+			{
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{"www.example.com"},
+			},
+		},
+	}
+	epSlice2A := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-2-a",
+			Labels:    map[string]string{discovery.LabelServiceName: "svc-2"},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{
+			{
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{"192.168.2.1"},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: testNS,
+					Name:      "pod-of-svc-2",
+				},
+			},
+		},
+	}
+
+	type env struct {
+		services       []*corev1.Service
+		endpointSlices []*discovery.EndpointSlice
+	}
+	type args struct {
+		svcKey types.NamespacedName
+		port   intstr.IntOrString
+		opts   []EndpointResolveOption
+	}
+	tests := []struct {
+		name                                string
+		env                                 env
+		pods                                []k8s.PodInfo
+		args                                args
+		want                                []PodEndpoint
+		wantContainsPotentialReadyEndpoints bool
+		wantErr                             error
+	}{
+		{
+			name: "ready endpoints from multiple endpointSlices will be aggregated",
+			env: env{
+				services:       []*corev1.Service{svc1},
+				endpointSlices: []*discovery.EndpointSlice{epSlice1A, epSlice1B, epSlice1FQDN, epSlice2A},
+			},
+			pods: []k8s.PodInfo{pod1, pod2, pod3},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   nil,
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+				{
+					IP:   "192.168.1.2",
+					Port: 8080,
+					Pod:  pod2,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "unready endpoints from multiple endpointSlices will be included if it have readinessGate and containerReady",
+			env: env{
+				services:       []*corev1.Service{svc1},
+				endpointSlices: []*discovery.EndpointSlice{epSlice1A, epSlice1B, epSlice1FQDN, epSlice2A},
+			},
+			pods: []k8s.PodInfo{pod1, pod2, pod3},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithPodReadinessGate("custom-condition")},
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+				{
+					IP:   "192.168.1.2",
+					Port: 8080,
+					Pod:  pod2,
+				},
+				{
+					IP:   "192.168.1.3",
+					Port: 8080,
+					Pod:  pod3,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "endpoints from endpointSlices will be resolved per service port",
+			env: env{
+				services:       []*corev1.Service{svc1},
+				endpointSlices: []*discovery.EndpointSlice{epSlice1A, epSlice1B, epSlice1FQDN, epSlice2A},
+			},
+			pods: []k8s.PodInfo{pod1, pod2, pod3},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("https"),
+				opts:   nil,
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8443,
+					Pod:  pod1,
+				},
+				{
+					IP:   "192.168.1.2",
+					Port: 8443,
+					Pod:  pod2,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "no endpointSlices",
+			env: env{
+				services:       []*corev1.Service{svc1},
+				endpointSlices: []*discovery.EndpointSlice{epSlice2A},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   nil,
+			},
+			want:                                nil,
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "service not found",
+			env: env{
+				services:       []*corev1.Service{},
+				endpointSlices: []*discovery.EndpointSlice{epSlice1A},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   nil,
+			},
+			wantErr: fmt.Errorf("%w: %v", ErrNotFound, "services \"svc-1\" not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			podInfoRepo := k8s.NewMockPodInfoRepo(ctrl)
+			for _, pod := range tt.pods {
+				podInfoRepo.EXPECT().Get(gomock.Any(), pod.Key).Return(pod, true, nil).AnyTimes()
+			}
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			ctx := context.Background()
+			for _, svc := range tt.env.services {
+				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			}
+			for _, epSlice := range tt.env.endpointSlices {
+				assert.NoError(t, k8sClient.Create(ctx, epSlice.DeepCopy()))
+			}
+
+			r := &defaultEndpointResolver{
+				k8sClient:            k8sClient,
+				podInfoRepo:          podInfoRepo,
+				endpointSliceEnabled: true,
+				logger:               &log.NullLogger{},
+			}
+			got, gotContainsPotentialReadyEndpoints, err := r.ResolvePodEndpoints(ctx, tt.args.svcKey, tt.args.port, tt.args.opts...)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				opt := cmp.Options{
+					equality.IgnoreFakeClientPopulatedFields(),
+					cmpopts.SortSlices(func(lhs PodEndpoint, rhs PodEndpoint) bool {
+						return lhs.IP < rhs.IP
+					}),
+				}
+				assert.True(t, cmp.Equal(tt.want, got, opt),
+					"diff: %v", cmp.Diff(tt.want, got, opt))
+				assert.Equal(t, tt.wantContainsPotentialReadyEndpoints, gotContainsPotentialReadyEndpoints)
+			}
+		})
+	}
+}
+
+func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
+	testNS := "test-ns"
+	node1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
 			Labels: map[string]string{
 				"labelA": "valueA",
 			},
@@ -915,6 +1578,24 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			},
 		},
 	}
+	svcLocal := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-local",
+		},
+		Spec: corev1.ServiceSpec{
+			Type:                  corev1.ServiceTypeLoadBalancer,
+			ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+			HealthCheckNodePort:   32000,
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "http",
+					Port:     80,
+					NodePort: 18080,
+				},
+			},
+		},
+	}
 	svc1WithoutHTTPPort := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNS,
@@ -1024,6 +1705,30 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "choose every ready node for service with externalTrafficPolicy Local",
+			env: env{
+				nodes:    []*corev1.Node{node1, node2, node3, node4},
+				services: []*corev1.Service{svcLocal},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svcLocal),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything())},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg1",
+					Port:       18080,
+					Node:       node1,
+				},
+				{
+					InstanceID: "i-abcdefg2",
+					Port:       18080,
+					Node:       node2,
+				},
+			},
+		},
 		{
 			name: "clusterIP service is not supported",
 			env: env{
@@ -35,6 +35,10 @@ type EndpointResolveOptions struct {
 	// [Pod Endpoint] If pod readinessGates is defined, then pods from unready addresses with any of these readinessGates and containersReady condition will be included as well.
 	// By default, no readinessGate is specified.
 	PodReadinessGates []corev1.PodConditionType
+
+	// [Pod Endpoint] If pod required conditions is defined, then only pods with all these conditions be true will be included.
+	// By default, no required condition is specified.
+	PodRequiredConditions []corev1.PodConditionType
 }
 
 func (opts *EndpointResolveOptions) ApplyOptions(options []EndpointResolveOption) {
@@ -59,10 +63,18 @@ func WithPodReadinessGate(cond corev1.PodConditionType) EndpointResolveOption {
 	}
 }
 
+// WithPodRequiredCondition is a option that appends podRequiredCondition into EndpointResolveOptions.
+func WithPodRequiredCondition(cond corev1.PodConditionType) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.PodRequiredConditions = append(opts.PodRequiredConditions, cond)
+	}
+}
+
 // defaultEndpointResolveOptions returns the default value for EndpointResolveOptions.
 func defaultEndpointResolveOptions() EndpointResolveOptions {
 	return EndpointResolveOptions{
-		NodeSelector:      labels.Nothing(),
-		PodReadinessGates: nil,
+		NodeSelector:          labels.Nothing(),
+		PodReadinessGates:     nil,
+		PodRequiredConditions: nil,
 	}
 }
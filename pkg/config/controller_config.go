@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,14 +16,37 @@ const (
 	flagK8sClusterName                               = "cluster-name"
 	flagDefaultTags                                  = "default-tags"
 	flagExternalManagedTags                          = "external-managed-tags"
+	flagNamespaceLabelTagPrefixes                    = "namespace-label-tag-prefixes"
 	flagServiceMaxConcurrentReconciles               = "service-max-concurrent-reconciles"
+	flagServiceDefaultHealthCheckPath                = "service-default-healthcheck-path"
 	flagTargetGroupBindingMaxConcurrentReconciles    = "targetgroupbinding-max-concurrent-reconciles"
 	flagTargetGroupBindingMaxExponentialBackoffDelay = "targetgroupbinding-max-exponential-backoff-delay"
+	flagTargetGroupBindingDeferSGRuleCleanup         = "targetgroupbinding-defer-sg-rule-cleanup"
+	flagTargetGroupBindingEndpointsDebounceWindow    = "targetgroupbinding-endpoints-debounce-window"
+	flagTargetGroupBindingTargetHealthRequeue        = "targetgroupbinding-target-health-requeue-interval"
+	flagTargetGroupBindingTargetHealthRequeueMax     = "targetgroupbinding-target-health-requeue-max-interval"
 	flagDefaultSSLPolicy                             = "default-ssl-policy"
+	flagDisableSubnetAutoDiscovery                   = "disable-subnet-auto-discovery"
+	flagMaxSubnetsPerLB                              = "max-subnets-per-lb"
+	flagDisableListenerPruning                       = "disable-listener-pruning"
+	flagEnableEndpointSlices                         = "enable-endpoint-slices"
+	flagLoadBalancerProvisioningTimeout              = "load-balancer-provisioning-timeout"
+	flagEventRateLimitQPS                            = "event-rate-limit-qps"
+	flagEventRateLimitBurst                          = "event-rate-limit-burst"
+	flagEnableOrphanedTargetGroupCleanup             = "enable-orphaned-target-group-cleanup"
+	flagMinReconcileInterval                         = "min-reconcile-interval"
+	flagEnableSGRulePortRangeConsolidation           = "enable-sg-rule-port-range-consolidation"
+	flagValidatePermissions                          = "validate-permissions"
+	flagValidatePermissionsFailFast                  = "validate-permissions-fail-fast"
 	defaultLogLevel                                  = "info"
 	defaultMaxConcurrentReconciles                   = 3
+	defaultServiceHealthCheckPath                    = "/"
+	maxHealthCheckPathLength                         = 1024
 	defaultMaxExponentialBackoffDelay                = time.Second * 1000
 	defaultSSLPolicy                                 = "ELBSecurityPolicy-2016-08"
+	defaultEventRateLimitBurst                       = 25
+	defaultTargetHealthRequeueInterval               = 15 * time.Second
+	defaultTargetHealthRequeueMaxInterval            = 5 * time.Minute
 )
 
 var (
@@ -58,16 +82,69 @@ type ControllerConfig struct {
 	// List of Tag keys on AWS resources that will be managed externally.
 	ExternalManagedTags []string
 
+	// Prefixes of Namespace label keys that will be applied as AWS Tags to load balancers, target groups and security groups.
+	NamespaceLabelTagPrefixes []string
+
 	// Default SSL Policy that will be applied to all ingresses or services that do not have
 	// the SSL Policy annotation.
 	DefaultSSLPolicy string
 
+	// DisableSubnetAutoDiscovery requires every Ingress and Service to specify subnets explicitly.
+	DisableSubnetAutoDiscovery bool
+
+	// MaxSubnetsPerLB is the maximum number of subnets chosen by subnet auto-discovery for a load balancer, 0 means unlimited.
+	MaxSubnetsPerLB int
+
+	// DisableListenerPruning keeps listeners on ports that are no longer specified by Ingresses or Services,
+	// so that they can be managed externally.
+	DisableListenerPruning bool
+
+	// EnableEndpointSlices enables resolving IP targets from EndpointSlices instead of Endpoints.
+	EnableEndpointSlices bool
+
+	// LoadBalancerProvisioningTimeout is the max duration to wait for newly created load balancers to finish provisioning.
+	// Zero disables the wait.
+	LoadBalancerProvisioningTimeout time.Duration
+
+	// EventRateLimitQPS is the rate of events allowed per object, identical consecutive events are deduplicated as well.
+	// Zero disables the rate limiting.
+	EventRateLimitQPS float32
+	// EventRateLimitBurst is the burst of events allowed per object.
+	EventRateLimitBurst int
+
+	// EnableOrphanedTargetGroupCleanup enables deleting targetGroups no longer referenced by any listener or listener rule,
+	// once all their targets are deregistered.
+	EnableOrphanedTargetGroupCleanup bool
+
+	// EnableSGRulePortRangeConsolidation enables consolidating rules of managed securityGroups with contiguous port ranges
+	// and same protocol and source into a single rule, to reduce the rule count.
+	EnableSGRulePortRangeConsolidation bool
+
+	// MinReconcileInterval is the minimum interval between reconciles of the same object.
+	// Zero disables the throttling.
+	MinReconcileInterval time.Duration
+
+	// ValidatePermissions enables validating IAM permissions with describe calls at startup, missing permissions are logged.
+	ValidatePermissions bool
+	// ValidatePermissionsFailFast fails the startup if any IAM permission is missing.
+	ValidatePermissionsFailFast bool
+
 	// Max concurrent reconcile loops for Service objects
 	ServiceMaxConcurrentReconciles int
+	// Default health check path for HTTP/HTTPS health checks of Service target groups without the healthcheck-path annotation
+	ServiceDefaultHealthCheckPath string
 	// Max concurrent reconcile loops for TargetGroupBinding objects
 	TargetGroupBindingMaxConcurrentReconciles int
 	// Max exponential backoff delay for reconcile failures of TargetGroupBinding
 	TargetGroupBindingMaxExponentialBackoffDelay time.Duration
+	// Defer revoking securityGroup rules of deleted TargetGroupBinding until later reconciles
+	TargetGroupBindingDeferSGRuleCleanup bool
+	// Window to coalesce endpoints changes into a single reconcile of TargetGroupBinding
+	TargetGroupBindingEndpointsDebounceWindow time.Duration
+	// Initial requeue interval of TargetGroupBinding to monitor targets that are not healthy yet
+	TargetGroupBindingTargetHealthRequeueInterval time.Duration
+	// Max requeue interval of TargetGroupBinding to monitor targets that are not healthy yet
+	TargetGroupBindingTargetHealthRequeueMaxInterval time.Duration
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -79,14 +156,50 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 		"Default AWS Tags that will be applied to all AWS resources managed by this controller")
 	fs.StringSliceVar(&cfg.ExternalManagedTags, flagExternalManagedTags, nil,
 		"List of Tag keys on AWS resources that will be managed externally")
+	fs.StringSliceVar(&cfg.NamespaceLabelTagPrefixes, flagNamespaceLabelTagPrefixes, nil,
+		"Prefixes of Namespace label keys, matching labels are applied as AWS Tags to load balancers, target groups and security groups created for resources in the Namespace")
 	fs.IntVar(&cfg.ServiceMaxConcurrentReconciles, flagServiceMaxConcurrentReconciles, defaultMaxConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for service")
+	fs.StringVar(&cfg.ServiceDefaultHealthCheckPath, flagServiceDefaultHealthCheckPath, defaultServiceHealthCheckPath,
+		"Default health check path for HTTP or HTTPS health checks of service target groups, can be overridden per service via annotation")
 	fs.IntVar(&cfg.TargetGroupBindingMaxConcurrentReconciles, flagTargetGroupBindingMaxConcurrentReconciles, defaultMaxConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for targetGroupBinding")
 	fs.DurationVar(&cfg.TargetGroupBindingMaxExponentialBackoffDelay, flagTargetGroupBindingMaxExponentialBackoffDelay, defaultMaxExponentialBackoffDelay,
 		"Maximum duration of exponential backoff for targetGroupBinding reconcile failures")
+	fs.BoolVar(&cfg.TargetGroupBindingDeferSGRuleCleanup, flagTargetGroupBindingDeferSGRuleCleanup, false,
+		"Defer revoking securityGroup rules of deleted targetGroupBinding until they are garbage collected by later reconciles")
+	fs.DurationVar(&cfg.TargetGroupBindingEndpointsDebounceWindow, flagTargetGroupBindingEndpointsDebounceWindow, 0,
+		"Window to coalesce bursts of endpoints changes into a single targetGroupBinding reconcile, 0 disables debouncing")
+	fs.DurationVar(&cfg.TargetGroupBindingTargetHealthRequeueInterval, flagTargetGroupBindingTargetHealthRequeue, defaultTargetHealthRequeueInterval,
+		"Initial interval to requeue targetGroupBinding when targets are not healthy yet, backs off exponentially while targets are only unhealthy")
+	fs.DurationVar(&cfg.TargetGroupBindingTargetHealthRequeueMaxInterval, flagTargetGroupBindingTargetHealthRequeueMax, defaultTargetHealthRequeueMaxInterval,
+		"Maximum interval to requeue targetGroupBinding when targets are unhealthy")
 	fs.StringVar(&cfg.DefaultSSLPolicy, flagDefaultSSLPolicy, defaultSSLPolicy,
 		"Default SSL policy for load balancers listeners")
+	fs.BoolVar(&cfg.DisableSubnetAutoDiscovery, flagDisableSubnetAutoDiscovery, false,
+		"Disable subnet auto-discovery, subnets must be specified explicitly via annotation")
+	fs.IntVar(&cfg.MaxSubnetsPerLB, flagMaxSubnetsPerLB, 0,
+		"Maximum number of subnets chosen by subnet auto-discovery for a load balancer, subnets are chosen in the order of AZ name. 0 means unlimited")
+	fs.BoolVar(&cfg.DisableListenerPruning, flagDisableListenerPruning, false,
+		"Disable deleting listeners on ports no longer specified by ingresses or services, so that they can be managed externally")
+	fs.BoolVar(&cfg.EnableEndpointSlices, flagEnableEndpointSlices, false,
+		"Enable EndpointSlices for IP targets instead of Endpoints")
+	fs.DurationVar(&cfg.LoadBalancerProvisioningTimeout, flagLoadBalancerProvisioningTimeout, 0,
+		"Maximum duration for newly created load balancers to finish provisioning, the reconcile is requeued while they're provisioning. 0 disables the check")
+	fs.Float32Var(&cfg.EventRateLimitQPS, flagEventRateLimitQPS, 0,
+		"Rate of events allowed per object, identical consecutive events are deduplicated as well. 0 disables the rate limiting")
+	fs.IntVar(&cfg.EventRateLimitBurst, flagEventRateLimitBurst, defaultEventRateLimitBurst,
+		"Burst of events allowed per object")
+	fs.BoolVar(&cfg.EnableOrphanedTargetGroupCleanup, flagEnableOrphanedTargetGroupCleanup, false,
+		"Enable deleting target groups no longer referenced by any listener or listener rule once their targets are deregistered")
+	fs.BoolVar(&cfg.EnableSGRulePortRangeConsolidation, flagEnableSGRulePortRangeConsolidation, false,
+		"Enable consolidating managed security group rules with contiguous port ranges and the same protocol and source into a single rule")
+	fs.DurationVar(&cfg.MinReconcileInterval, flagMinReconcileInterval, 0,
+		"Minimum interval between reconciles of the same ingress group, service or targetGroupBinding, 0 disables the throttling")
+	fs.BoolVar(&cfg.ValidatePermissions, flagValidatePermissions, false,
+		"Validate IAM permissions required by enabled features with describe calls at startup, and log missing permissions")
+	fs.BoolVar(&cfg.ValidatePermissionsFailFast, flagValidatePermissionsFailFast, false,
+		"Fail the startup if any IAM permission is missing, requires validate-permissions to be enabled")
 
 	cfg.AWSConfig.BindFlags(fs)
 	cfg.RuntimeConfig.BindFlags(fs)
@@ -111,6 +224,30 @@ func (cfg *ControllerConfig) Validate() error {
 	if err := cfg.validateExternalManagedTagsCollisionWithDefaultTags(); err != nil {
 		return err
 	}
+	if err := cfg.validateNamespaceLabelTagPrefixes(); err != nil {
+		return err
+	}
+	if err := cfg.validateEventRateLimit(); err != nil {
+		return err
+	}
+	if err := cfg.validateTargetGroupBindingTargetHealthRequeue(); err != nil {
+		return err
+	}
+	if err := cfg.validateServiceDefaultHealthCheckPath(); err != nil {
+		return err
+	}
+	if err := cfg.validatePermissionsValidation(); err != nil {
+		return err
+	}
+	if err := cfg.validateMaxSubnetsPerLB(); err != nil {
+		return err
+	}
+	if err := cfg.IngressConfig.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.PodWebhookConfig.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -141,3 +278,56 @@ func (cfg *ControllerConfig) validateExternalManagedTagsCollisionWithDefaultTags
 	}
 	return nil
 }
+
+func (cfg *ControllerConfig) validateNamespaceLabelTagPrefixes() error {
+	for _, prefix := range cfg.NamespaceLabelTagPrefixes {
+		if len(prefix) == 0 {
+			return errors.Errorf("%v flag must not contain empty prefix", flagNamespaceLabelTagPrefixes)
+		}
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateEventRateLimit() error {
+	if cfg.EventRateLimitQPS < 0 {
+		return errors.Errorf("%v flag must be non-negative: %v", flagEventRateLimitQPS, cfg.EventRateLimitQPS)
+	}
+	if cfg.EventRateLimitQPS > 0 && cfg.EventRateLimitBurst <= 0 {
+		return errors.Errorf("%v flag must be positive when %v is enabled: %v", flagEventRateLimitBurst, flagEventRateLimitQPS, cfg.EventRateLimitBurst)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateTargetGroupBindingTargetHealthRequeue() error {
+	if cfg.TargetGroupBindingTargetHealthRequeueInterval <= 0 {
+		return errors.Errorf("%v flag must be positive: %v", flagTargetGroupBindingTargetHealthRequeue, cfg.TargetGroupBindingTargetHealthRequeueInterval)
+	}
+	if cfg.TargetGroupBindingTargetHealthRequeueMaxInterval < cfg.TargetGroupBindingTargetHealthRequeueInterval {
+		return errors.Errorf("%v flag must be no less than %v flag: %v", flagTargetGroupBindingTargetHealthRequeueMax,
+			flagTargetGroupBindingTargetHealthRequeue, cfg.TargetGroupBindingTargetHealthRequeueMaxInterval)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateServiceDefaultHealthCheckPath() error {
+	if !strings.HasPrefix(cfg.ServiceDefaultHealthCheckPath, "/") || len(cfg.ServiceDefaultHealthCheckPath) > maxHealthCheckPathLength {
+		return errors.Errorf("%v flag must start with / and be at most %v characters: %v", flagServiceDefaultHealthCheckPath,
+			maxHealthCheckPathLength, cfg.ServiceDefaultHealthCheckPath)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validatePermissionsValidation() error {
+	if cfg.ValidatePermissionsFailFast && !cfg.ValidatePermissions {
+		return errors.Errorf("%v flag requires %v flag to be enabled", flagValidatePermissionsFailFast, flagValidatePermissions)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateMaxSubnetsPerLB() error {
+	// application load balancers require subnets from at least two AZs.
+	if cfg.MaxSubnetsPerLB != 0 && cfg.MaxSubnetsPerLB < 2 {
+		return errors.Errorf("%v flag must be either 0 or at least 2: %v", flagMaxSubnetsPerLB, cfg.MaxSubnetsPerLB)
+	}
+	return nil
+}
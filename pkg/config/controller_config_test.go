@@ -164,3 +164,118 @@ func TestControllerConfig_validateExternalManagedTagsCollisionWithDefaultTags(t
 		})
 	}
 }
+
+func TestControllerConfig_validateServiceDefaultHealthCheckPath(t *testing.T) {
+	tests := []struct {
+		name                          string
+		serviceDefaultHealthCheckPath string
+		wantErr                       error
+	}{
+		{
+			name:                          "root path",
+			serviceDefaultHealthCheckPath: "/",
+		},
+		{
+			name:                          "healthz path",
+			serviceDefaultHealthCheckPath: "/healthz",
+		},
+		{
+			name:                          "path without leading slash",
+			serviceDefaultHealthCheckPath: "healthz",
+			wantErr:                       errors.New("service-default-healthcheck-path flag must start with / and be at most 1024 characters: healthz"),
+		},
+		{
+			name:                          "empty path",
+			serviceDefaultHealthCheckPath: "",
+			wantErr:                       errors.New("service-default-healthcheck-path flag must start with / and be at most 1024 characters: "),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				ServiceDefaultHealthCheckPath: tt.serviceDefaultHealthCheckPath,
+			}
+			err := cfg.validateServiceDefaultHealthCheckPath()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateMaxSubnetsPerLB(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxSubnetsPerLB int
+		wantErr         error
+	}{
+		{
+			name:            "unlimited",
+			maxSubnetsPerLB: 0,
+		},
+		{
+			name:            "two subnets",
+			maxSubnetsPerLB: 2,
+		},
+		{
+			name:            "single subnet",
+			maxSubnetsPerLB: 1,
+			wantErr:         errors.New("max-subnets-per-lb flag must be either 0 or at least 2: 1"),
+		},
+		{
+			name:            "negative",
+			maxSubnetsPerLB: -1,
+			wantErr:         errors.New("max-subnets-per-lb flag must be either 0 or at least 2: -1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				MaxSubnetsPerLB: tt.maxSubnetsPerLB,
+			}
+			err := cfg.validateMaxSubnetsPerLB()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateNamespaceLabelTagPrefixes(t *testing.T) {
+	tests := []struct {
+		name                      string
+		namespaceLabelTagPrefixes []string
+		wantErr                   error
+	}{
+		{
+			name:                      "no prefixes",
+			namespaceLabelTagPrefixes: nil,
+		},
+		{
+			name:                      "valid prefixes",
+			namespaceLabelTagPrefixes: []string{"cost-center", "example.com/"},
+		},
+		{
+			name:                      "empty prefix",
+			namespaceLabelTagPrefixes: []string{"cost-center", ""},
+			wantErr:                   errors.New("namespace-label-tag-prefixes flag must not contain empty prefix"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				NamespaceLabelTagPrefixes: tt.namespaceLabelTagPrefixes,
+			}
+			err := cfg.validateNamespaceLabelTagPrefixes()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,16 +1,33 @@
 package config
 
-import "github.com/spf13/pflag"
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
 
 const (
 	flagIngressClass                         = "ingress-class"
+	flagDefaultIngressClass                  = "default-ingress-class"
 	flagDisableIngressClassAnnotation        = "disable-ingress-class-annotation"
 	flagDisableIngressGroupNameAnnotation    = "disable-ingress-group-name-annotation"
 	flagIngressMaxConcurrentReconciles       = "ingress-max-concurrent-reconciles"
+	flagIngressMaxListenerRules              = "ingress-max-listener-rules"
+	flagIngressSkipMissingBackends           = "ingress-skip-missing-backends"
+	flagIngressDuplicateRulePolicy           = "ingress-duplicate-rule-policy"
 	defaultIngressClass                      = "alb"
 	defaultDisableIngressClassAnnotation     = false
 	defaultDisableIngressGroupNameAnnotation = false
 	defaultMaxIngressConcurrentReconciles    = 3
+	defaultIngressMaxListenerRules           = 0
+	defaultIngressSkipMissingBackends        = false
+	defaultIngressDuplicateRulePolicy        = IngressDuplicateRulePolicyFirstWins
+)
+
+const (
+	// IngressDuplicateRulePolicyReject rejects Ingresses containing rules with duplicate conditions.
+	IngressDuplicateRulePolicyReject = "reject"
+	// IngressDuplicateRulePolicyFirstWins keeps the first rule in Ingress spec order among rules with duplicate conditions.
+	IngressDuplicateRulePolicyFirstWins = "first-wins"
 )
 
 // IngressConfig contains the configurations for the Ingress controller
@@ -19,6 +36,10 @@ type IngressConfig struct {
 	// If empty, all Ingresses without ingress.class annotation, or ingress.class==alb get considered
 	IngressClass string
 
+	// DefaultIngressClass is the Ingress class that Ingresses without any class are considered as.
+	// If it matches IngressClass, Ingresses without ingressClassName or ingress.class annotation get considered
+	DefaultIngressClass string
+
 	// DisableIngressClassAnnotation specifies whether to disable new usage of kubernetes.io/ingress.class annotation.
 	DisableIngressClassAnnotation bool
 
@@ -27,16 +48,49 @@ type IngressConfig struct {
 
 	// Max concurrent reconcile loops for Ingress objects
 	MaxConcurrentReconciles int
+
+	// Max number of rules per listener, the reconcile fails if it's exceeded. 0 means unlimited.
+	MaxListenerRules int64
+
+	// SkipMissingBackends specifies whether to skip backends referencing non-existent services instead of failing the reconcile.
+	SkipMissingBackends bool
+
+	// DuplicateRulePolicy specifies how rules with duplicate conditions within a single Ingress are handled.
+	DuplicateRulePolicy string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
 func (cfg *IngressConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.IngressClass, flagIngressClass, defaultIngressClass,
 		"Name of the ingress class this controller satisfies")
+	fs.StringVar(&cfg.DefaultIngressClass, flagDefaultIngressClass, "",
+		"Ingress class that ingresses without ingressClassName or ingress.class annotation are considered as, they are claimed if it matches ingress-class")
 	fs.BoolVar(&cfg.DisableIngressClassAnnotation, flagDisableIngressClassAnnotation, defaultDisableIngressClassAnnotation,
 		"Disable new usage of kubernetes.io/ingress.class annotation")
 	fs.BoolVar(&cfg.DisableIngressGroupNameAnnotation, flagDisableIngressGroupNameAnnotation, defaultDisableIngressGroupNameAnnotation,
 		"Disable new usage of alb.ingress.kubernetes.io/group.name annotation")
 	fs.IntVar(&cfg.MaxConcurrentReconciles, flagIngressMaxConcurrentReconciles, defaultMaxIngressConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for ingress")
+	fs.Int64Var(&cfg.MaxListenerRules, flagIngressMaxListenerRules, defaultIngressMaxListenerRules,
+		"Maximum number of rules per listener for ingress, the reconcile fails with an event if it's exceeded. 0 means unlimited")
+	fs.BoolVar(&cfg.SkipMissingBackends, flagIngressSkipMissingBackends, defaultIngressSkipMissingBackends,
+		"Skip backends referencing non-existent services with an event, instead of failing the reconcile of the whole ingress")
+	fs.StringVar(&cfg.DuplicateRulePolicy, flagIngressDuplicateRulePolicy, defaultIngressDuplicateRulePolicy,
+		"Policy for rules with duplicate conditions within an ingress: first-wins keeps the first rule in spec order, reject denies the ingress in webhook and fails the reconcile")
+}
+
+// Validate the Ingress controller configuration
+func (cfg *IngressConfig) Validate() error {
+	switch cfg.DuplicateRulePolicy {
+	case IngressDuplicateRulePolicyReject, IngressDuplicateRulePolicyFirstWins:
+		return nil
+	default:
+		return errors.Errorf("invalid %v flag: %v, must be one of [%v, %v]", flagIngressDuplicateRulePolicy,
+			cfg.DuplicateRulePolicy, IngressDuplicateRulePolicyReject, IngressDuplicateRulePolicyFirstWins)
+	}
+}
+
+// ManageIngressesWithoutIngressClass returns whether Ingresses without ingressClassName or ingress.class annotation should be managed.
+func (cfg *IngressConfig) ManageIngressesWithoutIngressClass() bool {
+	return cfg.IngressClass == "" || cfg.DefaultIngressClass == cfg.IngressClass
 }
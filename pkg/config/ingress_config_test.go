@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngressConfig_ManageIngressesWithoutIngressClass(t *testing.T) {
+	type fields struct {
+		IngressClass        string
+		DefaultIngressClass string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   bool
+	}{
+		{
+			name: "ingress class is empty",
+			fields: fields{
+				IngressClass: "",
+			},
+			want: true,
+		},
+		{
+			name: "default ingress class is empty",
+			fields: fields{
+				IngressClass:        "alb",
+				DefaultIngressClass: "",
+			},
+			want: false,
+		},
+		{
+			name: "default ingress class matches ingress class",
+			fields: fields{
+				IngressClass:        "alb",
+				DefaultIngressClass: "alb",
+			},
+			want: true,
+		},
+		{
+			name: "default ingress class mismatches ingress class",
+			fields: fields{
+				IngressClass:        "alb",
+				DefaultIngressClass: "nginx",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &IngressConfig{
+				IngressClass:        tt.fields.IngressClass,
+				DefaultIngressClass: tt.fields.DefaultIngressClass,
+			}
+			got := cfg.ManageIngressesWithoutIngressClass()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIngressConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name                string
+		duplicateRulePolicy string
+		wantErr             error
+	}{
+		{
+			name:                "reject duplicate rule policy",
+			duplicateRulePolicy: "reject",
+		},
+		{
+			name:                "first-wins duplicate rule policy",
+			duplicateRulePolicy: "first-wins",
+		},
+		{
+			name:                "invalid duplicate rule policy",
+			duplicateRulePolicy: "last-wins",
+			wantErr:             errors.New("invalid ingress-duplicate-rule-policy flag: last-wins, must be one of [reject, first-wins]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &IngressConfig{
+				DuplicateRulePolicy: tt.duplicateRulePolicy,
+			}
+			err := cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,24 +2,49 @@ package ec2
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
-	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"time"
 )
 
 const (
-	defaultWaitSGDeletionPollInterval = 2 * time.Second
-	defaultWaitSGDeletionTimeout      = 2 * time.Minute
+	defaultSGDeletionRequeueBaseDelay = 30 * time.Second
+	defaultSGDeletionRequeueMaxDelay  = 5 * time.Minute
+	defaultSGDeletionMaxAttempts      = 10
 )
 
+// SecurityGroupDeletionDelayedError is the error when SecurityGroup deletion is delayed since it's still referenced by other resources.
+// the controller should requeue after Delay, until the deletion succeeds or MaxAttempts is reached.
+type SecurityGroupDeletionDelayedError struct {
+	SecurityGroupID string
+	// the attempt of deletion that failed.
+	Attempt int
+	// the max attempts of deletion before failing with a terminal error.
+	MaxAttempts int
+	// the delay until next attempt.
+	Delay time.Duration
+	// the underlying DependencyViolation error.
+	Err error
+}
+
+func (e *SecurityGroupDeletionDelayedError) Error() string {
+	return fmt.Sprintf("securityGroup %v is still referenced by other resources, deletion will be retried in %v (attempt %v/%v): %v",
+		e.SecurityGroupID, e.Delay, e.Attempt, e.MaxAttempts, e.Err)
+}
+
+func (e *SecurityGroupDeletionDelayedError) Unwrap() error {
+	return e.Err
+}
+
 // SecurityGroupManager is responsible for create/update/delete SecurityGroup resources.
 type SecurityGroupManager interface {
 	Create(ctx context.Context, resSG *ec2model.SecurityGroup) (ec2model.SecurityGroupStatus, error)
@@ -31,18 +56,19 @@ type SecurityGroupManager interface {
 
 // NewDefaultSecurityGroupManager constructs new defaultSecurityGroupManager.
 func NewDefaultSecurityGroupManager(ec2Client services.EC2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	networkingSGReconciler networking.SecurityGroupReconciler, vpcID string, externalManagedTags []string, logger logr.Logger) *defaultSecurityGroupManager {
+	networkingSGReconciler networking.SecurityGroupReconciler, vpcID string, externalManagedTags []string, portRangeConsolidationEnabled bool, logger logr.Logger) *defaultSecurityGroupManager {
 	return &defaultSecurityGroupManager{
-		ec2Client:              ec2Client,
-		trackingProvider:       trackingProvider,
-		taggingManager:         taggingManager,
-		networkingSGReconciler: networkingSGReconciler,
-		vpcID:                  vpcID,
-		externalManagedTags:    externalManagedTags,
-		logger:                 logger,
-
-		waitSGDeletionPollInterval: defaultWaitSGDeletionPollInterval,
-		waitSGDeletionTimeout:      defaultWaitSGDeletionTimeout,
+		ec2Client:                     ec2Client,
+		trackingProvider:              trackingProvider,
+		taggingManager:                taggingManager,
+		networkingSGReconciler:        networkingSGReconciler,
+		vpcID:                         vpcID,
+		externalManagedTags:           externalManagedTags,
+		portRangeConsolidationEnabled: portRangeConsolidationEnabled,
+		logger:                        logger,
+
+		sgDeletionRequeueBackoff: workqueue.NewItemExponentialFailureRateLimiter(defaultSGDeletionRequeueBaseDelay, defaultSGDeletionRequeueMaxDelay),
+		sgDeletionMaxAttempts:    defaultSGDeletionMaxAttempts,
 	}
 }
 
@@ -54,10 +80,13 @@ type defaultSecurityGroupManager struct {
 	networkingSGReconciler networking.SecurityGroupReconciler
 	vpcID                  string
 	externalManagedTags    []string
-	logger                 logr.Logger
+	// whether to consolidate permissions with contiguous port ranges to reduce rule count.
+	portRangeConsolidationEnabled bool
+	logger                        logr.Logger
 
-	waitSGDeletionPollInterval time.Duration
-	waitSGDeletionTimeout      time.Duration
+	// sgDeletionRequeueBackoff computes the requeue delay per SecurityGroup whose deletion failed with DependencyViolation.
+	sgDeletionRequeueBackoff workqueue.RateLimiter
+	sgDeletionMaxAttempts    int
 }
 
 func (m *defaultSecurityGroupManager) Create(ctx context.Context, resSG *ec2model.SecurityGroup) (ec2model.SecurityGroupStatus, error) {
@@ -90,7 +119,8 @@ func (m *defaultSecurityGroupManager) Create(ctx context.Context, resSG *ec2mode
 		"resourceID", resSG.ID(),
 		"securityGroupID", sgID)
 
-	if err := m.networkingSGReconciler.ReconcileIngress(ctx, sgID, permissionInfos); err != nil {
+	if _, err := m.networkingSGReconciler.ReconcileIngress(ctx, sgID, permissionInfos,
+		networking.WithConsolidatePortRanges(m.portRangeConsolidationEnabled)); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
 
@@ -107,7 +137,8 @@ func (m *defaultSecurityGroupManager) Update(ctx context.Context, resSG *ec2mode
 	if err := m.updateSDKSecurityGroupGroupWithTags(ctx, resSG, sdkSG); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
-	if err := m.networkingSGReconciler.ReconcileIngress(ctx, sdkSG.SecurityGroupID, permissionInfos); err != nil {
+	if _, err := m.networkingSGReconciler.ReconcileIngress(ctx, sdkSG.SecurityGroupID, permissionInfos,
+		networking.WithConsolidatePortRanges(m.portRangeConsolidationEnabled)); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
 	return ec2model.SecurityGroupStatus{
@@ -122,18 +153,40 @@ func (m *defaultSecurityGroupManager) Delete(ctx context.Context, sdkSG networki
 
 	m.logger.Info("deleting securityGroup",
 		"securityGroupID", sdkSG.SecurityGroupID)
-	if err := runtime.RetryImmediateOnError(m.waitSGDeletionPollInterval, m.waitSGDeletionTimeout, isSecurityGroupDependencyViolationError, func() error {
-		_, err := m.ec2Client.DeleteSecurityGroupWithContext(ctx, req)
-		return err
-	}); err != nil {
+	if _, err := m.ec2Client.DeleteSecurityGroupWithContext(ctx, req); err != nil {
+		if isSecurityGroupDependencyViolationError(err) {
+			return m.delaySecurityGroupDeletion(sdkSG.SecurityGroupID, err)
+		}
 		return errors.Wrap(err, "failed to delete securityGroup")
 	}
+	m.sgDeletionRequeueBackoff.Forget(sdkSG.SecurityGroupID)
 	m.logger.Info("deleted securityGroup",
 		"securityGroupID", sdkSG.SecurityGroupID)
 
 	return nil
 }
 
+// delaySecurityGroupDeletion delays the deletion of SecurityGroup that is still referenced by other resources with exponential backoff.
+// a terminal error is returned once the max attempts is reached, and keeps being returned until the deletion succeeds.
+func (m *defaultSecurityGroupManager) delaySecurityGroupDeletion(sgID string, err error) error {
+	attempt := m.sgDeletionRequeueBackoff.NumRequeues(sgID) + 1
+	if attempt >= m.sgDeletionMaxAttempts {
+		return errors.Wrapf(err, "failed to delete securityGroup %v after %v attempts", sgID, m.sgDeletionMaxAttempts)
+	}
+	delay := m.sgDeletionRequeueBackoff.When(sgID)
+	m.logger.Info("securityGroup is still referenced by other resources, delaying deletion",
+		"securityGroupID", sgID,
+		"attempt", attempt,
+		"delay", delay)
+	return &SecurityGroupDeletionDelayedError{
+		SecurityGroupID: sgID,
+		Attempt:         attempt,
+		MaxAttempts:     m.sgDeletionMaxAttempts,
+		Delay:           delay,
+		Err:             err,
+	}
+}
+
 func (m *defaultSecurityGroupManager) updateSDKSecurityGroupGroupWithTags(ctx context.Context, resSG *ec2model.SecurityGroup, sdkSG networking.SecurityGroupInfo) error {
 	desiredSGTags := m.trackingProvider.ResourceTags(resSG.Stack(), resSG, resSG.Spec.Tags)
 	return m.taggingManager.ReconcileTags(ctx, sdkSG.SecurityGroupID, desiredSGTags,
@@ -1,12 +1,302 @@
 package ec2
 
 import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
+func Test_defaultSecurityGroupManager_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stack := core.NewDefaultStack(core.StackID{Namespace: "ns-1", Name: "ing-1"})
+	resSG := ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{
+		GroupName:   "k8s-ns1-ing1-abcdefg",
+		Description: "[k8s] Managed SecurityGroup for LoadBalancer",
+		Ingress: []ec2model.IPPermission{
+			{
+				IPProtocol: "tcp",
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				IPRanges: []ec2model.IPRange{
+					{
+						CIDRIP:      "10.0.0.0/16",
+						Description: "elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=10.0.0.0/16",
+					},
+				},
+			},
+			{
+				IPProtocol: "tcp",
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				IPv6Range: []ec2model.IPv6Range{
+					{
+						CIDRIPv6:    "::/0",
+						Description: "elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=::/0",
+					},
+				},
+			},
+		},
+	})
+
+	ec2Client := services.NewMockEC2(ctrl)
+	ec2Client.EXPECT().CreateSecurityGroupWithContext(gomock.Any(), gomock.Any()).Return(&ec2sdk.CreateSecurityGroupOutput{
+		GroupId: awssdk.String("sg-a"),
+	}, nil)
+	sgReconciler := networking.NewMockSecurityGroupReconciler(ctrl)
+	sgReconciler.EXPECT().ReconcileIngress(gomock.Any(), "sg-a", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, sgID string, desiredPermissions []networking.IPPermissionInfo, opts ...networking.SecurityGroupReconcileOption) (networking.SecurityGroupReconcileResult, error) {
+			assert.Equal(t, []networking.IPPermissionInfo{
+				networking.NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16",
+					networking.NewIPPermissionLabelsForRawDescription("elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=10.0.0.0/16")),
+				networking.NewCIDRv6IPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "::/0",
+					networking.NewIPPermissionLabelsForRawDescription("elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=::/0")),
+			}, desiredPermissions)
+			assert.Equal(t, "elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=10.0.0.0/16",
+				awssdk.StringValue(desiredPermissions[0].Permission.IpRanges[0].Description))
+
+			reconcileOpts := networking.SecurityGroupReconcileOptions{
+				PermissionSelector: labels.Everything(),
+			}
+			reconcileOpts.ApplyOptions(opts...)
+			undescribedPermission := networking.NewRawIPPermission(ec2sdk.IpPermission{
+				IpProtocol: awssdk.String("tcp"),
+				FromPort:   awssdk.Int64(443),
+				ToPort:     awssdk.Int64(443),
+				IpRanges: []*ec2sdk.IpRange{
+					{
+						CidrIp: awssdk.String("192.168.0.0/16"),
+					},
+				},
+			})
+			assert.True(t, reconcileOpts.PermissionSelector.Matches(labels.Set(undescribedPermission.Labels)))
+			return networking.SecurityGroupReconcileResult{}, nil
+		})
+
+	m := NewDefaultSecurityGroupManager(ec2Client, tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name"),
+		nil, sgReconciler, "vpc-a", nil, false, &log.NullLogger{})
+	got, err := m.Create(ctx, resSG)
+	assert.NoError(t, err)
+	assert.Equal(t, ec2model.SecurityGroupStatus{GroupID: "sg-a"}, got)
+}
+
+func Test_defaultSecurityGroupManager_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stack := core.NewDefaultStack(core.StackID{Namespace: "ns-1", Name: "ing-1"})
+	resSG := ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{
+		GroupName:   "k8s-ns1-ing1-abcdefg",
+		Description: "[k8s] Managed SecurityGroup for LoadBalancer",
+		Ingress: []ec2model.IPPermission{
+			{
+				IPProtocol: "tcp",
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				IPRanges: []ec2model.IPRange{
+					{
+						CIDRIP:      "10.0.0.0/16",
+						Description: "elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=10.0.0.0/16",
+					},
+				},
+			},
+		},
+	})
+	desiredPermission := networking.NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16",
+		networking.NewIPPermissionLabelsForRawDescription("elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=10.0.0.0/16"))
+	staleUndescribedPermission := networking.NewRawIPPermission(ec2sdk.IpPermission{
+		IpProtocol: awssdk.String("tcp"),
+		FromPort:   awssdk.Int64(22),
+		ToPort:     awssdk.Int64(22),
+		IpRanges: []*ec2sdk.IpRange{
+			{
+				CidrIp: awssdk.String("0.0.0.0/0"),
+			},
+		},
+	})
+	trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+	sgTags := trackingProvider.ResourceTags(stack, resSG, nil)
+
+	networkingSGManager := networking.NewMockSecurityGroupManager(ctrl)
+	networkingSGManager.EXPECT().FetchSGInfosByID(gomock.Any(), []string{"sg-a"}).Return(map[string]networking.SecurityGroupInfo{
+		"sg-a": {
+			SecurityGroupID: "sg-a",
+			Ingress: []networking.IPPermissionInfo{
+				networking.NewRawIPPermission(desiredPermission.Permission),
+				staleUndescribedPermission,
+			},
+			Tags: sgTags,
+		},
+	}, nil)
+	networkingSGManager.EXPECT().RevokeSGIngress(gomock.Any(), "sg-a", []networking.IPPermissionInfo{staleUndescribedPermission}).Return(nil)
+	taggingManager := NewDefaultTaggingManager(nil, networkingSGManager, "vpc-a", &log.NullLogger{})
+
+	m := NewDefaultSecurityGroupManager(nil, trackingProvider, taggingManager,
+		networking.NewDefaultSecurityGroupReconciler(networkingSGManager, &log.NullLogger{}), "vpc-a", nil, false, &log.NullLogger{})
+	got, err := m.Update(ctx, resSG, networking.SecurityGroupInfo{
+		SecurityGroupID: "sg-a",
+		Tags:            sgTags,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ec2model.SecurityGroupStatus{GroupID: "sg-a"}, got)
+}
+
+func Test_defaultSecurityGroupManager_Delete(t *testing.T) {
+	dependencyViolationErr := awserr.New("DependencyViolation", "resource sg-a has a dependent object", nil)
+	// deleteSGCall is the outcome of DeleteSecurityGroup call within a single Delete.
+	type deleteSGCall struct {
+		err error
+	}
+	type wantResult struct {
+		err         error
+		wantDelayed *SecurityGroupDeletionDelayedError
+	}
+	tests := []struct {
+		name          string
+		deleteSGCalls []deleteSGCall
+		maxAttempts   int
+		wantResults   []wantResult
+	}{
+		{
+			name: "successful deletion",
+			deleteSGCalls: []deleteSGCall{
+				{
+					err: nil,
+				},
+			},
+			maxAttempts: 3,
+			wantResults: []wantResult{
+				{},
+			},
+		},
+		{
+			name: "DependencyViolation delays deletion, then successful deletion on requeue",
+			deleteSGCalls: []deleteSGCall{
+				{
+					err: dependencyViolationErr,
+				},
+				{
+					err: nil,
+				},
+			},
+			maxAttempts: 3,
+			wantResults: []wantResult{
+				{
+					wantDelayed: &SecurityGroupDeletionDelayedError{
+						SecurityGroupID: "sg-a",
+						Attempt:         1,
+						MaxAttempts:     3,
+						Delay:           time.Second,
+						Err:             dependencyViolationErr,
+					},
+				},
+				{},
+			},
+		},
+		{
+			name: "DependencyViolation fails deletion after max attempts, until successful deletion",
+			deleteSGCalls: []deleteSGCall{
+				{
+					err: dependencyViolationErr,
+				},
+				{
+					err: dependencyViolationErr,
+				},
+				{
+					err: dependencyViolationErr,
+				},
+				{
+					err: nil,
+				},
+			},
+			maxAttempts: 2,
+			wantResults: []wantResult{
+				{
+					wantDelayed: &SecurityGroupDeletionDelayedError{
+						SecurityGroupID: "sg-a",
+						Attempt:         1,
+						MaxAttempts:     2,
+						Delay:           time.Second,
+						Err:             dependencyViolationErr,
+					},
+				},
+				{
+					err: errors.New("failed to delete securityGroup sg-a after 2 attempts: DependencyViolation: resource sg-a has a dependent object"),
+				},
+				{
+					err: errors.New("failed to delete securityGroup sg-a after 2 attempts: DependencyViolation: resource sg-a has a dependent object"),
+				},
+				{},
+			},
+		},
+		{
+			name: "other error fails deletion",
+			deleteSGCalls: []deleteSGCall{
+				{
+					err: errors.New("some error"),
+				},
+			},
+			maxAttempts: 3,
+			wantResults: []wantResult{
+				{
+					err: errors.New("failed to delete securityGroup: some error"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := services.NewMockEC2(ctrl)
+			m := &defaultSecurityGroupManager{
+				ec2Client:                ec2Client,
+				logger:                   &log.NullLogger{},
+				sgDeletionRequeueBackoff: workqueue.NewItemExponentialFailureRateLimiter(time.Second, time.Minute),
+				sgDeletionMaxAttempts:    tt.maxAttempts,
+			}
+			for _, call := range tt.deleteSGCalls {
+				ec2Client.EXPECT().DeleteSecurityGroupWithContext(gomock.Any(), &ec2sdk.DeleteSecurityGroupInput{
+					GroupId: awssdk.String("sg-a"),
+				}).Return(&ec2sdk.DeleteSecurityGroupOutput{}, call.err)
+			}
+			for i := range tt.deleteSGCalls {
+				err := m.Delete(context.Background(), networking.SecurityGroupInfo{SecurityGroupID: "sg-a"})
+				want := tt.wantResults[i]
+				if want.wantDelayed != nil {
+					var sgDeletionDelayedErr *SecurityGroupDeletionDelayedError
+					assert.True(t, errors.As(err, &sgDeletionDelayedErr))
+					assert.Equal(t, want.wantDelayed, sgDeletionDelayedErr)
+					assert.True(t, isSecurityGroupDependencyViolationError(err))
+				} else if want.err != nil {
+					assert.EqualError(t, err, want.err.Error())
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, 0, m.sgDeletionRequeueBackoff.NumRequeues("sg-a"))
+				}
+			}
+		})
+	}
+}
+
 func Test_isSecurityGroupDependencyViolationError(t *testing.T) {
 	type args struct {
 		err error
@@ -0,0 +1,39 @@
+package elbv2
+
+import (
+	"fmt"
+)
+
+const (
+	resourceTypeLoadBalancer = "loadBalancer"
+
+	immutableFieldCustomerOwnedIPv4Pool = "CustomerOwnedIPv4Pool"
+	immutableFieldNLBSubnets            = "Subnets"
+)
+
+// ImmutableFieldChangeError indicates a change is requested on an immutable field of AWS resource,
+// which cannot be applied in place and requires the AWS resource to be recreated.
+type ImmutableFieldChangeError struct {
+	// the type of AWS resource, e.g. loadBalancer.
+	ResourceType string
+	// the ARN of AWS resource.
+	ResourceARN string
+	// the immutable field requested to change.
+	Field string
+	// the current value of field.
+	CurrentValue string
+	// the desired value of field.
+	DesiredValue string
+}
+
+func (e *ImmutableFieldChangeError) Error() string {
+	return fmt.Sprintf("%v %v cannot change immutable field %v from %v to %v, recreate the Ingress or Service to apply the change",
+		e.ResourceType, e.ResourceARN, e.Field, formatImmutableFieldValue(e.CurrentValue), formatImmutableFieldValue(e.DesiredValue))
+}
+
+func formatImmutableFieldValue(value string) string {
+	if len(value) == 0 {
+		return "none"
+	}
+	return value
+}
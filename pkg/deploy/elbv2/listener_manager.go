@@ -27,15 +27,16 @@ type ListenerManager interface {
 }
 
 func NewDefaultListenerManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider,
-	taggingManager TaggingManager, externalManagedTags []string, logger logr.Logger) *defaultListenerManager {
+	taggingManager TaggingManager, noHealthyTargetsActionResolver NoHealthyTargetsActionResolver, externalManagedTags []string, logger logr.Logger) *defaultListenerManager {
 	return &defaultListenerManager{
-		elbv2Client:                 elbv2Client,
-		trackingProvider:            trackingProvider,
-		taggingManager:              taggingManager,
-		externalManagedTags:         externalManagedTags,
-		logger:                      logger,
-		waitLSExistencePollInterval: defaultWaitLSExistencePollInterval,
-		waitLSExistenceTimeout:      defaultWaitLSExistenceTimeout,
+		elbv2Client:                    elbv2Client,
+		trackingProvider:               trackingProvider,
+		taggingManager:                 taggingManager,
+		noHealthyTargetsActionResolver: noHealthyTargetsActionResolver,
+		externalManagedTags:            externalManagedTags,
+		logger:                         logger,
+		waitLSExistencePollInterval:    defaultWaitLSExistencePollInterval,
+		waitLSExistenceTimeout:         defaultWaitLSExistenceTimeout,
 	}
 }
 
@@ -43,40 +44,26 @@ var _ ListenerManager = &defaultListenerManager{}
 
 // default implementation for ListenerManager
 type defaultListenerManager struct {
-	elbv2Client         services.ELBV2
-	trackingProvider    tracking.Provider
-	taggingManager      TaggingManager
-	externalManagedTags []string
-	logger              logr.Logger
+	elbv2Client                    services.ELBV2
+	trackingProvider               tracking.Provider
+	taggingManager                 TaggingManager
+	noHealthyTargetsActionResolver NoHealthyTargetsActionResolver
+	externalManagedTags            []string
+	logger                         logr.Logger
 
 	waitLSExistencePollInterval time.Duration
 	waitLSExistenceTimeout      time.Duration
 }
 
 func (m *defaultListenerManager) Create(ctx context.Context, resLS *elbv2model.Listener) (elbv2model.ListenerStatus, error) {
-	req, err := buildSDKCreateListenerInput(resLS.Spec)
+	req, err := m.buildSDKCreateListenerInputForResLS(ctx, resLS)
 	if err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
-	lsTags := m.trackingProvider.ResourceTags(resLS.Stack(), resLS, resLS.Spec.Tags)
-	req.Tags = convertTagsToSDKTags(lsTags)
-
-	m.logger.Info("creating listener",
-		"stackID", resLS.Stack().StackID(),
-		"resourceID", resLS.ID())
-	resp, err := m.elbv2Client.CreateListenerWithContext(ctx, req)
+	sdkLS, err := m.createSDKListener(ctx, resLS, req)
 	if err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
-	sdkLS := ListenerWithTags{
-		Listener: resp.Listeners[0],
-		Tags:     lsTags,
-	}
-	m.logger.Info("created listener",
-		"stackID", resLS.Stack().StackID(),
-		"resourceID", resLS.ID(),
-		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn))
-
 	if err := runtime.RetryImmediateOnError(m.waitLSExistencePollInterval, m.waitLSExistenceTimeout, isListenerNotFoundError, func() error {
 		return m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, true)
 	}); err != nil {
@@ -89,6 +76,9 @@ func (m *defaultListenerManager) Update(ctx context.Context, resLS *elbv2model.L
 	if err := m.updateSDKListenerWithTags(ctx, resLS, sdkLS); err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
+	if isListenerRecreationRequired(resLS.Spec, sdkLS) {
+		return m.recreateSDKListener(ctx, resLS, sdkLS)
+	}
 	if err := m.updateSDKListenerWithSettings(ctx, resLS, sdkLS); err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
@@ -112,6 +102,94 @@ func (m *defaultListenerManager) Delete(ctx context.Context, sdkLS ListenerWithT
 	return nil
 }
 
+// buildSDKCreateListenerInputForResLS builds the CreateListener request for listener resource, with actions resolved according to current target health.
+func (m *defaultListenerManager) buildSDKCreateListenerInputForResLS(ctx context.Context, resLS *elbv2model.Listener) (*elbv2sdk.CreateListenerInput, error) {
+	lsSpec := resLS.Spec
+	defaultActions, err := m.noHealthyTargetsActionResolver.ResolveActions(ctx, lsSpec.DefaultActions)
+	if err != nil {
+		return nil, err
+	}
+	lsSpec.DefaultActions = defaultActions
+	req, err := buildSDKCreateListenerInput(lsSpec)
+	if err != nil {
+		return nil, err
+	}
+	lsTags := m.trackingProvider.ResourceTags(resLS.Stack(), resLS, resLS.Spec.Tags)
+	req.Tags = convertTagsToSDKTags(lsTags)
+	return req, nil
+}
+
+func (m *defaultListenerManager) createSDKListener(ctx context.Context, resLS *elbv2model.Listener, req *elbv2sdk.CreateListenerInput) (ListenerWithTags, error) {
+	m.logger.Info("creating listener",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID())
+	resp, err := m.elbv2Client.CreateListenerWithContext(ctx, req)
+	if err != nil {
+		return ListenerWithTags{}, err
+	}
+	sdkLS := ListenerWithTags{
+		Listener: resp.Listeners[0],
+		Tags:     convertSDKTagsToTags(req.Tags),
+	}
+	m.logger.Info("created listener",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID(),
+		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn))
+	return sdkLS, nil
+}
+
+// recreateSDKListener replaces the listener with a new one, for changes that cannot be applied by modifying the listener in place.
+// the new listener is built and validated before the current one is deleted, and the current listener is restored if the new one cannot be created.
+// listener rules are removed along with the listener, and will be created on the new listener by the listener rule synthesizer.
+func (m *defaultListenerManager) recreateSDKListener(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags) (elbv2model.ListenerStatus, error) {
+	req, err := m.buildSDKCreateListenerInputForResLS(ctx, resLS)
+	if err != nil {
+		return elbv2model.ListenerStatus{}, err
+	}
+	if err := validateSDKCreateListenerInput(req); err != nil {
+		return elbv2model.ListenerStatus{}, err
+	}
+	m.logger.Info("recreating listener since changes cannot be applied in place",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID(),
+		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
+		"currentProtocol", awssdk.StringValue(sdkLS.Listener.Protocol),
+		"desiredProtocol", resLS.Spec.Protocol)
+	if err := m.Delete(ctx, sdkLS); err != nil {
+		return elbv2model.ListenerStatus{}, err
+	}
+	newSDKLS, err := m.createSDKListener(ctx, resLS, req)
+	if err != nil {
+		if restoreErr := m.restoreSDKListener(ctx, sdkLS); restoreErr != nil {
+			return elbv2model.ListenerStatus{}, errors.Wrapf(err, "failed to recreate listener, and failed to restore previous listener: %v", restoreErr)
+		}
+		return elbv2model.ListenerStatus{}, errors.Wrap(err, "failed to recreate listener, previous listener restored")
+	}
+	if err := runtime.RetryImmediateOnError(m.waitLSExistencePollInterval, m.waitLSExistenceTimeout, isListenerNotFoundError, func() error {
+		return m.updateSDKListenerWithExtraCertificates(ctx, resLS, newSDKLS, true)
+	}); err != nil {
+		return elbv2model.ListenerStatus{}, errors.Wrap(err, "failed to update extra certificates on listener")
+	}
+	lsStatus := buildResListenerStatus(newSDKLS)
+	lsStatus.Recreated = true
+	return lsStatus, nil
+}
+
+// restoreSDKListener restores a deleted listener with its previous settings.
+// extra certificates and listener rules are restored by subsequent reconciles.
+func (m *defaultListenerManager) restoreSDKListener(ctx context.Context, sdkLS ListenerWithTags) error {
+	req := buildSDKCreateListenerInputFromSDKListener(sdkLS)
+	m.logger.Info("restoring listener",
+		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn))
+	resp, err := m.elbv2Client.CreateListenerWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("restored listener",
+		"arn", awssdk.StringValue(resp.Listeners[0].ListenerArn))
+	return nil
+}
+
 func (m *defaultListenerManager) updateSDKListenerWithTags(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags) error {
 	desiredLSTags := m.trackingProvider.ResourceTags(resLS.Stack(), resLS, resLS.Spec.Tags)
 	return m.taggingManager.ReconcileTags(ctx, awssdk.StringValue(sdkLS.Listener.ListenerArn), desiredLSTags,
@@ -120,7 +198,11 @@ func (m *defaultListenerManager) updateSDKListenerWithTags(ctx context.Context,
 }
 
 func (m *defaultListenerManager) updateSDKListenerWithSettings(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags) error {
-	desiredDefaultActions, err := buildSDKActions(resLS.Spec.DefaultActions)
+	defaultActions, err := m.noHealthyTargetsActionResolver.ResolveActions(ctx, resLS.Spec.DefaultActions)
+	if err != nil {
+		return err
+	}
+	desiredDefaultActions, err := buildSDKActions(defaultActions)
 	if err != nil {
 		return err
 	}
@@ -277,6 +359,20 @@ func buildSDKCreateListenerInput(lsSpec elbv2model.ListenerSpec) (*elbv2sdk.Crea
 	return sdkObj, nil
 }
 
+// buildSDKCreateListenerInputFromSDKListener builds the CreateListener request to create a listener with same settings as sdkLS.
+func buildSDKCreateListenerInputFromSDKListener(sdkLS ListenerWithTags) *elbv2sdk.CreateListenerInput {
+	return &elbv2sdk.CreateListenerInput{
+		LoadBalancerArn: sdkLS.Listener.LoadBalancerArn,
+		Port:            sdkLS.Listener.Port,
+		Protocol:        sdkLS.Listener.Protocol,
+		DefaultActions:  sdkLS.Listener.DefaultActions,
+		Certificates:    sdkLS.Listener.Certificates,
+		SslPolicy:       sdkLS.Listener.SslPolicy,
+		AlpnPolicy:      sdkLS.Listener.AlpnPolicy,
+		Tags:            convertTagsToSDKTags(sdkLS.Tags),
+	}
+}
+
 func buildSDKModifyListenerInput(lsSpec elbv2model.ListenerSpec, desiredDefaultActions []*elbv2sdk.Action, desiredDefaultCerts []*elbv2sdk.Certificate) *elbv2sdk.ModifyListenerInput {
 	sdkObj := &elbv2sdk.ModifyListenerInput{}
 	sdkObj.Port = awssdk.Int64(lsSpec.Port)
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2 (interfaces: ListenerManager)
+
+// Package elbv2 is a generated GoMock package.
+package elbv2
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	elbv20 "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// MockListenerManager is a mock of ListenerManager interface.
+type MockListenerManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockListenerManagerMockRecorder
+}
+
+// MockListenerManagerMockRecorder is the mock recorder for MockListenerManager.
+type MockListenerManagerMockRecorder struct {
+	mock *MockListenerManager
+}
+
+// NewMockListenerManager creates a new mock instance.
+func NewMockListenerManager(ctrl *gomock.Controller) *MockListenerManager {
+	mock := &MockListenerManager{ctrl: ctrl}
+	mock.recorder = &MockListenerManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListenerManager) EXPECT() *MockListenerManagerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockListenerManager) Create(arg0 context.Context, arg1 *elbv20.Listener) (elbv20.ListenerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(elbv20.ListenerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockListenerManagerMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockListenerManager)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockListenerManager) Delete(arg0 context.Context, arg1 ListenerWithTags) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListenerManagerMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockListenerManager)(nil).Delete), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockListenerManager) Update(arg0 context.Context, arg1 *elbv20.Listener, arg2 ListenerWithTags) (elbv20.ListenerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(elbv20.ListenerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockListenerManagerMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockListenerManager)(nil).Update), arg0, arg1, arg2)
+}
@@ -1,10 +1,18 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -184,3 +192,549 @@ func Test_isSDKListenerSettingsDrifted(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultListenerManager_updateSDKListenerWithTags(t *testing.T) {
+	type addTagsWithContextCall struct {
+		req *elbv2sdk.AddTagsInput
+	}
+	type removeTagsWithContextCall struct {
+		req *elbv2sdk.RemoveTagsInput
+	}
+	type fields struct {
+		externalManagedTags []string
+	}
+	type args struct {
+		desiredTags map[string]string
+		currentTags map[string]string
+	}
+	trackingTags := map[string]string{
+		"elbv2.k8s.aws/cluster":    "cluster-name",
+		"ingress.k8s.aws/stack":    "namespace/name",
+		"ingress.k8s.aws/resource": "80",
+	}
+	withTrackingTags := func(tags map[string]string) map[string]string {
+		merged := make(map[string]string, len(trackingTags)+len(tags))
+		for k, v := range trackingTags {
+			merged[k] = v
+		}
+		for k, v := range tags {
+			merged[k] = v
+		}
+		return merged
+	}
+	tests := []struct {
+		name                       string
+		fields                     fields
+		args                       args
+		addTagsWithContextCalls    []addTagsWithContextCall
+		removeTagsWithContextCalls []removeTagsWithContextCall
+	}{
+		{
+			name: "tags in sync",
+			args: args{
+				desiredTags: map[string]string{"team": "a"},
+				currentTags: withTrackingTags(map[string]string{"team": "a"}),
+			},
+		},
+		{
+			name: "drifted tags are reconciled",
+			args: args{
+				desiredTags: map[string]string{"team": "a", "cost-center": "1234"},
+				currentTags: withTrackingTags(map[string]string{"team": "b", "stale": "v"}),
+			},
+			addTagsWithContextCalls: []addTagsWithContextCall{
+				{
+					req: &elbv2sdk.AddTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"ls-arn"}),
+						Tags: []*elbv2sdk.Tag{
+							{Key: awssdk.String("cost-center"), Value: awssdk.String("1234")},
+							{Key: awssdk.String("team"), Value: awssdk.String("a")},
+						},
+					},
+				},
+			},
+			removeTagsWithContextCalls: []removeTagsWithContextCall{
+				{
+					req: &elbv2sdk.RemoveTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"ls-arn"}),
+						TagKeys:      awssdk.StringSlice([]string{"stale"}),
+					},
+				},
+			},
+		},
+		{
+			name: "external managed tags are left untouched",
+			fields: fields{
+				externalManagedTags: []string{"owner"},
+			},
+			args: args{
+				desiredTags: map[string]string{"team": "a"},
+				currentTags: withTrackingTags(map[string]string{"owner": "someone"}),
+			},
+			addTagsWithContextCalls: []addTagsWithContextCall{
+				{
+					req: &elbv2sdk.AddTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"ls-arn"}),
+						Tags: []*elbv2sdk.Tag{
+							{Key: awssdk.String("team"), Value: awssdk.String("a")},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.addTagsWithContextCalls {
+				elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.AddTagsOutput{}, nil)
+			}
+			for _, call := range tt.removeTagsWithContextCalls {
+				elbv2Client.EXPECT().RemoveTagsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.RemoveTagsOutput{}, nil)
+			}
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			taggingManager := NewDefaultTaggingManager(elbv2Client, &log.NullLogger{})
+			m := NewDefaultListenerManager(elbv2Client, trackingProvider, taggingManager, NewDefaultNoHealthyTargetsActionResolver(elbv2Client, &log.NullLogger{}), tt.fields.externalManagedTags, &log.NullLogger{})
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLS := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{
+				LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+				Port:            80,
+				Protocol:        elbv2model.ProtocolHTTP,
+				Tags:            tt.args.desiredTags,
+			})
+			sdkLS := ListenerWithTags{
+				Listener: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("ls-arn"),
+				},
+				Tags: tt.args.currentTags,
+			}
+			err := m.updateSDKListenerWithTags(context.Background(), resLS, sdkLS)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_defaultListenerManager_Update(t *testing.T) {
+	type modifyListenerWithContextCall struct {
+		req  *elbv2sdk.ModifyListenerInput
+		resp *elbv2sdk.ModifyListenerOutput
+		err  error
+	}
+	type deleteListenerWithContextCall struct {
+		req *elbv2sdk.DeleteListenerInput
+	}
+	type createListenerWithContextCall struct {
+		req  *elbv2sdk.CreateListenerInput
+		resp *elbv2sdk.CreateListenerOutput
+		err  error
+	}
+	type describeListenerCertificatesAsListCall struct {
+		req *elbv2sdk.DescribeListenerCertificatesInput
+	}
+	type args struct {
+		resLSSpec elbv2model.ListenerSpec
+		sdkLS     *elbv2sdk.Listener
+	}
+	currentTags := map[string]string{
+		"elbv2.k8s.aws/cluster":    "cluster-name",
+		"ingress.k8s.aws/stack":    "namespace/name",
+		"ingress.k8s.aws/resource": "80",
+	}
+	sdkTags := []*elbv2sdk.Tag{
+		{
+			Key:   awssdk.String("elbv2.k8s.aws/cluster"),
+			Value: awssdk.String("cluster-name"),
+		},
+		{
+			Key:   awssdk.String("ingress.k8s.aws/resource"),
+			Value: awssdk.String("80"),
+		},
+		{
+			Key:   awssdk.String("ingress.k8s.aws/stack"),
+			Value: awssdk.String("namespace/name"),
+		},
+	}
+	forwardActions := []elbv2model.Action{
+		{
+			Type: elbv2model.ActionTypeForward,
+			ForwardConfig: &elbv2model.ForwardActionConfig{
+				TargetGroups: []elbv2model.TargetGroupTuple{
+					{
+						TargetGroupARN: coremodel.LiteralStringToken("tg-arn"),
+					},
+				},
+			},
+		},
+	}
+	sdkForwardActions := []*elbv2sdk.Action{
+		{
+			Type:  awssdk.String("forward"),
+			Order: awssdk.Int64(1),
+			ForwardConfig: &elbv2sdk.ForwardActionConfig{
+				TargetGroups: []*elbv2sdk.TargetGroupTuple{
+					{
+						TargetGroupArn: awssdk.String("tg-arn"),
+					},
+				},
+			},
+		},
+	}
+	sdkTCPListener := &elbv2sdk.Listener{
+		ListenerArn:     awssdk.String("ls-arn"),
+		LoadBalancerArn: awssdk.String("lb-arn"),
+		Port:            awssdk.Int64(80),
+		Protocol:        awssdk.String("TCP"),
+		DefaultActions:  sdkForwardActions,
+	}
+	tests := []struct {
+		name                                    string
+		args                                    args
+		modifyListenerWithContextCalls          []modifyListenerWithContextCall
+		deleteListenerWithContextCalls          []deleteListenerWithContextCall
+		createListenerWithContextCalls          []createListenerWithContextCall
+		describeListenerCertificatesAsListCalls []describeListenerCertificatesAsListCall
+		want                                    elbv2model.ListenerStatus
+		wantErr                                 error
+	}{
+		{
+			name: "listener modified in place",
+			args: args{
+				resLSSpec: elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+					Port:            8080,
+					Protocol:        elbv2model.ProtocolHTTP,
+				},
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("ls-arn"),
+					Port:        awssdk.Int64(80),
+					Protocol:    awssdk.String("HTTP"),
+				},
+			},
+			modifyListenerWithContextCalls: []modifyListenerWithContextCall{
+				{
+					req: &elbv2sdk.ModifyListenerInput{
+						ListenerArn: awssdk.String("ls-arn"),
+						Port:        awssdk.Int64(8080),
+						Protocol:    awssdk.String("HTTP"),
+					},
+					resp: &elbv2sdk.ModifyListenerOutput{},
+				},
+			},
+			describeListenerCertificatesAsListCalls: []describeListenerCertificatesAsListCall{
+				{
+					req: &elbv2sdk.DescribeListenerCertificatesInput{
+						ListenerArn: awssdk.String("ls-arn"),
+					},
+				},
+			},
+			want: elbv2model.ListenerStatus{
+				ListenerARN: "ls-arn",
+			},
+		},
+		{
+			name: "listener modified in place for protocol change within protocol family",
+			args: args{
+				resLSSpec: elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+					Port:            80,
+					Protocol:        elbv2model.ProtocolTLS,
+					Certificates: []elbv2model.Certificate{
+						{
+							CertificateARN: awssdk.String("cert-arn"),
+						},
+					},
+				},
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("ls-arn"),
+					Port:        awssdk.Int64(80),
+					Protocol:    awssdk.String("TCP"),
+				},
+			},
+			modifyListenerWithContextCalls: []modifyListenerWithContextCall{
+				{
+					req: &elbv2sdk.ModifyListenerInput{
+						ListenerArn: awssdk.String("ls-arn"),
+						Port:        awssdk.Int64(80),
+						Protocol:    awssdk.String("TLS"),
+						Certificates: []*elbv2sdk.Certificate{
+							{
+								CertificateArn: awssdk.String("cert-arn"),
+							},
+						},
+					},
+					resp: &elbv2sdk.ModifyListenerOutput{},
+				},
+			},
+			describeListenerCertificatesAsListCalls: []describeListenerCertificatesAsListCall{
+				{
+					req: &elbv2sdk.DescribeListenerCertificatesInput{
+						ListenerArn: awssdk.String("ls-arn"),
+					},
+				},
+			},
+			want: elbv2model.ListenerStatus{
+				ListenerARN: "ls-arn",
+			},
+		},
+		{
+			name: "listener recreated for protocol change across protocol families",
+			args: args{
+				resLSSpec: elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+					Port:            80,
+					Protocol:        elbv2model.ProtocolUDP,
+					DefaultActions:  forwardActions,
+				},
+				sdkLS: sdkTCPListener,
+			},
+			deleteListenerWithContextCalls: []deleteListenerWithContextCall{
+				{
+					req: &elbv2sdk.DeleteListenerInput{
+						ListenerArn: awssdk.String("ls-arn"),
+					},
+				},
+			},
+			createListenerWithContextCalls: []createListenerWithContextCall{
+				{
+					req: &elbv2sdk.CreateListenerInput{
+						LoadBalancerArn: awssdk.String("lb-arn"),
+						Port:            awssdk.Int64(80),
+						Protocol:        awssdk.String("UDP"),
+						DefaultActions:  sdkForwardActions,
+						Tags:            sdkTags,
+					},
+					resp: &elbv2sdk.CreateListenerOutput{
+						Listeners: []*elbv2sdk.Listener{
+							{
+								ListenerArn: awssdk.String("ls-arn-new"),
+							},
+						},
+					},
+				},
+			},
+			want: elbv2model.ListenerStatus{
+				ListenerARN: "ls-arn-new",
+				Recreated:   true,
+			},
+		},
+		{
+			name: "listener not deleted when replacement is invalid",
+			args: args{
+				resLSSpec: elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+					Port:            80,
+					Protocol:        elbv2model.ProtocolTLS,
+					DefaultActions:  forwardActions,
+				},
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("ls-arn"),
+					Port:        awssdk.Int64(80),
+					Protocol:    awssdk.String("UDP"),
+				},
+			},
+			wantErr: errors.New("listener protocol TLS requires a certificate"),
+		},
+		{
+			name: "previous listener restored when creation fails after deletion",
+			args: args{
+				resLSSpec: elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+					Port:            80,
+					Protocol:        elbv2model.ProtocolUDP,
+					DefaultActions:  forwardActions,
+				},
+				sdkLS: sdkTCPListener,
+			},
+			deleteListenerWithContextCalls: []deleteListenerWithContextCall{
+				{
+					req: &elbv2sdk.DeleteListenerInput{
+						ListenerArn: awssdk.String("ls-arn"),
+					},
+				},
+			},
+			createListenerWithContextCalls: []createListenerWithContextCall{
+				{
+					req: &elbv2sdk.CreateListenerInput{
+						LoadBalancerArn: awssdk.String("lb-arn"),
+						Port:            awssdk.Int64(80),
+						Protocol:        awssdk.String("UDP"),
+						DefaultActions:  sdkForwardActions,
+						Tags:            sdkTags,
+					},
+					err: awserr.New("IncompatibleProtocols", "some message", nil),
+				},
+				{
+					req: &elbv2sdk.CreateListenerInput{
+						LoadBalancerArn: awssdk.String("lb-arn"),
+						Port:            awssdk.Int64(80),
+						Protocol:        awssdk.String("TCP"),
+						DefaultActions:  sdkForwardActions,
+						Tags:            sdkTags,
+					},
+					resp: &elbv2sdk.CreateListenerOutput{
+						Listeners: []*elbv2sdk.Listener{
+							{
+								ListenerArn: awssdk.String("ls-arn-restored"),
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to recreate listener, previous listener restored: IncompatibleProtocols: some message"),
+		},
+		{
+			name: "error returned when both creation and restoration fail after deletion",
+			args: args{
+				resLSSpec: elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+					Port:            80,
+					Protocol:        elbv2model.ProtocolUDP,
+					DefaultActions:  forwardActions,
+				},
+				sdkLS: sdkTCPListener,
+			},
+			deleteListenerWithContextCalls: []deleteListenerWithContextCall{
+				{
+					req: &elbv2sdk.DeleteListenerInput{
+						ListenerArn: awssdk.String("ls-arn"),
+					},
+				},
+			},
+			createListenerWithContextCalls: []createListenerWithContextCall{
+				{
+					req: &elbv2sdk.CreateListenerInput{
+						LoadBalancerArn: awssdk.String("lb-arn"),
+						Port:            awssdk.Int64(80),
+						Protocol:        awssdk.String("UDP"),
+						DefaultActions:  sdkForwardActions,
+						Tags:            sdkTags,
+					},
+					err: awserr.New("IncompatibleProtocols", "some message", nil),
+				},
+				{
+					req: &elbv2sdk.CreateListenerInput{
+						LoadBalancerArn: awssdk.String("lb-arn"),
+						Port:            awssdk.Int64(80),
+						Protocol:        awssdk.String("TCP"),
+						DefaultActions:  sdkForwardActions,
+						Tags:            sdkTags,
+					},
+					err: awserr.New("TooManyListeners", "some message", nil),
+				},
+			},
+			wantErr: errors.New("failed to recreate listener, and failed to restore previous listener: TooManyListeners: some message: IncompatibleProtocols: some message"),
+		},
+		{
+			name: "other modification failures are returned",
+			args: args{
+				resLSSpec: elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+					Port:            8080,
+					Protocol:        elbv2model.ProtocolHTTP,
+				},
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("ls-arn"),
+					Port:        awssdk.Int64(80),
+					Protocol:    awssdk.String("HTTP"),
+				},
+			},
+			modifyListenerWithContextCalls: []modifyListenerWithContextCall{
+				{
+					req: &elbv2sdk.ModifyListenerInput{
+						ListenerArn: awssdk.String("ls-arn"),
+						Port:        awssdk.Int64(8080),
+						Protocol:    awssdk.String("HTTP"),
+					},
+					err: awserr.New("DuplicateListener", "some message", nil),
+				},
+			},
+			wantErr: awserr.New("DuplicateListener", "some message", nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.modifyListenerWithContextCalls {
+				elbv2Client.EXPECT().ModifyListenerWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.deleteListenerWithContextCalls {
+				elbv2Client.EXPECT().DeleteListenerWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeleteListenerOutput{}, nil)
+			}
+			for _, call := range tt.createListenerWithContextCalls {
+				elbv2Client.EXPECT().CreateListenerWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.describeListenerCertificatesAsListCalls {
+				elbv2Client.EXPECT().DescribeListenerCertificatesAsList(gomock.Any(), call.req).Return(nil, nil)
+			}
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			taggingManager := NewDefaultTaggingManager(elbv2Client, &log.NullLogger{})
+			m := NewDefaultListenerManager(elbv2Client, trackingProvider, taggingManager, NewDefaultNoHealthyTargetsActionResolver(elbv2Client, &log.NullLogger{}), nil, &log.NullLogger{})
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLS := elbv2model.NewListener(stack, "80", tt.args.resLSSpec)
+			sdkLS := ListenerWithTags{
+				Listener: tt.args.sdkLS,
+				Tags:     currentTags,
+			}
+			got, err := m.Update(context.Background(), resLS, sdkLS)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_buildSDKCertificates(t *testing.T) {
+	tests := []struct {
+		name            string
+		modelCerts      []elbv2model.Certificate
+		wantDefaultCert []*elbv2sdk.Certificate
+		wantExtraCerts  []*elbv2sdk.Certificate
+	}{
+		{
+			name:       "no certificates",
+			modelCerts: nil,
+		},
+		{
+			name: "single certificate",
+			modelCerts: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-1")},
+			},
+			wantDefaultCert: []*elbv2sdk.Certificate{
+				{CertificateArn: awssdk.String("cert-1")},
+			},
+		},
+		{
+			name: "first certificate is default and remaining ones are SNI certificates",
+			modelCerts: []elbv2model.Certificate{
+				{CertificateARN: awssdk.String("cert-3")},
+				{CertificateARN: awssdk.String("cert-1")},
+				{CertificateARN: awssdk.String("cert-2")},
+			},
+			wantDefaultCert: []*elbv2sdk.Certificate{
+				{CertificateArn: awssdk.String("cert-3")},
+			},
+			wantExtraCerts: []*elbv2sdk.Certificate{
+				{CertificateArn: awssdk.String("cert-1")},
+				{CertificateArn: awssdk.String("cert-2")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDefaultCert, gotExtraCerts := buildSDKCertificates(tt.modelCerts)
+			assert.Equal(t, tt.wantDefaultCert, gotDefaultCert)
+			assert.Equal(t, tt.wantExtraCerts, gotExtraCerts)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	elbv2equality "sigs.k8s.io/aws-load-balancer-controller/pkg/equality/elbv2"
@@ -22,36 +23,47 @@ type ListenerRuleManager interface {
 	Update(ctx context.Context, resLR *elbv2model.ListenerRule, sdkLR ListenerRuleWithTags) (elbv2model.ListenerRuleStatus, error)
 
 	Delete(ctx context.Context, sdkLR ListenerRuleWithTags) error
+
+	// SetPriorities updates the priorities of listener rules in a single batch, keyed by ruleARN.
+	SetPriorities(ctx context.Context, priorityByRuleARN map[string]int64) error
 }
 
 // NewDefaultListenerRuleManager constructs new defaultListenerRuleManager.
 func NewDefaultListenerRuleManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider,
-	taggingManager TaggingManager, externalManagedTags []string, logger logr.Logger) *defaultListenerRuleManager {
+	taggingManager TaggingManager, noHealthyTargetsActionResolver NoHealthyTargetsActionResolver, externalManagedTags []string, logger logr.Logger) *defaultListenerRuleManager {
 	return &defaultListenerRuleManager{
-		elbv2Client:                 elbv2Client,
-		trackingProvider:            trackingProvider,
-		taggingManager:              taggingManager,
-		externalManagedTags:         externalManagedTags,
-		logger:                      logger,
-		waitLSExistencePollInterval: defaultWaitLSExistencePollInterval,
-		waitLSExistenceTimeout:      defaultWaitLSExistenceTimeout,
+		elbv2Client:                    elbv2Client,
+		trackingProvider:               trackingProvider,
+		taggingManager:                 taggingManager,
+		noHealthyTargetsActionResolver: noHealthyTargetsActionResolver,
+		externalManagedTags:            externalManagedTags,
+		logger:                         logger,
+		waitLSExistencePollInterval:    defaultWaitLSExistencePollInterval,
+		waitLSExistenceTimeout:         defaultWaitLSExistenceTimeout,
 	}
 }
 
 // default implementation for ListenerRuleManager.
 type defaultListenerRuleManager struct {
-	elbv2Client         services.ELBV2
-	trackingProvider    tracking.Provider
-	taggingManager      TaggingManager
-	externalManagedTags []string
-	logger              logr.Logger
+	elbv2Client                    services.ELBV2
+	trackingProvider               tracking.Provider
+	taggingManager                 TaggingManager
+	noHealthyTargetsActionResolver NoHealthyTargetsActionResolver
+	externalManagedTags            []string
+	logger                         logr.Logger
 
 	waitLSExistencePollInterval time.Duration
 	waitLSExistenceTimeout      time.Duration
 }
 
 func (m *defaultListenerRuleManager) Create(ctx context.Context, resLR *elbv2model.ListenerRule) (elbv2model.ListenerRuleStatus, error) {
-	req, err := buildSDKCreateListenerRuleInput(resLR.Spec)
+	lrSpec := resLR.Spec
+	actions, err := m.noHealthyTargetsActionResolver.ResolveActions(ctx, lrSpec.Actions)
+	if err != nil {
+		return elbv2model.ListenerRuleStatus{}, err
+	}
+	lrSpec.Actions = actions
+	req, err := buildSDKCreateListenerRuleInput(lrSpec)
 	if err != nil {
 		return elbv2model.ListenerRuleStatus{}, err
 	}
@@ -107,8 +119,33 @@ func (m *defaultListenerRuleManager) Delete(ctx context.Context, sdkLR ListenerR
 	return nil
 }
 
+func (m *defaultListenerRuleManager) SetPriorities(ctx context.Context, priorityByRuleARN map[string]int64) error {
+	if len(priorityByRuleARN) == 0 {
+		return nil
+	}
+	req := &elbv2sdk.SetRulePrioritiesInput{}
+	for _, ruleARN := range sets.StringKeySet(priorityByRuleARN).List() {
+		req.RulePriorities = append(req.RulePriorities, &elbv2sdk.RulePriorityPair{
+			RuleArn:  awssdk.String(ruleARN),
+			Priority: awssdk.Int64(priorityByRuleARN[ruleARN]),
+		})
+	}
+	m.logger.Info("setting listener rule priorities",
+		"priorities", priorityByRuleARN)
+	if _, err := m.elbv2Client.SetRulePrioritiesWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("set listener rule priorities",
+		"priorities", priorityByRuleARN)
+	return nil
+}
+
 func (m *defaultListenerRuleManager) updateSDKListenerRuleWithSettings(ctx context.Context, resLR *elbv2model.ListenerRule, sdkLR ListenerRuleWithTags) error {
-	desiredActions, err := buildSDKActions(resLR.Spec.Actions)
+	actions, err := m.noHealthyTargetsActionResolver.ResolveActions(ctx, resLR.Spec.Actions)
+	if err != nil {
+		return err
+	}
+	desiredActions, err := buildSDKActions(actions)
 	if err != nil {
 		return err
 	}
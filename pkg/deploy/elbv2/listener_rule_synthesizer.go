@@ -66,18 +66,17 @@ func (s *listenerRuleSynthesizer) synthesizeListenerRulesOnListener(ctx context.
 		return err
 	}
 
-	matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs := matchResAndSDKListenerRules(resLRs, sdkLRs)
+	matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs, err := matchResAndSDKListenerRules(resLRs, sdkLRs)
+	if err != nil {
+		return err
+	}
 	for _, sdkLR := range unmatchedSDKLRs {
 		if err := s.lrManager.Delete(ctx, sdkLR); err != nil {
 			return err
 		}
 	}
-	for _, resLR := range unmatchedResLRs {
-		lrStatus, err := s.lrManager.Create(ctx, resLR)
-		if err != nil {
-			return err
-		}
-		resLR.SetStatus(lrStatus)
+	if err := s.lrManager.SetPriorities(ctx, computeListenerRulePriorityChanges(matchedResAndSDKLRs)); err != nil {
+		return err
 	}
 	for _, resAndSDKLR := range matchedResAndSDKLRs {
 		lsStatus, err := s.lrManager.Update(ctx, resAndSDKLR.resLR, resAndSDKLR.sdkLR)
@@ -86,6 +85,13 @@ func (s *listenerRuleSynthesizer) synthesizeListenerRulesOnListener(ctx context.
 		}
 		resAndSDKLR.resLR.SetStatus(lsStatus)
 	}
+	for _, resLR := range unmatchedResLRs {
+		lrStatus, err := s.lrManager.Create(ctx, resLR)
+		if err != nil {
+			return err
+		}
+		resLR.SetStatus(lrStatus)
+	}
 	return nil
 }
 
@@ -110,7 +116,10 @@ type resAndSDKListenerRulePair struct {
 	sdkLR ListenerRuleWithTags
 }
 
-func matchResAndSDKListenerRules(resLRs []*elbv2model.ListenerRule, sdkLRs []ListenerRuleWithTags) ([]resAndSDKListenerRulePair, []*elbv2model.ListenerRule, []ListenerRuleWithTags) {
+// matchResAndSDKListenerRules matches resLRs with sdkLRs with minimal changes to existing rules.
+// firstly, rules with identical actions and conditions are matched regardless of priority, so they can be preserved with only priority changes.
+// then, remaining rules are matched by priority, so they can be modified in place.
+func matchResAndSDKListenerRules(resLRs []*elbv2model.ListenerRule, sdkLRs []ListenerRuleWithTags) ([]resAndSDKListenerRulePair, []*elbv2model.ListenerRule, []ListenerRuleWithTags, error) {
 	var matchedResAndSDKLRs []resAndSDKListenerRulePair
 	var unmatchedResLRs []*elbv2model.ListenerRule
 	var unmatchedSDKLRs []ListenerRuleWithTags
@@ -119,6 +128,32 @@ func matchResAndSDKListenerRules(resLRs []*elbv2model.ListenerRule, sdkLRs []Lis
 	sdkLRByPriority := mapSDKListenerRuleByPriority(sdkLRs)
 	resLRPriorities := sets.Int64KeySet(resLRByPriority)
 	sdkLRPriorities := sets.Int64KeySet(sdkLRByPriority)
+	for _, resPriority := range resLRPriorities.List() {
+		resLR := resLRByPriority[resPriority]
+		desiredActions, err := buildSDKActions(resLR.Spec.Actions)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		desiredConditions := buildSDKRuleConditions(resLR.Spec.Conditions)
+		candidateSDKPriorities := sdkLRPriorities.List()
+		if sdkLRPriorities.Has(resPriority) {
+			candidateSDKPriorities = append([]int64{resPriority}, candidateSDKPriorities...)
+		}
+		for _, sdkPriority := range candidateSDKPriorities {
+			sdkLR := sdkLRByPriority[sdkPriority]
+			if isSDKListenerRuleSettingsDrifted(resLR.Spec, sdkLR, desiredActions, desiredConditions) {
+				continue
+			}
+			matchedResAndSDKLRs = append(matchedResAndSDKLRs, resAndSDKListenerRulePair{
+				resLR: resLR,
+				sdkLR: sdkLR,
+			})
+			resLRPriorities.Delete(resPriority)
+			sdkLRPriorities.Delete(sdkPriority)
+			break
+		}
+	}
+
 	for _, priority := range resLRPriorities.Intersection(sdkLRPriorities).List() {
 		resLR := resLRByPriority[priority]
 		sdkLR := sdkLRByPriority[priority]
@@ -134,7 +169,19 @@ func matchResAndSDKListenerRules(resLRs []*elbv2model.ListenerRule, sdkLRs []Lis
 		unmatchedSDKLRs = append(unmatchedSDKLRs, sdkLRByPriority[priority])
 	}
 
-	return matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs
+	return matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs, nil
+}
+
+// computeListenerRulePriorityChanges computes the new priorities by ruleARN for matched rules whose priority changed.
+func computeListenerRulePriorityChanges(matchedResAndSDKLRs []resAndSDKListenerRulePair) map[string]int64 {
+	priorityByRuleARN := make(map[string]int64)
+	for _, resAndSDKLR := range matchedResAndSDKLRs {
+		sdkPriority, _ := strconv.ParseInt(awssdk.StringValue(resAndSDKLR.sdkLR.ListenerRule.Priority), 10, 64)
+		if sdkPriority != resAndSDKLR.resLR.Spec.Priority {
+			priorityByRuleARN[awssdk.StringValue(resAndSDKLR.sdkLR.ListenerRule.RuleArn)] = resAndSDKLR.resLR.Spec.Priority
+		}
+	}
+	return priorityByRuleARN
 }
 
 func mapResListenerRuleByPriority(resLRs []*elbv2model.ListenerRule) map[int64]*elbv2model.ListenerRule {
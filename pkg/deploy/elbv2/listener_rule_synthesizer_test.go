@@ -0,0 +1,205 @@
+package elbv2
+
+import (
+	"strconv"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+func Test_matchResAndSDKListenerRules(t *testing.T) {
+	type rule struct {
+		arn      string
+		priority int64
+		path     string
+	}
+	type operationCounts struct {
+		creates         int
+		deletes         int
+		modifies        int
+		priorityChanges int
+	}
+	tests := []struct {
+		name                    string
+		resRules                []rule
+		sdkRules                []rule
+		wantMatchedARNs         map[int64]string
+		wantPriorityByRuleARN   map[string]int64
+		wantUnmatchedPriorities []int64
+		wantUnmatchedSDKARNs    []string
+		wantOperationCounts     operationCounts
+	}{
+		{
+			name: "rules unchanged",
+			resRules: []rule{
+				{priority: 1, path: "/a"},
+				{priority: 2, path: "/b"},
+			},
+			sdkRules: []rule{
+				{arn: "arn-a", priority: 1, path: "/a"},
+				{arn: "arn-b", priority: 2, path: "/b"},
+			},
+			wantMatchedARNs:       map[int64]string{1: "arn-a", 2: "arn-b"},
+			wantPriorityByRuleARN: map[string]int64{},
+			wantOperationCounts:   operationCounts{},
+		},
+		{
+			name: "rules reordered",
+			resRules: []rule{
+				{priority: 1, path: "/c"},
+				{priority: 2, path: "/a"},
+				{priority: 3, path: "/b"},
+			},
+			sdkRules: []rule{
+				{arn: "arn-a", priority: 1, path: "/a"},
+				{arn: "arn-b", priority: 2, path: "/b"},
+				{arn: "arn-c", priority: 3, path: "/c"},
+			},
+			wantMatchedARNs:       map[int64]string{1: "arn-c", 2: "arn-a", 3: "arn-b"},
+			wantPriorityByRuleARN: map[string]int64{"arn-a": 2, "arn-b": 3, "arn-c": 1},
+			wantOperationCounts:   operationCounts{priorityChanges: 3},
+		},
+		{
+			name: "rule inserted at top",
+			resRules: []rule{
+				{priority: 1, path: "/new"},
+				{priority: 2, path: "/a"},
+				{priority: 3, path: "/b"},
+			},
+			sdkRules: []rule{
+				{arn: "arn-a", priority: 1, path: "/a"},
+				{arn: "arn-b", priority: 2, path: "/b"},
+			},
+			wantMatchedARNs:         map[int64]string{2: "arn-a", 3: "arn-b"},
+			wantPriorityByRuleARN:   map[string]int64{"arn-a": 2, "arn-b": 3},
+			wantUnmatchedPriorities: []int64{1},
+			wantOperationCounts:     operationCounts{creates: 1, priorityChanges: 2},
+		},
+		{
+			name: "rule removed from middle",
+			resRules: []rule{
+				{priority: 1, path: "/a"},
+				{priority: 2, path: "/c"},
+			},
+			sdkRules: []rule{
+				{arn: "arn-a", priority: 1, path: "/a"},
+				{arn: "arn-b", priority: 2, path: "/b"},
+				{arn: "arn-c", priority: 3, path: "/c"},
+			},
+			wantMatchedARNs:       map[int64]string{1: "arn-a", 2: "arn-c"},
+			wantPriorityByRuleARN: map[string]int64{"arn-c": 2},
+			wantUnmatchedSDKARNs:  []string{"arn-b"},
+			wantOperationCounts:   operationCounts{deletes: 1, priorityChanges: 1},
+		},
+		{
+			name: "rule modified in place",
+			resRules: []rule{
+				{priority: 1, path: "/a"},
+				{priority: 2, path: "/b-modified"},
+			},
+			sdkRules: []rule{
+				{arn: "arn-a", priority: 1, path: "/a"},
+				{arn: "arn-b", priority: 2, path: "/b"},
+			},
+			wantMatchedARNs:       map[int64]string{1: "arn-a", 2: "arn-b"},
+			wantPriorityByRuleARN: map[string]int64{},
+			wantOperationCounts:   operationCounts{modifies: 1},
+		},
+		{
+			name: "identical rules prefer same priority",
+			resRules: []rule{
+				{priority: 2, path: "/a"},
+			},
+			sdkRules: []rule{
+				{arn: "arn-a1", priority: 1, path: "/a"},
+				{arn: "arn-a2", priority: 2, path: "/a"},
+			},
+			wantMatchedARNs:       map[int64]string{2: "arn-a2"},
+			wantPriorityByRuleARN: map[string]int64{},
+			wantUnmatchedSDKARNs:  []string{"arn-a1"},
+			wantOperationCounts:   operationCounts{deletes: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resLRs []*elbv2model.ListenerRule
+			for _, r := range tt.resRules {
+				resLRs = append(resLRs, &elbv2model.ListenerRule{
+					Spec: buildTestListenerRuleSpec(r.priority, r.path),
+				})
+			}
+			var sdkLRs []ListenerRuleWithTags
+			for _, r := range tt.sdkRules {
+				spec := buildTestListenerRuleSpec(r.priority, r.path)
+				actions, err := buildSDKActions(spec.Actions)
+				assert.NoError(t, err)
+				sdkLRs = append(sdkLRs, ListenerRuleWithTags{
+					ListenerRule: &elbv2sdk.Rule{
+						RuleArn:    awssdk.String(r.arn),
+						Priority:   awssdk.String(strconv.FormatInt(r.priority, 10)),
+						Actions:    actions,
+						Conditions: buildSDKRuleConditions(spec.Conditions),
+					},
+				})
+			}
+
+			matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs, err := matchResAndSDKListenerRules(resLRs, sdkLRs)
+			assert.NoError(t, err)
+
+			gotMatchedARNs := make(map[int64]string)
+			gotOperationCounts := operationCounts{
+				creates: len(unmatchedResLRs),
+				deletes: len(unmatchedSDKLRs),
+			}
+			for _, pair := range matchedResAndSDKLRs {
+				gotMatchedARNs[pair.resLR.Spec.Priority] = awssdk.StringValue(pair.sdkLR.ListenerRule.RuleArn)
+				actions, err := buildSDKActions(pair.resLR.Spec.Actions)
+				assert.NoError(t, err)
+				if isSDKListenerRuleSettingsDrifted(pair.resLR.Spec, pair.sdkLR, actions, buildSDKRuleConditions(pair.resLR.Spec.Conditions)) {
+					gotOperationCounts.modifies++
+				}
+			}
+			gotPriorityByRuleARN := computeListenerRulePriorityChanges(matchedResAndSDKLRs)
+			gotOperationCounts.priorityChanges = len(gotPriorityByRuleARN)
+			var gotUnmatchedPriorities []int64
+			for _, resLR := range unmatchedResLRs {
+				gotUnmatchedPriorities = append(gotUnmatchedPriorities, resLR.Spec.Priority)
+			}
+			var gotUnmatchedSDKARNs []string
+			for _, sdkLR := range unmatchedSDKLRs {
+				gotUnmatchedSDKARNs = append(gotUnmatchedSDKARNs, awssdk.StringValue(sdkLR.ListenerRule.RuleArn))
+			}
+
+			assert.Equal(t, tt.wantMatchedARNs, gotMatchedARNs)
+			assert.Equal(t, tt.wantPriorityByRuleARN, gotPriorityByRuleARN)
+			assert.Equal(t, tt.wantUnmatchedPriorities, gotUnmatchedPriorities)
+			assert.Equal(t, tt.wantUnmatchedSDKARNs, gotUnmatchedSDKARNs)
+			assert.Equal(t, tt.wantOperationCounts, gotOperationCounts)
+		})
+	}
+}
+
+func buildTestListenerRuleSpec(priority int64, path string) elbv2model.ListenerRuleSpec {
+	return elbv2model.ListenerRuleSpec{
+		Priority: priority,
+		Actions: []elbv2model.Action{
+			{
+				Type: elbv2model.ActionTypeFixedResponse,
+				FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+					StatusCode: "200",
+				},
+			},
+		},
+		Conditions: []elbv2model.RuleCondition{
+			{
+				Field: elbv2model.RuleConditionFieldPathPattern,
+				PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+					Values: []string{path},
+				},
+			},
+		},
+	}
+}
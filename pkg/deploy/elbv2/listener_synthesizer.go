@@ -11,13 +11,15 @@ import (
 )
 
 func NewListenerSynthesizer(elbv2Client services.ELBV2, taggingManager TaggingManager,
-	lsManager ListenerManager, logger logr.Logger, stack core.Stack) *listenerSynthesizer {
+	lsManager ListenerManager, listenerPruningDisabled bool, keptListenerTGARNs sets.String, logger logr.Logger, stack core.Stack) *listenerSynthesizer {
 	return &listenerSynthesizer{
-		elbv2Client:    elbv2Client,
-		lsManager:      lsManager,
-		logger:         logger,
-		taggingManager: taggingManager,
-		stack:          stack,
+		elbv2Client:             elbv2Client,
+		lsManager:               lsManager,
+		listenerPruningDisabled: listenerPruningDisabled,
+		keptListenerTGARNs:      keptListenerTGARNs,
+		logger:                  logger,
+		taggingManager:          taggingManager,
+		stack:                   stack,
 	}
 }
 
@@ -26,6 +28,11 @@ type listenerSynthesizer struct {
 	lsManager      ListenerManager
 	logger         logr.Logger
 	taggingManager TaggingManager
+	// listenerPruningDisabled keeps listeners on LoadBalancer that are not in stack, so that they can be managed externally.
+	listenerPruningDisabled bool
+	// keptListenerTGARNs collects ARNs of targetGroups still referenced by listeners kept on LoadBalancer,
+	// so that these targetGroups and their TargetGroupBindings are kept as well.
+	keptListenerTGARNs sets.String
 
 	stack core.Stack
 }
@@ -58,6 +65,14 @@ func (s *listenerSynthesizer) synthesizeListenersOnLB(ctx context.Context, lbARN
 	}
 	matchedResAndSDKLSs, unmatchedResLSs, unmatchedSDKLSs := matchResAndSDKListeners(resLSs, sdkLSs)
 	for _, sdkLS := range unmatchedSDKLSs {
+		if s.listenerPruningDisabled {
+			s.logger.V(1).Info("skipping deletion of listener not in stack",
+				"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn))
+			if err := s.collectKeptListenerTargetGroupARNs(ctx, sdkLS); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := s.lsManager.Delete(ctx, sdkLS); err != nil {
 			return err
 		}
@@ -79,6 +94,19 @@ func (s *listenerSynthesizer) synthesizeListenersOnLB(ctx context.Context, lbARN
 	return nil
 }
 
+// collectKeptListenerTargetGroupARNs collects ARNs of targetGroups referenced by the default actions and rules of kept listener.
+func (s *listenerSynthesizer) collectKeptListenerTargetGroupARNs(ctx context.Context, sdkLS ListenerWithTags) error {
+	collectSDKActionsTargetGroupARNs(sdkLS.Listener.DefaultActions, s.keptListenerTGARNs)
+	sdkLRs, err := s.taggingManager.ListListenerRules(ctx, awssdk.StringValue(sdkLS.Listener.ListenerArn))
+	if err != nil {
+		return err
+	}
+	for _, sdkLR := range sdkLRs {
+		collectSDKActionsTargetGroupARNs(sdkLR.ListenerRule.Actions, s.keptListenerTGARNs)
+	}
+	return nil
+}
+
 // findSDKListenersOnLB returns the listeners configured on LoadBalancer.
 func (s *listenerSynthesizer) findSDKListenersOnLB(ctx context.Context, lbARN string) ([]ListenerWithTags, error) {
 	return s.taggingManager.ListListeners(ctx, lbARN)
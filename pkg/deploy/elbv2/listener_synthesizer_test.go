@@ -0,0 +1,136 @@
+package elbv2
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_listenerSynthesizer_synthesizeListenersOnLB(t *testing.T) {
+	lbARN := "lb-arn"
+	sdkLS80 := ListenerWithTags{
+		Listener: &elbv2sdk.Listener{
+			ListenerArn: awssdk.String("ls-80-arn"),
+			Port:        awssdk.Int64(80),
+		},
+	}
+	sdkLS443 := ListenerWithTags{
+		Listener: &elbv2sdk.Listener{
+			ListenerArn: awssdk.String("ls-443-arn"),
+			Port:        awssdk.Int64(443),
+			DefaultActions: []*elbv2sdk.Action{
+				{
+					Type:           awssdk.String("forward"),
+					TargetGroupArn: awssdk.String("tg-443-arn"),
+				},
+			},
+		},
+	}
+	sdkLRs443 := []ListenerRuleWithTags{
+		{
+			ListenerRule: &elbv2sdk.Rule{
+				RuleArn: awssdk.String("lr-443-arn"),
+				Actions: []*elbv2sdk.Action{
+					{
+						Type: awssdk.String("forward"),
+						ForwardConfig: &elbv2sdk.ForwardActionConfig{
+							TargetGroups: []*elbv2sdk.TargetGroupTuple{
+								{
+									TargetGroupArn: awssdk.String("tg-443-rule-arn"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                    string
+		listenerPruningDisabled bool
+		resLSPorts              []int64
+		sdkLSs                  []ListenerWithTags
+		wantCreatedPorts        []int64
+		wantUpdatedPorts        []int64
+		wantDeletedSDKLSs       []ListenerWithTags
+		wantKeptListenerTGARNs  []string
+	}{
+		{
+			name:              "listener on removed port is deleted",
+			resLSPorts:        []int64{80},
+			sdkLSs:            []ListenerWithTags{sdkLS80, sdkLS443},
+			wantUpdatedPorts:  []int64{80},
+			wantDeletedSDKLSs: []ListenerWithTags{sdkLS443},
+		},
+		{
+			name:                    "listener on removed port is kept when pruning disabled",
+			listenerPruningDisabled: true,
+			resLSPorts:              []int64{80},
+			sdkLSs:                  []ListenerWithTags{sdkLS80, sdkLS443},
+			wantUpdatedPorts:        []int64{80},
+			wantKeptListenerTGARNs:  []string{"tg-443-arn", "tg-443-rule-arn"},
+		},
+		{
+			name:             "listener on added port is created",
+			resLSPorts:       []int64{80, 443},
+			sdkLSs:           []ListenerWithTags{sdkLS80},
+			wantCreatedPorts: []int64{443},
+			wantUpdatedPorts: []int64{80},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			var resLSs []*elbv2model.Listener
+			for _, port := range tt.resLSPorts {
+				resLSs = append(resLSs, elbv2model.NewListener(stack, strconv.FormatInt(port, 10), elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken(lbARN),
+					Port:            port,
+				}))
+			}
+
+			taggingManager := NewMockTaggingManager(ctrl)
+			taggingManager.EXPECT().ListListeners(gomock.Any(), lbARN).Return(tt.sdkLSs, nil)
+			taggingManager.EXPECT().ListListenerRules(gomock.Any(), "ls-443-arn").Return(sdkLRs443, nil).AnyTimes()
+			lsManager := NewMockListenerManager(ctrl)
+			var gotCreatedPorts, gotUpdatedPorts []int64
+			var gotDeletedSDKLSs []ListenerWithTags
+			lsManager.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, resLS *elbv2model.Listener) (elbv2model.ListenerStatus, error) {
+					gotCreatedPorts = append(gotCreatedPorts, resLS.Spec.Port)
+					return elbv2model.ListenerStatus{ListenerARN: "created-arn"}, nil
+				}).AnyTimes()
+			lsManager.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags) (elbv2model.ListenerStatus, error) {
+					gotUpdatedPorts = append(gotUpdatedPorts, resLS.Spec.Port)
+					return elbv2model.ListenerStatus{ListenerARN: awssdk.StringValue(sdkLS.Listener.ListenerArn)}, nil
+				}).AnyTimes()
+			lsManager.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, sdkLS ListenerWithTags) error {
+					gotDeletedSDKLSs = append(gotDeletedSDKLSs, sdkLS)
+					return nil
+				}).AnyTimes()
+
+			keptListenerTGARNs := sets.NewString()
+			s := NewListenerSynthesizer(nil, taggingManager, lsManager, tt.listenerPruningDisabled, keptListenerTGARNs, &log.NullLogger{}, stack)
+			err := s.synthesizeListenersOnLB(context.Background(), lbARN, resLSs)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCreatedPorts, gotCreatedPorts)
+			assert.Equal(t, tt.wantUpdatedPorts, gotUpdatedPorts)
+			assert.Equal(t, tt.wantDeletedSDKLSs, gotDeletedSDKLSs)
+			assert.Equal(t, sets.NewString(tt.wantKeptListenerTGARNs...), keptListenerTGARNs)
+		})
+	}
+}
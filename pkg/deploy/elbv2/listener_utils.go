@@ -216,3 +216,39 @@ func isListenerNotFoundError(err error) bool {
 	}
 	return false
 }
+
+// listenerProtocolFamilies groups listener protocols, a listener can only be modified in place between protocols of same family.
+var listenerProtocolFamilies = map[elbv2model.Protocol]string{
+	elbv2model.ProtocolHTTP:    "application",
+	elbv2model.ProtocolHTTPS:   "application",
+	elbv2model.ProtocolTCP:     "stream",
+	elbv2model.ProtocolTLS:     "stream",
+	elbv2model.ProtocolUDP:     "datagram",
+	elbv2model.ProtocolTCP_UDP: "datagram",
+}
+
+// isListenerRecreationRequired checks whether the listener must be recreated to apply the desired listener spec.
+// changing the protocol to another protocol family requires recreation, all other changes are modified in place.
+func isListenerRecreationRequired(lsSpec elbv2model.ListenerSpec, sdkLS ListenerWithTags) bool {
+	currentProtocol := elbv2model.Protocol(awssdk.StringValue(sdkLS.Listener.Protocol))
+	if lsSpec.Protocol == currentProtocol {
+		return false
+	}
+	return listenerProtocolFamilies[lsSpec.Protocol] != listenerProtocolFamilies[currentProtocol]
+}
+
+// validateSDKCreateListenerInput validates the CreateListener request before it's used to replace an existing listener,
+// so that the existing listener isn't deleted for a replacement that's known to be rejected.
+func validateSDKCreateListenerInput(req *elbv2sdk.CreateListenerInput) error {
+	protocol := elbv2model.Protocol(awssdk.StringValue(req.Protocol))
+	if _, ok := listenerProtocolFamilies[protocol]; !ok {
+		return errors.Errorf("unsupported listener protocol: %v", protocol)
+	}
+	if (protocol == elbv2model.ProtocolHTTPS || protocol == elbv2model.ProtocolTLS) && len(req.Certificates) == 0 {
+		return errors.Errorf("listener protocol %v requires a certificate", protocol)
+	}
+	if len(req.DefaultActions) == 0 {
+		return errors.New("listener requires a default action")
+	}
+	return nil
+}
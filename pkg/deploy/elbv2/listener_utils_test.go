@@ -1,9 +1,12 @@
 package elbv2
 
 import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
 )
 
@@ -45,3 +48,126 @@ func Test_isListenerNotFoundError(t *testing.T) {
 		})
 	}
 }
+
+func Test_isListenerRecreationRequired(t *testing.T) {
+	tests := []struct {
+		name            string
+		desiredProtocol elbv2model.Protocol
+		currentProtocol string
+		want            bool
+	}{
+		{
+			name:            "protocol unchanged",
+			desiredProtocol: elbv2model.ProtocolTCP,
+			currentProtocol: "TCP",
+			want:            false,
+		},
+		{
+			name:            "protocol changed within application protocols",
+			desiredProtocol: elbv2model.ProtocolHTTPS,
+			currentProtocol: "HTTP",
+			want:            false,
+		},
+		{
+			name:            "protocol changed within stream protocols",
+			desiredProtocol: elbv2model.ProtocolTLS,
+			currentProtocol: "TCP",
+			want:            false,
+		},
+		{
+			name:            "protocol changed from stream to datagram protocol",
+			desiredProtocol: elbv2model.ProtocolUDP,
+			currentProtocol: "TCP",
+			want:            true,
+		},
+		{
+			name:            "protocol changed from datagram to stream protocol",
+			desiredProtocol: elbv2model.ProtocolTLS,
+			currentProtocol: "TCP_UDP",
+			want:            true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lsSpec := elbv2model.ListenerSpec{
+				Port:     80,
+				Protocol: tt.desiredProtocol,
+			}
+			sdkLS := ListenerWithTags{
+				Listener: &elbv2sdk.Listener{
+					Port:     awssdk.Int64(80),
+					Protocol: awssdk.String(tt.currentProtocol),
+				},
+			}
+			got := isListenerRecreationRequired(lsSpec, sdkLS)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_validateSDKCreateListenerInput(t *testing.T) {
+	forwardActions := []*elbv2sdk.Action{
+		{
+			Type:           awssdk.String("forward"),
+			TargetGroupArn: awssdk.String("tg-arn"),
+		},
+	}
+	tests := []struct {
+		name    string
+		req     *elbv2sdk.CreateListenerInput
+		wantErr error
+	}{
+		{
+			name: "valid listener",
+			req: &elbv2sdk.CreateListenerInput{
+				Protocol:       awssdk.String("UDP"),
+				DefaultActions: forwardActions,
+			},
+		},
+		{
+			name: "TLS listener with certificate",
+			req: &elbv2sdk.CreateListenerInput{
+				Protocol: awssdk.String("TLS"),
+				Certificates: []*elbv2sdk.Certificate{
+					{
+						CertificateArn: awssdk.String("cert-arn"),
+					},
+				},
+				DefaultActions: forwardActions,
+			},
+		},
+		{
+			name: "TLS listener without certificate",
+			req: &elbv2sdk.CreateListenerInput{
+				Protocol:       awssdk.String("TLS"),
+				DefaultActions: forwardActions,
+			},
+			wantErr: errors.New("listener protocol TLS requires a certificate"),
+		},
+		{
+			name: "listener without default action",
+			req: &elbv2sdk.CreateListenerInput{
+				Protocol: awssdk.String("TCP"),
+			},
+			wantErr: errors.New("listener requires a default action"),
+		},
+		{
+			name: "unsupported protocol",
+			req: &elbv2sdk.CreateListenerInput{
+				Protocol:       awssdk.String("GENEVE"),
+				DefaultActions: forwardActions,
+			},
+			wantErr: errors.New("unsupported listener protocol: GENEVE"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSDKCreateListenerInput(tt.req)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
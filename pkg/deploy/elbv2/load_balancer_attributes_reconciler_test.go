@@ -106,6 +106,61 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 				},
 			},
 		},
+		{
+			name: "waf fail open attribute should be updated",
+			fields: fields{
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("idle_timeout.timeout_seconds"),
+									Value: awssdk.String("60"),
+								},
+								{
+									Key:   awssdk.String("waf.fail_open.enabled"),
+									Value: awssdk.String("false"),
+								},
+							},
+						},
+					},
+				},
+				modifyLoadBalancerAttributesWithContextCalls: []modifyLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("waf.fail_open.enabled"),
+									Value: awssdk.String("true"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+							{
+								Key:   "waf.fail_open.enabled",
+								Value: "true",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "no attributes should be updated",
 			fields: fields{
@@ -0,0 +1,106 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// errCapacityReservationNotSupported indicates capacity reservation isn't supported for the loadBalancer, e.g. not available for the account.
+var errCapacityReservationNotSupported = errors.New("capacity reservation not supported")
+
+// capacityReservationNotSupportedErrorCodes are AWS error codes that indicates capacity reservation isn't supported.
+var capacityReservationNotSupportedErrorCodes = sets.NewString(
+	"InvalidAction",
+	"OperationNotPermitted",
+	"UnsupportedOperation",
+)
+
+// reconciler for LoadBalancer capacity reservation
+type LoadBalancerCapacityReservationReconciler interface {
+	// Reconcile loadBalancer capacity reservation
+	Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error
+}
+
+// NewDefaultLoadBalancerCapacityReservationReconciler constructs new defaultLoadBalancerCapacityReservationReconciler.
+func NewDefaultLoadBalancerCapacityReservationReconciler(elbv2Client services.ELBV2, logger logr.Logger) *defaultLoadBalancerCapacityReservationReconciler {
+	return &defaultLoadBalancerCapacityReservationReconciler{
+		elbv2Client: elbv2Client,
+		logger:      logger,
+	}
+}
+
+var _ LoadBalancerCapacityReservationReconciler = &defaultLoadBalancerCapacityReservationReconciler{}
+
+// default implementation for LoadBalancerCapacityReservationReconciler
+type defaultLoadBalancerCapacityReservationReconciler struct {
+	elbv2Client services.ELBV2
+	logger      logr.Logger
+}
+
+// Reconcile modifies the capacity reservation of loadBalancer when it differs from the desired minimum capacity.
+// for loadBalancers without desired minimum capacity, existing capacity reservation is reset.
+func (r *defaultLoadBalancerCapacityReservationReconciler) Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error {
+	var desiredCapacityUnits int64
+	if resLB.Spec.MinimumLoadBalancerCapacity != nil {
+		desiredCapacityUnits = resLB.Spec.MinimumLoadBalancerCapacity.CapacityUnits
+	}
+	describeResp, err := r.elbv2Client.DescribeCapacityReservationWithContext(ctx, &services.DescribeCapacityReservationInput{
+		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
+	})
+	if err != nil {
+		err = wrapCapacityReservationError(err)
+		// there is nothing to reset when capacity reservation isn't supported.
+		if resLB.Spec.MinimumLoadBalancerCapacity == nil && errors.Is(err, errCapacityReservationNotSupported) {
+			return nil
+		}
+		return err
+	}
+	var currentCapacityUnits int64
+	if describeResp.MinimumLoadBalancerCapacity != nil {
+		currentCapacityUnits = awssdk.Int64Value(describeResp.MinimumLoadBalancerCapacity.CapacityUnits)
+	}
+	if desiredCapacityUnits == currentCapacityUnits {
+		return nil
+	}
+
+	req := &services.ModifyCapacityReservationInput{
+		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
+	}
+	if resLB.Spec.MinimumLoadBalancerCapacity != nil {
+		req.MinimumLoadBalancerCapacity = &services.MinimumLoadBalancerCapacity{
+			CapacityUnits: awssdk.Int64(desiredCapacityUnits),
+		}
+	} else {
+		req.ResetCapacityReservation = awssdk.Bool(true)
+	}
+	changeDesc := fmt.Sprintf("%v => %v", currentCapacityUnits, desiredCapacityUnits)
+	r.logger.Info("modifying loadBalancer capacity reservation",
+		"stackID", resLB.Stack().StackID(),
+		"resourceID", resLB.ID(),
+		"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+		"change", changeDesc)
+	if _, err := r.elbv2Client.ModifyCapacityReservationWithContext(ctx, req); err != nil {
+		return wrapCapacityReservationError(err)
+	}
+	r.logger.Info("modified loadBalancer capacity reservation",
+		"stackID", resLB.Stack().StackID(),
+		"resourceID", resLB.ID(),
+		"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn))
+	return nil
+}
+
+// wrapCapacityReservationError wraps errors that indicates capacity reservation isn't supported as errCapacityReservationNotSupported.
+func wrapCapacityReservationError(err error) error {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && capacityReservationNotSupportedErrorCodes.Has(awsErr.Code()) {
+		return errors.Wrap(errCapacityReservationNotSupported, awsErr.Message())
+	}
+	return err
+}
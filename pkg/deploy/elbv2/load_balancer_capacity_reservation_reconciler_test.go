@@ -0,0 +1,264 @@
+package elbv2
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultLoadBalancerCapacityReservationReconciler_Reconcile(t *testing.T) {
+	type describeCapacityReservationWithContextCall struct {
+		req  *services.DescribeCapacityReservationInput
+		resp *services.DescribeCapacityReservationOutput
+		err  error
+	}
+	type modifyCapacityReservationWithContextCall struct {
+		req  *services.ModifyCapacityReservationInput
+		resp *services.ModifyCapacityReservationOutput
+		err  error
+	}
+	type fields struct {
+		describeCapacityReservationWithContextCalls []describeCapacityReservationWithContextCall
+		modifyCapacityReservationWithContextCalls   []modifyCapacityReservationWithContextCall
+	}
+	type args struct {
+		minimumLBCapacity *elbv2model.MinimumLoadBalancerCapacity
+	}
+
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	sdkLB := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerArn: awssdk.String("my-arn"),
+		},
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		args                args
+		wantErr             error
+		wantNotSupportedErr bool
+	}{
+		{
+			name: "capacity reservation should be left untouched when not specified and not reserved",
+			fields: fields{
+				describeCapacityReservationWithContextCalls: []describeCapacityReservationWithContextCall{
+					{
+						req: &services.DescribeCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeCapacityReservationOutput{},
+					},
+				},
+			},
+			args: args{
+				minimumLBCapacity: nil,
+			},
+		},
+		{
+			name: "capacity reservation should be reset when not specified but reserved",
+			fields: fields{
+				describeCapacityReservationWithContextCalls: []describeCapacityReservationWithContextCall{
+					{
+						req: &services.DescribeCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeCapacityReservationOutput{
+							MinimumLoadBalancerCapacity: &services.MinimumLoadBalancerCapacity{
+								CapacityUnits: awssdk.Int64(1000),
+							},
+						},
+					},
+				},
+				modifyCapacityReservationWithContextCalls: []modifyCapacityReservationWithContextCall{
+					{
+						req: &services.ModifyCapacityReservationInput{
+							LoadBalancerArn:          awssdk.String("my-arn"),
+							ResetCapacityReservation: awssdk.Bool(true),
+						},
+						resp: &services.ModifyCapacityReservationOutput{},
+					},
+				},
+			},
+			args: args{
+				minimumLBCapacity: nil,
+			},
+		},
+		{
+			name: "capacity reservation should be left untouched when not specified and not supported",
+			fields: fields{
+				describeCapacityReservationWithContextCalls: []describeCapacityReservationWithContextCall{
+					{
+						req: &services.DescribeCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						err: awserr.New("OperationNotPermitted", "capacity reservation is not available for this account", nil),
+					},
+				},
+			},
+			args: args{
+				minimumLBCapacity: nil,
+			},
+		},
+		{
+			name: "capacity reservation should be reserved when not reserved yet",
+			fields: fields{
+				describeCapacityReservationWithContextCalls: []describeCapacityReservationWithContextCall{
+					{
+						req: &services.DescribeCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeCapacityReservationOutput{},
+					},
+				},
+				modifyCapacityReservationWithContextCalls: []modifyCapacityReservationWithContextCall{
+					{
+						req: &services.ModifyCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							MinimumLoadBalancerCapacity: &services.MinimumLoadBalancerCapacity{
+								CapacityUnits: awssdk.Int64(1500),
+							},
+						},
+						resp: &services.ModifyCapacityReservationOutput{},
+					},
+				},
+			},
+			args: args{
+				minimumLBCapacity: &elbv2model.MinimumLoadBalancerCapacity{CapacityUnits: 1500},
+			},
+		},
+		{
+			name: "capacity reservation should be modified when differs",
+			fields: fields{
+				describeCapacityReservationWithContextCalls: []describeCapacityReservationWithContextCall{
+					{
+						req: &services.DescribeCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeCapacityReservationOutput{
+							MinimumLoadBalancerCapacity: &services.MinimumLoadBalancerCapacity{
+								CapacityUnits: awssdk.Int64(1000),
+							},
+						},
+					},
+				},
+				modifyCapacityReservationWithContextCalls: []modifyCapacityReservationWithContextCall{
+					{
+						req: &services.ModifyCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							MinimumLoadBalancerCapacity: &services.MinimumLoadBalancerCapacity{
+								CapacityUnits: awssdk.Int64(1500),
+							},
+						},
+						resp: &services.ModifyCapacityReservationOutput{},
+					},
+				},
+			},
+			args: args{
+				minimumLBCapacity: &elbv2model.MinimumLoadBalancerCapacity{CapacityUnits: 1500},
+			},
+		},
+		{
+			name: "capacity reservation shouldn't be modified when matches",
+			fields: fields{
+				describeCapacityReservationWithContextCalls: []describeCapacityReservationWithContextCall{
+					{
+						req: &services.DescribeCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeCapacityReservationOutput{
+							MinimumLoadBalancerCapacity: &services.MinimumLoadBalancerCapacity{
+								CapacityUnits: awssdk.Int64(1500),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				minimumLBCapacity: &elbv2model.MinimumLoadBalancerCapacity{CapacityUnits: 1500},
+			},
+		},
+		{
+			name: "capacity reservation isn't supported",
+			fields: fields{
+				describeCapacityReservationWithContextCalls: []describeCapacityReservationWithContextCall{
+					{
+						req: &services.DescribeCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						err: awserr.New("OperationNotPermitted", "capacity reservation is not available for this account", nil),
+					},
+				},
+			},
+			args: args{
+				minimumLBCapacity: &elbv2model.MinimumLoadBalancerCapacity{CapacityUnits: 1500},
+			},
+			wantErr:             errors.New("capacity reservation is not available for this account: capacity reservation not supported"),
+			wantNotSupportedErr: true,
+		},
+		{
+			name: "modify capacity reservation failed",
+			fields: fields{
+				describeCapacityReservationWithContextCalls: []describeCapacityReservationWithContextCall{
+					{
+						req: &services.DescribeCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeCapacityReservationOutput{},
+					},
+				},
+				modifyCapacityReservationWithContextCalls: []modifyCapacityReservationWithContextCall{
+					{
+						req: &services.ModifyCapacityReservationInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							MinimumLoadBalancerCapacity: &services.MinimumLoadBalancerCapacity{
+								CapacityUnits: awssdk.Int64(1500),
+							},
+						},
+						err: awserr.New("CapacityUnitsLimitExceeded", "some error", nil),
+					},
+				},
+			},
+			args: args{
+				minimumLBCapacity: &elbv2model.MinimumLoadBalancerCapacity{CapacityUnits: 1500},
+			},
+			wantErr: errors.New("CapacityUnitsLimitExceeded: some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeCapacityReservationWithContextCalls {
+				elbv2Client.EXPECT().DescribeCapacityReservationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.modifyCapacityReservationWithContextCalls {
+				elbv2Client.EXPECT().ModifyCapacityReservationWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := &defaultLoadBalancerCapacityReservationReconciler{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+				MinimumLoadBalancerCapacity: tt.args.minimumLBCapacity,
+			})
+			err := r.Reconcile(context.Background(), resLB, sdkLB)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Equal(t, tt.wantNotSupportedErr, errors.Is(err, errCapacityReservationNotSupported))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strings"
 )
 
 // LoadBalancerManager is responsible for create/update/delete LoadBalancer resources.
@@ -27,12 +28,13 @@ type LoadBalancerManager interface {
 func NewDefaultLoadBalancerManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider,
 	taggingManager TaggingManager, externalManagedTags []string, logger logr.Logger) *defaultLoadBalancerManager {
 	return &defaultLoadBalancerManager{
-		elbv2Client:          elbv2Client,
-		trackingProvider:     trackingProvider,
-		taggingManager:       taggingManager,
-		attributesReconciler: NewDefaultLoadBalancerAttributeReconciler(elbv2Client, logger),
-		externalManagedTags:  externalManagedTags,
-		logger:               logger,
+		elbv2Client:                   elbv2Client,
+		trackingProvider:              trackingProvider,
+		taggingManager:                taggingManager,
+		attributesReconciler:          NewDefaultLoadBalancerAttributeReconciler(elbv2Client, logger),
+		capacityReservationReconciler: NewDefaultLoadBalancerCapacityReservationReconciler(elbv2Client, logger),
+		externalManagedTags:           externalManagedTags,
+		logger:                        logger,
 	}
 }
 
@@ -40,11 +42,12 @@ var _ LoadBalancerManager = &defaultLoadBalancerManager{}
 
 // defaultLoadBalancerManager implement LoadBalancerManager
 type defaultLoadBalancerManager struct {
-	elbv2Client          services.ELBV2
-	trackingProvider     tracking.Provider
-	taggingManager       TaggingManager
-	attributesReconciler LoadBalancerAttributeReconciler
-	externalManagedTags  []string
+	elbv2Client                   services.ELBV2
+	trackingProvider              tracking.Provider
+	taggingManager                TaggingManager
+	attributesReconciler          LoadBalancerAttributeReconciler
+	capacityReservationReconciler LoadBalancerCapacityReservationReconciler
+	externalManagedTags           []string
 
 	logger logr.Logger
 }
@@ -76,7 +79,7 @@ func (m *defaultLoadBalancerManager) Create(ctx context.Context, resLB *elbv2mod
 		return elbv2model.LoadBalancerStatus{}, err
 	}
 
-	return buildResLoadBalancerStatus(sdkLB), nil
+	return m.reconcileSDKLoadBalancerCapacityReservation(ctx, resLB, sdkLB)
 }
 
 func (m *defaultLoadBalancerManager) Update(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) (elbv2model.LoadBalancerStatus, error) {
@@ -98,7 +101,7 @@ func (m *defaultLoadBalancerManager) Update(ctx context.Context, resLB *elbv2mod
 	if err := m.checkSDKLoadBalancerWithCOIPv4Pool(ctx, resLB, sdkLB); err != nil {
 		return elbv2model.LoadBalancerStatus{}, err
 	}
-	return buildResLoadBalancerStatus(sdkLB), nil
+	return m.reconcileSDKLoadBalancerCapacityReservation(ctx, resLB, sdkLB)
 }
 
 func (m *defaultLoadBalancerManager) Delete(ctx context.Context, sdkLB LoadBalancerWithTags) error {
@@ -115,6 +118,24 @@ func (m *defaultLoadBalancerManager) Delete(ctx context.Context, sdkLB LoadBalan
 	return nil
 }
 
+// reconcileSDKLoadBalancerCapacityReservation reconciles the capacity reservation and builds the loadBalancer status.
+// capacity reservation that isn't supported is reported via status instead of failing the deployment.
+func (m *defaultLoadBalancerManager) reconcileSDKLoadBalancerCapacityReservation(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) (elbv2model.LoadBalancerStatus, error) {
+	lbStatus := buildResLoadBalancerStatus(sdkLB)
+	if err := m.capacityReservationReconciler.Reconcile(ctx, resLB, sdkLB); err != nil {
+		if !errors.Is(err, errCapacityReservationNotSupported) {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+		m.logger.Info("skipped loadBalancer capacity reservation",
+			"stackID", resLB.Stack().StackID(),
+			"resourceID", resLB.ID(),
+			"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+			"reason", err.Error())
+		lbStatus.CapacityReservationNotSupported = true
+	}
+	return lbStatus, nil
+}
+
 func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithIPAddressType(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error {
 	if resLB.Spec.IPAddressType == nil {
 		return nil
@@ -158,6 +179,15 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithSubnetMappings(ctx
 	if desiredSubnets.Equal(currentSubnets) {
 		return nil
 	}
+	if resLB.Spec.Type == elbv2model.LoadBalancerTypeNetwork && !desiredSubnets.IsSuperset(currentSubnets) {
+		return &ImmutableFieldChangeError{
+			ResourceType: resourceTypeLoadBalancer,
+			ResourceARN:  awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+			Field:        immutableFieldNLBSubnets,
+			CurrentValue: strings.Join(currentSubnets.List(), ","),
+			DesiredValue: strings.Join(desiredSubnets.List(), ","),
+		}
+	}
 
 	req := &elbv2sdk.SetSubnetsInput{
 		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
@@ -214,7 +244,13 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithSecurityGroups(ctx
 
 func (m *defaultLoadBalancerManager) checkSDKLoadBalancerWithCOIPv4Pool(_ context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error {
 	if awssdk.StringValue(resLB.Spec.CustomerOwnedIPv4Pool) != awssdk.StringValue(sdkLB.LoadBalancer.CustomerOwnedIpv4Pool) {
-		return errors.New("loadBalancer has drifted CustomerOwnedIPv4Pool setting")
+		return &ImmutableFieldChangeError{
+			ResourceType: resourceTypeLoadBalancer,
+			ResourceARN:  awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+			Field:        immutableFieldCustomerOwnedIPv4Pool,
+			CurrentValue: awssdk.StringValue(sdkLB.LoadBalancer.CustomerOwnedIpv4Pool),
+			DesiredValue: awssdk.StringValue(resLB.Spec.CustomerOwnedIPv4Pool),
+		}
 	}
 	return nil
 }
@@ -291,8 +327,37 @@ func buildSDKSubnetMapping(modelSubnetMapping elbv2model.SubnetMapping) *elbv2sd
 }
 
 func buildResLoadBalancerStatus(sdkLB LoadBalancerWithTags) elbv2model.LoadBalancerStatus {
+	var state string
+	if sdkLB.LoadBalancer.State != nil {
+		state = awssdk.StringValue(sdkLB.LoadBalancer.State.Code)
+	}
 	return elbv2model.LoadBalancerStatus{
 		LoadBalancerARN: awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
 		DNSName:         awssdk.StringValue(sdkLB.LoadBalancer.DNSName),
+		State:           state,
+		ZonalDNSNames:   buildResLoadBalancerZonalDNSNames(sdkLB),
+	}
+}
+
+// buildResLoadBalancerZonalDNSNames builds the zonal DNS names for network loadBalancer, sorted by availability zone.
+// each zonal DNS name is the regional DNS name prefixed with the availability zone.
+func buildResLoadBalancerZonalDNSNames(sdkLB LoadBalancerWithTags) []string {
+	if awssdk.StringValue(sdkLB.LoadBalancer.Type) != elbv2sdk.LoadBalancerTypeEnumNetwork {
+		return nil
+	}
+	dnsName := awssdk.StringValue(sdkLB.LoadBalancer.DNSName)
+	if dnsName == "" {
+		return nil
+	}
+	zoneNames := sets.NewString()
+	for _, az := range sdkLB.LoadBalancer.AvailabilityZones {
+		if zoneName := awssdk.StringValue(az.ZoneName); zoneName != "" {
+			zoneNames.Insert(zoneName)
+		}
+	}
+	var zonalDNSNames []string
+	for _, zoneName := range zoneNames.List() {
+		zonalDNSNames = append(zonalDNSNames, fmt.Sprintf("%s.%s", zoneName, dnsName))
 	}
+	return zonalDNSNames
 }
@@ -5,9 +5,12 @@ import (
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -298,6 +301,77 @@ func Test_buildResLoadBalancerStatus(t *testing.T) {
 				DNSName:         "www.example.com",
 			},
 		},
+		{
+			name: "provisioning loadBalancer",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						DNSName:         awssdk.String("www.example.com"),
+						State: &elbv2sdk.LoadBalancerState{
+							Code: awssdk.String(elbv2sdk.LoadBalancerStateEnumProvisioning),
+						},
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "my-arn",
+				DNSName:         "www.example.com",
+				State:           "provisioning",
+			},
+		},
+		{
+			name: "network loadBalancer with zonal DNS names",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						DNSName:         awssdk.String("my-nlb-1234.elb.us-west-2.amazonaws.com"),
+						Type:            awssdk.String(elbv2sdk.LoadBalancerTypeEnumNetwork),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{
+								ZoneName: awssdk.String("us-west-2b"),
+								SubnetId: awssdk.String("subnet-b"),
+							},
+							{
+								ZoneName: awssdk.String("us-west-2a"),
+								SubnetId: awssdk.String("subnet-a"),
+							},
+						},
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "my-arn",
+				DNSName:         "my-nlb-1234.elb.us-west-2.amazonaws.com",
+				ZonalDNSNames: []string{
+					"us-west-2a.my-nlb-1234.elb.us-west-2.amazonaws.com",
+					"us-west-2b.my-nlb-1234.elb.us-west-2.amazonaws.com",
+				},
+			},
+		},
+		{
+			name: "application loadBalancer doesn't have zonal DNS names",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						DNSName:         awssdk.String("my-alb-1234.us-west-2.elb.amazonaws.com"),
+						Type:            awssdk.String(elbv2sdk.LoadBalancerTypeEnumApplication),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{
+								ZoneName: awssdk.String("us-west-2a"),
+								SubnetId: awssdk.String("subnet-a"),
+							},
+						},
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "my-arn",
+				DNSName:         "my-alb-1234.us-west-2.elb.amazonaws.com",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -327,6 +401,7 @@ func Test_defaultLoadBalancerManager_checkSDKLoadBalancerWithCOIPv4Pool(t *testi
 				},
 				sdkLB: LoadBalancerWithTags{
 					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn:       awssdk.String("my-arn"),
 						CustomerOwnedIpv4Pool: nil,
 					},
 				},
@@ -343,6 +418,7 @@ func Test_defaultLoadBalancerManager_checkSDKLoadBalancerWithCOIPv4Pool(t *testi
 				},
 				sdkLB: LoadBalancerWithTags{
 					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn:       awssdk.String("my-arn"),
 						CustomerOwnedIpv4Pool: awssdk.String("ipv4pool-coip-abc"),
 					},
 				},
@@ -359,11 +435,18 @@ func Test_defaultLoadBalancerManager_checkSDKLoadBalancerWithCOIPv4Pool(t *testi
 				},
 				sdkLB: LoadBalancerWithTags{
 					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn:       awssdk.String("my-arn"),
 						CustomerOwnedIpv4Pool: awssdk.String("ipv4pool-coip-def"),
 					},
 				},
 			},
-			wantErr: errors.New("loadBalancer has drifted CustomerOwnedIPv4Pool setting"),
+			wantErr: &ImmutableFieldChangeError{
+				ResourceType: "loadBalancer",
+				ResourceARN:  "my-arn",
+				Field:        "CustomerOwnedIPv4Pool",
+				CurrentValue: "ipv4pool-coip-def",
+				DesiredValue: "ipv4pool-coip-abc",
+			},
 		},
 		{
 			name: "only resLB have CustomerOwnedIPv4Pool setting",
@@ -375,11 +458,18 @@ func Test_defaultLoadBalancerManager_checkSDKLoadBalancerWithCOIPv4Pool(t *testi
 				},
 				sdkLB: LoadBalancerWithTags{
 					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn:       awssdk.String("my-arn"),
 						CustomerOwnedIpv4Pool: nil,
 					},
 				},
 			},
-			wantErr: errors.New("loadBalancer has drifted CustomerOwnedIPv4Pool setting"),
+			wantErr: &ImmutableFieldChangeError{
+				ResourceType: "loadBalancer",
+				ResourceARN:  "my-arn",
+				Field:        "CustomerOwnedIPv4Pool",
+				CurrentValue: "",
+				DesiredValue: "ipv4pool-coip-abc",
+			},
 		},
 		{
 			name: "only sdkLB have CustomerOwnedIPv4Pool setting",
@@ -391,11 +481,18 @@ func Test_defaultLoadBalancerManager_checkSDKLoadBalancerWithCOIPv4Pool(t *testi
 				},
 				sdkLB: LoadBalancerWithTags{
 					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn:       awssdk.String("my-arn"),
 						CustomerOwnedIpv4Pool: awssdk.String("ipv4pool-coip-abc"),
 					},
 				},
 			},
-			wantErr: errors.New("loadBalancer has drifted CustomerOwnedIPv4Pool setting"),
+			wantErr: &ImmutableFieldChangeError{
+				ResourceType: "loadBalancer",
+				ResourceARN:  "my-arn",
+				Field:        "CustomerOwnedIPv4Pool",
+				CurrentValue: "ipv4pool-coip-abc",
+				DesiredValue: "",
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -410,3 +507,141 @@ func Test_defaultLoadBalancerManager_checkSDKLoadBalancerWithCOIPv4Pool(t *testi
 		})
 	}
 }
+
+func Test_defaultLoadBalancerManager_updateSDKLoadBalancerWithSubnetMappings(t *testing.T) {
+	type setSubnetsCall struct {
+		req  *elbv2sdk.SetSubnetsInput
+		resp *elbv2sdk.SetSubnetsOutput
+		err  error
+	}
+	type args struct {
+		lbType         elbv2model.LoadBalancerType
+		subnetMappings []elbv2model.SubnetMapping
+		sdkLB          LoadBalancerWithTags
+	}
+	tests := []struct {
+		name           string
+		setSubnetsCall *setSubnetsCall
+		args           args
+		wantErr        error
+	}{
+		{
+			name: "subnets unchanged",
+			args: args{
+				lbType:         elbv2model.LoadBalancerTypeNetwork,
+				subnetMappings: []elbv2model.SubnetMapping{{SubnetID: "subnet-a"}, {SubnetID: "subnet-b"}},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "subnets added to network loadBalancer",
+			setSubnetsCall: &setSubnetsCall{
+				req: &elbv2sdk.SetSubnetsInput{
+					LoadBalancerArn: awssdk.String("my-arn"),
+					SubnetMappings: []*elbv2sdk.SubnetMapping{
+						{SubnetId: awssdk.String("subnet-a")},
+						{SubnetId: awssdk.String("subnet-b")},
+					},
+				},
+				resp: &elbv2sdk.SetSubnetsOutput{},
+			},
+			args: args{
+				lbType:         elbv2model.LoadBalancerTypeNetwork,
+				subnetMappings: []elbv2model.SubnetMapping{{SubnetID: "subnet-a"}, {SubnetID: "subnet-b"}},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "subnets removed from network loadBalancer",
+			args: args{
+				lbType:         elbv2model.LoadBalancerTypeNetwork,
+				subnetMappings: []elbv2model.SubnetMapping{{SubnetID: "subnet-a"}, {SubnetID: "subnet-c"}},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+				},
+			},
+			wantErr: &ImmutableFieldChangeError{
+				ResourceType: "loadBalancer",
+				ResourceARN:  "my-arn",
+				Field:        "Subnets",
+				CurrentValue: "subnet-a,subnet-b",
+				DesiredValue: "subnet-a,subnet-c",
+			},
+		},
+		{
+			name: "subnets removed from application loadBalancer",
+			setSubnetsCall: &setSubnetsCall{
+				req: &elbv2sdk.SetSubnetsInput{
+					LoadBalancerArn: awssdk.String("my-arn"),
+					SubnetMappings: []*elbv2sdk.SubnetMapping{
+						{SubnetId: awssdk.String("subnet-a")},
+						{SubnetId: awssdk.String("subnet-c")},
+					},
+				},
+				resp: &elbv2sdk.SetSubnetsOutput{},
+			},
+			args: args{
+				lbType:         elbv2model.LoadBalancerTypeApplication,
+				subnetMappings: []elbv2model.SubnetMapping{{SubnetID: "subnet-a"}, {SubnetID: "subnet-c"}},
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			if tt.setSubnetsCall != nil {
+				elbv2Client.EXPECT().SetSubnetsWithContext(gomock.Any(), tt.setSubnetsCall.req).Return(tt.setSubnetsCall.resp, tt.setSubnetsCall.err)
+			}
+			m := &defaultLoadBalancerManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+				Type:           tt.args.lbType,
+				SubnetMappings: tt.args.subnetMappings,
+			})
+			err := m.updateSDKLoadBalancerWithSubnetMappings(context.Background(), resLB, tt.args.sdkLB)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				var immutableFieldChangeErr *ImmutableFieldChangeError
+				assert.True(t, errors.As(err, &immutableFieldChangeErr))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
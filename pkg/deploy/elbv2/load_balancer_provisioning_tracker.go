@@ -0,0 +1,70 @@
+package elbv2
+
+import (
+	"sync"
+	"time"
+
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+// LoadBalancerProvisioningTracker is responsible for tracking LoadBalancer provisioning across reconciles.
+type LoadBalancerProvisioningTracker interface {
+	// TrackState tracks the state of LoadBalancer observed during reconcile, and returns whether it's still provisioning.
+	// error is returned when the LoadBalancer failed provisioning, or has been provisioning for longer than timeout.
+	TrackState(lbARN string, state string) (bool, error)
+}
+
+// NewDefaultLoadBalancerProvisioningTracker constructs new defaultLoadBalancerProvisioningTracker.
+func NewDefaultLoadBalancerProvisioningTracker(timeout time.Duration, logger logr.Logger) *defaultLoadBalancerProvisioningTracker {
+	return newLoadBalancerProvisioningTrackerWithClock(timeout, logger, clock.RealClock{})
+}
+
+func newLoadBalancerProvisioningTrackerWithClock(timeout time.Duration, logger logr.Logger, clock clock.Clock) *defaultLoadBalancerProvisioningTracker {
+	return &defaultLoadBalancerProvisioningTracker{
+		timeout:               timeout,
+		logger:                logger,
+		clock:                 clock,
+		provisioningSinceByLB: make(map[string]time.Time),
+	}
+}
+
+var _ LoadBalancerProvisioningTracker = &defaultLoadBalancerProvisioningTracker{}
+
+// default implementation for LoadBalancerProvisioningTracker.
+// the provisioning duration is measured since the LoadBalancer is first observed in provisioning state.
+type defaultLoadBalancerProvisioningTracker struct {
+	timeout time.Duration
+	logger  logr.Logger
+	clock   clock.Clock
+
+	mutex                 sync.Mutex
+	provisioningSinceByLB map[string]time.Time
+}
+
+func (t *defaultLoadBalancerProvisioningTracker) TrackState(lbARN string, state string) (bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	switch state {
+	case elbv2sdk.LoadBalancerStateEnumProvisioning:
+		provisioningSince, exists := t.provisioningSinceByLB[lbARN]
+		if !exists {
+			provisioningSince = t.clock.Now()
+			t.provisioningSinceByLB[lbARN] = provisioningSince
+		}
+		if t.clock.Since(provisioningSince) >= t.timeout {
+			return true, errors.Errorf("timed out waiting loadBalancer %v provisioning after %v, state=%v", lbARN, t.timeout, state)
+		}
+		t.logger.V(1).Info("waiting loadBalancer provisioning", "arn", lbARN, "state", state)
+		return true, nil
+	case elbv2sdk.LoadBalancerStateEnumFailed:
+		delete(t.provisioningSinceByLB, lbARN)
+		return false, errors.Errorf("loadBalancer %v failed provisioning", lbARN)
+	default:
+		delete(t.provisioningSinceByLB, lbARN)
+		return false, nil
+	}
+}
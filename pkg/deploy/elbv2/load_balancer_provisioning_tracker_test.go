@@ -0,0 +1,134 @@
+package elbv2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultLoadBalancerProvisioningTracker_TrackState(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1234567890123456"
+	type trackStateCall struct {
+		elapsed          time.Duration
+		state            string
+		wantProvisioning bool
+		wantErr          error
+	}
+	tests := []struct {
+		name            string
+		trackStateCalls []trackStateCall
+	}{
+		{
+			name: "loadBalancer transitions from provisioning to active",
+			trackStateCalls: []trackStateCall{
+				{
+					state:            "provisioning",
+					wantProvisioning: true,
+				},
+				{
+					elapsed:          15 * time.Second,
+					state:            "provisioning",
+					wantProvisioning: true,
+				},
+				{
+					elapsed:          15 * time.Second,
+					state:            "active",
+					wantProvisioning: false,
+				},
+			},
+		},
+		{
+			name: "loadBalancer already active",
+			trackStateCalls: []trackStateCall{
+				{
+					state:            "active",
+					wantProvisioning: false,
+				},
+			},
+		},
+		{
+			name: "loadBalancer failed provisioning",
+			trackStateCalls: []trackStateCall{
+				{
+					state:            "provisioning",
+					wantProvisioning: true,
+				},
+				{
+					elapsed:          15 * time.Second,
+					state:            "failed",
+					wantProvisioning: false,
+					wantErr:          errors.New("loadBalancer arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1234567890123456 failed provisioning"),
+				},
+			},
+		},
+		{
+			name: "loadBalancer provisioning timed out",
+			trackStateCalls: []trackStateCall{
+				{
+					state:            "provisioning",
+					wantProvisioning: true,
+				},
+				{
+					elapsed:          45 * time.Second,
+					state:            "provisioning",
+					wantProvisioning: true,
+				},
+				{
+					elapsed:          15 * time.Second,
+					state:            "provisioning",
+					wantProvisioning: true,
+					wantErr:          errors.New("timed out waiting loadBalancer arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1234567890123456 provisioning after 1m0s, state=provisioning"),
+				},
+				{
+					elapsed:          15 * time.Second,
+					state:            "active",
+					wantProvisioning: false,
+				},
+			},
+		},
+		{
+			name: "provisioning duration is reset once loadBalancer is no longer provisioning",
+			trackStateCalls: []trackStateCall{
+				{
+					state:            "provisioning",
+					wantProvisioning: true,
+				},
+				{
+					elapsed:          45 * time.Second,
+					state:            "active",
+					wantProvisioning: false,
+				},
+				{
+					elapsed:          15 * time.Second,
+					state:            "provisioning",
+					wantProvisioning: true,
+				},
+				{
+					elapsed:          45 * time.Second,
+					state:            "provisioning",
+					wantProvisioning: true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClock := clock.NewFakeClock(time.Now())
+			tracker := newLoadBalancerProvisioningTrackerWithClock(time.Minute, &log.NullLogger{}, fakeClock)
+			for _, call := range tt.trackStateCalls {
+				fakeClock.Step(call.elapsed)
+				gotProvisioning, err := tracker.TrackState(lbARN, call.state)
+				if call.wantErr != nil {
+					assert.EqualError(t, err, call.wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, call.wantProvisioning, gotProvisioning)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+const (
+	// target health is cached briefly, so that targetGroups referenced by multiple listeners and rules are described once per reconcile.
+	defaultTargetHealthCacheTTL = 10 * time.Second
+
+	defaultNoHealthyTargetsForwardRetryBaseDelay = 1 * time.Minute
+	defaultNoHealthyTargetsForwardRetryMaxDelay  = 15 * time.Minute
+)
+
+// NoHealthyTargetsActionResolver resolves the actions to apply according to current target health.
+type NoHealthyTargetsActionResolver interface {
+	// ResolveActions replaces forward actions with NoHealthyTargetsFixedResponseConfig with the fixed response while none of their targetGroups has healthy targets.
+	ResolveActions(ctx context.Context, modelActions []elbv2model.Action) ([]elbv2model.Action, error)
+}
+
+// NewDefaultNoHealthyTargetsActionResolver constructs new defaultNoHealthyTargetsActionResolver.
+func NewDefaultNoHealthyTargetsActionResolver(elbv2Client services.ELBV2, logger logr.Logger) *defaultNoHealthyTargetsActionResolver {
+	return newNoHealthyTargetsActionResolverWithClock(elbv2Client, logger, clock.RealClock{})
+}
+
+func newNoHealthyTargetsActionResolverWithClock(elbv2Client services.ELBV2, logger logr.Logger, clock clock.Clock) *defaultNoHealthyTargetsActionResolver {
+	return &defaultNoHealthyTargetsActionResolver{
+		elbv2Client:          elbv2Client,
+		logger:               logger,
+		clock:                clock,
+		targetHealthCache:    cache.NewExpiringWithClock(clock),
+		targetHealthCacheTTL: defaultTargetHealthCacheTTL,
+		forwardRetryBackoff:  workqueue.NewItemExponentialFailureRateLimiter(defaultNoHealthyTargetsForwardRetryBaseDelay, defaultNoHealthyTargetsForwardRetryMaxDelay),
+		detachedTGInfoByARN:  make(map[string]detachedTargetGroupInfo),
+	}
+}
+
+var _ NoHealthyTargetsActionResolver = &defaultNoHealthyTargetsActionResolver{}
+
+// detachedTargetGroupInfo records a targetGroup detached from forward actions since it had no healthy targets.
+// targets of detached targetGroup are no longer health checked and become unused, which doesn't mean they recovered.
+type detachedTargetGroupInfo struct {
+	// targets registered when the targetGroup is detached.
+	targets sets.String
+	// the forward action is retried after this time, so that targets are health checked again.
+	retryAfter time.Time
+	// whether the forward action is being retried.
+	retrying bool
+}
+
+// default implementation for NoHealthyTargetsActionResolver.
+// targetGroups detached by fixed response are only forwarded to again when their targets change, or the retry backoff elapses.
+type defaultNoHealthyTargetsActionResolver struct {
+	elbv2Client services.ELBV2
+	logger      logr.Logger
+	clock       clock.Clock
+
+	targetHealthCache    *cache.Expiring
+	targetHealthCacheTTL time.Duration
+	// forwardRetryBackoff computes the delay before forward action is retried per detached targetGroup.
+	forwardRetryBackoff workqueue.RateLimiter
+
+	mutex               sync.Mutex
+	detachedTGInfoByARN map[string]detachedTargetGroupInfo
+}
+
+func (r *defaultNoHealthyTargetsActionResolver) ResolveActions(ctx context.Context, modelActions []elbv2model.Action) ([]elbv2model.Action, error) {
+	var resolvedActions []elbv2model.Action
+	for _, modelAction := range modelActions {
+		if modelAction.ForwardConfig == nil || modelAction.ForwardConfig.NoHealthyTargetsFixedResponseConfig == nil {
+			resolvedActions = append(resolvedActions, modelAction)
+			continue
+		}
+		shouldForward, err := r.shouldForwardToForwardActionConfig(ctx, *modelAction.ForwardConfig)
+		if err != nil {
+			return nil, err
+		}
+		if shouldForward {
+			resolvedActions = append(resolvedActions, modelAction)
+			continue
+		}
+		resolvedActions = append(resolvedActions, elbv2model.Action{
+			Type:                elbv2model.ActionTypeFixedResponse,
+			FixedResponseConfig: modelAction.ForwardConfig.NoHealthyTargetsFixedResponseConfig,
+		})
+	}
+	return resolvedActions, nil
+}
+
+// shouldForwardToForwardActionConfig checks whether the forward action should be applied, i.e. any of its targetGroups should be forwarded to.
+func (r *defaultNoHealthyTargetsActionResolver) shouldForwardToForwardActionConfig(ctx context.Context, modelCfg elbv2model.ForwardActionConfig) (bool, error) {
+	for _, tgt := range modelCfg.TargetGroups {
+		tgARN, err := tgt.TargetGroupARN.Resolve(ctx)
+		if err != nil {
+			return false, err
+		}
+		targetHealthDescriptions, err := r.describeTargetHealth(ctx, tgARN)
+		if err != nil {
+			return false, err
+		}
+		if r.shouldForwardToTargetGroup(tgARN, targetHealthDescriptions) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// shouldForwardToTargetGroup checks whether the targetGroup should be forwarded to according to its target health.
+//  - targets that are healthy or being health checked are forwarded to.
+//  - targets that are unused since targetGroup isn't attached yet are forwarded to, so that they get health checked.
+//  - targets that are unused since targetGroup is detached by us are only forwarded to again once targets changed, or the retry backoff elapsed.
+func (r *defaultNoHealthyTargetsActionResolver) shouldForwardToTargetGroup(tgARN string, targetHealthDescriptions []*elbv2sdk.TargetHealthDescription) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	targetStates := sets.NewString()
+	targets := sets.NewString()
+	for _, thd := range targetHealthDescriptions {
+		if thd.TargetHealth != nil {
+			targetStates.Insert(awssdk.StringValue(thd.TargetHealth.State))
+		}
+		if thd.Target != nil {
+			targets.Insert(fmt.Sprintf("%v:%v", awssdk.StringValue(thd.Target.Id), awssdk.Int64Value(thd.Target.Port)))
+		}
+	}
+
+	if targetStates.Has(elbv2sdk.TargetHealthStateEnumHealthy) {
+		delete(r.detachedTGInfoByARN, tgARN)
+		r.forwardRetryBackoff.Forget(tgARN)
+		return true
+	}
+	if targetStates.Has(elbv2sdk.TargetHealthStateEnumInitial) {
+		return true
+	}
+
+	detachedTGInfo, detached := r.detachedTGInfoByARN[tgARN]
+	if targetStates.Len() != 0 && targetStates.Equal(sets.NewString(elbv2sdk.TargetHealthStateEnumUnused)) {
+		if !detached || detachedTGInfo.retrying {
+			return true
+		}
+		if !detachedTGInfo.targets.Equal(targets) || !r.clock.Now().Before(detachedTGInfo.retryAfter) {
+			r.logger.Info("retrying forward to targetGroup without healthy targets",
+				"arn", tgARN,
+				"targetsChanged", !detachedTGInfo.targets.Equal(targets))
+			detachedTGInfo.retrying = true
+			r.detachedTGInfoByARN[tgARN] = detachedTGInfo
+			return true
+		}
+		return false
+	}
+
+	if detached && !detachedTGInfo.retrying {
+		return false
+	}
+	retryDelay := r.forwardRetryBackoff.When(tgARN)
+	r.logger.Info("detaching targetGroup without healthy targets",
+		"arn", tgARN,
+		"retryDelay", retryDelay)
+	r.detachedTGInfoByARN[tgARN] = detachedTargetGroupInfo{
+		targets:    targets,
+		retryAfter: r.clock.Now().Add(retryDelay),
+	}
+	return false
+}
+
+func (r *defaultNoHealthyTargetsActionResolver) describeTargetHealth(ctx context.Context, tgARN string) ([]*elbv2sdk.TargetHealthDescription, error) {
+	if rawCacheItem, exists := r.targetHealthCache.Get(tgARN); exists {
+		return rawCacheItem.([]*elbv2sdk.TargetHealthDescription), nil
+	}
+	resp, err := r.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.targetHealthCache.Set(tgARN, resp.TargetHealthDescriptions, r.targetHealthCacheTTL)
+	return resp.TargetHealthDescriptions, nil
+}
@@ -0,0 +1,394 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_defaultNoHealthyTargetsActionResolver_ResolveActions(t *testing.T) {
+	type describeTargetHealthWithContextCall struct {
+		req  *elbv2sdk.DescribeTargetHealthInput
+		resp *elbv2sdk.DescribeTargetHealthOutput
+		err  error
+	}
+	fixedResponseCfg := &elbv2model.FixedResponseActionConfig{
+		ContentType: awssdk.String("text/plain"),
+		MessageBody: awssdk.String("503 Service Unavailable"),
+		StatusCode:  "503",
+	}
+	forwardAction := elbv2model.Action{
+		Type: elbv2model.ActionTypeForward,
+		ForwardConfig: &elbv2model.ForwardActionConfig{
+			TargetGroups: []elbv2model.TargetGroupTuple{
+				{
+					TargetGroupARN: coremodel.LiteralStringToken("tg-1"),
+				},
+				{
+					TargetGroupARN: coremodel.LiteralStringToken("tg-2"),
+				},
+			},
+			NoHealthyTargetsFixedResponseConfig: fixedResponseCfg,
+		},
+	}
+	fixedResponseAction := elbv2model.Action{
+		Type:                elbv2model.ActionTypeFixedResponse,
+		FixedResponseConfig: fixedResponseCfg,
+	}
+	targetHealthOutput := func(states ...string) *elbv2sdk.DescribeTargetHealthOutput {
+		resp := &elbv2sdk.DescribeTargetHealthOutput{}
+		for _, state := range states {
+			resp.TargetHealthDescriptions = append(resp.TargetHealthDescriptions, &elbv2sdk.TargetHealthDescription{
+				TargetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(state),
+				},
+			})
+		}
+		return resp
+	}
+	tests := []struct {
+		name                                 string
+		modelActions                         []elbv2model.Action
+		describeTargetHealthWithContextCalls []describeTargetHealthWithContextCall
+		want                                 []elbv2model.Action
+		wantErr                              error
+	}{
+		{
+			name: "forward action without fixed response on no healthy targets",
+			modelActions: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeForward,
+					ForwardConfig: &elbv2model.ForwardActionConfig{
+						TargetGroups: []elbv2model.TargetGroupTuple{
+							{
+								TargetGroupARN: coremodel.LiteralStringToken("tg-1"),
+							},
+						},
+					},
+				},
+			},
+			want: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeForward,
+					ForwardConfig: &elbv2model.ForwardActionConfig{
+						TargetGroups: []elbv2model.TargetGroupTuple{
+							{
+								TargetGroupARN: coremodel.LiteralStringToken("tg-1"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "forward action kept when targetGroup has healthy targets",
+			modelActions: []elbv2model.Action{forwardAction},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-1")},
+					resp: targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnhealthy, elbv2sdk.TargetHealthStateEnumHealthy),
+				},
+			},
+			want: []elbv2model.Action{forwardAction},
+		},
+		{
+			name:         "forward action kept when another targetGroup has healthy targets",
+			modelActions: []elbv2model.Action{forwardAction},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-1")},
+					resp: targetHealthOutput(),
+				},
+				{
+					req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-2")},
+					resp: targetHealthOutput(elbv2sdk.TargetHealthStateEnumHealthy),
+				},
+			},
+			want: []elbv2model.Action{forwardAction},
+		},
+		{
+			name:         "forward action replaced with fixed response when targets are unhealthy",
+			modelActions: []elbv2model.Action{forwardAction},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-1")},
+					resp: targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnhealthy, elbv2sdk.TargetHealthStateEnumDraining),
+				},
+				{
+					req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-2")},
+					resp: targetHealthOutput(),
+				},
+			},
+			want: []elbv2model.Action{fixedResponseAction},
+		},
+		{
+			name:         "forward action kept when targetGroup is unused without being detached",
+			modelActions: []elbv2model.Action{forwardAction},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-1")},
+					resp: targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnused),
+				},
+			},
+			want: []elbv2model.Action{forwardAction},
+		},
+		{
+			name: "only forward action is replaced with fixed response",
+			modelActions: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeAuthenticateOIDC,
+					AuthenticateOIDCConfig: &elbv2model.AuthenticateOIDCActionConfig{
+						Issuer: "https://example.com",
+					},
+				},
+				forwardAction,
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-1")},
+					resp: targetHealthOutput(),
+				},
+				{
+					req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-2")},
+					resp: targetHealthOutput(),
+				},
+			},
+			want: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeAuthenticateOIDC,
+					AuthenticateOIDCConfig: &elbv2model.AuthenticateOIDCActionConfig{
+						Issuer: "https://example.com",
+					},
+				},
+				fixedResponseAction,
+			},
+		},
+		{
+			name:         "failed to describe target health",
+			modelActions: []elbv2model.Action{forwardAction},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-1")},
+					err: errors.New("some error"),
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeTargetHealthWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := NewDefaultNoHealthyTargetsActionResolver(elbv2Client, &log.NullLogger{})
+			got, err := r.ResolveActions(context.Background(), tt.modelActions)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultNoHealthyTargetsActionResolver_ResolveActions_detachedTargetGroup(t *testing.T) {
+	fixedResponseCfg := &elbv2model.FixedResponseActionConfig{
+		ContentType: awssdk.String("text/plain"),
+		MessageBody: awssdk.String("503 Service Unavailable"),
+		StatusCode:  "503",
+	}
+	forwardAction := elbv2model.Action{
+		Type: elbv2model.ActionTypeForward,
+		ForwardConfig: &elbv2model.ForwardActionConfig{
+			TargetGroups: []elbv2model.TargetGroupTuple{
+				{
+					TargetGroupARN: coremodel.LiteralStringToken("tg-1"),
+				},
+			},
+			NoHealthyTargetsFixedResponseConfig: fixedResponseCfg,
+		},
+	}
+	fixedResponseAction := elbv2model.Action{
+		Type:                elbv2model.ActionTypeFixedResponse,
+		FixedResponseConfig: fixedResponseCfg,
+	}
+	targetHealthOutput := func(state string, targetIDs ...string) *elbv2sdk.DescribeTargetHealthOutput {
+		resp := &elbv2sdk.DescribeTargetHealthOutput{}
+		for _, targetID := range targetIDs {
+			resp.TargetHealthDescriptions = append(resp.TargetHealthDescriptions, &elbv2sdk.TargetHealthDescription{
+				Target: &elbv2sdk.TargetDescription{
+					Id:   awssdk.String(targetID),
+					Port: awssdk.Int64(8080),
+				},
+				TargetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(state),
+				},
+			})
+		}
+		return resp
+	}
+	type reconcile struct {
+		// time elapsed since previous reconcile.
+		elapsed time.Duration
+		resp    *elbv2sdk.DescribeTargetHealthOutput
+		want    elbv2model.Action
+	}
+	tests := []struct {
+		name       string
+		reconciles []reconcile
+	}{
+		{
+			name: "detached targetGroup isn't forwarded to again while its targets are unused",
+			reconciles: []reconcile{
+				{
+					resp: targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnhealthy, "10.0.0.1"),
+					want: fixedResponseAction,
+				},
+				{
+					elapsed: 30 * time.Second,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnused, "10.0.0.1"),
+					want:    fixedResponseAction,
+				},
+				{
+					elapsed: 11 * time.Second,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnhealthy, "10.0.0.1"),
+					want:    fixedResponseAction,
+				},
+				{
+					elapsed: 11 * time.Second,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnused, "10.0.0.1"),
+					want:    fixedResponseAction,
+				},
+			},
+		},
+		{
+			name: "detached targetGroup is forwarded to again with increasing backoff",
+			reconciles: []reconcile{
+				{
+					resp: targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnhealthy, "10.0.0.1"),
+					want: fixedResponseAction,
+				},
+				{
+					elapsed: time.Minute,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnused, "10.0.0.1"),
+					want:    forwardAction,
+				},
+				{
+					elapsed: 30 * time.Second,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumInitial, "10.0.0.1"),
+					want:    forwardAction,
+				},
+				{
+					elapsed: 30 * time.Second,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnhealthy, "10.0.0.1"),
+					want:    fixedResponseAction,
+				},
+				{
+					elapsed: time.Minute,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnused, "10.0.0.1"),
+					want:    fixedResponseAction,
+				},
+				{
+					elapsed: time.Minute,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnused, "10.0.0.1"),
+					want:    forwardAction,
+				},
+			},
+		},
+		{
+			name: "detached targetGroup is forwarded to again once targets changed",
+			reconciles: []reconcile{
+				{
+					resp: targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnhealthy, "10.0.0.1"),
+					want: fixedResponseAction,
+				},
+				{
+					elapsed: 30 * time.Second,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnused, "10.0.0.1"),
+					want:    fixedResponseAction,
+				},
+				{
+					elapsed: 20 * time.Second,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumUnused, "10.0.0.2"),
+					want:    forwardAction,
+				},
+				{
+					elapsed: 20 * time.Second,
+					resp:    targetHealthOutput(elbv2sdk.TargetHealthStateEnumHealthy, "10.0.0.2"),
+					want:    forwardAction,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			fakeClock := clock.NewFakeClock(time.Now())
+			r := newNoHealthyTargetsActionResolverWithClock(elbv2Client, &log.NullLogger{}, fakeClock)
+			for _, reconcile := range tt.reconciles {
+				fakeClock.Step(reconcile.elapsed)
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+					TargetGroupArn: awssdk.String("tg-1"),
+				}).Return(reconcile.resp, nil)
+				got, err := r.ResolveActions(context.Background(), []elbv2model.Action{forwardAction})
+				assert.NoError(t, err)
+				assert.Equal(t, []elbv2model.Action{reconcile.want}, got)
+			}
+		})
+	}
+}
+
+func Test_defaultNoHealthyTargetsActionResolver_ResolveActions_cachesTargetHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	forwardAction := elbv2model.Action{
+		Type: elbv2model.ActionTypeForward,
+		ForwardConfig: &elbv2model.ForwardActionConfig{
+			TargetGroups: []elbv2model.TargetGroupTuple{
+				{
+					TargetGroupARN: coremodel.LiteralStringToken("tg-1"),
+				},
+			},
+			NoHealthyTargetsFixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+				StatusCode: "503",
+			},
+		},
+	}
+	elbv2Client := services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String("tg-1"),
+	}).Return(&elbv2sdk.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+			{
+				TargetHealth: &elbv2sdk.TargetHealth{
+					State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+				},
+			},
+		},
+	}, nil).Times(1)
+	r := NewDefaultNoHealthyTargetsActionResolver(elbv2Client, &log.NullLogger{})
+	for i := 0; i < 3; i++ {
+		got, err := r.ResolveActions(context.Background(), []elbv2model.Action{forwardAction})
+		assert.NoError(t, err)
+		assert.Equal(t, []elbv2model.Action{forwardAction}, got)
+	}
+}
@@ -186,6 +186,7 @@ func buildK8sTargetGroupBindingSpec(ctx context.Context, resTGB *elbv2model.Targ
 		k8sTGBSpec.Networking = &k8sTGBNetworking
 	}
 	k8sTGBSpec.NodeSelector = resTGB.Spec.Template.Spec.NodeSelector
+	k8sTGBSpec.HealthCheckGracePeriodSeconds = resTGB.Spec.Template.Spec.HealthCheckGracePeriodSeconds
 	return k8sTGBSpec, nil
 }
 
@@ -6,19 +6,22 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewTargetGroupBindingSynthesizer constructs new targetGroupBindingSynthesizer
-func NewTargetGroupBindingSynthesizer(k8sClient client.Client, trackingProvider tracking.Provider, tgbManager TargetGroupBindingManager, logger logr.Logger, stack core.Stack) *targetGroupBindingSynthesizer {
+func NewTargetGroupBindingSynthesizer(k8sClient client.Client, trackingProvider tracking.Provider, tgbManager TargetGroupBindingManager,
+	keptListenerTGARNs sets.String, logger logr.Logger, stack core.Stack) *targetGroupBindingSynthesizer {
 	return &targetGroupBindingSynthesizer{
-		k8sClient:        k8sClient,
-		trackingProvider: trackingProvider,
-		tgbManager:       tgbManager,
-		logger:           logger,
-		stack:            stack,
+		k8sClient:          k8sClient,
+		trackingProvider:   trackingProvider,
+		tgbManager:         tgbManager,
+		keptListenerTGARNs: keptListenerTGARNs,
+		logger:             logger,
+		stack:              stack,
 
 		unmatchedK8sTGBs: nil,
 	}
@@ -29,8 +32,10 @@ type targetGroupBindingSynthesizer struct {
 	k8sClient        client.Client
 	trackingProvider tracking.Provider
 	tgbManager       TargetGroupBindingManager
-	logger           logr.Logger
-	stack            core.Stack
+	// keptListenerTGARNs contains ARNs of targetGroups still referenced by listeners kept on LoadBalancer, whose TargetGroupBindings shouldn't be deleted.
+	keptListenerTGARNs sets.String
+	logger             logr.Logger
+	stack              core.Stack
 
 	unmatchedK8sTGBs []*elbv2api.TargetGroupBinding
 }
@@ -68,6 +73,11 @@ func (s *targetGroupBindingSynthesizer) Synthesize(ctx context.Context) error {
 
 func (s *targetGroupBindingSynthesizer) PostSynthesize(ctx context.Context) error {
 	for _, k8sTGB := range s.unmatchedK8sTGBs {
+		if s.keptListenerTGARNs.Has(k8sTGB.Spec.TargetGroupARN) {
+			s.logger.V(1).Info("skipping deletion of targetGroupBinding referenced by kept listener",
+				"targetGroupBinding", k8s.NamespacedName(k8sTGB))
+			continue
+		}
 		if err := s.tgbManager.Delete(ctx, k8sTGB); err != nil {
 			return err
 		}
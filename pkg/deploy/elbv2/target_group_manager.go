@@ -168,7 +168,7 @@ func isSDKTargetGroupHealthCheckDrifted(tgSpec elbv2model.TargetGroupSpec, sdkTG
 	if hcConfig.Path != nil && awssdk.StringValue(hcConfig.Path) != awssdk.StringValue(sdkObj.HealthCheckPath) {
 		return true
 	}
-	if hcConfig.Matcher != nil && (sdkObj.Matcher == nil || awssdk.StringValue(hcConfig.Matcher.GRPCCode) != awssdk.StringValue(sdkObj.Matcher.GrpcCode) || awssdk.StringValue(hcConfig.Matcher.HTTPCode) != awssdk.StringValue(sdkObj.Matcher.HttpCode)) {
+	if hcConfig.Matcher != nil && isHealthCheckMatcherSupported(hcConfig.Protocol) && (sdkObj.Matcher == nil || awssdk.StringValue(hcConfig.Matcher.GRPCCode) != awssdk.StringValue(sdkObj.Matcher.GrpcCode) || awssdk.StringValue(hcConfig.Matcher.HTTPCode) != awssdk.StringValue(sdkObj.Matcher.HttpCode)) {
 		return true
 	}
 	if hcConfig.IntervalSeconds != nil && awssdk.Int64Value(hcConfig.IntervalSeconds) != awssdk.Int64Value(sdkObj.HealthCheckIntervalSeconds) {
@@ -203,7 +203,7 @@ func buildSDKCreateTargetGroupInput(tgSpec elbv2model.TargetGroupSpec) *elbv2sdk
 		}
 		sdkObj.HealthCheckProtocol = (*string)(hcConfig.Protocol)
 		sdkObj.HealthCheckPath = hcConfig.Path
-		if tgSpec.HealthCheckConfig.Matcher != nil {
+		if hcConfig.Matcher != nil && isHealthCheckMatcherSupported(hcConfig.Protocol) {
 			sdkObj.Matcher = buildSDKMatcher(*hcConfig.Matcher)
 		}
 		sdkObj.HealthCheckIntervalSeconds = hcConfig.IntervalSeconds
@@ -225,7 +225,7 @@ func buildSDKModifyTargetGroupInput(tgSpec elbv2model.TargetGroupSpec) *elbv2sdk
 		}
 		sdkObj.HealthCheckProtocol = (*string)(hcConfig.Protocol)
 		sdkObj.HealthCheckPath = hcConfig.Path
-		if tgSpec.HealthCheckConfig.Matcher != nil {
+		if hcConfig.Matcher != nil && isHealthCheckMatcherSupported(hcConfig.Protocol) {
 			sdkObj.Matcher = buildSDKMatcher(*hcConfig.Matcher)
 		}
 		sdkObj.HealthCheckIntervalSeconds = hcConfig.IntervalSeconds
@@ -236,6 +236,16 @@ func buildSDKModifyTargetGroupInput(tgSpec elbv2model.TargetGroupSpec) *elbv2sdk
 	return sdkObj
 }
 
+// isHealthCheckMatcherSupported checks whether success codes matcher is supported by health check protocol.
+// matcher is only sent with HTTP/HTTPS health checks, so that switching health check protocol to TCP doesn't carry over
+// the stale matcher, which is rejected by ELBV2. unspecified protocol is left for ELBV2 to determine.
+func isHealthCheckMatcherSupported(protocol *elbv2model.Protocol) bool {
+	if protocol == nil {
+		return true
+	}
+	return *protocol == elbv2model.ProtocolHTTP || *protocol == elbv2model.ProtocolHTTPS
+}
+
 func buildSDKMatcher(modelMatcher elbv2model.HealthCheckMatcher) *elbv2sdk.Matcher {
 	return &elbv2sdk.Matcher{
 		GrpcCode: modelMatcher.GRPCCode,
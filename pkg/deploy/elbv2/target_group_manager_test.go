@@ -1,13 +1,19 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -639,3 +645,210 @@ func Test_isTargetGroupResourceInUseError(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultTargetGroupManager_updateSDKTargetGroupWithTags(t *testing.T) {
+	type addTagsWithContextCall struct {
+		req *elbv2sdk.AddTagsInput
+	}
+	type removeTagsWithContextCall struct {
+		req *elbv2sdk.RemoveTagsInput
+	}
+	type fields struct {
+		externalManagedTags []string
+	}
+	type args struct {
+		desiredTags map[string]string
+		currentTags map[string]string
+	}
+	tests := []struct {
+		name                       string
+		fields                     fields
+		args                       args
+		addTagsWithContextCalls    []addTagsWithContextCall
+		removeTagsWithContextCalls []removeTagsWithContextCall
+	}{
+		{
+			name: "tags in sync",
+			args: args{
+				desiredTags: map[string]string{"team": "a"},
+				currentTags: map[string]string{
+					"elbv2.k8s.aws/cluster":    "cluster-name",
+					"ingress.k8s.aws/stack":    "namespace/name",
+					"ingress.k8s.aws/resource": "namespace/name-svc:80",
+					"team":                     "a",
+				},
+			},
+		},
+		{
+			name: "drifted tags are reconciled, while legacy and external managed tags are left untouched",
+			fields: fields{
+				externalManagedTags: []string{"owner"},
+			},
+			args: args{
+				desiredTags: map[string]string{"team": "a", "cost-center": "1234"},
+				currentTags: map[string]string{
+					"elbv2.k8s.aws/cluster":      "cluster-name",
+					"ingress.k8s.aws/stack":      "namespace/name",
+					"kubernetes.io/service-name": "svc",
+					"owner":                      "someone",
+					"team":                       "b",
+					"stale":                      "v",
+				},
+			},
+			addTagsWithContextCalls: []addTagsWithContextCall{
+				{
+					req: &elbv2sdk.AddTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"tg-arn"}),
+						Tags: []*elbv2sdk.Tag{
+							{Key: awssdk.String("cost-center"), Value: awssdk.String("1234")},
+							{Key: awssdk.String("ingress.k8s.aws/resource"), Value: awssdk.String("namespace/name-svc:80")},
+							{Key: awssdk.String("team"), Value: awssdk.String("a")},
+						},
+					},
+				},
+			},
+			removeTagsWithContextCalls: []removeTagsWithContextCall{
+				{
+					req: &elbv2sdk.RemoveTagsInput{
+						ResourceArns: awssdk.StringSlice([]string{"tg-arn"}),
+						TagKeys:      awssdk.StringSlice([]string{"stale"}),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.addTagsWithContextCalls {
+				elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.AddTagsOutput{}, nil)
+			}
+			for _, call := range tt.removeTagsWithContextCalls {
+				elbv2Client.EXPECT().RemoveTagsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.RemoveTagsOutput{}, nil)
+			}
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			taggingManager := NewDefaultTaggingManager(elbv2Client, &log.NullLogger{})
+			m := NewDefaultTargetGroupManager(elbv2Client, trackingProvider, taggingManager, "vpc-id", tt.fields.externalManagedTags, &log.NullLogger{})
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resTG := elbv2model.NewTargetGroup(stack, "namespace/name-svc:80", elbv2model.TargetGroupSpec{
+				Name: "k8s-tg",
+				Tags: tt.args.desiredTags,
+			})
+			sdkTG := TargetGroupWithTags{
+				TargetGroup: &elbv2sdk.TargetGroup{
+					TargetGroupArn: awssdk.String("tg-arn"),
+				},
+				Tags: tt.args.currentTags,
+			}
+			err := m.updateSDKTargetGroupWithTags(context.Background(), resTG, sdkTG)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_defaultTargetGroupManager_updateSDKTargetGroupWithHealthCheck(t *testing.T) {
+	port9090 := intstr.FromInt(9090)
+	protocolHTTP := elbv2model.ProtocolHTTP
+	protocolTCP := elbv2model.ProtocolTCP
+	type args struct {
+		hcConfig *elbv2model.TargetGroupHealthCheckConfig
+		sdkTG    *elbv2sdk.TargetGroup
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantReq *elbv2sdk.ModifyTargetGroupInput
+	}{
+		{
+			name: "health check protocol toggled from TCP to HTTP adds matcher",
+			args: args{
+				hcConfig: &elbv2model.TargetGroupHealthCheckConfig{
+					Port:     &port9090,
+					Protocol: &protocolHTTP,
+					Path:     awssdk.String("/healthz"),
+					Matcher:  &elbv2model.HealthCheckMatcher{HTTPCode: awssdk.String("200-399")},
+				},
+				sdkTG: &elbv2sdk.TargetGroup{
+					TargetGroupArn:      awssdk.String("tg-arn"),
+					HealthCheckPort:     awssdk.String("9090"),
+					HealthCheckProtocol: awssdk.String("TCP"),
+				},
+			},
+			wantReq: &elbv2sdk.ModifyTargetGroupInput{
+				TargetGroupArn:      awssdk.String("tg-arn"),
+				HealthCheckEnabled:  awssdk.Bool(true),
+				HealthCheckPort:     awssdk.String("9090"),
+				HealthCheckProtocol: awssdk.String("HTTP"),
+				HealthCheckPath:     awssdk.String("/healthz"),
+				Matcher:             &elbv2sdk.Matcher{HttpCode: awssdk.String("200-399")},
+			},
+		},
+		{
+			name: "health check protocol toggled from HTTP to TCP drops matcher",
+			args: args{
+				hcConfig: &elbv2model.TargetGroupHealthCheckConfig{
+					Port:     &port9090,
+					Protocol: &protocolTCP,
+					Matcher:  &elbv2model.HealthCheckMatcher{HTTPCode: awssdk.String("200-399")},
+				},
+				sdkTG: &elbv2sdk.TargetGroup{
+					TargetGroupArn:      awssdk.String("tg-arn"),
+					HealthCheckPort:     awssdk.String("9090"),
+					HealthCheckProtocol: awssdk.String("HTTP"),
+					HealthCheckPath:     awssdk.String("/healthz"),
+					Matcher:             &elbv2sdk.Matcher{HttpCode: awssdk.String("200-399")},
+				},
+			},
+			wantReq: &elbv2sdk.ModifyTargetGroupInput{
+				TargetGroupArn:      awssdk.String("tg-arn"),
+				HealthCheckEnabled:  awssdk.Bool(true),
+				HealthCheckPort:     awssdk.String("9090"),
+				HealthCheckProtocol: awssdk.String("TCP"),
+			},
+		},
+		{
+			name: "stale matcher on TCP health check isn't drifted",
+			args: args{
+				hcConfig: &elbv2model.TargetGroupHealthCheckConfig{
+					Port:     &port9090,
+					Protocol: &protocolTCP,
+					Matcher:  &elbv2model.HealthCheckMatcher{HTTPCode: awssdk.String("200")},
+				},
+				sdkTG: &elbv2sdk.TargetGroup{
+					TargetGroupArn:      awssdk.String("tg-arn"),
+					HealthCheckPort:     awssdk.String("9090"),
+					HealthCheckProtocol: awssdk.String("TCP"),
+					Matcher:             &elbv2sdk.Matcher{HttpCode: awssdk.String("200-399")},
+				},
+			},
+			wantReq: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			if tt.wantReq != nil {
+				elbv2Client.EXPECT().ModifyTargetGroupWithContext(gomock.Any(), tt.wantReq).Return(&elbv2sdk.ModifyTargetGroupOutput{}, nil)
+			}
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			taggingManager := NewDefaultTaggingManager(elbv2Client, &log.NullLogger{})
+			m := NewDefaultTargetGroupManager(elbv2Client, trackingProvider, taggingManager, "vpc-id", nil, &log.NullLogger{})
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resTG := elbv2model.NewTargetGroup(stack, "namespace/name-svc:80", elbv2model.TargetGroupSpec{
+				Name:              "k8s-tg",
+				Protocol:          elbv2model.ProtocolTCP,
+				HealthCheckConfig: tt.args.hcConfig,
+			})
+			err := m.updateSDKTargetGroupWithHealthCheck(context.Background(), resTG, TargetGroupWithTags{TargetGroup: tt.args.sdkTG})
+			assert.NoError(t, err)
+		})
+	}
+}
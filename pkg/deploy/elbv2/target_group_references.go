@@ -0,0 +1,24 @@
+package elbv2
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// collectSDKActionsTargetGroupARNs collects ARNs of targetGroups referenced by sdk actions into tgARNs.
+func collectSDKActionsTargetGroupARNs(actions []*elbv2sdk.Action, tgARNs sets.String) {
+	for _, action := range actions {
+		if action.TargetGroupArn != nil {
+			tgARNs.Insert(awssdk.StringValue(action.TargetGroupArn))
+		}
+		if action.ForwardConfig == nil {
+			continue
+		}
+		for _, tgTuple := range action.ForwardConfig.TargetGroups {
+			if tgTuple.TargetGroupArn != nil {
+				tgARNs.Insert(awssdk.StringValue(tgTuple.TargetGroupArn))
+			}
+		}
+	}
+}
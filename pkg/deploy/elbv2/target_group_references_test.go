@@ -0,0 +1,85 @@
+package elbv2
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+func forwardActions(resTGs ...*elbv2model.TargetGroup) []elbv2model.Action {
+	var tgTuples []elbv2model.TargetGroupTuple
+	for _, resTG := range resTGs {
+		tgTuples = append(tgTuples, elbv2model.TargetGroupTuple{
+			TargetGroupARN: resTG.TargetGroupARN(),
+		})
+	}
+	return []elbv2model.Action{
+		{
+			Type: elbv2model.ActionTypeForward,
+			ForwardConfig: &elbv2model.ForwardActionConfig{
+				TargetGroups: tgTuples,
+			},
+		},
+	}
+}
+
+func Test_collectSDKActionsTargetGroupARNs(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []*elbv2sdk.Action
+		want    sets.String
+	}{
+		{
+			name: "forward to single targetGroup",
+			actions: []*elbv2sdk.Action{
+				{
+					Type:           awssdk.String("forward"),
+					TargetGroupArn: awssdk.String("tg-1"),
+				},
+			},
+			want: sets.NewString("tg-1"),
+		},
+		{
+			name: "forward to weighted targetGroups",
+			actions: []*elbv2sdk.Action{
+				{
+					Type: awssdk.String("forward"),
+					ForwardConfig: &elbv2sdk.ForwardActionConfig{
+						TargetGroups: []*elbv2sdk.TargetGroupTuple{
+							{
+								TargetGroupArn: awssdk.String("tg-1"),
+							},
+							{
+								TargetGroupArn: awssdk.String("tg-2"),
+							},
+						},
+					},
+				},
+			},
+			want: sets.NewString("tg-1", "tg-2"),
+		},
+		{
+			name: "no forward actions",
+			actions: []*elbv2sdk.Action{
+				{
+					Type: awssdk.String("fixed-response"),
+					FixedResponseConfig: &elbv2sdk.FixedResponseActionConfig{
+						StatusCode: awssdk.String("404"),
+					},
+				},
+			},
+			want: sets.NewString(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sets.NewString()
+			collectSDKActionsTargetGroupARNs(tt.actions, got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,40 +2,54 @@ package elbv2
 
 import (
 	"context"
+
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewTargetGroupSynthesizer constructs targetGroupSynthesizer
-func NewTargetGroupSynthesizer(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	tgManager TargetGroupManager, logger logr.Logger, stack core.Stack) *targetGroupSynthesizer {
+func NewTargetGroupSynthesizer(elbv2Client services.ELBV2, k8sClient client.Client, trackingProvider tracking.Provider, taggingManager TaggingManager,
+	tgManager TargetGroupManager, orphanedTGCleanupEnabled bool, keptListenerTGARNs sets.String, logger logr.Logger, stack core.Stack) *targetGroupSynthesizer {
 	return &targetGroupSynthesizer{
-		elbv2Client:      elbv2Client,
-		trackingProvider: trackingProvider,
-		taggingManager:   taggingManager,
-		tgManager:        tgManager,
-		logger:           logger,
-		stack:            stack,
-		unmatchedSDKTGs:  nil,
+		elbv2Client:              elbv2Client,
+		k8sClient:                k8sClient,
+		trackingProvider:         trackingProvider,
+		taggingManager:           taggingManager,
+		tgManager:                tgManager,
+		orphanedTGCleanupEnabled: orphanedTGCleanupEnabled,
+		keptListenerTGARNs:       keptListenerTGARNs,
+		logger:                   logger,
+		stack:                    stack,
+		unmatchedSDKTGs:          nil,
 	}
 }
 
 // targetGroupSynthesizer is responsible for synthesize TargetGroup resources types for certain stack.
 type targetGroupSynthesizer struct {
-	elbv2Client      services.ELBV2
-	trackingProvider tracking.Provider
-	taggingManager   TaggingManager
-	tgManager        TargetGroupManager
-	logger           logr.Logger
+	elbv2Client              services.ELBV2
+	k8sClient                client.Client
+	trackingProvider         tracking.Provider
+	taggingManager           TaggingManager
+	tgManager                TargetGroupManager
+	orphanedTGCleanupEnabled bool
+	// keptListenerTGARNs contains ARNs of targetGroups still referenced by listeners kept on LoadBalancer, which shouldn't be deleted.
+	keptListenerTGARNs sets.String
+	logger             logr.Logger
 
 	stack           core.Stack
 	unmatchedSDKTGs []TargetGroupWithTags
+	// whether orphaned targetGroups are kept until a later reconcile since their targets are still deregistering.
+	orphanedTGCleanupPending bool
 }
 
 func (s *targetGroupSynthesizer) Synthesize(ctx context.Context) error {
@@ -72,7 +86,61 @@ func (s *targetGroupSynthesizer) Synthesize(ctx context.Context) error {
 }
 
 func (s *targetGroupSynthesizer) PostSynthesize(ctx context.Context) error {
+	var unmatchedSDKTGs []TargetGroupWithTags
 	for _, sdkTG := range s.unmatchedSDKTGs {
+		if s.keptListenerTGARNs.Has(awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn)) {
+			s.logger.V(1).Info("skipping deletion of targetGroup referenced by kept listener",
+				"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn))
+			continue
+		}
+		unmatchedSDKTGs = append(unmatchedSDKTGs, sdkTG)
+	}
+	// unmatched targetGroups are no longer referenced by any listener or listener rule after listener rules are synthesized.
+	// they are orphaned while the LoadBalancer is kept, otherwise there is no traffic to drain and they are deleted right away.
+	if s.orphanedTGCleanupEnabled && stackHasLoadBalancer(s.stack) {
+		return s.cleanupOrphanedSDKTargetGroups(ctx, unmatchedSDKTGs)
+	}
+	for _, sdkTG := range unmatchedSDKTGs {
+		if err := s.tgManager.Delete(ctx, sdkTG); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OrphanedTargetGroupCleanupPending checks whether orphaned targetGroups are left to be deleted by a later reconcile.
+func (s *targetGroupSynthesizer) OrphanedTargetGroupCleanupPending() bool {
+	return s.orphanedTGCleanupPending
+}
+
+// cleanupOrphanedSDKTargetGroups deletes orphaned targetGroups once all their targets are deregistered.
+// targetGroups that are bound by TargetGroupBindings outside of this stack are kept.
+func (s *targetGroupSynthesizer) cleanupOrphanedSDKTargetGroups(ctx context.Context, orphanedSDKTGs []TargetGroupWithTags) error {
+	if len(orphanedSDKTGs) == 0 {
+		return nil
+	}
+	externalBoundTGARNs, err := s.findExternalBoundTargetGroupARNs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sdkTG := range orphanedSDKTGs {
+		tgARN := awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn)
+		if externalBoundTGARNs.Has(tgARN) {
+			s.logger.Info("skipping cleanup of orphaned targetGroup bound by TargetGroupBinding", "arn", tgARN)
+			continue
+		}
+		resp, err := s.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+			TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
+		})
+		if err != nil {
+			return err
+		}
+		if len(resp.TargetHealthDescriptions) != 0 {
+			s.logger.V(1).Info("waiting for targets deregistration of orphaned targetGroup",
+				"arn", tgARN, "targets", len(resp.TargetHealthDescriptions))
+			s.orphanedTGCleanupPending = true
+			continue
+		}
 		if err := s.tgManager.Delete(ctx, sdkTG); err != nil {
 			return err
 		}
@@ -80,6 +148,23 @@ func (s *targetGroupSynthesizer) PostSynthesize(ctx context.Context) error {
 	return nil
 }
 
+// findExternalBoundTargetGroupARNs finds ARNs of targetGroups bound by TargetGroupBindings that don't belong to this stack.
+func (s *targetGroupSynthesizer) findExternalBoundTargetGroupARNs(ctx context.Context) (sets.String, error) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := s.k8sClient.List(ctx, tgbList); err != nil {
+		return nil, err
+	}
+	stackLabels := s.trackingProvider.StackLabels(s.stack)
+	tgARNs := sets.NewString()
+	for _, tgb := range tgbList.Items {
+		if labels.SelectorFromSet(stackLabels).Matches(labels.Set(tgb.Labels)) {
+			continue
+		}
+		tgARNs.Insert(tgb.Spec.TargetGroupARN)
+	}
+	return tgARNs, nil
+}
+
 // findSDKTargetGroups will find all AWS TargetGroups created for stack.
 func (s *targetGroupSynthesizer) findSDKTargetGroups(ctx context.Context) ([]TargetGroupWithTags, error) {
 	stackTags := s.trackingProvider.StackTags(s.stack)
@@ -89,6 +174,13 @@ func (s *targetGroupSynthesizer) findSDKTargetGroups(ctx context.Context) ([]Tar
 		tracking.TagsAsTagFilter(stackTagsLegacy))
 }
 
+// stackHasLoadBalancer checks whether stack contains a LoadBalancer, i.e. the LoadBalancer is kept.
+func stackHasLoadBalancer(stack core.Stack) bool {
+	var resLBs []*elbv2model.LoadBalancer
+	stack.ListResources(&resLBs)
+	return len(resLBs) != 0
+}
+
 type resAndSDKTargetGroupPair struct {
 	resTG *elbv2model.TargetGroup
 	sdkTG TargetGroupWithTags
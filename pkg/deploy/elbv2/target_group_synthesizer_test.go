@@ -1,13 +1,24 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -980,3 +991,174 @@ func Test_isSDKTargetGroupRequiresReplacementDueToNLBHealthCheck(t *testing.T) {
 		})
 	}
 }
+
+func Test_targetGroupSynthesizer_orphanedTargetGroupCleanup(t *testing.T) {
+	type describeTargetHealthCall struct {
+		tgARN   string
+		targets int
+	}
+	tests := []struct {
+		name                      string
+		k8sTGBs                   []*elbv2api.TargetGroupBinding
+		describeTargetHealthCalls []describeTargetHealthCall
+		loadBalancerDeleted       bool
+		wantDeletedTGARNs         []string
+		wantCleanupPending        bool
+	}{
+		{
+			name: "orphaned targetGroup deleted once targets deregistered",
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{tgARN: "arn-2", targets: 0},
+			},
+			wantDeletedTGARNs: []string{"arn-2"},
+		},
+		{
+			name: "orphaned targetGroup kept while targets deregistering",
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{tgARN: "arn-2", targets: 2},
+			},
+			wantCleanupPending: true,
+		},
+		{
+			name: "orphaned targetGroup kept when bound by separate TargetGroupBinding",
+			k8sTGBs: []*elbv2api.TargetGroupBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "user-tgb",
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "arn-2",
+					},
+				},
+			},
+		},
+		{
+			name: "orphaned targetGroup deleted when only bound by TargetGroupBinding of stack",
+			k8sTGBs: []*elbv2api.TargetGroupBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "stack-tgb",
+						Labels: map[string]string{
+							"ingress.k8s.aws/stack-namespace": "namespace",
+							"ingress.k8s.aws/stack-name":      "name",
+						},
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "arn-2",
+					},
+				},
+			},
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{tgARN: "arn-2", targets: 0},
+			},
+			wantDeletedTGARNs: []string{"arn-2"},
+		},
+		{
+			name:                "unmatched targetGroup deleted right away when LoadBalancer is deleted",
+			loadBalancerDeleted: true,
+			wantDeletedTGARNs:   []string{"arn-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ctx := context.Background()
+			k8sSchema := k8sruntime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, tgb := range tt.k8sTGBs {
+				assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+			}
+
+			// the listener rule referencing tg-2 has been removed, thus tg-2 is no longer in stack and orphaned.
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			if !tt.loadBalancerDeleted {
+				lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{Name: "lb"})
+				tg1 := elbv2model.NewTargetGroup(stack, "tg-1", elbv2model.TargetGroupSpec{Name: "tg-1"})
+				elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{
+					LoadBalancerARN: lb.LoadBalancerARN(),
+					Port:            80,
+					DefaultActions:  forwardActions(tg1),
+				})
+			}
+
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			taggingManager := NewMockTaggingManager(mockCtrl)
+			taggingManager.EXPECT().ListTargetGroups(gomock.Any(), gomock.Any()).Return([]TargetGroupWithTags{
+				{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("arn-2"),
+					},
+					Tags: map[string]string{
+						"ingress.k8s.aws/resource": "tg-2",
+					},
+				},
+			}, nil)
+			elbv2Client := services.NewMockELBV2(mockCtrl)
+			for _, call := range tt.describeTargetHealthCalls {
+				resp := &elbv2sdk.DescribeTargetHealthOutput{}
+				for i := 0; i < call.targets; i++ {
+					resp.TargetHealthDescriptions = append(resp.TargetHealthDescriptions, &elbv2sdk.TargetHealthDescription{})
+				}
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+					TargetGroupArn: awssdk.String(call.tgARN),
+				}).Return(resp, nil)
+			}
+			for _, tgARN := range tt.wantDeletedTGARNs {
+				elbv2Client.EXPECT().DeleteTargetGroupWithContext(gomock.Any(), &elbv2sdk.DeleteTargetGroupInput{
+					TargetGroupArn: awssdk.String(tgARN),
+				}).Return(&elbv2sdk.DeleteTargetGroupOutput{}, nil)
+			}
+			tgManager := NewDefaultTargetGroupManager(elbv2Client, trackingProvider, taggingManager, "vpc-1", nil, &log.NullLogger{})
+
+			// tg-1 is still referenced and doesn't exist yet, its creation is out of scope for this test.
+			s := NewTargetGroupSynthesizer(elbv2Client, k8sClient, trackingProvider, taggingManager,
+				&noopCreateTargetGroupManager{TargetGroupManager: tgManager}, true, sets.NewString(), &log.NullLogger{}, stack)
+			assert.NoError(t, s.Synthesize(ctx))
+			assert.NoError(t, s.PostSynthesize(ctx))
+			assert.Equal(t, tt.wantCleanupPending, s.OrphanedTargetGroupCleanupPending())
+		})
+	}
+}
+
+func Test_targetGroupSynthesizer_PostSynthesize_keptListenerTargetGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	elbv2Client := services.NewMockELBV2(mockCtrl)
+	elbv2Client.EXPECT().DeleteTargetGroupWithContext(gomock.Any(), &elbv2sdk.DeleteTargetGroupInput{
+		TargetGroupArn: awssdk.String("arn-2"),
+	}).Return(&elbv2sdk.DeleteTargetGroupOutput{}, nil)
+	trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+	tgManager := NewDefaultTargetGroupManager(elbv2Client, trackingProvider, nil, "vpc-1", nil, &log.NullLogger{})
+
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	s := NewTargetGroupSynthesizer(elbv2Client, nil, trackingProvider, nil, tgManager, false, sets.NewString("arn-1"), &log.NullLogger{}, stack)
+	s.unmatchedSDKTGs = []TargetGroupWithTags{
+		{
+			TargetGroup: &elbv2sdk.TargetGroup{
+				TargetGroupArn: awssdk.String("arn-1"),
+			},
+		},
+		{
+			TargetGroup: &elbv2sdk.TargetGroup{
+				TargetGroupArn: awssdk.String("arn-2"),
+			},
+		},
+	}
+	assert.NoError(t, s.PostSynthesize(context.Background()))
+}
+
+// noopCreateTargetGroupManager is a TargetGroupManager that fulfills TargetGroups without creating them.
+type noopCreateTargetGroupManager struct {
+	TargetGroupManager
+}
+
+func (m *noopCreateTargetGroupManager) Create(_ context.Context, resTG *elbv2model.TargetGroup) (elbv2model.TargetGroupStatus, error) {
+	return elbv2model.TargetGroupStatus{TargetGroupARN: "arn-" + resTG.ID()}, nil
+}
@@ -3,6 +3,7 @@ package deploy
 import (
 	"context"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
@@ -13,15 +14,23 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/wafv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
 )
 
 // StackDeployer will deploy a resource stack into AWS and K8S.
 type StackDeployer interface {
 	// Deploy a resource stack.
+	// RequeueNeededAfter is returned once the whole stack is deployed, when cleanup of orphaned resources is left to a later reconcile.
 	Deploy(ctx context.Context, stack core.Stack) error
 }
 
+const (
+	// requeue interval when orphaned targetGroups are waiting for their targets to be deregistered.
+	orphanedTGCleanupRequeueInterval = 15 * time.Second
+)
+
 // NewDefaultStackDeployer constructs new defaultStackDeployer.
 func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 	networkingSGManager networking.SecurityGroupManager, networkingSGReconciler networking.SecurityGroupReconciler,
@@ -30,6 +39,7 @@ func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 	trackingProvider := tracking.NewDefaultProvider(tagPrefix, config.ClusterName)
 	ec2TaggingManager := ec2.NewDefaultTaggingManager(cloud.EC2(), networkingSGManager, cloud.VpcID(), logger)
 	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(cloud.ELBV2(), logger)
+	elbv2NoHealthyTargetsActionResolver := elbv2.NewDefaultNoHealthyTargetsActionResolver(cloud.ELBV2(), logger)
 
 	return &defaultStackDeployer{
 		cloud:                               cloud,
@@ -37,17 +47,19 @@ func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 		addonsConfig:                        config.AddonsConfig,
 		trackingProvider:                    trackingProvider,
 		ec2TaggingManager:                   ec2TaggingManager,
-		ec2SGManager:                        ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGReconciler, cloud.VpcID(), config.ExternalManagedTags, logger),
+		ec2SGManager:                        ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGReconciler, cloud.VpcID(), config.ExternalManagedTags, config.EnableSGRulePortRangeConsolidation, logger),
 		elbv2TaggingManager:                 elbv2TaggingManager,
 		elbv2LBManager:                      elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, config.ExternalManagedTags, logger),
-		elbv2LSManager:                      elbv2.NewDefaultListenerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, config.ExternalManagedTags, logger),
-		elbv2LRManager:                      elbv2.NewDefaultListenerRuleManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, config.ExternalManagedTags, logger),
+		elbv2LSManager:                      elbv2.NewDefaultListenerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, elbv2NoHealthyTargetsActionResolver, config.ExternalManagedTags, logger),
+		elbv2LRManager:                      elbv2.NewDefaultListenerRuleManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, elbv2NoHealthyTargetsActionResolver, config.ExternalManagedTags, logger),
 		elbv2TGManager:                      elbv2.NewDefaultTargetGroupManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, cloud.VpcID(), config.ExternalManagedTags, logger),
 		elbv2TGBManager:                     elbv2.NewDefaultTargetGroupBindingManager(k8sClient, trackingProvider, logger),
 		wafv2WebACLAssociationManager:       wafv2.NewDefaultWebACLAssociationManager(cloud.WAFv2(), logger),
 		wafRegionalWebACLAssociationManager: wafregional.NewDefaultWebACLAssociationManager(cloud.WAFRegional(), logger),
 		shieldProtectionManager:             shield.NewDefaultProtectionManager(cloud.Shield(), logger),
 		vpcID:                               cloud.VpcID(),
+		orphanedTGCleanupEnabled:            config.EnableOrphanedTargetGroupCleanup,
+		listenerPruningDisabled:             config.DisableListenerPruning,
 		logger:                              logger,
 	}
 }
@@ -72,6 +84,8 @@ type defaultStackDeployer struct {
 	wafRegionalWebACLAssociationManager wafregional.WebACLAssociationManager
 	shieldProtectionManager             shield.ProtectionManager
 	vpcID                               string
+	orphanedTGCleanupEnabled            bool
+	listenerPruningDisabled             bool
 
 	logger logr.Logger
 }
@@ -83,13 +97,16 @@ type ResourceSynthesizer interface {
 
 // Deploy a resource stack.
 func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack) error {
+	// targetGroups referenced by listeners kept on LoadBalancer must be kept together with their TargetGroupBindings.
+	keptListenerTGARNs := sets.NewString()
+	tgSynthesizer := elbv2.NewTargetGroupSynthesizer(d.cloud.ELBV2(), d.k8sClient, d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGManager, d.orphanedTGCleanupEnabled, keptListenerTGARNs, d.logger, stack)
 	synthesizers := []ResourceSynthesizer{
 		ec2.NewSecurityGroupSynthesizer(d.cloud.EC2(), d.trackingProvider, d.ec2TaggingManager, d.ec2SGManager, d.vpcID, d.logger, stack),
-		elbv2.NewTargetGroupSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGManager, d.logger, stack),
+		tgSynthesizer,
 		elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.logger, stack),
-		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LSManager, d.logger, stack),
+		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LSManager, d.listenerPruningDisabled, keptListenerTGARNs, d.logger, stack),
 		elbv2.NewListenerRuleSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LRManager, d.logger, stack),
-		elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TGBManager, d.logger, stack),
+		elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TGBManager, keptListenerTGARNs, d.logger, stack),
 	}
 
 	if d.addonsConfig.WAFV2Enabled {
@@ -116,6 +133,9 @@ func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack) err
 			return err
 		}
 	}
+	if tgSynthesizer.OrphanedTargetGroupCleanupPending() {
+		return runtime.NewRequeueNeededAfter("OrphanedTargetGroupCleanupPending", orphanedTGCleanupRequeueInterval)
+	}
 
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -23,13 +24,15 @@ const (
 	// the domain names for imported certificates will be cached for 5 minute.
 	defaultImportedCertDomainsCacheTTL = 5 * time.Minute
 	// the domain names for private certificates won't change, cache for a longer time.
+	// the notBefore changes upon managed renewal, which is only used for tie-breaking, thus it's fine to be stale for a while.
 	defaultPrivateCertDomainsCacheTTL = 10 * time.Hour
 )
 
 // CertDiscovery is responsible for auto-discover TLS certificates for tls hosts.
 type CertDiscovery interface {
 	// Discover will try to find valid certificateARNs for each tlsHost.
-	Discover(ctx context.Context, tlsHosts []string) ([]string, error)
+	// if multiple certificates match a tlsHost, the one in preferredCertARNs will be chosen.
+	Discover(ctx context.Context, tlsHosts []string, preferredCertARNs []string) ([]string, error)
 }
 
 // NewACMCertDiscovery constructs new acmCertDiscovery
@@ -38,10 +41,10 @@ func NewACMCertDiscovery(acmClient services.ACM, logger logr.Logger) *acmCertDis
 		acmClient: acmClient,
 		logger:    logger,
 
-		loadDomainsByCertARNMutex:   sync.Mutex{},
+		loadCertInfoByARNMutex:      sync.Mutex{},
 		certARNsCache:               cache.NewExpiring(),
 		certARNsCacheTTL:            defaultCertARNsCacheTTL,
-		certDomainsCache:            cache.NewExpiring(),
+		certInfoCache:               cache.NewExpiring(),
 		importedCertDomainsCacheTTL: defaultImportedCertDomainsCacheTTL,
 		privateCertDomainsCacheTTL:  defaultPrivateCertDomainsCacheTTL,
 	}
@@ -54,25 +57,31 @@ type acmCertDiscovery struct {
 	acmClient services.ACM
 	logger    logr.Logger
 
-	// mutex to serialize the call to loadDomainsForAllCertificates
-	loadDomainsByCertARNMutex   sync.Mutex
+	// mutex to serialize the call to loadInfoForAllCertificates
+	loadCertInfoByARNMutex      sync.Mutex
 	certARNsCache               *cache.Expiring
 	certARNsCacheTTL            time.Duration
-	certDomainsCache            *cache.Expiring
+	certInfoCache               *cache.Expiring
 	importedCertDomainsCacheTTL time.Duration
 	privateCertDomainsCacheTTL  time.Duration
 }
 
-func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]string, error) {
-	domainsByCertARN, err := d.loadDomainsForAllCertificates(ctx)
+// certInfo contains the information of an ACM certificate used for discovery.
+type certInfo struct {
+	domains   sets.String
+	notBefore time.Time
+}
+
+func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string, preferredCertARNs []string) ([]string, error) {
+	certInfoByARN, err := d.loadInfoForAllCertificates(ctx)
 	if err != nil {
 		return nil, err
 	}
 	certARNs := sets.NewString()
 	for _, host := range tlsHosts {
 		var certARNsForHost []string
-		for certARN, domains := range domainsByCertARN {
-			for domain := range domains {
+		for certARN, info := range certInfoByARN {
+			for domain := range info.domains {
 				if d.domainMatchesHost(domain, host) {
 					certARNsForHost = append(certARNsForHost, certARN)
 					break
@@ -80,34 +89,62 @@ func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]s
 			}
 		}
 
-		if len(certARNsForHost) > 1 {
-			return nil, errors.Errorf("multiple certificate found for host: %s, certARNs: %v", host, certARNsForHost)
-		}
 		if len(certARNsForHost) == 0 {
 			return nil, errors.Errorf("none certificate found for host: %s", host)
 		}
-		certARNs.Insert(certARNsForHost...)
+		certARN := chooseCertificateARN(certARNsForHost, certInfoByARN, sets.NewString(preferredCertARNs...))
+		if len(certARNsForHost) > 1 {
+			sort.Strings(certARNsForHost)
+			d.logger.Info("multiple certificates found for host",
+				"host", host, "candidates", certARNsForHost, "chosen", certARN)
+		}
+		certARNs.Insert(certARN)
 	}
 	return certARNs.List(), nil
 }
 
-func (d *acmCertDiscovery) loadDomainsForAllCertificates(ctx context.Context) (map[string]sets.String, error) {
-	d.loadDomainsByCertARNMutex.Lock()
-	defer d.loadDomainsByCertARNMutex.Unlock()
+// chooseCertificateARN deterministically chooses a certificate among candidates matching the same host.
+// certificates in preferredCertARNs take priority, then the certificate with the latest notBefore, then the one with the lowest ARN.
+func chooseCertificateARN(candidateCertARNs []string, certInfoByARN map[string]certInfo, preferredCertARNs sets.String) string {
+	chosenCertARN := candidateCertARNs[0]
+	for _, certARN := range candidateCertARNs[1:] {
+		if certARNTakesPriority(certARN, chosenCertARN, certInfoByARN, preferredCertARNs) {
+			chosenCertARN = certARN
+		}
+	}
+	return chosenCertARN
+}
+
+// certARNTakesPriority checks whether certificate lhsCertARN takes priority over rhsCertARN.
+func certARNTakesPriority(lhsCertARN string, rhsCertARN string, certInfoByARN map[string]certInfo, preferredCertARNs sets.String) bool {
+	lhsPreferred, rhsPreferred := preferredCertARNs.Has(lhsCertARN), preferredCertARNs.Has(rhsCertARN)
+	if lhsPreferred != rhsPreferred {
+		return lhsPreferred
+	}
+	lhsNotBefore, rhsNotBefore := certInfoByARN[lhsCertARN].notBefore, certInfoByARN[rhsCertARN].notBefore
+	if !lhsNotBefore.Equal(rhsNotBefore) {
+		return lhsNotBefore.After(rhsNotBefore)
+	}
+	return lhsCertARN < rhsCertARN
+}
+
+func (d *acmCertDiscovery) loadInfoForAllCertificates(ctx context.Context) (map[string]certInfo, error) {
+	d.loadCertInfoByARNMutex.Lock()
+	defer d.loadCertInfoByARNMutex.Unlock()
 
 	certARNs, err := d.loadAllCertificateARNs(ctx)
 	if err != nil {
 		return nil, err
 	}
-	domainsByCertARN := make(map[string]sets.String, len(certARNs))
+	certInfoByARN := make(map[string]certInfo, len(certARNs))
 	for _, certARN := range certARNs {
-		certDomains, err := d.loadDomainsForCertificate(ctx, certARN)
+		info, err := d.loadInfoForCertificate(ctx, certARN)
 		if err != nil {
 			return nil, err
 		}
-		domainsByCertARN[certARN] = certDomains
+		certInfoByARN[certARN] = info
 	}
-	return domainsByCertARN, nil
+	return certInfoByARN, nil
 }
 
 func (d *acmCertDiscovery) loadAllCertificateARNs(ctx context.Context) ([]string, error) {
@@ -131,26 +168,29 @@ func (d *acmCertDiscovery) loadAllCertificateARNs(ctx context.Context) ([]string
 	return certARNs, nil
 }
 
-func (d *acmCertDiscovery) loadDomainsForCertificate(ctx context.Context, certARN string) (sets.String, error) {
-	if rawCacheItem, ok := d.certDomainsCache.Get(certARN); ok {
-		return rawCacheItem.(sets.String), nil
+func (d *acmCertDiscovery) loadInfoForCertificate(ctx context.Context, certARN string) (certInfo, error) {
+	if rawCacheItem, ok := d.certInfoCache.Get(certARN); ok {
+		return rawCacheItem.(certInfo), nil
 	}
 	req := &acm.DescribeCertificateInput{
 		CertificateArn: aws.String(certARN),
 	}
 	resp, err := d.acmClient.DescribeCertificateWithContext(ctx, req)
 	if err != nil {
-		return nil, err
+		return certInfo{}, err
 	}
 	certDetail := resp.Certificate
-	domains := sets.NewString(aws.StringValueSlice(certDetail.SubjectAlternativeNames)...)
+	info := certInfo{
+		domains:   sets.NewString(aws.StringValueSlice(certDetail.SubjectAlternativeNames)...),
+		notBefore: aws.TimeValue(certDetail.NotBefore),
+	}
 	switch aws.StringValue(certDetail.Type) {
 	case acm.CertificateTypeImported:
-		d.certDomainsCache.Set(certARN, domains, d.importedCertDomainsCacheTTL)
+		d.certInfoCache.Set(certARN, info, d.importedCertDomainsCacheTTL)
 	case acm.CertificateTypeAmazonIssued, acm.CertificateTypePrivate:
-		d.certDomainsCache.Set(certARN, domains, d.privateCertDomainsCacheTTL)
+		d.certInfoCache.Set(certARN, info, d.privateCertDomainsCacheTTL)
 	}
-	return domains, nil
+	return info, nil
 }
 
 func (d *acmCertDiscovery) domainMatchesHost(domainName string, tlsHost string) bool {
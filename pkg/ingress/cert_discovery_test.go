@@ -1,8 +1,16 @@
 package ingress
 
 import (
+	"context"
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_acmCertDiscovery_domainMatchesHost(t *testing.T) {
@@ -64,3 +72,128 @@ func Test_acmCertDiscovery_domainMatchesHost(t *testing.T) {
 		})
 	}
 }
+
+func Test_acmCertDiscovery_Discover(t *testing.T) {
+	type describeCertificateCall struct {
+		req  *acm.DescribeCertificateInput
+		resp *acm.DescribeCertificateOutput
+	}
+	type args struct {
+		tlsHosts          []string
+		preferredCertARNs []string
+	}
+	certSummaries := []*acm.CertificateSummary{
+		{CertificateArn: awssdk.String("arn:aws:acm:us-west-2:xxxxx:certificate/cert-b")},
+		{CertificateArn: awssdk.String("arn:aws:acm:us-west-2:xxxxx:certificate/cert-a")},
+		{CertificateArn: awssdk.String("arn:aws:acm:us-west-2:xxxxx:certificate/cert-c")},
+	}
+	newDescribeCertificateCall := func(certARN string, notBefore time.Time, domains ...string) describeCertificateCall {
+		return describeCertificateCall{
+			req: &acm.DescribeCertificateInput{
+				CertificateArn: awssdk.String(certARN),
+			},
+			resp: &acm.DescribeCertificateOutput{
+				Certificate: &acm.CertificateDetail{
+					CertificateArn:          awssdk.String(certARN),
+					SubjectAlternativeNames: awssdk.StringSlice(domains),
+					NotBefore:               awssdk.Time(notBefore),
+					Type:                    awssdk.String(acm.CertificateTypeAmazonIssued),
+				},
+			},
+		}
+	}
+	earlier := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                     string
+		describeCertificateCalls []describeCertificateCall
+		args                     args
+		want                     []string
+		wantErr                  error
+	}{
+		{
+			name: "single certificate matches host",
+			describeCertificateCalls: []describeCertificateCall{
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-a", earlier, "www.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-b", later, "*.example.org"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-c", later, "example.com"),
+			},
+			args: args{
+				tlsHosts: []string{"www.example.com"},
+			},
+			want: []string{"arn:aws:acm:us-west-2:xxxxx:certificate/cert-a"},
+		},
+		{
+			name: "multiple certificates match host, choose the one with latest notBefore",
+			describeCertificateCalls: []describeCertificateCall{
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-a", earlier, "www.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-b", later, "*.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-c", later, "example.com"),
+			},
+			args: args{
+				tlsHosts: []string{"www.example.com"},
+			},
+			want: []string{"arn:aws:acm:us-west-2:xxxxx:certificate/cert-b"},
+		},
+		{
+			name: "multiple certificates match host with same notBefore, choose the one with lowest ARN",
+			describeCertificateCalls: []describeCertificateCall{
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-a", later, "www.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-b", later, "*.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-c", later, "example.com"),
+			},
+			args: args{
+				tlsHosts: []string{"www.example.com"},
+			},
+			want: []string{"arn:aws:acm:us-west-2:xxxxx:certificate/cert-a"},
+		},
+		{
+			name: "multiple certificates match host, choose the preferred one",
+			describeCertificateCalls: []describeCertificateCall{
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-a", earlier, "www.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-b", later, "*.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-c", later, "example.com"),
+			},
+			args: args{
+				tlsHosts:          []string{"www.example.com", "example.com"},
+				preferredCertARNs: []string{"arn:aws:acm:us-west-2:xxxxx:certificate/cert-a"},
+			},
+			want: []string{
+				"arn:aws:acm:us-west-2:xxxxx:certificate/cert-a",
+				"arn:aws:acm:us-west-2:xxxxx:certificate/cert-c",
+			},
+		},
+		{
+			name: "no certificate matches host",
+			describeCertificateCalls: []describeCertificateCall{
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-a", earlier, "www.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-b", later, "*.example.com"),
+				newDescribeCertificateCall("arn:aws:acm:us-west-2:xxxxx:certificate/cert-c", later, "example.com"),
+			},
+			args: args{
+				tlsHosts: []string{"www.example.org"},
+			},
+			wantErr: errors.New("none certificate found for host: www.example.org"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			acmClient := services.NewMockACM(ctrl)
+			acmClient.EXPECT().ListCertificatesAsList(gomock.Any(), gomock.Any()).Return(certSummaries, nil)
+			for _, call := range tt.describeCertificateCalls {
+				acmClient.EXPECT().DescribeCertificateWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			d := NewACMCertDiscovery(acmClient, &log.NullLogger{})
+			got, err := d.Discover(context.Background(), tt.args.tlsHosts, tt.args.preferredCertARNs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
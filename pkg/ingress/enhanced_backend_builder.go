@@ -11,8 +11,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -79,14 +81,17 @@ type EnhancedBackendBuilder interface {
 }
 
 // NewDefaultEnhancedBackendBuilder constructs new defaultEnhancedBackendBuilder.
-func NewDefaultEnhancedBackendBuilder(k8sClient client.Client, annotationParser annotations.Parser, authConfigBuilder AuthConfigBuilder) *defaultEnhancedBackendBuilder {
+func NewDefaultEnhancedBackendBuilder(k8sClient client.Client, eventRecorder record.EventRecorder, annotationParser annotations.Parser,
+	authConfigBuilder AuthConfigBuilder, skipNonExistentBackendServices bool) *defaultEnhancedBackendBuilder {
 	return &defaultEnhancedBackendBuilder{
 		k8sClient:         k8sClient,
+		eventRecorder:     eventRecorder,
 		annotationParser:  annotationParser,
 		authConfigBuilder: authConfigBuilder,
 
 		tolerateNonExistentBackendService: defaultTolerateNonExistentBackendAction,
 		tolerateNonExistentBackendAction:  defaultTolerateNonExistentBackendService,
+		skipNonExistentBackendServices:    skipNonExistentBackendServices,
 	}
 }
 
@@ -95,6 +100,7 @@ var _ EnhancedBackendBuilder = &defaultEnhancedBackendBuilder{}
 // default implementation for defaultEnhancedBackendBuilder
 type defaultEnhancedBackendBuilder struct {
 	k8sClient         client.Client
+	eventRecorder     record.EventRecorder
 	annotationParser  annotations.Parser
 	authConfigBuilder AuthConfigBuilder
 
@@ -104,6 +110,9 @@ type defaultEnhancedBackendBuilder struct {
 	// whether to tolerate misconfiguration that used a non-existent backend action.
 	// when tolerate, If the backend action annotation is non-existent, a fixed 503 response will be used instead.
 	tolerateNonExistentBackendAction bool
+	// whether to skip non-existent backend services when forward to multiple target groups.
+	// when skip, the non-existent backend services are removed from the forward action, and a fixed 503 response will be used if none remains.
+	skipNonExistentBackendServices bool
 }
 
 func (b *defaultEnhancedBackendBuilder) Build(ctx context.Context, ing *networking.Ingress, backend networking.IngressBackend, opts ...EnhancedBackendBuildOption) (EnhancedBackend, error) {
@@ -131,9 +140,14 @@ func (b *defaultEnhancedBackendBuilder) Build(ctx context.Context, ing *networki
 
 	var authCfg AuthConfig
 	if buildOpts.LoadBackendServices {
-		if err := b.loadBackendServices(ctx, &action, ing.Namespace, buildOpts.BackendServices); err != nil {
+		skippedSvcKeys, err := b.loadBackendServices(ctx, &action, ing.Namespace, buildOpts.BackendServices)
+		if err != nil {
 			return EnhancedBackend{}, err
 		}
+		for _, svcKey := range skippedSvcKeys {
+			b.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonBackendNotFound,
+				fmt.Sprintf("skipped non-existent backend service %v", svcKey))
+		}
 
 		if buildOpts.LoadAuthConfig {
 			authCfg, err = b.buildAuthConfig(ctx, action, ing.Namespace, ing.Annotations, buildOpts.BackendServices)
@@ -235,35 +249,66 @@ func (b *defaultEnhancedBackendBuilder) normalizeServicePortForBackwardsCompatib
 
 // loadBackendServices will load referenced backend services into backendServices.
 // when tolerateNonExistentBackendService==true, and forward to a single non-existent Kubernetes Service, a fixed 503 response instead.
+// when skipNonExistentBackendServices==true, non-existent Kubernetes Services are removed from action and returned as skipped.
 func (b *defaultEnhancedBackendBuilder) loadBackendServices(ctx context.Context, action *Action, namespace string,
-	backendServices map[types.NamespacedName]*corev1.Service) error {
-	if action.Type == ActionTypeForward && action.ForwardConfig != nil {
-		svcNames := sets.NewString()
-		for _, tgt := range action.ForwardConfig.TargetGroups {
-			if tgt.ServiceName != nil {
-				svcNames.Insert(awssdk.StringValue(tgt.ServiceName))
-			}
+	backendServices map[types.NamespacedName]*corev1.Service) ([]types.NamespacedName, error) {
+	if action.Type != ActionTypeForward || action.ForwardConfig == nil {
+		return nil, nil
+	}
+	svcNames := sets.NewString()
+	for _, tgt := range action.ForwardConfig.TargetGroups {
+		if tgt.ServiceName != nil {
+			svcNames.Insert(awssdk.StringValue(tgt.ServiceName))
 		}
-		forwardToSingleSvc := (len(action.ForwardConfig.TargetGroups) == 1) && (svcNames.Len() == 1)
-		tolerateNonExistentBackendService := b.tolerateNonExistentBackendService && forwardToSingleSvc
-		for svcName := range svcNames {
-			svcKey := types.NamespacedName{Namespace: namespace, Name: svcName}
-			if _, ok := backendServices[svcKey]; ok {
+	}
+	forwardToSingleSvc := (len(action.ForwardConfig.TargetGroups) == 1) && (svcNames.Len() == 1)
+	tolerateNonExistentBackendService := b.tolerateNonExistentBackendService && forwardToSingleSvc
+	var skippedSvcKeys []types.NamespacedName
+	for _, svcName := range svcNames.List() {
+		svcKey := types.NamespacedName{Namespace: namespace, Name: svcName}
+		if _, ok := backendServices[svcKey]; ok {
+			continue
+		}
+
+		svc := &corev1.Service{}
+		if err := b.k8sClient.Get(ctx, svcKey, svc); err != nil {
+			if apierrors.IsNotFound(err) && tolerateNonExistentBackendService {
+				*action = b.build503ResponseAction(nonExistentBackendServiceMessageBody)
+				return nil, nil
+			}
+			if apierrors.IsNotFound(err) && b.skipNonExistentBackendServices {
+				skippedSvcKeys = append(skippedSvcKeys, svcKey)
 				continue
 			}
+			return nil, err
+		}
+		backendServices[svcKey] = svc
+	}
+	if len(skippedSvcKeys) != 0 {
+		b.removeSkippedBackendServices(action, skippedSvcKeys)
+	}
+	return skippedSvcKeys, nil
+}
 
-			svc := &corev1.Service{}
-			if err := b.k8sClient.Get(ctx, svcKey, svc); err != nil {
-				if apierrors.IsNotFound(err) && tolerateNonExistentBackendService {
-					*action = b.build503ResponseAction(nonExistentBackendServiceMessageBody)
-					return nil
-				}
-				return err
-			}
-			backendServices[svcKey] = svc
+// removeSkippedBackendServices removes target groups referencing skipped Kubernetes Services from forward action.
+// if no target group remains, a fixed 503 response will be used instead.
+func (b *defaultEnhancedBackendBuilder) removeSkippedBackendServices(action *Action, skippedSvcKeys []types.NamespacedName) {
+	skippedSvcNames := sets.NewString()
+	for _, svcKey := range skippedSvcKeys {
+		skippedSvcNames.Insert(svcKey.Name)
+	}
+	var remainingTGTs []TargetGroupTuple
+	for _, tgt := range action.ForwardConfig.TargetGroups {
+		if tgt.ServiceName != nil && skippedSvcNames.Has(awssdk.StringValue(tgt.ServiceName)) {
+			continue
 		}
+		remainingTGTs = append(remainingTGTs, tgt)
+	}
+	if len(remainingTGTs) == 0 {
+		*action = b.build503ResponseAction(nonExistentBackendServiceMessageBody)
+		return
 	}
-	return nil
+	action.ForwardConfig.TargetGroups = remainingTGTs
 }
 
 func (b *defaultEnhancedBackendBuilder) buildAuthConfig(ctx context.Context, action Action, namespace string, ingAnnotation map[string]string, backendServices map[types.NamespacedName]*corev1.Service) (AuthConfig, error) {
@@ -1268,6 +1268,7 @@ func Test_defaultEnhancedBackendBuilder_loadBackendServices(t *testing.T) {
 	}
 	type fields struct {
 		tolerateNonExistentBackendService bool
+		skipNonExistentBackendServices    bool
 	}
 	type args struct {
 		action          *Action
@@ -1281,6 +1282,7 @@ func Test_defaultEnhancedBackendBuilder_loadBackendServices(t *testing.T) {
 		args                args
 		wantAction          Action
 		wantBackendServices map[types.NamespacedName]*corev1.Service
+		wantSkippedSvcKeys  []types.NamespacedName
 		wantErr             error
 	}{
 		{
@@ -1504,6 +1506,94 @@ func Test_defaultEnhancedBackendBuilder_loadBackendServices(t *testing.T) {
 			},
 			wantErr: errors.New("services \"svc-2\" not found"),
 		},
+		{
+			name: "forward to multiple services, one of them is non-existent - skipNonExistentBackendServices == true",
+			env: env{
+				svcs: []*corev1.Service{svc1},
+			},
+			fields: fields{
+				tolerateNonExistentBackendService: true,
+				skipNonExistentBackendServices:    true,
+			},
+			args: args{
+				action: &Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName: awssdk.String("svc-1"),
+								ServicePort: &port80,
+							},
+							{
+								ServiceName: awssdk.String("svc-2"),
+								ServicePort: &port80,
+							},
+						},
+					},
+				},
+				namespace:       "awesome-ns",
+				backendServices: map[types.NamespacedName]*corev1.Service{},
+			},
+			wantAction: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							ServiceName: awssdk.String("svc-1"),
+							ServicePort: &port80,
+						},
+					},
+				},
+			},
+			wantBackendServices: map[types.NamespacedName]*corev1.Service{
+				types.NamespacedName{Namespace: "awesome-ns", Name: "svc-1"}: svc1,
+			},
+			wantSkippedSvcKeys: []types.NamespacedName{
+				{Namespace: "awesome-ns", Name: "svc-2"},
+			},
+		},
+		{
+			name: "forward to multiple services, all of them are non-existent - skipNonExistentBackendServices == true",
+			env: env{
+				svcs: []*corev1.Service{},
+			},
+			fields: fields{
+				tolerateNonExistentBackendService: true,
+				skipNonExistentBackendServices:    true,
+			},
+			args: args{
+				action: &Action{
+					Type: ActionTypeForward,
+					ForwardConfig: &ForwardActionConfig{
+						TargetGroups: []TargetGroupTuple{
+							{
+								ServiceName: awssdk.String("svc-1"),
+								ServicePort: &port80,
+							},
+							{
+								ServiceName: awssdk.String("svc-2"),
+								ServicePort: &port80,
+							},
+						},
+					},
+				},
+				namespace:       "awesome-ns",
+				backendServices: map[types.NamespacedName]*corev1.Service{},
+			},
+			wantAction: Action{
+				Type: ActionTypeFixedResponse,
+				FixedResponseConfig: &FixedResponseActionConfig{
+					ContentType: awssdk.String("text/plain"),
+					StatusCode:  "503",
+					MessageBody: awssdk.String(nonExistentBackendServiceMessageBody),
+				},
+			},
+			wantBackendServices: map[types.NamespacedName]*corev1.Service{},
+			wantSkippedSvcKeys: []types.NamespacedName{
+				{Namespace: "awesome-ns", Name: "svc-1"},
+				{Namespace: "awesome-ns", Name: "svc-2"},
+			},
+		},
 		{
 			name: "load for fixed response action is noop",
 			fields: fields{
@@ -1543,13 +1633,15 @@ func Test_defaultEnhancedBackendBuilder_loadBackendServices(t *testing.T) {
 			b := &defaultEnhancedBackendBuilder{
 				k8sClient:                         k8sClient,
 				tolerateNonExistentBackendService: tt.fields.tolerateNonExistentBackendService,
+				skipNonExistentBackendServices:    tt.fields.skipNonExistentBackendServices,
 			}
-			err := b.loadBackendServices(ctx, tt.args.action, tt.args.namespace, tt.args.backendServices)
+			skippedSvcKeys, err := b.loadBackendServices(ctx, tt.args.action, tt.args.namespace, tt.args.backendServices)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.wantAction, *tt.args.action)
+				assert.Equal(t, tt.wantSkippedSvcKeys, skippedSvcKeys)
 				opt := equality.IgnoreFakeClientPopulatedFields()
 				assert.True(t, cmp.Equal(tt.wantBackendServices, tt.args.backendServices, opt),
 					"diff: %v", cmp.Diff(tt.wantBackendServices, tt.args.backendServices, opt))
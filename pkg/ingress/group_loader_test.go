@@ -1918,6 +1918,149 @@ func Test_defaultGroupLoader_classifyIngress(t *testing.T) {
 			},
 			wantIngressClassMatches: false,
 		},
+		{
+			name: "class specified via annotation - matches, default ingress class claimed",
+			fields: fields{
+				ingressClass:                       "alb",
+				manageIngressesWithoutIngressClass: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+					},
+				},
+				IngClassConfig: ClassConfiguration{},
+			},
+			wantIngressClassMatches: true,
+		},
+		{
+			name: "class specified via annotation - mismatches, default ingress class claimed",
+			fields: fields{
+				ingressClass:                       "alb",
+				manageIngressesWithoutIngressClass: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "nginx",
+						},
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "nginx",
+						},
+					},
+				},
+				IngClassConfig: ClassConfiguration{},
+			},
+			wantIngressClassMatches: false,
+		},
+		{
+			name: "class specified via ingressClassName - mismatches, default ingress class claimed",
+			env: env{
+				ingClassList: []*networking.IngressClass{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "nginx",
+						},
+						Spec: networking.IngressClassSpec{
+							Controller: "k8s.io/ingress-nginx",
+						},
+					},
+				},
+			},
+			fields: fields{
+				ingressClass:                       "alb",
+				manageIngressesWithoutIngressClass: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "ing-ns",
+						Name:        "ing-name",
+						Annotations: map[string]string{},
+					},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("nginx"),
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "ing-ns",
+						Name:        "ing-name",
+						Annotations: map[string]string{},
+					},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("nginx"),
+					},
+				},
+				IngClassConfig: ClassConfiguration{
+					IngClass: &networking.IngressClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "nginx",
+						},
+						Spec: networking.IngressClassSpec{
+							Controller: "k8s.io/ingress-nginx",
+						},
+					},
+				},
+			},
+			wantIngressClassMatches: false,
+		},
+		{
+			name: "no class specified - default ingress class claimed",
+			fields: fields{
+				ingressClass:                       "alb",
+				manageIngressesWithoutIngressClass: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "ing-ns",
+						Name:        "ing-name",
+						Annotations: map[string]string{},
+					},
+				},
+			},
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "ing-ns",
+						Name:        "ing-name",
+						Annotations: map[string]string{},
+					},
+				},
+				IngClassConfig: ClassConfiguration{},
+			},
+			wantIngressClassMatches: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
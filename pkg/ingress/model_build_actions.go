@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"strings"
@@ -130,15 +131,38 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing Clas
 		}
 	}
 
+	noHealthyTargetsFixedResponseCfg, err := t.buildNoHealthyTargetsFixedResponseConfig(ctx, ing)
+	if err != nil {
+		return elbv2model.Action{}, err
+	}
+
 	return elbv2model.Action{
 		Type: elbv2model.ActionTypeForward,
 		ForwardConfig: &elbv2model.ForwardActionConfig{
-			TargetGroups:                targetGroupTuples,
-			TargetGroupStickinessConfig: stickinessCfg,
+			TargetGroups:                        targetGroupTuples,
+			TargetGroupStickinessConfig:         stickinessCfg,
+			NoHealthyTargetsFixedResponseConfig: noHealthyTargetsFixedResponseCfg,
 		},
 	}, nil
 }
 
+// buildNoHealthyTargetsFixedResponseConfig builds the fixed response served instead of forward actions of Ingress,
+// while none of the forwarded targetGroups has healthy targets.
+func (t *defaultModelBuildTask) buildNoHealthyTargetsFixedResponseConfig(_ context.Context, ing ClassifiedIngress) (*elbv2model.FixedResponseActionConfig, error) {
+	rawEnabled := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixNoHealthyTargetsFixedResponse, &rawEnabled, ing.Ing.Annotations); err != nil {
+		return nil, err
+	}
+	if !rawEnabled {
+		return nil, nil
+	}
+	return &elbv2model.FixedResponseActionConfig{
+		ContentType: awssdk.String("text/plain"),
+		MessageBody: awssdk.String("503 Service Unavailable"),
+		StatusCode:  "503",
+	}, nil
+}
+
 func (t *defaultModelBuildTask) buildAuthenticateCognitoAction(_ context.Context, authCfg AuthConfig) (elbv2model.Action, error) {
 	if authCfg.IDPConfigCognito == nil {
 		return elbv2model.Action{}, errors.New("missing IDPConfigCognito")
@@ -4,12 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net"
 	"strings"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 )
 
 func (t *defaultModelBuildTask) buildListener(ctx context.Context, lbARN core.StringToken, port int64, config listenPortConfig, ingList []ClassifiedIngress) (*elbv2model.Listener, error) {
@@ -70,13 +71,8 @@ func (t *defaultModelBuildTask) buildListenerDefaultActions(ctx context.Context,
 		action404 := t.build404Action(ctx)
 		return []elbv2model.Action{action404}, nil
 	}
-	if len(ingsWithDefaultBackend) > 1 {
-		ingKeys := make([]types.NamespacedName, 0, len(ingsWithDefaultBackend))
-		for _, ing := range ingsWithDefaultBackend {
-			ingKeys = append(ingKeys, k8s.NamespacedName(ing.Ing))
-		}
-		return nil, errors.Errorf("multiple ingress defined default backend: %v", ingKeys)
-	}
+	// ingList is sorted by group order, so the ingress with lowest group order sets the default action.
+	// other ingresses within the group can only specify an identical default backend.
 	ing := ingsWithDefaultBackend[0]
 	enhancedBackend, err := t.enhancedBackendBuilder.Build(ctx, ing.Ing, *ing.Ing.Spec.Backend,
 		WithLoadBackendServices(true, t.backendServices),
@@ -84,6 +80,22 @@ func (t *defaultModelBuildTask) buildListenerDefaultActions(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	var conflictingIngKeys []types.NamespacedName
+	for _, otherIng := range ingsWithDefaultBackend[1:] {
+		otherEnhancedBackend, err := t.enhancedBackendBuilder.Build(ctx, otherIng.Ing, *otherIng.Ing.Spec.Backend,
+			WithLoadBackendServices(true, t.backendServices),
+			WithLoadAuthConfig(true))
+		if err != nil {
+			return nil, err
+		}
+		if otherIng.Ing.Namespace != ing.Ing.Namespace || !equality.Semantic.DeepEqual(enhancedBackend, otherEnhancedBackend) {
+			conflictingIngKeys = append(conflictingIngKeys, k8s.NamespacedName(otherIng.Ing))
+		}
+	}
+	if len(conflictingIngKeys) != 0 {
+		ingKeys := append([]types.NamespacedName{k8s.NamespacedName(ing.Ing)}, conflictingIngKeys...)
+		return nil, errors.Errorf("conflicting default backend defined by multiple ingresses: %v", ingKeys)
+	}
 	return t.buildActions(ctx, protocol, ing, enhancedBackend)
 }
 
@@ -102,11 +114,18 @@ type listenPortConfig struct {
 	inboundCIDRv6s []string
 	sslPolicy      *string
 	tlsCerts       []string
+	// the certificate to be used as listener's default certificate, it must be one of tlsCerts.
+	// when unspecified, the first certificate of tlsCerts will be used.
+	defaultTLSCert *string
 }
 
 func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context.Context, ing *networking.Ingress) (map[int64]listenPortConfig, error) {
 	explicitTLSCertARNs := t.computeIngressExplicitTLSCertARNs(ctx, ing)
 	explicitSSLPolicy := t.computeIngressExplicitSSLPolicy(ctx, ing)
+	explicitDefaultTLSCertARN, err := t.computeIngressExplicitDefaultTLSCertARN(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
 	inboundCIDRv4s, inboundCIDRV6s, err := t.computeIngressExplicitInboundCIDRs(ctx, ing)
 	if err != nil {
 		return nil, err
@@ -131,6 +150,14 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 			return nil, err
 		}
 	}
+	tlsCertARNs := explicitTLSCertARNs
+	if len(explicitTLSCertARNs) == 0 {
+		tlsCertARNs = inferredTLSCertARNs
+	}
+	if containsHTTPSPort && explicitDefaultTLSCertARN != nil && !sets.NewString(tlsCertARNs...).Has(*explicitDefaultTLSCertARN) {
+		return nil, errors.Errorf("%v %v must be one of the TLS certificates on Ingress: %v",
+			annotations.IngressSuffixDefaultSSLCert, *explicitDefaultTLSCertARN, k8s.NamespacedName(ing))
+	}
 
 	listenPortConfigByPort := make(map[int64]listenPortConfig, len(listenPorts))
 	for port, protocol := range listenPorts {
@@ -140,11 +167,8 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 			inboundCIDRv6s: inboundCIDRV6s,
 		}
 		if protocol == elbv2model.ProtocolHTTPS {
-			if len(explicitTLSCertARNs) == 0 {
-				cfg.tlsCerts = inferredTLSCertARNs
-			} else {
-				cfg.tlsCerts = explicitTLSCertARNs
-			}
+			cfg.tlsCerts = tlsCertARNs
+			cfg.defaultTLSCert = explicitDefaultTLSCertARN
 			cfg.sslPolicy = explicitSSLPolicy
 		}
 		listenPortConfigByPort[port] = cfg
@@ -159,6 +183,19 @@ func (t *defaultModelBuildTask) computeIngressExplicitTLSCertARNs(_ context.Cont
 	return rawTLSCertARNs
 }
 
+// computeIngressExplicitDefaultTLSCertARN computes the default certificate specified via annotation, exactly one certificate can be specified.
+func (t *defaultModelBuildTask) computeIngressExplicitDefaultTLSCertARN(_ context.Context, ing *networking.Ingress) (*string, error) {
+	var rawDefaultTLSCertARNs []string
+	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixDefaultSSLCert, &rawDefaultTLSCertARNs, ing.Annotations); !exists {
+		return nil, nil
+	}
+	if len(rawDefaultTLSCertARNs) != 1 {
+		return nil, errors.Errorf("exactly one certificate must be specified by %v on Ingress: %v, got: %v",
+			annotations.IngressSuffixDefaultSSLCert, k8s.NamespacedName(ing), rawDefaultTLSCertARNs)
+	}
+	return &rawDefaultTLSCertARNs[0], nil
+}
+
 func (t *defaultModelBuildTask) computeIngressInferredTLSCertARNs(ctx context.Context, ing *networking.Ingress) ([]string, error) {
 	hosts := sets.NewString()
 	for _, r := range ing.Spec.Rules {
@@ -169,7 +206,9 @@ func (t *defaultModelBuildTask) computeIngressInferredTLSCertARNs(ctx context.Co
 	for _, t := range ing.Spec.TLS {
 		hosts.Insert(t.Hosts...)
 	}
-	return t.certDiscovery.Discover(ctx, hosts.List())
+	var preferredCertARNs []string
+	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixPreferredCertificateARN, &preferredCertARNs, ing.Annotations)
+	return t.certDiscovery.Discover(ctx, hosts.List(), preferredCertARNs)
 }
 
 func (t *defaultModelBuildTask) computeIngressListenPorts(_ context.Context, ing *networking.Ingress, preferTLS bool) (map[int64]elbv2model.Protocol, error) {
@@ -213,12 +252,13 @@ func (t *defaultModelBuildTask) computeIngressExplicitInboundCIDRs(_ context.Con
 	var rawInboundCIDRs []string
 	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixInboundCIDRs, &rawInboundCIDRs, ing.Annotations)
 
+	// overlapping CIDRs are deduplicated so that the same CIDR doesn't result in duplicated security group rules.
+	inboundCIDRs, err := networkingpkg.DeduplicateCIDRs(rawInboundCIDRs)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid %v settings on Ingress: %v", annotations.IngressSuffixInboundCIDRs, k8s.NamespacedName(ing))
+	}
 	var inboundCIDRv4s, inboundCIDRv6s []string
-	for _, cidr := range rawInboundCIDRs {
-		_, _, err := net.ParseCIDR(cidr)
-		if err != nil {
-			return nil, nil, errors.Wrapf(err, "invalid %v settings on Ingress: %v", annotations.IngressSuffixInboundCIDRs, k8s.NamespacedName(ing))
-		}
+	for _, cidr := range inboundCIDRs {
 		if strings.Contains(cidr, ":") {
 			inboundCIDRv6s = append(inboundCIDRv6s, cidr)
 		} else {
@@ -6,8 +6,11 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
@@ -20,11 +23,12 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 
 	var rules []Rule
 	for _, ing := range ingList {
-		for _, rule := range ing.Ing.Spec.Rules {
+		var ingRulePaths []ingressRulePath
+		for ruleIdx, rule := range ing.Ing.Spec.Rules {
 			if rule.HTTP == nil {
 				continue
 			}
-			for _, path := range rule.HTTP.Paths {
+			for pathIdx, path := range rule.HTTP.Paths {
 				enhancedBackend, err := t.enhancedBackendBuilder.Build(ctx, ing.Ing, path.Backend,
 					WithLoadBackendServices(true, t.backendServices),
 					WithLoadAuthConfig(true))
@@ -35,6 +39,14 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
 				}
+				rulePath := ingressRulePath{ruleIdx: ruleIdx, pathIdx: pathIdx, conditions: conditions}
+				if duplicatedRulePath, exists := findRulePathWithConditions(ingRulePaths, conditions); exists {
+					if err := t.handleDuplicateRule(port, ing, rulePath, duplicatedRulePath); err != nil {
+						return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
+					}
+					continue
+				}
+				ingRulePaths = append(ingRulePaths, rulePath)
 				actions, err := t.buildActions(ctx, protocol, ing, enhancedBackend)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
@@ -47,6 +59,7 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 					Conditions: conditions,
 					Actions:    actions,
 					Tags:       tags,
+					Ing:        ing.Ing,
 				})
 			}
 		}
@@ -55,6 +68,9 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 	if err != nil {
 		return err
 	}
+	if err := t.validateListenerRuleLimit(ctx, port, optimizedRules); err != nil {
+		return err
+	}
 
 	priority := int64(1)
 	for _, rule := range optimizedRules {
@@ -72,6 +88,89 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 	return nil
 }
 
+// ingressRulePath identifies a path within Ingress rules along with the listener rule conditions built from it.
+type ingressRulePath struct {
+	ruleIdx    int
+	pathIdx    int
+	conditions []elbv2model.RuleCondition
+}
+
+func (p ingressRulePath) String() string {
+	return fmt.Sprintf("spec.rules[%v].http.paths[%v]", p.ruleIdx, p.pathIdx)
+}
+
+// findRulePathWithConditions finds the rule path with exactly the same conditions.
+func findRulePathWithConditions(rulePaths []ingressRulePath, conditions []elbv2model.RuleCondition) (ingressRulePath, bool) {
+	for _, rulePath := range rulePaths {
+		if equality.Semantic.DeepEqual(rulePath.conditions, conditions) {
+			return rulePath, true
+		}
+	}
+	return ingressRulePath{}, false
+}
+
+// handleDuplicateRule handles a rule path with the same conditions as a preceding rule path within the same Ingress.
+// with reject policy, the Ingress is rejected, as it's ambiguous which backend is desired.
+// otherwise the duplicate rule path is ignored with an event, since the preceding one always takes priority.
+func (t *defaultModelBuildTask) handleDuplicateRule(port int64, ing ClassifiedIngress, rulePath ingressRulePath, duplicatedRulePath ingressRulePath) error {
+	if t.duplicateRulePolicy == config.IngressDuplicateRulePolicyReject {
+		return errors.Errorf("%v has duplicate conditions with %v: [%v]",
+			rulePath, duplicatedRulePath, describeRuleConditions(rulePath.conditions))
+	}
+	t.logger.Info("ignoring rule with duplicate conditions",
+		"ingress", k8s.NamespacedName(ing.Ing), "port", port, "rule", rulePath.String(), "duplicatedRule", duplicatedRulePath.String())
+	if t.eventRecorder != nil {
+		t.eventRecorder.Eventf(ing.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonDuplicateRuleIgnored,
+			"%v ignored on port %v, it has duplicate conditions with %v: [%v]",
+			rulePath, port, duplicatedRulePath, describeRuleConditions(rulePath.conditions))
+	}
+	return nil
+}
+
+// validateListenerRuleLimit validates the rules don't exceed the maximum number of rules per listener.
+// rules are ordered by priority, an event will be emitted on each Ingress owning rules beyond the limit, and the build fails
+// rather than silently dropping rules, so that traffic never falls through to the default action.
+func (t *defaultModelBuildTask) validateListenerRuleLimit(_ context.Context, port int64, rules []Rule) error {
+	if t.maxListenerRules <= 0 || int64(len(rules)) <= t.maxListenerRules {
+		return nil
+	}
+	excessRules := rules[t.maxListenerRules:]
+
+	var ingsWithExcessRules []*networking.Ingress
+	excessRuleDescriptionsByIng := make(map[*networking.Ingress][]string)
+	for _, rule := range excessRules {
+		if _, exists := excessRuleDescriptionsByIng[rule.Ing]; !exists {
+			ingsWithExcessRules = append(ingsWithExcessRules, rule.Ing)
+		}
+		excessRuleDescriptionsByIng[rule.Ing] = append(excessRuleDescriptionsByIng[rule.Ing], describeRuleConditions(rule.Conditions))
+	}
+	for _, ing := range ingsWithExcessRules {
+		if ing == nil || t.eventRecorder == nil {
+			continue
+		}
+		t.eventRecorder.Eventf(ing, corev1.EventTypeWarning, k8s.IngressEventReasonListenerRuleLimitExceeded,
+			"listener rule limit of %v exceeded on port %v, rules beyond limit: [%v]",
+			t.maxListenerRules, port, strings.Join(excessRuleDescriptionsByIng[ing], "; "))
+	}
+	return errors.Errorf("listener rule limit of %v exceeded on port %v: %v rules desired", t.maxListenerRules, port, len(rules))
+}
+
+// describeRuleConditions returns a human readable description of rule conditions.
+func describeRuleConditions(conditions []elbv2model.RuleCondition) string {
+	var descriptions []string
+	for _, condition := range conditions {
+		switch {
+		case condition.Field == elbv2model.RuleConditionFieldHostHeader && condition.HostHeaderConfig != nil:
+			descriptions = append(descriptions, fmt.Sprintf("%v: %v", condition.Field, strings.Join(condition.HostHeaderConfig.Values, ",")))
+		case condition.Field == elbv2model.RuleConditionFieldPathPattern && condition.PathPatternConfig != nil:
+			descriptions = append(descriptions, fmt.Sprintf("%v: %v", condition.Field, strings.Join(condition.PathPatternConfig.Values, ",")))
+		default:
+			descriptions = append(descriptions, string(condition.Field))
+		}
+	}
+	return strings.Join(descriptions, " ")
+}
+
 func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule networking.IngressRule,
 	path networking.HTTPIngressPath, backend EnhancedBackend) ([]elbv2model.RuleCondition, error) {
 	var hosts []string
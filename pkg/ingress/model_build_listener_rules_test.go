@@ -1,9 +1,20 @@
 package ingress
 
 import (
+	"context"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -330,3 +341,203 @@ func Test_defaultModelBuildTask_buildPathPatternsForPrefixPathType(t *testing.T)
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_validateListenerRuleLimit(t *testing.T) {
+	ing1 := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"}}
+	ing2 := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-2"}}
+	buildRule := func(ing *networking.Ingress, path string) Rule {
+		return Rule{
+			Conditions: []elbv2model.RuleCondition{
+				{
+					Field: elbv2model.RuleConditionFieldPathPattern,
+					PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+						Values: []string{path},
+					},
+				},
+			},
+			Ing: ing,
+		}
+	}
+	rules := []Rule{
+		buildRule(ing1, "/a"),
+		buildRule(ing1, "/b"),
+		buildRule(ing2, "/c"),
+		buildRule(ing2, "/d"),
+	}
+	tests := []struct {
+		name             string
+		maxListenerRules int64
+		rules            []Rule
+		wantErr          error
+		wantEvents       []string
+	}{
+		{
+			name:             "rules within limit",
+			maxListenerRules: 4,
+			rules:            rules,
+		},
+		{
+			name:             "no limit",
+			maxListenerRules: 0,
+			rules:            rules,
+		},
+		{
+			name:             "rules exceeding limit from single ingress",
+			maxListenerRules: 3,
+			rules:            rules,
+			wantErr:          errors.New("listener rule limit of 3 exceeded on port 80: 4 rules desired"),
+			wantEvents: []string{
+				"Warning ListenerRuleLimitExceeded listener rule limit of 3 exceeded on port 80, rules beyond limit: [path-pattern: /d]",
+			},
+		},
+		{
+			name:             "rules exceeding limit from multiple ingresses",
+			maxListenerRules: 1,
+			rules:            rules,
+			wantErr:          errors.New("listener rule limit of 1 exceeded on port 80: 4 rules desired"),
+			wantEvents: []string{
+				"Warning ListenerRuleLimitExceeded listener rule limit of 1 exceeded on port 80, rules beyond limit: [path-pattern: /b]",
+				"Warning ListenerRuleLimitExceeded listener rule limit of 1 exceeded on port 80, rules beyond limit: [path-pattern: /c; path-pattern: /d]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				eventRecorder:    eventRecorder,
+				maxListenerRules: tt.maxListenerRules,
+				logger:           &log.NullLogger{},
+			}
+			err := task.validateListenerRuleLimit(context.Background(), 80, tt.rules)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerRules_duplicateRules(t *testing.T) {
+	fixedResponseAction := `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"503","messageBody":"503 error text"}}`
+	newIngressPath := func(path string) networking.HTTPIngressPath {
+		return networking.HTTPIngressPath{
+			Path: path,
+			Backend: networking.IngressBackend{
+				ServiceName: "response-503",
+				ServicePort: intstr.FromString("use-annotation"),
+			},
+		}
+	}
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-1",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.response-503": fixedResponseAction,
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: "app.example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{newIngressPath("/foo"), newIngressPath("/bar")},
+						},
+					},
+				},
+				{
+					Host: "app.example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{newIngressPath("/foo")},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                 string
+		duplicateRulePolicy  string
+		wantRuleDescriptions []string
+		wantEvents           []string
+		wantErr              error
+	}{
+		{
+			name:                "duplicate rules ignored except first one by default",
+			duplicateRulePolicy: "",
+			wantRuleDescriptions: []string{
+				"host-header: app.example.com path-pattern: /foo",
+				"host-header: app.example.com path-pattern: /bar",
+			},
+			wantEvents: []string{
+				"Warning DuplicateRuleIgnored spec.rules[1].http.paths[0] ignored on port 80, it has duplicate conditions with spec.rules[0].http.paths[0]: [host-header: app.example.com path-pattern: /foo]",
+			},
+		},
+		{
+			name:                "duplicate rules rejected",
+			duplicateRulePolicy: "reject",
+			wantErr:             errors.New("ingress: awesome-ns/ing-1: spec.rules[1].http.paths[0] has duplicate conditions with spec.rules[0].http.paths[0]: [host-header: app.example.com path-pattern: /foo]"),
+		},
+		{
+			name:                "duplicate rules ignored except first one",
+			duplicateRulePolicy: "first-wins",
+			wantRuleDescriptions: []string{
+				"host-header: app.example.com path-pattern: /foo",
+				"host-header: app.example.com path-pattern: /bar",
+			},
+			wantEvents: []string{
+				"Warning DuplicateRuleIgnored spec.rules[1].http.paths[0] ignored on port 80, it has duplicate conditions with spec.rules[0].http.paths[0]: [host-header: app.example.com path-pattern: /foo]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			eventRecorder := record.NewFakeRecorder(10)
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				eventRecorder:          eventRecorder,
+				annotationParser:       annotationParser,
+				enhancedBackendBuilder: NewDefaultEnhancedBackendBuilder(k8sClient, eventRecorder, annotationParser, authConfigBuilder, false),
+				ruleOptimizer:          NewDefaultRuleOptimizer(&log.NullLogger{}),
+				logger:                 &log.NullLogger{},
+				stack:                  stack,
+				duplicateRulePolicy:    tt.duplicateRulePolicy,
+			}
+			err := task.buildListenerRules(context.Background(), core.LiteralStringToken("ls-arn"), 80, elbv2model.ProtocolHTTP,
+				[]ClassifiedIngress{{Ing: ing}})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				var resLRs []*elbv2model.ListenerRule
+				stack.ListResources(&resLRs)
+				var gotRuleDescriptions []string
+				for _, resLR := range resLRs {
+					gotRuleDescriptions = append(gotRuleDescriptions, describeRuleConditions(resLR.Spec.Conditions))
+				}
+				assert.ElementsMatch(t, tt.wantRuleDescriptions, gotRuleDescriptions)
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
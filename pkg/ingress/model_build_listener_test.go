@@ -0,0 +1,320 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_defaultModelBuildTask_buildListenerDefaultActions(t *testing.T) {
+	buildIngWithFixedResponseBackend := func(namespace string, name string, statusCode string) ClassifiedIngress {
+		ing := &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Annotations: map[string]string{
+					"alb.ingress.kubernetes.io/actions.default-response": `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"` + statusCode + `"}}`,
+				},
+			},
+			Spec: networking.IngressSpec{
+				Backend: &networking.IngressBackend{
+					ServiceName: "default-response",
+					ServicePort: intstr.FromString("use-annotation"),
+				},
+			},
+		}
+		return ClassifiedIngress{Ing: ing}
+	}
+	ingWithoutBackend := ClassifiedIngress{
+		Ing: &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      "ing-without-backend",
+			},
+		},
+	}
+	type args struct {
+		protocol elbv2model.Protocol
+		ingList  []ClassifiedIngress
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []elbv2model.Action
+		wantErr error
+	}{
+		{
+			name: "no ingress defines default backend",
+			args: args{
+				protocol: elbv2model.ProtocolHTTP,
+				ingList:  []ClassifiedIngress{ingWithoutBackend},
+			},
+			want: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeFixedResponse,
+					FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+						ContentType: awssdk.String("text/plain"),
+						StatusCode:  "404",
+					},
+				},
+			},
+		},
+		{
+			name: "single ingress defines default backend",
+			args: args{
+				protocol: elbv2model.ProtocolHTTP,
+				ingList: []ClassifiedIngress{
+					ingWithoutBackend,
+					buildIngWithFixedResponseBackend("awesome-ns", "ing-1", "503"),
+				},
+			},
+			want: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeFixedResponse,
+					FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+						ContentType: awssdk.String("text/plain"),
+						StatusCode:  "503",
+					},
+				},
+			},
+		},
+		{
+			name: "multiple ingresses define identical default backend",
+			args: args{
+				protocol: elbv2model.ProtocolHTTP,
+				ingList: []ClassifiedIngress{
+					buildIngWithFixedResponseBackend("awesome-ns", "ing-1", "503"),
+					buildIngWithFixedResponseBackend("awesome-ns", "ing-2", "503"),
+				},
+			},
+			want: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeFixedResponse,
+					FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+						ContentType: awssdk.String("text/plain"),
+						StatusCode:  "503",
+					},
+				},
+			},
+		},
+		{
+			name: "multiple ingresses define conflicting default backend",
+			args: args{
+				protocol: elbv2model.ProtocolHTTP,
+				ingList: []ClassifiedIngress{
+					buildIngWithFixedResponseBackend("awesome-ns", "ing-1", "503"),
+					buildIngWithFixedResponseBackend("awesome-ns", "ing-2", "503"),
+					buildIngWithFixedResponseBackend("awesome-ns", "ing-3", "500"),
+				},
+			},
+			wantErr: errors.Errorf("conflicting default backend defined by multiple ingresses: %v", []types.NamespacedName{
+				{Namespace: "awesome-ns", Name: "ing-1"},
+				{Namespace: "awesome-ns", Name: "ing-3"},
+			}),
+		},
+		{
+			name: "multiple ingresses define default backend in different namespaces",
+			args: args{
+				protocol: elbv2model.ProtocolHTTP,
+				ingList: []ClassifiedIngress{
+					buildIngWithFixedResponseBackend("awesome-ns", "ing-1", "503"),
+					buildIngWithFixedResponseBackend("other-ns", "ing-2", "503"),
+				},
+			},
+			wantErr: errors.Errorf("conflicting default backend defined by multiple ingresses: %v", []types.NamespacedName{
+				{Namespace: "awesome-ns", Name: "ing-1"},
+				{Namespace: "other-ns", Name: "ing-2"},
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
+			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(k8sClient, record.NewFakeRecorder(10), annotationParser, authConfigBuilder, false)
+			task := &defaultModelBuildTask{
+				k8sClient:              k8sClient,
+				enhancedBackendBuilder: enhancedBackendBuilder,
+				backendServices:        map[types.NamespacedName]*corev1.Service{},
+			}
+			got, err := task.buildListenerDefaultActions(context.Background(), tt.args.protocol, tt.args.ingList)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_computeIngressExplicitInboundCIDRs(t *testing.T) {
+	tests := []struct {
+		name    string
+		ing     *networking.Ingress
+		wantV4s []string
+		wantV6s []string
+		wantErr error
+	}{
+		{
+			name: "no inbound-cidrs annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+				},
+			},
+		},
+		{
+			name: "overlapping inbound-cidrs",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/inbound-cidrs": "10.0.0.0/16, 2001:db8::/32, 10.0.0.1/16, 192.168.0.0/24, 2001:db8::/32",
+					},
+				},
+			},
+			wantV4s: []string{"10.0.0.0/16", "192.168.0.0/24"},
+			wantV6s: []string{"2001:db8::/32"},
+		},
+		{
+			name: "invalid inbound-cidrs",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/inbound-cidrs": "10.0.0.0/16, 10.0.0.0",
+					},
+				},
+			},
+			wantErr: errors.New("invalid inbound-cidrs settings on Ingress: awesome-ns/ing-1: invalid CIDR 10.0.0.0: invalid CIDR address: 10.0.0.0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			gotV4s, gotV6s, err := task.computeIngressExplicitInboundCIDRs(context.Background(), tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantV4s, gotV4s)
+				assert.Equal(t, tt.wantV6s, gotV6s)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_computeIngressListenPortConfigByPort_defaultSSLCert(t *testing.T) {
+	tests := []struct {
+		name    string
+		ing     *networking.Ingress
+		want    map[int64]listenPortConfig
+		wantErr error
+	}{
+		{
+			name: "default-ssl-cert is one of the certificate-arn",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/certificate-arn":  "arn:aws:acm:us-east-1:9999999:certificate/cert-1,arn:aws:acm:us-east-1:9999999:certificate/cert-2",
+						"alb.ingress.kubernetes.io/default-ssl-cert": "arn:aws:acm:us-east-1:9999999:certificate/cert-2",
+					},
+				},
+			},
+			want: map[int64]listenPortConfig{
+				443: {
+					protocol: elbv2model.ProtocolHTTPS,
+					tlsCerts: []string{
+						"arn:aws:acm:us-east-1:9999999:certificate/cert-1",
+						"arn:aws:acm:us-east-1:9999999:certificate/cert-2",
+					},
+					defaultTLSCert: awssdk.String("arn:aws:acm:us-east-1:9999999:certificate/cert-2"),
+				},
+			},
+		},
+		{
+			name: "default-ssl-cert is ignored for HTTP listeners",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/default-ssl-cert": "arn:aws:acm:us-east-1:9999999:certificate/cert-2",
+					},
+				},
+			},
+			want: map[int64]listenPortConfig{
+				80: {
+					protocol: elbv2model.ProtocolHTTP,
+				},
+			},
+		},
+		{
+			name: "default-ssl-cert isn't one of the certificate-arn",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/certificate-arn":  "arn:aws:acm:us-east-1:9999999:certificate/cert-1",
+						"alb.ingress.kubernetes.io/default-ssl-cert": "arn:aws:acm:us-east-1:9999999:certificate/cert-2",
+					},
+				},
+			},
+			wantErr: errors.New("default-ssl-cert arn:aws:acm:us-east-1:9999999:certificate/cert-2 must be one of the TLS certificates on Ingress: awesome-ns/ing-1"),
+		},
+		{
+			name: "default-ssl-cert specifies multiple certificates",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/certificate-arn":  "arn:aws:acm:us-east-1:9999999:certificate/cert-1,arn:aws:acm:us-east-1:9999999:certificate/cert-2",
+						"alb.ingress.kubernetes.io/default-ssl-cert": "arn:aws:acm:us-east-1:9999999:certificate/cert-1,arn:aws:acm:us-east-1:9999999:certificate/cert-2",
+					},
+				},
+			},
+			wantErr: errors.New("exactly one certificate must be specified by default-ssl-cert on Ingress: awesome-ns/ing-1, got: [arn:aws:acm:us-east-1:9999999:certificate/cert-1 arn:aws:acm:us-east-1:9999999:certificate/cert-2]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.computeIngressListenPortConfigByPort(context.Background(), tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"regexp"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"strconv"
 	"strings"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
@@ -25,6 +28,8 @@ import (
 
 const (
 	resourceIDLoadBalancer = "LoadBalancer"
+
+	lbAttrsWAFFailOpenEnabled = "waf.fail_open.enabled"
 )
 
 func (t *defaultModelBuildTask) buildLoadBalancer(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig) (*elbv2model.LoadBalancer, error) {
@@ -294,11 +299,11 @@ func (t *defaultModelBuildTask) buildLoadBalancerCOIPv4Pool(_ context.Context) (
 	return &rawCOIPv4Pool, nil
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
+func (t *defaultModelBuildTask) buildLoadBalancerAttributes(ctx context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
 	mergedAttributes := make(map[string]string)
 	for _, member := range t.ingGroup.Members {
-		var rawAttributes map[string]string
-		if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixLoadBalancerAttributes, &rawAttributes, member.Ing.Annotations); err != nil {
+		rawAttributes, err := t.buildIngressLoadBalancerAttributes(ctx, member)
+		if err != nil {
 			return nil, err
 		}
 		for attrKey, attrValue := range rawAttributes {
@@ -308,6 +313,9 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 			mergedAttributes[attrKey] = attrValue
 		}
 	}
+	if err := t.checkWAFFailOpenAttribute(mergedAttributes); err != nil {
+		return nil, err
+	}
 	attributes := make([]elbv2model.LoadBalancerAttribute, 0, len(mergedAttributes))
 	for attrKey, attrValue := range mergedAttributes {
 		attributes = append(attributes, elbv2model.LoadBalancerAttribute{
@@ -318,12 +326,83 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 	return attributes, nil
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerTags(_ context.Context) (map[string]string, error) {
+// buildIngressLoadBalancerAttributes builds the loadBalancer attributes specified by a single Ingress.
+// attributes from the referenced ConfigMap are overridden by attributes specified inline via annotation.
+func (t *defaultModelBuildTask) buildIngressLoadBalancerAttributes(ctx context.Context, ing ClassifiedIngress) (map[string]string, error) {
+	rawAttributes := make(map[string]string)
+	var configMapName string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixLoadBalancerAttributesConfigMap, &configMapName, ing.Ing.Annotations); exists {
+		configMapKey := types.NamespacedName{
+			Namespace: ing.Ing.Namespace,
+			Name:      configMapName,
+		}
+		configMap := &corev1.ConfigMap{}
+		if err := t.k8sClient.Get(ctx, configMapKey, configMap); err != nil {
+			return nil, errors.Wrapf(err, "failed to load loadBalancer attributes configMap %v", configMapKey)
+		}
+		for attrKey, attrValue := range configMap.Data {
+			rawAttributes[attrKey] = attrValue
+		}
+	}
+
+	var inlineAttributes map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixLoadBalancerAttributes, &inlineAttributes, ing.Ing.Annotations); err != nil {
+		return nil, err
+	}
+	for attrKey, attrValue := range inlineAttributes {
+		rawAttributes[attrKey] = attrValue
+	}
+	return rawAttributes, nil
+}
+
+// checkWAFFailOpenAttribute validates the waf.fail_open.enabled attribute.
+// the attribute only takes effect with a WAFv2 WebACL associated, so we warn if none is associated via annotation.
+// the attribute is still applied in that case, since the WebACL might be associated externally.
+func (t *defaultModelBuildTask) checkWAFFailOpenAttribute(attributes map[string]string) error {
+	rawFailOpenEnabled, exists := attributes[lbAttrsWAFFailOpenEnabled]
+	if !exists {
+		return nil
+	}
+	if _, err := strconv.ParseBool(rawFailOpenEnabled); err != nil {
+		return errors.Wrapf(err, "failed to parse attribute %v=%v", lbAttrsWAFFailOpenEnabled, rawFailOpenEnabled)
+	}
+	for _, member := range t.ingGroup.Members {
+		rawWebACLARN := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixWAFv2ACLARN, &rawWebACLARN, member.Ing.Annotations); exists && rawWebACLARN != "" {
+			return nil
+		}
+	}
+
+	t.logger.Info("loadBalancer attribute has no effect without WAFv2 WebACL associated",
+		"attribute", lbAttrsWAFFailOpenEnabled)
+	if t.eventRecorder == nil {
+		return nil
+	}
+	for _, member := range t.ingGroup.Members {
+		var rawAttributes map[string]string
+		if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixLoadBalancerAttributes, &rawAttributes, member.Ing.Annotations); err != nil {
+			return err
+		}
+		if _, exists := rawAttributes[lbAttrsWAFFailOpenEnabled]; !exists {
+			continue
+		}
+		t.eventRecorder.Eventf(member.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonWAFFailOpenWithoutWebACL,
+			"loadBalancer attribute %v has no effect without WAFv2 WebACL associated, specify %v%v annotation to associate one",
+			lbAttrsWAFFailOpenEnabled, annotations.AnnotationPrefixIngress+"/", annotations.IngressSuffixWAFv2ACLARN)
+	}
+	return nil
+}
+
+func (t *defaultModelBuildTask) buildLoadBalancerTags(ctx context.Context) (map[string]string, error) {
 	ingGroupTags, err := t.buildIngressGroupResourceTags(t.ingGroup.Members)
 	if err != nil {
 		return nil, err
 	}
-	return algorithm.MergeStringMap(t.defaultTags, ingGroupTags), nil
+	ingGroupNSTags, err := t.buildIngressGroupNamespaceTags(ctx, t.ingGroup.Members)
+	if err != nil {
+		return nil, err
+	}
+	return t.mergeNamespaceTags(algorithm.MergeStringMap(t.defaultTags, ingGroupTags), ingGroupNSTags, t.ingGroup.Members), nil
 }
 
 func (t *defaultModelBuildTask) resolveSecurityGroupIDsViaNameOrIDSlice(ctx context.Context, sgNameOrIDs []string) ([]string, error) {
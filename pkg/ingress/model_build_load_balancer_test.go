@@ -5,11 +5,18 @@ import (
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -227,9 +234,11 @@ func Test_defaultModelBuildTask_buildLoadBalancerCOIPv4Pool(t *testing.T) {
 
 func Test_defaultModelBuildTask_buildLoadBalancerTags(t *testing.T) {
 	type fields struct {
-		ingGroup            Group
-		defaultTags         map[string]string
-		externalManagedTags sets.String
+		ingGroup                  Group
+		defaultTags               map[string]string
+		externalManagedTags       sets.String
+		namespaces                []*corev1.Namespace
+		namespaceLabelTagPrefixes []string
 	}
 	tests := []struct {
 		name    string
@@ -460,14 +469,83 @@ func Test_defaultModelBuildTask_buildLoadBalancerTags(t *testing.T) {
 			},
 			wantErr: errors.New("failed build tags for Ingress awesome-ns/ing-2: external managed tag key k2 cannot be specified"),
 		},
+		{
+			name: "namespace labels become tags",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "ns-a",
+									Name:      "ing-1",
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/tags": "team/k1=v1",
+									},
+								},
+							},
+						},
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "ns-b",
+									Name:      "ing-2",
+								},
+							},
+						},
+					},
+				},
+				defaultTags: map[string]string{
+					"team/k2": "default-v2",
+				},
+				externalManagedTags: sets.NewString("team/k3"),
+				namespaces: []*corev1.Namespace{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "ns-a",
+							Labels: map[string]string{
+								"team/owner":       "team-a",
+								"team/cost-center": "1234",
+								"team/k1":          "ns-v1",
+								"team/k2":          "ns-v2",
+								"team/k3":          "ns-v3",
+								"app":              "awesome-app",
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "ns-b",
+							Labels: map[string]string{
+								"team/owner":       "team-a",
+								"team/cost-center": "5678",
+							},
+						},
+					},
+				},
+				namespaceLabelTagPrefixes: []string{"team/"},
+			},
+			want: map[string]string{
+				"team/k1":    "v1",
+				"team/k2":    "default-v2",
+				"team/owner": "team-a",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ns := range tt.fields.namespaces {
+				assert.NoError(t, k8sClient.Create(context.Background(), ns.DeepCopy()))
+			}
 			task := &defaultModelBuildTask{
-				ingGroup:            tt.fields.ingGroup,
-				defaultTags:         tt.fields.defaultTags,
-				externalManagedTags: tt.fields.externalManagedTags,
-				annotationParser:    annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ingGroup:              tt.fields.ingGroup,
+				defaultTags:           tt.fields.defaultTags,
+				externalManagedTags:   tt.fields.externalManagedTags,
+				annotationParser:      annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				namespaceTagsResolver: k8s.NewDefaultNamespaceTagsResolver(k8sClient, tt.fields.namespaceLabelTagPrefixes, &log.NullLogger{}),
 			}
 			got, err := task.buildLoadBalancerTags(context.Background())
 			if tt.wantErr != nil {
@@ -643,3 +721,257 @@ func Test_defaultModelBuildTask_buildLoadBalancerName(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildLoadBalancerAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		configMaps []*corev1.ConfigMap
+		ingGroup   Group
+		want       []elbv2.LoadBalancerAttribute
+		wantEvents []string
+		wantErr    error
+	}{
+		{
+			name: "no attributes annotation",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{},
+							},
+						},
+					},
+				},
+			},
+			want: []elbv2.LoadBalancerAttribute{},
+		},
+		{
+			name: "waf fail open enabled with WAFv2 WebACL",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-attributes": "waf.fail_open.enabled=true",
+								},
+							},
+						},
+					},
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/wafv2-acl-arn": "arn:aws:wafv2:us-west-2:xxxxx:regional/webacl/xxxxxxx/3ab78708-85b0-49d3-b4e1-7a9615a6613b",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   "waf.fail_open.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "waf fail open enabled without WAFv2 WebACL",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-attributes": "waf.fail_open.enabled=true",
+								},
+							},
+						},
+					},
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "awesome-ns",
+								Name:        "ing-2",
+								Annotations: map[string]string{},
+							},
+						},
+					},
+				},
+			},
+			want: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   "waf.fail_open.enabled",
+					Value: "true",
+				},
+			},
+			wantEvents: []string{
+				"Warning WAFFailOpenWithoutWebACL loadBalancer attribute waf.fail_open.enabled has no effect without WAFv2 WebACL associated, specify alb.ingress.kubernetes.io/wafv2-acl-arn annotation to associate one",
+			},
+		},
+		{
+			name: "waf fail open enabled with invalid value",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-attributes": "waf.fail_open.enabled=yes-please",
+									"alb.ingress.kubernetes.io/wafv2-acl-arn":            "arn:aws:wafv2:us-west-2:xxxxx:regional/webacl/xxxxxxx/3ab78708-85b0-49d3-b4e1-7a9615a6613b",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to parse attribute waf.fail_open.enabled=yes-please: strconv.ParseBool: parsing \"yes-please\": invalid syntax"),
+		},
+		{
+			name: "attributes split between annotation and configMap",
+			configMaps: []*corev1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "lb-attributes",
+					},
+					Data: map[string]string{
+						"access_logs.s3.enabled": "true",
+						"access_logs.s3.bucket":  "my-access-log-bucket",
+						"access_logs.s3.prefix":  "shared",
+					},
+				},
+			},
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-attributes-configmap": "lb-attributes",
+									"alb.ingress.kubernetes.io/load-balancer-attributes":           "access_logs.s3.prefix=my-app,idle_timeout.timeout_seconds=600",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   "access_logs.s3.bucket",
+					Value: "my-access-log-bucket",
+				},
+				{
+					Key:   "access_logs.s3.enabled",
+					Value: "true",
+				},
+				{
+					Key:   "access_logs.s3.prefix",
+					Value: "my-app",
+				},
+				{
+					Key:   "idle_timeout.timeout_seconds",
+					Value: "600",
+				},
+			},
+		},
+		{
+			name: "attributes from configMap conflicts with another Ingress",
+			configMaps: []*corev1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "lb-attributes",
+					},
+					Data: map[string]string{
+						"idle_timeout.timeout_seconds": "600",
+					},
+				},
+			},
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-attributes-configmap": "lb-attributes",
+								},
+							},
+						},
+					},
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-2",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=60",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting loadBalancerAttribute idle_timeout.timeout_seconds: 600 | 60"),
+		},
+		{
+			name: "referenced configMap not found",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-attributes-configmap": "lb-attributes",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to load loadBalancer attributes configMap awesome-ns/lb-attributes: configmaps \"lb-attributes\" not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, configMap := range tt.configMaps {
+				assert.NoError(t, k8sClient.Create(ctx, configMap.DeepCopy()))
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				k8sClient:        k8sClient,
+				ingGroup:         tt.ingGroup,
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				eventRecorder:    eventRecorder,
+				logger:           &log.NullLogger{},
+			}
+			got, err := task.buildLoadBalancerAttributes(ctx)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, tt.want, got)
+			}
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 )
 
 const (
@@ -61,12 +62,16 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupName(_ context.Context)
 	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
 }
 
-func (t *defaultModelBuildTask) buildManagedSecurityGroupTags(_ context.Context) (map[string]string, error) {
+func (t *defaultModelBuildTask) buildManagedSecurityGroupTags(ctx context.Context) (map[string]string, error) {
 	ingGroupTags, err := t.buildIngressGroupResourceTags(t.ingGroup.Members)
 	if err != nil {
 		return nil, err
 	}
-	return algorithm.MergeStringMap(t.defaultTags, ingGroupTags), nil
+	ingGroupNSTags, err := t.buildIngressGroupNamespaceTags(ctx, t.ingGroup.Members)
+	if err != nil {
+		return nil, err
+	}
+	return t.mergeNamespaceTags(algorithm.MergeStringMap(t.defaultTags, ingGroupTags), ingGroupNSTags, t.ingGroup.Members), nil
 }
 
 func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) []ec2model.IPPermission {
@@ -79,7 +84,8 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ co
 				ToPort:     awssdk.Int64(port),
 				IPRanges: []ec2model.IPRange{
 					{
-						CIDRIP: cidr,
+						CIDRIP:      cidr,
+						Description: t.buildManagedSecurityGroupIngressPermissionDescription(cidr),
 					},
 				},
 			})
@@ -92,7 +98,8 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ co
 					ToPort:     awssdk.Int64(port),
 					IPv6Range: []ec2model.IPv6Range{
 						{
-							CIDRIPv6: cidr,
+							CIDRIPv6:    cidr,
+							Description: t.buildManagedSecurityGroupIngressPermissionDescription(cidr),
 						},
 					},
 				})
@@ -101,3 +108,12 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ co
 	}
 	return permissions
 }
+
+// buildManagedSecurityGroupIngressPermissionDescription builds the description for ingress permission of managed securityGroup.
+// the description identifies the IngressGroup and CIDR source of the permission, so that it can be selected as managed rule.
+func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissionDescription(cidr string) string {
+	return networking.NewIPPermissionDescriptionForLabels(map[string]string{
+		networking.IPPermissionLabelKeyResource: t.ingGroup.ID.String(),
+		networking.IPPermissionLabelKeySource:   cidr,
+	})
+}
@@ -2,12 +2,15 @@ package ingress
 
 import (
 	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
 )
 
@@ -265,3 +268,82 @@ func Test_defaultModelBuildTask_buildManagedSecurityGroupTags(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildManagedSecurityGroupIngressPermissions(t *testing.T) {
+	tests := []struct {
+		name                   string
+		ingGroup               Group
+		listenPortConfigByPort map[int64]listenPortConfig
+		ipAddressType          elbv2model.IPAddressType
+		want                   []ec2model.IPPermission
+	}{
+		{
+			name:     "ipv4 inbound CIDRs for implicit IngressGroup",
+			ingGroup: Group{ID: GroupID{Namespace: "ns-1", Name: "ing-1"}},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				80: {
+					inboundCIDRv4s: []string{"10.0.0.0/16"},
+					inboundCIDRv6s: []string{"::/0"},
+				},
+			},
+			ipAddressType: elbv2model.IPAddressTypeIPV4,
+			want: []ec2model.IPPermission{
+				{
+					IPProtocol: "tcp",
+					FromPort:   awssdk.Int64(80),
+					ToPort:     awssdk.Int64(80),
+					IPRanges: []ec2model.IPRange{
+						{
+							CIDRIP:      "10.0.0.0/16",
+							Description: "elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=10.0.0.0/16",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "dualstack inbound CIDRs for explicit IngressGroup",
+			ingGroup: Group{ID: GroupID{Namespace: "", Name: "awesome-group"}},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				443: {
+					inboundCIDRv4s: []string{"0.0.0.0/0"},
+					inboundCIDRv6s: []string{"::/0"},
+				},
+			},
+			ipAddressType: elbv2model.IPAddressTypeDualStack,
+			want: []ec2model.IPPermission{
+				{
+					IPProtocol: "tcp",
+					FromPort:   awssdk.Int64(443),
+					ToPort:     awssdk.Int64(443),
+					IPRanges: []ec2model.IPRange{
+						{
+							CIDRIP:      "0.0.0.0/0",
+							Description: "elbv2.k8s.aws/resource=awesome-group,elbv2.k8s.aws/source=0.0.0.0/0",
+						},
+					},
+				},
+				{
+					IPProtocol: "tcp",
+					FromPort:   awssdk.Int64(443),
+					ToPort:     awssdk.Int64(443),
+					IPv6Range: []ec2model.IPv6Range{
+						{
+							CIDRIPv6:    "::/0",
+							Description: "elbv2.k8s.aws/resource=awesome-group,elbv2.k8s.aws/source=::/0",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				ingGroup: tt.ingGroup,
+			}
+			got := task.buildManagedSecurityGroupIngressPermissions(context.Background(), tt.listenPortConfigByPort, tt.ipAddressType)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
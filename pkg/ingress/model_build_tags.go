@@ -1,6 +1,7 @@
 package ingress
 
 import (
+	"context"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
@@ -94,3 +95,58 @@ func (t *defaultModelBuildTask) validateTagCollisionWithExternalManagedTags(tags
 	}
 	return nil
 }
+
+// buildIngressGroupNamespaceTags builds the AWS Tags from labels of Namespaces of a group of Ingress. e.g. LoadBalancer, SecurityGroup
+// Note: tags with conflicting values across Namespaces are skipped, since they cannot be resolved by users of a single Namespace.
+func (t *defaultModelBuildTask) buildIngressGroupNamespaceTags(ctx context.Context, ingList []ClassifiedIngress) (map[string]string, error) {
+	ingGroupNSTags := make(map[string]string)
+	conflictingTagKeys := make(map[string]struct{})
+	for _, ing := range ingList {
+		nsTags, err := t.buildNamespaceTags(ctx, ing.Ing.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for tagKey, tagValue := range nsTags {
+			if existingTagValue, exists := ingGroupNSTags[tagKey]; exists && existingTagValue != tagValue {
+				conflictingTagKeys[tagKey] = struct{}{}
+			}
+			ingGroupNSTags[tagKey] = tagValue
+		}
+	}
+	for tagKey := range conflictingTagKeys {
+		delete(ingGroupNSTags, tagKey)
+	}
+	return ingGroupNSTags, nil
+}
+
+// buildNamespaceTags builds the AWS Tags from labels of Namespace. e.g. TargetGroup
+// Note: tags with external managed keys are skipped, instead of failing Ingresses in the Namespace.
+func (t *defaultModelBuildTask) buildNamespaceTags(ctx context.Context, namespace string) (map[string]string, error) {
+	if t.namespaceTagsResolver == nil {
+		return nil, nil
+	}
+	nsTags, err := t.namespaceTagsResolver.Resolve(ctx, namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed build tags for Namespace %v", namespace)
+	}
+	for tagKey := range nsTags {
+		if t.externalManagedTags.Has(tagKey) {
+			delete(nsTags, tagKey)
+		}
+	}
+	return nsTags, nil
+}
+
+// mergeNamespaceTags merges nsTags into tags with the lowest priority.
+// namespace tags exceeding the AWS limit of tags per resource are skipped, with an event recorded on ingList.
+func (t *defaultModelBuildTask) mergeNamespaceTags(tags map[string]string, nsTags map[string]string, ingList []ClassifiedIngress) map[string]string {
+	mergedTags, skippedNSTagKeys := k8s.MergeNamespaceTags(tags, nsTags)
+	if len(skippedNSTagKeys) == 0 {
+		return mergedTags
+	}
+	for _, ing := range ingList {
+		t.eventRecorder.Eventf(ing.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonNamespaceTagsSkipped,
+			"namespace tags %v are skipped since they exceed the limit of %v tags per AWS resource", skippedNSTagKeys, k8s.MaxTagsPerResource)
+	}
+	return mergedTags
+}
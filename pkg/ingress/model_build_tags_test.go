@@ -1,13 +1,16 @@
 package ingress
 
 import (
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"testing"
 )
@@ -644,3 +647,64 @@ func Test_defaultModelBuildTask_validateTagCollisionWithExternalManagedTags(t *t
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_mergeNamespaceTags(t *testing.T) {
+	buildTags := func(keyPrefix string, count int) map[string]string {
+		tags := make(map[string]string, count)
+		for i := 0; i < count; i++ {
+			tags[fmt.Sprintf("%s%02d", keyPrefix, i)] = "value"
+		}
+		return tags
+	}
+	ingList := []ClassifiedIngress{
+		{
+			Ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		tags       map[string]string
+		nsTags     map[string]string
+		want       map[string]string
+		wantEvents []string
+	}{
+		{
+			name:   "namespace tags within limit",
+			tags:   map[string]string{"k1": "v1"},
+			nsTags: map[string]string{"k1": "ns-v1", "k2": "ns-v2"},
+			want:   map[string]string{"k1": "v1", "k2": "ns-v2"},
+		},
+		{
+			name:   "namespace tags exceeding limit are skipped with event",
+			tags:   buildTags("tag-", 46),
+			nsTags: buildTags("ns-", 2),
+			want: algorithm.MergeStringMap(buildTags("tag-", 46), map[string]string{
+				"ns-00": "value",
+			}),
+			wantEvents: []string{
+				"Warning NamespaceTagsSkipped namespace tags [ns-01] are skipped since they exceed the limit of 50 tags per AWS resource",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				eventRecorder: eventRecorder,
+			}
+			got := task.mergeNamespaceTags(tt.tags, tt.nsTags, ingList)
+			assert.Equal(t, tt.want, got)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for e := range eventRecorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -18,10 +20,17 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	healthCheckPortTrafficPort = "traffic-port"
+
+	healthCheckPathTokenNamespace = "{namespace}"
+	healthCheckPathTokenService   = "{service}"
+
+	minHealthCheckMatcherGRPCCode = 0
+	maxHealthCheckMatcherGRPCCode = 99
 )
 
 func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context,
@@ -39,19 +48,25 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	hcGracePeriodSeconds, err := t.buildTargetGroupBindingHealthCheckGracePeriod(ctx, ing, svc, tgSpec.TargetType)
+	if err != nil {
+		return nil, err
+	}
 	tg := elbv2model.NewTargetGroup(t.stack, tgResID, tgSpec)
 	t.tgByResID[tgResID] = tg
-	_ = t.buildTargetGroupBinding(ctx, tg, svc, port, nodeSelector)
+	_ = t.buildTargetGroupBinding(ctx, tg, svc, port, nodeSelector, hcGracePeriodSeconds)
 	return tg, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, nodeSelector *metav1.LabelSelector) *elbv2model.TargetGroupBindingResource {
-	tgbSpec := t.buildTargetGroupBindingSpec(ctx, tg, svc, port, nodeSelector)
+func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString,
+	nodeSelector *metav1.LabelSelector, hcGracePeriodSeconds *int64) *elbv2model.TargetGroupBindingResource {
+	tgbSpec := t.buildTargetGroupBindingSpec(ctx, tg, svc, port, nodeSelector, hcGracePeriodSeconds)
 	tgb := elbv2model.NewTargetGroupBindingResource(t.stack, tg.ID(), tgbSpec)
 	return tgb
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, nodeSelector *metav1.LabelSelector) elbv2model.TargetGroupBindingResourceSpec {
+func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString,
+	nodeSelector *metav1.LabelSelector, hcGracePeriodSeconds *int64) elbv2model.TargetGroupBindingResourceSpec {
 	targetType := elbv2api.TargetType(tg.Spec.TargetType)
 	tgbNetworking := t.buildTargetGroupBindingNetworking(ctx)
 	return elbv2model.TargetGroupBindingResourceSpec{
@@ -67,8 +82,9 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 					Name: svc.Name,
 					Port: port,
 				},
-				Networking:   tgbNetworking,
-				NodeSelector: nodeSelector,
+				Networking:                    tgbNetworking,
+				NodeSelector:                  nodeSelector,
+				HealthCheckGracePeriodSeconds: hcGracePeriodSeconds,
 			},
 		},
 	}
@@ -119,6 +135,7 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
+	t.checkTargetGroupHealthCheckProtocol(ing, svc, port, tgProtocol, healthCheckConfig)
 	tgAttributes, err := t.buildTargetGroupAttributes(ctx, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
@@ -235,8 +252,14 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 	if err != nil {
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
 	}
-	healthCheckPath := t.buildTargetGroupHealthCheckPath(ctx, svcAndIngAnnotations, tgProtocolVersion)
-	healthCheckMatcher := t.buildTargetGroupHealthCheckMatcher(ctx, svcAndIngAnnotations, tgProtocolVersion)
+	healthCheckPath, err := t.buildTargetGroupHealthCheckPath(ctx, svc, svcAndIngAnnotations, tgProtocolVersion)
+	if err != nil {
+		return elbv2model.TargetGroupHealthCheckConfig{}, err
+	}
+	healthCheckMatcher, err := t.buildTargetGroupHealthCheckMatcher(ctx, svcAndIngAnnotations, tgProtocolVersion)
+	if err != nil {
+		return elbv2model.TargetGroupHealthCheckConfig{}, err
+	}
 	healthCheckIntervalSeconds, err := t.buildTargetGroupHealthCheckIntervalSeconds(ctx, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
@@ -265,7 +288,7 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 	}, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Context, svc *corev1.Service, svcAndIngAnnotations map[string]string, targetType elbv2model.TargetType) (intstr.IntOrString, error) {
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(ctx context.Context, svc *corev1.Service, svcAndIngAnnotations map[string]string, targetType elbv2model.TargetType) (intstr.IntOrString, error) {
 	rawHealthCheckPort := ""
 	if exist := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixHealthCheckPort, &rawHealthCheckPort, svcAndIngAnnotations); !exist {
 		return intstr.FromString(healthCheckPortTrafficPort), nil
@@ -280,6 +303,10 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Contex
 
 	svcPort, err := k8s.LookupServicePort(svc, healthCheckPort)
 	if err != nil {
+		// for IP TargetType, health checks can target a named containerPort that isn't exposed by service, e.g. dedicated gRPC health port.
+		if targetType == elbv2model.TargetTypeIP {
+			return t.resolveHealthCheckContainerPort(ctx, svc, healthCheckPort)
+		}
 		return intstr.IntOrString{}, errors.Wrap(err, "failed to resolve healthCheckPort")
 	}
 	if targetType == elbv2model.TargetTypeInstance {
@@ -288,7 +315,36 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Contex
 	if svcPort.TargetPort.Type == intstr.Int {
 		return svcPort.TargetPort, nil
 	}
-	return intstr.IntOrString{}, errors.New("cannot use named healthCheckPort for IP TargetType when service's targetPort is a named port")
+	return t.resolveHealthCheckContainerPort(ctx, svc, svcPort.TargetPort)
+}
+
+// resolveHealthCheckContainerPort resolves the named containerPort on pods selected by service into numerical port.
+// the named containerPort must exist on all selected pods with the same port number, since targetGroup only supports a single healthCheckPort.
+func (t *defaultModelBuildTask) resolveHealthCheckContainerPort(ctx context.Context, svc *corev1.Service, port intstr.IntOrString) (intstr.IntOrString, error) {
+	if len(svc.Spec.Selector) == 0 {
+		return intstr.IntOrString{}, errors.Errorf("cannot resolve named healthCheckPort %v, service %v has no pod selector", port.String(), k8s.NamespacedName(svc))
+	}
+	podList := &corev1.PodList{}
+	if err := t.k8sClient.List(ctx, podList, client.InNamespace(svc.Namespace), client.MatchingLabels(svc.Spec.Selector)); err != nil {
+		return intstr.IntOrString{}, errors.Wrap(err, "failed to resolve healthCheckPort")
+	}
+	if len(podList.Items) == 0 {
+		return intstr.IntOrString{}, errors.Errorf("cannot resolve named healthCheckPort %v, no pods selected by service %v", port.String(), k8s.NamespacedName(svc))
+	}
+	var containerPort int64
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		podContainerPort, err := k8s.LookupContainerPort(pod, port)
+		if err != nil {
+			return intstr.IntOrString{}, errors.Wrap(err, "failed to resolve healthCheckPort")
+		}
+		if i > 0 && podContainerPort != containerPort {
+			return intstr.IntOrString{}, errors.Errorf("cannot resolve named healthCheckPort %v, pods selected by service %v use different port numbers: %v, %v",
+				port.String(), k8s.NamespacedName(svc), containerPort, podContainerPort)
+		}
+		containerPort = podContainerPort
+	}
+	return intstr.FromInt(int(containerPort)), nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckProtocol(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocol elbv2model.Protocol) (elbv2model.Protocol, error) {
@@ -304,7 +360,30 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckProtocol(_ context.Co
 	}
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) string {
+// checkTargetGroupHealthCheckProtocol emits an event when health checks use a different protocol than the targetGroup against the traffic port.
+// AWS accepts such configuration, but health checks will fail if targets only serve the targetGroup protocol on the traffic port, e.g. HTTP health checks against TLS-only targets.
+// health checks against a different port are not checked, since that port might serve a different protocol.
+func (t *defaultModelBuildTask) checkTargetGroupHealthCheckProtocol(ing ClassifiedIngress, svc *corev1.Service, port intstr.IntOrString,
+	tgProtocol elbv2model.Protocol, healthCheckConfig elbv2model.TargetGroupHealthCheckConfig) {
+	if healthCheckConfig.Port == nil || healthCheckConfig.Port.String() != healthCheckPortTrafficPort || healthCheckConfig.Protocol == nil {
+		return
+	}
+	healthCheckProtocol := *healthCheckConfig.Protocol
+	if healthCheckProtocol == tgProtocol {
+		return
+	}
+	t.logger.Info("health check protocol mismatches targetGroup protocol",
+		"ingress", k8s.NamespacedName(ing.Ing), "service", k8s.NamespacedName(svc), "port", port.String(),
+		"protocol", tgProtocol, "healthCheckProtocol", healthCheckProtocol)
+	if t.eventRecorder == nil {
+		return
+	}
+	t.eventRecorder.Eventf(ing.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonHealthCheckProtocolMismatch,
+		"targetGroup for service %v port %v uses protocol %v, but health checks use protocol %v against traffic-port, health checks will fail if targets only serve %v",
+		k8s.NamespacedName(svc), port.String(), tgProtocol, healthCheckProtocol, tgProtocol)
+}
+
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Context, svc *corev1.Service, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) (string, error) {
 	var rawHealthCheckPath string
 	switch tgProtocolVersion {
 	case elbv2model.ProtocolVersionHTTP1, elbv2model.ProtocolVersionHTTP2:
@@ -313,10 +392,42 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Contex
 		rawHealthCheckPath = t.defaultHealthCheckPathGRPC
 	}
 	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixHealthCheckPath, &rawHealthCheckPath, svcAndIngAnnotations)
-	return rawHealthCheckPath
+	healthCheckPath, err := renderHealthCheckPath(rawHealthCheckPath, svc)
+	if err != nil {
+		return "", err
+	}
+	if tgProtocolVersion == elbv2model.ProtocolVersionGRPC && !healthCheckPathGRPCPattern.MatchString(healthCheckPath) {
+		return "", errors.Errorf("healthCheckPath for GRPC must be in the form /package.service/method: %v", healthCheckPath)
+	}
+	return healthCheckPath, nil
+}
+
+var healthCheckPathTokenPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// healthCheckPathGRPCPattern matches gRPC health check path, which must name both the service and method, e.g. /AWS.ALB/healthcheck.
+var healthCheckPathGRPCPattern = regexp.MustCompile(`^/[^/]+/[^/]+$`)
+
+// renderHealthCheckPath substitutes the supported tokens within healthCheckPath with service metadata.
+// supported tokens are {namespace} and {service}, the rendered path must be a valid URL path.
+func renderHealthCheckPath(healthCheckPath string, svc *corev1.Service) (string, error) {
+	renderedPath := strings.NewReplacer(
+		healthCheckPathTokenNamespace, svc.Namespace,
+		healthCheckPathTokenService, svc.Name,
+	).Replace(healthCheckPath)
+	if unknownToken := healthCheckPathTokenPattern.FindString(renderedPath); unknownToken != "" {
+		return "", errors.Errorf("unsupported token %v in healthCheckPath: %v", unknownToken, healthCheckPath)
+	}
+	if !strings.HasPrefix(renderedPath, "/") {
+		return "", errors.Errorf("healthCheckPath must start with /: %v", renderedPath)
+	}
+	parsedURL, err := url.Parse(renderedPath)
+	if err != nil || parsedURL.EscapedPath() != renderedPath {
+		return "", errors.Errorf("healthCheckPath must be a valid URL path: %v", renderedPath)
+	}
+	return renderedPath, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) elbv2model.HealthCheckMatcher {
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) (elbv2model.HealthCheckMatcher, error) {
 	var rawHealthCheckMatcherHTTPCode string
 	switch tgProtocolVersion {
 	case elbv2model.ProtocolVersionHTTP1, elbv2model.ProtocolVersionHTTP2:
@@ -327,13 +438,40 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Con
 
 	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSuccessCodes, &rawHealthCheckMatcherHTTPCode, svcAndIngAnnotations)
 	if tgProtocolVersion == elbv2model.ProtocolVersionGRPC {
+		if err := validateHealthCheckMatcherGRPCCode(rawHealthCheckMatcherHTTPCode); err != nil {
+			return elbv2model.HealthCheckMatcher{}, err
+		}
 		return elbv2model.HealthCheckMatcher{
 			GRPCCode: &rawHealthCheckMatcherHTTPCode,
-		}
+		}, nil
 	}
 	return elbv2model.HealthCheckMatcher{
 		HTTPCode: &rawHealthCheckMatcherHTTPCode,
+	}, nil
+}
+
+// validateHealthCheckMatcherGRPCCode validates the gRPC success codes, which can be a comma separated list of codes or code ranges like "0,12-14".
+// each gRPC code must be within [0, 99], so HTTP style status codes like "200" are rejected.
+func validateHealthCheckMatcherGRPCCode(grpcCode string) error {
+	for _, rawCodeRange := range strings.Split(grpcCode, ",") {
+		rawCodes := strings.SplitN(rawCodeRange, "-", 2)
+		var codes []int64
+		for _, rawCode := range rawCodes {
+			code, err := strconv.ParseInt(rawCode, 10, 64)
+			if err != nil {
+				return errors.Errorf("invalid gRPC success codes: %v, %v is not a valid code or code range", grpcCode, rawCodeRange)
+			}
+			if code < minHealthCheckMatcherGRPCCode || code > maxHealthCheckMatcherGRPCCode {
+				return errors.Errorf("invalid gRPC success codes: %v, code %v must be within [%v, %v]",
+					grpcCode, code, minHealthCheckMatcherGRPCCode, maxHealthCheckMatcherGRPCCode)
+			}
+			codes = append(codes, code)
+		}
+		if len(codes) == 2 && codes[0] > codes[1] {
+			return errors.Errorf("invalid gRPC success codes: %v, code range %v must be ascending", grpcCode, rawCodeRange)
+		}
 	}
+	return nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckIntervalSeconds(_ context.Context, svcAndIngAnnotations map[string]string) (int64, error) {
@@ -387,12 +525,16 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, sv
 	return attributes, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupTags(_ context.Context, ing ClassifiedIngress, svc *corev1.Service) (map[string]string, error) {
+func (t *defaultModelBuildTask) buildTargetGroupTags(ctx context.Context, ing ClassifiedIngress, svc *corev1.Service) (map[string]string, error) {
 	ingSvcTags, err := t.buildIngressBackendResourceTags(ing, svc)
 	if err != nil {
 		return nil, err
 	}
-	return algorithm.MergeStringMap(t.defaultTags, ingSvcTags), nil
+	nsTags, err := t.buildNamespaceTags(ctx, ing.Ing.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return t.mergeNamespaceTags(algorithm.MergeStringMap(t.defaultTags, ingSvcTags), nsTags, []ClassifiedIngress{ing}), nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupResourceID(ingKey types.NamespacedName, svcKey types.NamespacedName, port intstr.IntOrString) string {
@@ -416,3 +558,22 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNodeSelector(_ context.Co
 		MatchLabels: targetNodeLabels,
 	}, nil
 }
+
+func (t *defaultModelBuildTask) buildTargetGroupBindingHealthCheckGracePeriod(_ context.Context, ing ClassifiedIngress, svc *corev1.Service, targetType elbv2model.TargetType) (*int64, error) {
+	if targetType != elbv2model.TargetTypeIP {
+		return nil, nil
+	}
+	var rawGracePeriod int64
+	svcAndIngAnnotations := algorithm.MergeStringMap(svc.Annotations, ing.Ing.Annotations)
+	exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixHealthCheckGracePeriod, &rawGracePeriod, svcAndIngAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	if rawGracePeriod < 0 {
+		return nil, errors.Errorf("healthcheck grace period must be non-negative: %v", rawGracePeriod)
+	}
+	return &rawGracePeriod, nil
+}
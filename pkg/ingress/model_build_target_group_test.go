@@ -8,11 +8,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -380,14 +385,22 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 		defaultHealthCheckPathGRPC string
 	}
 	type args struct {
+		svc                  *corev1.Service
 		svcAndIngAnnotations map[string]string
 		tgProtocolVersion    elbv2model.ProtocolVersion
 	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-svc",
+		},
+	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   string
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr error
 	}{
 		{
 			name: "HTTP1, without annotation configured",
@@ -396,6 +409,7 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
 			},
 			args: args{
+				svc:                  svc,
 				svcAndIngAnnotations: nil,
 				tgProtocolVersion:    elbv2model.ProtocolVersionHTTP1,
 			},
@@ -408,6 +422,7 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
 			},
 			args: args{
+				svc:                  svc,
 				svcAndIngAnnotations: nil,
 				tgProtocolVersion:    elbv2model.ProtocolVersionHTTP2,
 			},
@@ -420,6 +435,7 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
 			},
 			args: args{
+				svc:                  svc,
 				svcAndIngAnnotations: nil,
 				tgProtocolVersion:    elbv2model.ProtocolVersionGRPC,
 			},
@@ -432,6 +448,7 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
 			},
 			args: args{
+				svc: svc,
 				svcAndIngAnnotations: map[string]string{
 					"alb.ingress.kubernetes.io/healthcheck-path": "/ping",
 				},
@@ -446,6 +463,7 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
 			},
 			args: args{
+				svc: svc,
 				svcAndIngAnnotations: map[string]string{
 					"alb.ingress.kubernetes.io/healthcheck-path": "/ping",
 				},
@@ -460,6 +478,7 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
 			},
 			args: args{
+				svc: svc,
 				svcAndIngAnnotations: map[string]string{
 					"alb.ingress.kubernetes.io/healthcheck-path": "/package.service/method",
 				},
@@ -467,6 +486,109 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 			},
 			want: "/package.service/method",
 		},
+		{
+			name: "HTTP1, with templated annotation configured",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svc: svc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-path": "/{namespace}/{service}/ping",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: "/awesome-ns/awesome-svc/ping",
+		},
+		{
+			name: "HTTP1, with templated default configured",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/healthz/{service}",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svc:                  svc,
+				svcAndIngAnnotations: nil,
+				tgProtocolVersion:    elbv2model.ProtocolVersionHTTP1,
+			},
+			want: "/healthz/awesome-svc",
+		},
+		{
+			name: "HTTP1, with unsupported token configured",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svc: svc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-path": "/{pod}/ping",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			wantErr: errors.New("unsupported token {pod} in healthCheckPath: /{pod}/ping"),
+		},
+		{
+			name: "HTTP1, with invalid rendered path",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svc: svc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-path": "/{service}?check=true",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			wantErr: errors.New("healthCheckPath must be a valid URL path: /awesome-svc?check=true"),
+		},
+		{
+			name: "HTTP1, with relative path",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svc: svc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-path": "{service}/ping",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			wantErr: errors.New("healthCheckPath must start with /: awesome-svc/ping"),
+		},
+		{
+			name: "GRPC, with path-less annotation configured",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svc: svc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-path": "/",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			wantErr: errors.New("healthCheckPath for GRPC must be in the form /package.service/method: /"),
+		},
+		{
+			name: "GRPC, with method-less annotation configured",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svc: svc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-path": "/package.service",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			wantErr: errors.New("healthCheckPath for GRPC must be in the form /package.service/method: /package.service"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -475,8 +597,224 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 				defaultHealthCheckPathHTTP: tt.fields.defaultHealthCheckPathHTTP,
 				defaultHealthCheckPathGRPC: tt.fields.defaultHealthCheckPathGRPC,
 			}
-			got := task.buildTargetGroupHealthCheckPath(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
-			assert.Equal(t, tt.want, got)
+			got, err := task.buildTargetGroupHealthCheckPath(context.Background(), tt.args.svc, tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPort(t *testing.T) {
+	grpcSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "grpc-svc",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "grpc"},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "grpc",
+					Port:       50051,
+					TargetPort: intstr.FromString("grpc"),
+					NodePort:   32001,
+				},
+				{
+					Name:       "grpc-health",
+					Port:       50052,
+					TargetPort: intstr.FromString("grpc-health"),
+					NodePort:   32002,
+				},
+			},
+		},
+	}
+	buildGRPCPod := func(name string, healthPort int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      name,
+				Labels:    map[string]string{"app": "grpc"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "server",
+						Ports: []corev1.ContainerPort{
+							{Name: "grpc", ContainerPort: 9000},
+							{Name: "grpc-health", ContainerPort: healthPort},
+						},
+					},
+				},
+			},
+		}
+	}
+	podWithoutHealthPort := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "pod-without-health-port",
+			Labels:    map[string]string{"app": "grpc"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "server",
+					Ports: []corev1.ContainerPort{{Name: "grpc", ContainerPort: 9000}},
+				},
+			},
+		},
+	}
+	type args struct {
+		svc                  *corev1.Service
+		svcAndIngAnnotations map[string]string
+		targetType           elbv2model.TargetType
+	}
+	tests := []struct {
+		name    string
+		pods    []*corev1.Pod
+		args    args
+		want    intstr.IntOrString
+		wantErr error
+	}{
+		{
+			name: "without annotation configured",
+			args: args{
+				svc:        grpcSvc,
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: intstr.FromString("traffic-port"),
+		},
+		{
+			name: "numerical port configured",
+			args: args{
+				svc: grpcSvc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "8080",
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: intstr.FromInt(8080),
+		},
+		{
+			name: "named service port for instance TargetType",
+			args: args{
+				svc: grpcSvc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "grpc-health",
+				},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			want: intstr.FromInt(32002),
+		},
+		{
+			name: "named service port with named targetPort for IP TargetType",
+			pods: []*corev1.Pod{buildGRPCPod("pod-1", 9001), buildGRPCPod("pod-2", 9001)},
+			args: args{
+				svc: grpcSvc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "grpc-health",
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: intstr.FromInt(9001),
+		},
+		{
+			name: "named containerPort not exposed by service for IP TargetType",
+			pods: []*corev1.Pod{buildGRPCPod("pod-1", 9001)},
+			args: args{
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "grpc-svc",
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "grpc"},
+						Ports: []corev1.ServicePort{
+							{
+								Name:       "grpc",
+								Port:       50051,
+								TargetPort: intstr.FromString("grpc"),
+							},
+						},
+					},
+				},
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "grpc-health",
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: intstr.FromInt(9001),
+		},
+		{
+			name: "named port missing on pod for IP TargetType",
+			pods: []*corev1.Pod{buildGRPCPod("pod-1", 9001), podWithoutHealthPort},
+			args: args{
+				svc: grpcSvc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "grpc-health",
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("failed to resolve healthCheckPort: unable to find port grpc-health on pod awesome-ns/pod-without-health-port"),
+		},
+		{
+			name: "named port with different numbers on pods for IP TargetType",
+			pods: []*corev1.Pod{buildGRPCPod("pod-1", 9001), buildGRPCPod("pod-2", 9002)},
+			args: args{
+				svc: grpcSvc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "grpc-health",
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("cannot resolve named healthCheckPort grpc-health, pods selected by service awesome-ns/grpc-svc use different port numbers: 9001, 9002"),
+		},
+		{
+			name: "named port without pods for IP TargetType",
+			args: args{
+				svc: grpcSvc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "grpc-health",
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("cannot resolve named healthCheckPort grpc-health, no pods selected by service awesome-ns/grpc-svc"),
+		},
+		{
+			name: "unknown named port for instance TargetType",
+			args: args{
+				svc: grpcSvc,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "unknown",
+				},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			wantErr: errors.New("failed to resolve healthCheckPort: unable to find port unknown on service awesome-ns/grpc-svc"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, pod := range tt.pods {
+				assert.NoError(t, k8sClient.Create(ctx, pod.DeepCopy()))
+			}
+			task := &defaultModelBuildTask{
+				k8sClient:        k8sClient,
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupHealthCheckPort(ctx, tt.args.svc, tt.args.svcAndIngAnnotations, tt.args.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
 		})
 	}
 }
@@ -491,10 +829,11 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T)
 		tgProtocolVersion    elbv2model.ProtocolVersion
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   elbv2model.HealthCheckMatcher
+		name    string
+		fields  fields
+		args    args
+		want    elbv2model.HealthCheckMatcher
+		wantErr error
 	}{
 		{
 			name: "HTTP1, without annotation configured",
@@ -586,6 +925,94 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T)
 				GRPCCode: awssdk.String("0"),
 			},
 		},
+		{
+			name: "GRPC, with code ranges configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "0,12-14,99",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			want: elbv2model.HealthCheckMatcher{
+				GRPCCode: awssdk.String("0,12-14,99"),
+			},
+		},
+		{
+			name: "GRPC, with HTTP style code configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			wantErr: errors.New("invalid gRPC success codes: 200, code 200 must be within [0, 99]"),
+		},
+		{
+			name: "GRPC, with code range exceeds limit",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "0,90-100",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			wantErr: errors.New("invalid gRPC success codes: 0,90-100, code 100 must be within [0, 99]"),
+		},
+		{
+			name: "GRPC, with descending code range",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "14-12",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			wantErr: errors.New("invalid gRPC success codes: 14-12, code range 14-12 must be ascending"),
+		},
+		{
+			name: "GRPC, with malformed codes",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "0,,12",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			wantErr: errors.New("invalid gRPC success codes: 0,,12,  is not a valid code or code range"),
+		},
+		{
+			name: "HTTP1, with HTTP style code range configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200-399",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: elbv2model.HealthCheckMatcher{
+				HTTPCode: awssdk.String("200-399"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -594,8 +1021,103 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T)
 				defaultHealthCheckMatcherHTTPCode: tt.fields.defaultHealthCheckMatcherHTTPCode,
 				defaultHealthCheckMatcherGRPCCode: tt.fields.defaultHealthCheckMatcherGRPCCode,
 			}
-			got := task.buildTargetGroupHealthCheckMatcher(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
-			assert.Equal(t, tt.want, got)
+			got, err := task.buildTargetGroupHealthCheckMatcher(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_checkTargetGroupHealthCheckProtocol(t *testing.T) {
+	trafficPort := intstr.FromString("traffic-port")
+	customPort := intstr.FromInt(8443)
+	protocolHTTP := elbv2model.ProtocolHTTP
+	protocolHTTPS := elbv2model.ProtocolHTTPS
+	tests := []struct {
+		name              string
+		tgProtocol        elbv2model.Protocol
+		healthCheckConfig elbv2model.TargetGroupHealthCheckConfig
+		wantEvents        []string
+	}{
+		{
+			name:       "HTTPS targetGroup with HTTPS health check",
+			tgProtocol: elbv2model.ProtocolHTTPS,
+			healthCheckConfig: elbv2model.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: &protocolHTTPS,
+			},
+		},
+		{
+			name:       "HTTP targetGroup with HTTP health check",
+			tgProtocol: elbv2model.ProtocolHTTP,
+			healthCheckConfig: elbv2model.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: &protocolHTTP,
+			},
+		},
+		{
+			name:       "HTTPS targetGroup with HTTP health check against traffic-port",
+			tgProtocol: elbv2model.ProtocolHTTPS,
+			healthCheckConfig: elbv2model.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: &protocolHTTP,
+			},
+			wantEvents: []string{
+				"Warning HealthCheckProtocolMismatch targetGroup for service awesome-ns/awesome-svc port https uses protocol HTTPS, but health checks use protocol HTTP against traffic-port, health checks will fail if targets only serve HTTPS",
+			},
+		},
+		{
+			name:       "HTTP targetGroup with HTTPS health check against traffic-port",
+			tgProtocol: elbv2model.ProtocolHTTP,
+			healthCheckConfig: elbv2model.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: &protocolHTTPS,
+			},
+			wantEvents: []string{
+				"Warning HealthCheckProtocolMismatch targetGroup for service awesome-ns/awesome-svc port https uses protocol HTTP, but health checks use protocol HTTPS against traffic-port, health checks will fail if targets only serve HTTP",
+			},
+		},
+		{
+			name:       "HTTPS targetGroup with HTTP health check against custom port",
+			tgProtocol: elbv2model.ProtocolHTTPS,
+			healthCheckConfig: elbv2model.TargetGroupHealthCheckConfig{
+				Port:     &customPort,
+				Protocol: &protocolHTTP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				eventRecorder: eventRecorder,
+				logger:        &log.NullLogger{},
+			}
+			ing := ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+					},
+				},
+			}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+				},
+			}
+			task.checkTargetGroupHealthCheckProtocol(ing, svc, intstr.FromString("https"), tt.tgProtocol, tt.healthCheckConfig)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
 		})
 	}
 }
@@ -732,3 +1254,117 @@ func Test_defaultModelBuildTask_buildTargetGroupBindingNodeSelector(t *testing.T
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildTargetGroupBindingHealthCheckGracePeriod(t *testing.T) {
+	type args struct {
+		ing        ClassifiedIngress
+		svc        *corev1.Service
+		targetType elbv2model.TargetType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *int64
+		wantErr error
+	}{
+		{
+			name: "no annotation",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: nil,
+		},
+		{
+			name: "ingress has annotation",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/healthcheck-grace-period-seconds": "60",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: awssdk.Int64(60),
+		},
+		{
+			name: "service annotation overrides ingress",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/healthcheck-grace-period-seconds": "60",
+							},
+						},
+					},
+				},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/healthcheck-grace-period-seconds": "120",
+						},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: awssdk.Int64(120),
+		},
+		{
+			name: "target type instance",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/healthcheck-grace-period-seconds": "60",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			want: nil,
+		},
+		{
+			name: "negative grace period",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/healthcheck-grace-period-seconds": "-10",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("healthcheck grace period must be non-negative: -10"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupBindingHealthCheckGracePeriod(context.Background(), tt.args.ing, tt.args.svc, tt.args.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -33,9 +33,9 @@ func NewDefaultModelBuilder(k8sClient client.Client, eventRecorder record.EventR
 	ec2Client services.EC2, acmClient services.ACM,
 	annotationParser annotations.Parser, subnetsResolver networkingpkg.SubnetsResolver,
 	authConfigBuilder AuthConfigBuilder, enhancedBackendBuilder EnhancedBackendBuilder,
-	trackingProvider tracking.Provider, elbv2TaggingManager elbv2deploy.TaggingManager,
+	trackingProvider tracking.Provider, elbv2TaggingManager elbv2deploy.TaggingManager, namespaceTagsResolver k8s.NamespaceTagsResolver,
 	vpcID string, clusterName string, defaultTags map[string]string, externalManagedTags []string, defaultSSLPolicy string,
-	logger logr.Logger) *defaultModelBuilder {
+	maxListenerRules int64, duplicateRulePolicy string, logger logr.Logger) *defaultModelBuilder {
 	certDiscovery := NewACMCertDiscovery(acmClient, logger)
 	ruleOptimizer := NewDefaultRuleOptimizer(logger)
 	return &defaultModelBuilder{
@@ -52,9 +52,12 @@ func NewDefaultModelBuilder(k8sClient client.Client, eventRecorder record.EventR
 		ruleOptimizer:          ruleOptimizer,
 		trackingProvider:       trackingProvider,
 		elbv2TaggingManager:    elbv2TaggingManager,
+		namespaceTagsResolver:  namespaceTagsResolver,
 		defaultTags:            defaultTags,
 		externalManagedTags:    sets.NewString(externalManagedTags...),
 		defaultSSLPolicy:       defaultSSLPolicy,
+		maxListenerRules:       maxListenerRules,
+		duplicateRulePolicy:    duplicateRulePolicy,
 		logger:                 logger,
 	}
 }
@@ -78,9 +81,12 @@ type defaultModelBuilder struct {
 	ruleOptimizer          RuleOptimizer
 	trackingProvider       tracking.Provider
 	elbv2TaggingManager    elbv2deploy.TaggingManager
+	namespaceTagsResolver  k8s.NamespaceTagsResolver
 	defaultTags            map[string]string
 	externalManagedTags    sets.String
 	defaultSSLPolicy       string
+	maxListenerRules       int64
+	duplicateRulePolicy    string
 
 	logger logr.Logger
 }
@@ -102,6 +108,7 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		ruleOptimizer:          b.ruleOptimizer,
 		trackingProvider:       b.trackingProvider,
 		elbv2TaggingManager:    b.elbv2TaggingManager,
+		namespaceTagsResolver:  b.namespaceTagsResolver,
 		logger:                 b.logger,
 
 		ingGroup: ingGroup,
@@ -123,6 +130,8 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		defaultHealthCheckUnhealthyThresholdCount: 2,
 		defaultHealthCheckMatcherHTTPCode:         "200",
 		defaultHealthCheckMatcherGRPCCode:         "12",
+		maxListenerRules:                          b.maxListenerRules,
+		duplicateRulePolicy:                       b.duplicateRulePolicy,
 
 		loadBalancer:    nil,
 		tgByResID:       make(map[string]*elbv2model.TargetGroup),
@@ -149,6 +158,7 @@ type defaultModelBuildTask struct {
 	ruleOptimizer          RuleOptimizer
 	trackingProvider       tracking.Provider
 	elbv2TaggingManager    elbv2deploy.TaggingManager
+	namespaceTagsResolver  k8s.NamespaceTagsResolver
 	logger                 logr.Logger
 
 	ingGroup          Group
@@ -171,6 +181,10 @@ type defaultModelBuildTask struct {
 	defaultHealthCheckUnhealthyThresholdCount int64
 	defaultHealthCheckMatcherHTTPCode         string
 	defaultHealthCheckMatcherGRPCCode         string
+	// maximum number of rules per listener, the build fails if it's exceeded. zero means no limit.
+	maxListenerRules int64
+	// policy for rules with duplicate conditions within a single Ingress, rejected unless it's first-wins.
+	duplicateRulePolicy string
 
 	loadBalancer    *elbv2model.LoadBalancer
 	managedSG       *ec2model.SecurityGroup
@@ -249,7 +263,12 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 	var mergedTLSCerts []string
 	mergedTLSCertsSet := sets.NewString()
 
-	for _, cfg := range listenPortConfigs {
+	var mergedDefaultTLSCertProvider *types.NamespacedName
+	var mergedDefaultTLSCert *string
+
+	for i := range listenPortConfigs {
+		// the provider keys are taken by address, so each iteration must use its own copy.
+		cfg := listenPortConfigs[i]
 		if mergedProtocolProvider == nil {
 			mergedProtocolProvider = &cfg.ingKey
 			mergedProtocol = cfg.listenPortConfig.protocol
@@ -288,6 +307,16 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 			mergedTLSCertsSet.Insert(cert)
 			mergedTLSCerts = append(mergedTLSCerts, cert)
 		}
+
+		if cfg.listenPortConfig.defaultTLSCert != nil {
+			if mergedDefaultTLSCertProvider == nil {
+				mergedDefaultTLSCertProvider = &cfg.ingKey
+				mergedDefaultTLSCert = cfg.listenPortConfig.defaultTLSCert
+			} else if awssdk.StringValue(mergedDefaultTLSCert) != awssdk.StringValue(cfg.listenPortConfig.defaultTLSCert) {
+				return listenPortConfig{}, errors.Errorf("conflicting %v, %v: %v | %v: %v", annotations.IngressSuffixDefaultSSLCert,
+					*mergedDefaultTLSCertProvider, awssdk.StringValue(mergedDefaultTLSCert), cfg.ingKey, awssdk.StringValue(cfg.listenPortConfig.defaultTLSCert))
+			}
+		}
 	}
 
 	if len(mergedInboundCIDRv4s) == 0 && len(mergedInboundCIDRv6s) == 0 {
@@ -297,6 +326,16 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 	if mergedProtocol == elbv2model.ProtocolHTTPS && mergedSSLPolicy == nil {
 		mergedSSLPolicy = awssdk.String(t.defaultSSLPolicy)
 	}
+	// the first certificate is used as listener's default certificate, and remaining ones are added as SNI certificates.
+	if mergedDefaultTLSCert != nil {
+		defaultFirstTLSCerts := []string{awssdk.StringValue(mergedDefaultTLSCert)}
+		for _, cert := range mergedTLSCerts {
+			if cert != awssdk.StringValue(mergedDefaultTLSCert) {
+				defaultFirstTLSCerts = append(defaultFirstTLSCerts, cert)
+			}
+		}
+		mergedTLSCerts = defaultFirstTLSCerts
+	}
 
 	return listenPortConfig{
 		protocol:       mergedProtocol,
@@ -304,6 +343,7 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 		inboundCIDRv6s: mergedInboundCIDRv6s.List(),
 		sslPolicy:      mergedSSLPolicy,
 		tlsCerts:       mergedTLSCerts,
+		defaultTLSCert: mergedDefaultTLSCert,
 	}, nil
 }
 
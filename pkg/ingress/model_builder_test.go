@@ -12,6 +12,7 @@ import (
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -239,7 +240,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":80,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=0.0.0.0/0"
                                 }
                             ]
                         }
@@ -690,7 +692,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":80,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=0.0.0.0/0"
                                 }
                             ]
                         }
@@ -1142,7 +1145,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":443,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=0.0.0.0/0"
                                 }
                             ]
                         }
@@ -1586,7 +1590,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":80,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=0.0.0.0/0"
                                 }
                             ]
                         }
@@ -1987,7 +1992,8 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                             "toPort":80,
                             "ipRanges":[
                                 {
-                                    "cidrIP":"0.0.0.0/0"
+                                    "cidrIP":"0.0.0.0/0",
+                                    "description":"elbv2.k8s.aws/resource=ns-1/ing-1,elbv2.k8s.aws/source=0.0.0.0/0"
                                 }
                             ]
                         }
@@ -2382,7 +2388,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 			certDiscovery := NewMockCertDiscovery(ctrl)
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
 			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
-			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, authConfigBuilder)
+			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(k8sClient, eventRecorder, annotationParser, authConfigBuilder, false)
 			ruleOptimizer := NewDefaultRuleOptimizer(&log.NullLogger{})
 			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", clusterName)
 			stackMarshaller := deploy.NewDefaultStackMarshaller()
@@ -2984,3 +2990,103 @@ func Test_defaultModelBuildTask_buildSSLRedirectConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_mergeListenPortConfigs(t *testing.T) {
+	tests := []struct {
+		name              string
+		listenPortConfigs []listenPortConfigWithIngress
+		want              listenPortConfig
+		wantErr           error
+	}{
+		{
+			name: "first certificate is used as default certificate without default-ssl-cert",
+			listenPortConfigs: []listenPortConfigWithIngress{
+				{
+					ingKey: types.NamespacedName{Namespace: "ns-1", Name: "ing-1"},
+					listenPortConfig: listenPortConfig{
+						protocol: elbv2model.ProtocolHTTPS,
+						tlsCerts: []string{"cert-1", "cert-2"},
+					},
+				},
+				{
+					ingKey: types.NamespacedName{Namespace: "ns-1", Name: "ing-2"},
+					listenPortConfig: listenPortConfig{
+						protocol: elbv2model.ProtocolHTTPS,
+						tlsCerts: []string{"cert-2", "cert-3"},
+					},
+				},
+			},
+			want: listenPortConfig{
+				protocol:       elbv2model.ProtocolHTTPS,
+				inboundCIDRv4s: []string{"0.0.0.0/0"},
+				inboundCIDRv6s: []string{"::/0"},
+				sslPolicy:      awssdk.String("ELBSecurityPolicy-2016-08"),
+				tlsCerts:       []string{"cert-1", "cert-2", "cert-3"},
+			},
+		},
+		{
+			name: "default-ssl-cert is used as default certificate and remaining ones as SNI certificates",
+			listenPortConfigs: []listenPortConfigWithIngress{
+				{
+					ingKey: types.NamespacedName{Namespace: "ns-1", Name: "ing-1"},
+					listenPortConfig: listenPortConfig{
+						protocol: elbv2model.ProtocolHTTPS,
+						tlsCerts: []string{"cert-1", "cert-2"},
+					},
+				},
+				{
+					ingKey: types.NamespacedName{Namespace: "ns-1", Name: "ing-2"},
+					listenPortConfig: listenPortConfig{
+						protocol:       elbv2model.ProtocolHTTPS,
+						tlsCerts:       []string{"cert-2", "cert-3"},
+						defaultTLSCert: awssdk.String("cert-3"),
+					},
+				},
+			},
+			want: listenPortConfig{
+				protocol:       elbv2model.ProtocolHTTPS,
+				inboundCIDRv4s: []string{"0.0.0.0/0"},
+				inboundCIDRv6s: []string{"::/0"},
+				sslPolicy:      awssdk.String("ELBSecurityPolicy-2016-08"),
+				tlsCerts:       []string{"cert-3", "cert-1", "cert-2"},
+				defaultTLSCert: awssdk.String("cert-3"),
+			},
+		},
+		{
+			name: "conflicting default-ssl-cert",
+			listenPortConfigs: []listenPortConfigWithIngress{
+				{
+					ingKey: types.NamespacedName{Namespace: "ns-1", Name: "ing-1"},
+					listenPortConfig: listenPortConfig{
+						protocol:       elbv2model.ProtocolHTTPS,
+						tlsCerts:       []string{"cert-1", "cert-2"},
+						defaultTLSCert: awssdk.String("cert-1"),
+					},
+				},
+				{
+					ingKey: types.NamespacedName{Namespace: "ns-1", Name: "ing-2"},
+					listenPortConfig: listenPortConfig{
+						protocol:       elbv2model.ProtocolHTTPS,
+						tlsCerts:       []string{"cert-2"},
+						defaultTLSCert: awssdk.String("cert-2"),
+					},
+				},
+			},
+			wantErr: errors.New("conflicting default-ssl-cert, ns-1/ing-1: cert-1 | ns-1/ing-2: cert-2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				defaultSSLPolicy: "ELBSecurityPolicy-2016-08",
+			}
+			got, err := task.mergeListenPortConfigs(context.Background(), tt.listenPortConfigs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
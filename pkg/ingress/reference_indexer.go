@@ -7,6 +7,7 @@ import (
 	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -15,6 +16,8 @@ const (
 	IndexKeyServiceRefName = "ingress.serviceRef.name"
 	// IndexKeySecretRefName is index key for secrets referenced by Ingress or Service.
 	IndexKeySecretRefName = "ingress.secretRef.name"
+	// IndexKeyConfigMapRefName is index key for configMaps referenced by Ingress.
+	IndexKeyConfigMapRefName = "ingress.configMapRef.name"
 	// IndexKeyIngressClassRefName is index key for ingressClass referenced by Ingress.
 	IndexKeyIngressClassRefName = "ingress.ingressClassRef.name"
 	// IndexKeyIngressClassParamsRefName is index key for ingressClassParams referenced by IngressClass.
@@ -27,6 +30,8 @@ type ReferenceIndexer interface {
 	BuildServiceRefIndexes(ctx context.Context, ing *networking.Ingress) []string
 	// BuildSecretRefIndexes returns the name of related Secret objects.
 	BuildSecretRefIndexes(ctx context.Context, ingOrSvc client.Object) []string
+	// BuildConfigMapRefIndexes returns the name of related ConfigMap objects.
+	BuildConfigMapRefIndexes(ctx context.Context, ing *networking.Ingress) []string
 	// BuildIngressClassRefIndexes returns the name of related IngressClass objects.
 	BuildIngressClassRefIndexes(ctx context.Context, ing *networking.Ingress) []string
 	// BuildIngressClassParamsRefIndexes returns the name of related IngressClassParams objects.
@@ -34,10 +39,12 @@ type ReferenceIndexer interface {
 }
 
 // NewDefaultReferenceIndexer constructs new defaultReferenceIndexer.
-func NewDefaultReferenceIndexer(enhancedBackendBuilder EnhancedBackendBuilder, authConfigBuilder AuthConfigBuilder, logger logr.Logger) *defaultReferenceIndexer {
+func NewDefaultReferenceIndexer(enhancedBackendBuilder EnhancedBackendBuilder, authConfigBuilder AuthConfigBuilder,
+	annotationParser annotations.Parser, logger logr.Logger) *defaultReferenceIndexer {
 	return &defaultReferenceIndexer{
 		enhancedBackendBuilder: enhancedBackendBuilder,
 		authConfigBuilder:      authConfigBuilder,
+		annotationParser:       annotationParser,
 		logger:                 logger,
 	}
 }
@@ -48,6 +55,7 @@ var _ ReferenceIndexer = &defaultReferenceIndexer{}
 type defaultReferenceIndexer struct {
 	enhancedBackendBuilder EnhancedBackendBuilder
 	authConfigBuilder      AuthConfigBuilder
+	annotationParser       annotations.Parser
 	logger                 logr.Logger
 }
 
@@ -92,6 +100,14 @@ func (i *defaultReferenceIndexer) BuildSecretRefIndexes(ctx context.Context, ing
 	return extractSecretNamesFromAuthConfig(authCfg)
 }
 
+func (i *defaultReferenceIndexer) BuildConfigMapRefIndexes(_ context.Context, ing *networking.Ingress) []string {
+	var configMapName string
+	if exists := i.annotationParser.ParseStringAnnotation(annotations.IngressSuffixLoadBalancerAttributesConfigMap, &configMapName, ing.Annotations); !exists {
+		return nil
+	}
+	return []string{configMapName}
+}
+
 func (i *defaultReferenceIndexer) BuildIngressClassRefIndexes(_ context.Context, ing *networking.Ingress) []string {
 	if ing.Spec.IngressClassName == nil {
 		return nil
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
@@ -13,6 +14,9 @@ type Rule struct {
 	Conditions []elbv2model.RuleCondition
 	Actions    []elbv2model.Action
 	Tags       map[string]string
+
+	// Ing is the Ingress this rule is derived from.
+	Ing *networking.Ingress
 }
 
 // RuleOptimizer will optimize the listener Rules for a single Listener.
@@ -1,16 +1,34 @@
 package inject
 
-import "github.com/spf13/pflag"
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
+)
 
 const (
-	flagEnablePodReadinessGateInject = "enable-pod-readiness-gate-inject"
+	flagEnablePodReadinessGateInject      = "enable-pod-readiness-gate-inject"
+	flagPodReadinessGateNamespaceSelector = "pod-readiness-gate-namespace-selector"
 )
 
 type Config struct {
 	EnablePodReadinessGateInject bool
+	// PodReadinessGateNamespaceSelector is the label selector for namespaces where readiness gates are injected,
+	// in addition to namespaces labeled with the readiness gate inject label.
+	PodReadinessGateNamespaceSelector string
 }
 
 func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&cfg.EnablePodReadinessGateInject, flagEnablePodReadinessGateInject, true,
 		`If enabled, targetHealth readiness gate will get injected to the pod spec for the matching endpoint pods`)
+	fs.StringVar(&cfg.PodReadinessGateNamespaceSelector, flagPodReadinessGateNamespaceSelector, "",
+		`Label selector for namespaces where targetHealth readiness gate will get injected, in addition to namespaces labeled with elbv2.k8s.aws/pod-readiness-gate-inject=enabled`)
+}
+
+// Validate the pod webhook configuration
+func (cfg *Config) Validate() error {
+	if _, err := labels.Parse(cfg.PodReadinessGateNamespaceSelector); err != nil {
+		return errors.Wrapf(err, "invalid %v flag", flagPodReadinessGateNamespaceSelector)
+	}
+	return nil
 }
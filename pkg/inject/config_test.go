@@ -0,0 +1,45 @@
+package inject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:    "namespace selector unspecified",
+			config:  Config{},
+			wantErr: false,
+		},
+		{
+			name: "valid namespace selector",
+			config: Config{
+				PodReadinessGateNamespaceSelector: "team in (platform),!legacy",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid namespace selector",
+			config: Config{
+				PodReadinessGateNamespaceSelector: "team in platform",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -16,6 +16,13 @@ import (
 	"strings"
 )
 
+const (
+	// podReadinessGateInjectLabel is the namespace label to opt-in readiness gate injection.
+	podReadinessGateInjectLabel = "elbv2.k8s.aws/pod-readiness-gate-inject"
+	// podReadinessGateInjectLabelEnabled is the value of podReadinessGateInjectLabel to opt-in readiness gate injection.
+	podReadinessGateInjectLabelEnabled = "enabled"
+)
+
 // NewPodReadinessGate constructs new PodReadinessGate
 func NewPodReadinessGate(config Config, k8sClient client.Client, logger logr.Logger) *PodReadinessGate {
 	return &PodReadinessGate{
@@ -41,6 +48,13 @@ func (m *PodReadinessGate) Mutate(ctx context.Context, pod *corev1.Pod) error {
 
 	// see https://github.com/kubernetes/kubernetes/issues/88282 and https://github.com/kubernetes/kubernetes/issues/76680
 	req := webhook.ContextGetAdmissionRequest(ctx)
+	injectEnabled, err := m.isInjectEnabledForNamespace(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	if !injectEnabled {
+		return nil
+	}
 	targetHealthCondTypes, err := m.computeTargetHealthReadinessGateConditionTypes(ctx, req.Namespace, pod)
 	if err != nil {
 		return err
@@ -61,6 +75,27 @@ func (m *PodReadinessGate) Mutate(ctx context.Context, pod *corev1.Pod) error {
 	return nil
 }
 
+// isInjectEnabledForNamespace checks whether readiness gates should be injected for pods in namespace.
+// when namespace selector is configured, namespaces either labeled with the readiness gate inject label or matching the selector are enabled.
+// otherwise, all namespaces are enabled since the webhook is only invoked for namespaces labeled with the readiness gate inject label.
+func (m *PodReadinessGate) isInjectEnabledForNamespace(ctx context.Context, namespace string) (bool, error) {
+	if len(m.config.PodReadinessGateNamespaceSelector) == 0 {
+		return true, nil
+	}
+	nsSelector, err := labels.Parse(m.config.PodReadinessGateNamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	ns := &corev1.Namespace{}
+	if err := m.k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, errors.Wrap(err, "unable to determine targetHealth readinessGates")
+	}
+	if ns.Labels[podReadinessGateInjectLabel] == podReadinessGateInjectLabelEnabled {
+		return true, nil
+	}
+	return nsSelector.Matches(labels.Set(ns.Labels)), nil
+}
+
 // computeTargetHealthReadinessGateConditionTypes computes the desired condition types for targetHealth readiness gate.
 func (m *PodReadinessGate) computeTargetHealthReadinessGateConditionTypes(ctx context.Context, namespace string, pod *corev1.Pod) ([]corev1.PodConditionType, error) {
 	tgbList := &elbv2api.TargetGroupBindingList{}
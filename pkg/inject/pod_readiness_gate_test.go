@@ -114,14 +114,15 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 	}
 
 	tests := []struct {
-		name      string
-		namespace string
-		services  []*corev1.Service
-		tgbList   []*elbv2api.TargetGroupBinding
-		pod       *corev1.Pod
-		want      []corev1.PodReadinessGate
-		config    Config
-		wantError bool
+		name       string
+		namespace  string
+		services   []*corev1.Service
+		tgbList    []*elbv2api.TargetGroupBinding
+		namespaces []*corev1.Namespace
+		pod        *corev1.Pod
+		want       []corev1.PodReadinessGate
+		config     Config
+		wantError  bool
 	}{
 		{
 			name:      "matching tgb with ip targetType",
@@ -146,6 +147,36 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 				EnablePodReadinessGateInject: true,
 			},
 		},
+		{
+			name:      "matching tgb with readiness gate already injected",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb1},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":    "app-1",
+						"svc":    "svc1",
+						"stable": "none",
+					},
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/tgb-1-l6qw1",
+						},
+					},
+				},
+			},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-1-l6qw1",
+				},
+			},
+			config: Config{
+				EnablePodReadinessGateInject: true,
+			},
+		},
 		{
 			name:      "matching tgb but with instance targetType",
 			namespace: testNS1,
@@ -304,6 +335,105 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 				EnablePodReadinessGateInject: true,
 			},
 		},
+		{
+			name:      "namespace matches namespace selector",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb1},
+			namespaces: []*corev1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testNS1,
+						Labels: map[string]string{
+							"team": "platform",
+						},
+					},
+				},
+			},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":    "app-1",
+						"svc":    "svc1",
+						"stable": "none",
+					},
+				},
+			},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-1-l6qw1",
+				},
+			},
+			config: Config{
+				EnablePodReadinessGateInject:      true,
+				PodReadinessGateNamespaceSelector: "team in (platform)",
+			},
+		},
+		{
+			name:      "namespace doesn't match namespace selector",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb1},
+			namespaces: []*corev1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testNS1,
+						Labels: map[string]string{
+							"team": "app",
+						},
+					},
+				},
+			},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":    "app-1",
+						"svc":    "svc1",
+						"stable": "none",
+					},
+				},
+			},
+			want: nil,
+			config: Config{
+				EnablePodReadinessGateInject:      true,
+				PodReadinessGateNamespaceSelector: "team in (platform)",
+			},
+		},
+		{
+			name:      "namespace doesn't match namespace selector but labeled with inject label",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb1},
+			namespaces: []*corev1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testNS1,
+						Labels: map[string]string{
+							"team": "app",
+							"elbv2.k8s.aws/pod-readiness-gate-inject": "enabled",
+						},
+					},
+				},
+			},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":    "app-1",
+						"svc":    "svc1",
+						"stable": "none",
+					},
+				},
+			},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-1-l6qw1",
+				},
+			},
+			config: Config{
+				EnablePodReadinessGateInject:      true,
+				PodReadinessGateNamespaceSelector: "team in (platform)",
+			},
+		},
 		{
 			name:      "inject disabled",
 			namespace: testNS1,
@@ -351,6 +481,9 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 			for _, tgb := range tt.tgbList {
 				assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
 			}
+			for _, ns := range tt.namespaces {
+				assert.NoError(t, k8sClient.Create(ctx, ns.DeepCopy()))
+			}
 			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{
 				AdmissionRequest: admissionv1.AdmissionRequest{Namespace: tt.namespace},
 			})
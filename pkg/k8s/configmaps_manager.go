@@ -0,0 +1,131 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// ConfigMapsManager manages the ConfigMap informers for referenced ConfigMaps.
+type ConfigMapsManager interface {
+	// MonitorConfigMaps watches the given ConfigMaps on behalf of the given consumer, ConfigMaps no longer
+	// referenced by any consumer are no longer watched.
+	MonitorConfigMaps(consumerID string, configMaps []types.NamespacedName)
+}
+
+// NewDefaultConfigMapsManager constructs new defaultConfigMapsManager.
+func NewDefaultConfigMapsManager(clientSet kubernetes.Interface, configMapEventChan chan<- event.GenericEvent, logger logr.Logger) *defaultConfigMapsManager {
+	return &defaultConfigMapsManager{
+		clientSet:          clientSet,
+		configMapEventChan: configMapEventChan,
+		configMapItems:     make(map[types.NamespacedName]*configMapItem),
+		logger:             logger,
+	}
+}
+
+var _ ConfigMapsManager = &defaultConfigMapsManager{}
+
+// defaultConfigMapsManager runs an informer with field selector on metadata.name per referenced ConfigMap,
+// so that only referenced ConfigMaps are watched and cached.
+type defaultConfigMapsManager struct {
+	clientSet          kubernetes.Interface
+	configMapEventChan chan<- event.GenericEvent
+	logger             logr.Logger
+
+	mutex          sync.Mutex
+	configMapItems map[types.NamespacedName]*configMapItem
+}
+
+type configMapItem struct {
+	consumers sets.String
+	stopCh    chan struct{}
+}
+
+func (m *defaultConfigMapsManager) MonitorConfigMaps(consumerID string, configMaps []types.NamespacedName) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	desiredConfigMaps := make(map[types.NamespacedName]struct{}, len(configMaps))
+	for _, configMapKey := range configMaps {
+		desiredConfigMaps[configMapKey] = struct{}{}
+		item, exists := m.configMapItems[configMapKey]
+		if !exists {
+			item = m.startInformer(configMapKey)
+			m.configMapItems[configMapKey] = item
+		}
+		item.consumers.Insert(consumerID)
+	}
+
+	for configMapKey, item := range m.configMapItems {
+		if _, desired := desiredConfigMaps[configMapKey]; desired {
+			continue
+		}
+		item.consumers.Delete(consumerID)
+		if item.consumers.Len() == 0 {
+			m.logger.V(1).Info("stop monitoring configMap", "configMap", configMapKey)
+			close(item.stopCh)
+			delete(m.configMapItems, configMapKey)
+		}
+	}
+}
+
+func (m *defaultConfigMapsManager) startInformer(configMapKey types.NamespacedName) *configMapItem {
+	m.logger.V(1).Info("start monitoring configMap", "configMap", configMapKey)
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", configMapKey.Name).String()
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return m.clientSet.CoreV1().ConfigMaps(configMapKey.Namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return m.clientSet.CoreV1().ConfigMaps(configMapKey.Namespace).Watch(context.Background(), options)
+		},
+	}
+	_, informer := cache.NewInformer(listWatch, &corev1.ConfigMap{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			m.notify(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// we only care about ConfigMap data updates.
+			if equality.Semantic.DeepEqual(oldObj.(*corev1.ConfigMap).Data, newObj.(*corev1.ConfigMap).Data) {
+				return
+			}
+			m.notify(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			m.notify(obj)
+		},
+	})
+	stopCh := make(chan struct{})
+	go informer.Run(stopCh)
+	return &configMapItem{
+		consumers: sets.NewString(),
+		stopCh:    stopCh,
+	}
+}
+
+func (m *defaultConfigMapsManager) notify(obj interface{}) {
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	m.configMapEventChan <- event.GenericEvent{
+		Object: configMap,
+	}
+}
@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultConfigMapsManager_MonitorConfigMaps(t *testing.T) {
+	type monitorCall struct {
+		consumerID string
+		configMaps []types.NamespacedName
+	}
+	cmA := types.NamespacedName{Namespace: "ns-1", Name: "cm-a"}
+	cmB := types.NamespacedName{Namespace: "ns-1", Name: "cm-b"}
+	cmC := types.NamespacedName{Namespace: "ns-2", Name: "cm-a"}
+	tests := []struct {
+		name          string
+		monitorCalls  []monitorCall
+		wantConsumers map[types.NamespacedName]sets.String
+	}{
+		{
+			name: "single consumer with configMaps",
+			monitorCalls: []monitorCall{
+				{consumerID: "group-1", configMaps: []types.NamespacedName{cmA, cmB}},
+			},
+			wantConsumers: map[types.NamespacedName]sets.String{
+				cmA: sets.NewString("group-1"),
+				cmB: sets.NewString("group-1"),
+			},
+		},
+		{
+			name: "multiple consumers share configMap",
+			monitorCalls: []monitorCall{
+				{consumerID: "group-1", configMaps: []types.NamespacedName{cmA, cmB}},
+				{consumerID: "group-2", configMaps: []types.NamespacedName{cmB, cmC}},
+			},
+			wantConsumers: map[types.NamespacedName]sets.String{
+				cmA: sets.NewString("group-1"),
+				cmB: sets.NewString("group-1", "group-2"),
+				cmC: sets.NewString("group-2"),
+			},
+		},
+		{
+			name: "configMaps no longer referenced by consumer are released",
+			monitorCalls: []monitorCall{
+				{consumerID: "group-1", configMaps: []types.NamespacedName{cmA, cmB}},
+				{consumerID: "group-2", configMaps: []types.NamespacedName{cmB}},
+				{consumerID: "group-1", configMaps: []types.NamespacedName{cmC}},
+			},
+			wantConsumers: map[types.NamespacedName]sets.String{
+				cmB: sets.NewString("group-2"),
+				cmC: sets.NewString("group-1"),
+			},
+		},
+		{
+			name: "all configMaps released",
+			monitorCalls: []monitorCall{
+				{consumerID: "group-1", configMaps: []types.NamespacedName{cmA}},
+				{consumerID: "group-1", configMaps: nil},
+			},
+			wantConsumers: map[types.NamespacedName]sets.String{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewDefaultConfigMapsManager(fake.NewSimpleClientset(), make(chan event.GenericEvent, 10), &log.NullLogger{})
+			for _, call := range tt.monitorCalls {
+				m.MonitorConfigMaps(call.consumerID, call.configMaps)
+			}
+			gotConsumers := make(map[types.NamespacedName]sets.String, len(m.configMapItems))
+			for configMapKey, item := range m.configMapItems {
+				gotConsumers[configMapKey] = item.consumers
+			}
+			assert.Equal(t, tt.wantConsumers, gotConsumers)
+			m.MonitorConfigMaps("group-1", nil)
+			m.MonitorConfigMaps("group-2", nil)
+		})
+	}
+}
+
+func Test_defaultConfigMapsManager_notifiesConfigMapEvents(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	configMapEventChan := make(chan event.GenericEvent, 10)
+	m := NewDefaultConfigMapsManager(clientSet, configMapEventChan, &log.NullLogger{})
+	configMapKey := types.NamespacedName{Namespace: "ns-1", Name: "cm-a"}
+	m.MonitorConfigMaps("group-1", []types.NamespacedName{configMapKey})
+	defer m.MonitorConfigMaps("group-1", nil)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: configMapKey.Namespace, Name: configMapKey.Name},
+		Data:       map[string]string{"idle_timeout.timeout_seconds": "60"},
+	}
+	_, err := clientSet.CoreV1().ConfigMaps(configMapKey.Namespace).Create(context.Background(), configMap, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	select {
+	case e := <-configMapEventChan:
+		assert.Equal(t, configMapKey, NamespacedName(e.Object))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for configMap event")
+	}
+}
@@ -0,0 +1,108 @@
+package k8s
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// max number of objects to track event rate limiting state for.
+	eventRateLimitStateCacheSize = 4096
+	// duration to keep event rate limiting state of an object since its last emitted event.
+	// identical events are emitted again once expired, which matches the default TTL of events on API server.
+	eventRateLimitStateTTL = 1 * time.Hour
+)
+
+// NewRateLimitedEventRecorder constructs new EventRecorder that rate limits events per object with token bucket.
+// Identical consecutive events for the same object are deduplicated as well.
+func NewRateLimitedEventRecorder(eventRecorder record.EventRecorder, qps float32, burst int) *rateLimitedEventRecorder {
+	return &rateLimitedEventRecorder{
+		eventRecorder: eventRecorder,
+		qps:           qps,
+		burst:         burst,
+		stateByObject: cache.NewLRUExpireCache(eventRateLimitStateCacheSize),
+	}
+}
+
+var _ record.EventRecorder = &rateLimitedEventRecorder{}
+
+// eventRateLimitState is the event rate limiting state of an object.
+type eventRateLimitState struct {
+	rateLimiter flowcontrol.RateLimiter
+	lastEvent   string
+}
+
+// rateLimitedEventRecorder is an EventRecorder that rate limits events per object.
+type rateLimitedEventRecorder struct {
+	eventRecorder record.EventRecorder
+	qps           float32
+	burst         int
+
+	stateByObjectMutex sync.Mutex
+	stateByObject      *cache.LRUExpireCache
+}
+
+func (r *rateLimitedEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.shouldEmit(object, eventtype, reason, message) {
+		r.eventRecorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *rateLimitedEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *rateLimitedEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.shouldEmit(object, eventtype, reason, message) {
+		r.eventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// shouldEmit checks whether an event should be emitted for object, and updates the rate limiting state of object if so.
+func (r *rateLimitedEventRecorder) shouldEmit(object runtime.Object, eventtype, reason, message string) bool {
+	objectKey, ok := buildEventObjectKey(object)
+	if !ok {
+		return true
+	}
+	event := fmt.Sprintf("%v/%v/%v", eventtype, reason, message)
+
+	r.stateByObjectMutex.Lock()
+	defer r.stateByObjectMutex.Unlock()
+	var state *eventRateLimitState
+	if rawState, exists := r.stateByObject.Get(objectKey); exists {
+		state = rawState.(*eventRateLimitState)
+	} else {
+		state = &eventRateLimitState{
+			rateLimiter: flowcontrol.NewTokenBucketRateLimiter(r.qps, r.burst),
+		}
+	}
+	if state.lastEvent == event {
+		return false
+	}
+	if !state.rateLimiter.TryAccept() {
+		return false
+	}
+	state.lastEvent = event
+	r.stateByObject.Add(objectKey, state, eventRateLimitStateTTL)
+	return true
+}
+
+// buildEventObjectKey builds the key to track event rate limiting state of object.
+func buildEventObjectKey(object runtime.Object) (string, bool) {
+	metaObj, err := meta.Accessor(object)
+	if err != nil {
+		return "", false
+	}
+	if uid := metaObj.GetUID(); len(uid) != 0 {
+		return string(uid), true
+	}
+	return fmt.Sprintf("%T/%v/%v", object, metaObj.GetNamespace(), metaObj.GetName()), true
+}
@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_rateLimitedEventRecorder_Event(t *testing.T) {
+	type event struct {
+		objectUID types.UID
+		eventtype string
+		reason    string
+		message   string
+	}
+	tests := []struct {
+		name       string
+		burst      int
+		events     []event
+		wantEvents []string
+	}{
+		{
+			name:  "identical consecutive events should be deduplicated",
+			burst: 10,
+			events: []event{
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "some error"},
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "some error"},
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "some error"},
+			},
+			wantEvents: []string{
+				"Warning FailedDeployModel some error",
+			},
+		},
+		{
+			name:  "identical non-consecutive events should be emitted",
+			burst: 10,
+			events: []event{
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "some error"},
+				{objectUID: "uid-1", eventtype: corev1.EventTypeNormal, reason: "SuccessfullyReconciled", message: "Successfully reconciled"},
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "some error"},
+			},
+			wantEvents: []string{
+				"Warning FailedDeployModel some error",
+				"Normal SuccessfullyReconciled Successfully reconciled",
+				"Warning FailedDeployModel some error",
+			},
+		},
+		{
+			name:  "events exceeding burst should be suppressed",
+			burst: 2,
+			events: []event{
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "error 1"},
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "error 2"},
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "error 3"},
+			},
+			wantEvents: []string{
+				"Warning FailedDeployModel error 1",
+				"Warning FailedDeployModel error 2",
+			},
+		},
+		{
+			name:  "events should be rate limited per object",
+			burst: 1,
+			events: []event{
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "error 1"},
+				{objectUID: "uid-2", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "error 1"},
+				{objectUID: "uid-1", eventtype: corev1.EventTypeWarning, reason: "FailedDeployModel", message: "error 2"},
+			},
+			wantEvents: []string{
+				"Warning FailedDeployModel error 1",
+				"Warning FailedDeployModel error 1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeRecorder := record.NewFakeRecorder(len(tt.events))
+			r := NewRateLimitedEventRecorder(fakeRecorder, 0.001, tt.burst)
+			for _, e := range tt.events {
+				svc := &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-svc",
+						UID:       e.objectUID,
+					},
+				}
+				r.Event(svc, e.eventtype, e.reason, e.message)
+			}
+			close(fakeRecorder.Events)
+			var gotEvents []string
+			for e := range fakeRecorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
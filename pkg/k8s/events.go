@@ -2,28 +2,51 @@ package k8s
 
 const (
 	// Ingress events
-	IngressEventReasonConflictingIngressClass = "ConflictingIngressClass"
-	IngressEventReasonFailedLoadGroupID       = "FailedLoadGroupID"
-	IngressEventReasonFailedAddFinalizer      = "FailedAddFinalizer"
-	IngressEventReasonFailedRemoveFinalizer   = "FailedRemoveFinalizer"
-	IngressEventReasonFailedUpdateStatus      = "FailedUpdateStatus"
-	IngressEventReasonFailedBuildModel        = "FailedBuildModel"
-	IngressEventReasonFailedDeployModel       = "FailedDeployModel"
-	IngressEventReasonSuccessfullyReconciled  = "SuccessfullyReconciled"
+	IngressEventReasonConflictingIngressClass      = "ConflictingIngressClass"
+	IngressEventReasonFailedLoadGroupID            = "FailedLoadGroupID"
+	IngressEventReasonFailedAddFinalizer           = "FailedAddFinalizer"
+	IngressEventReasonFailedRemoveFinalizer        = "FailedRemoveFinalizer"
+	IngressEventReasonFailedUpdateStatus           = "FailedUpdateStatus"
+	IngressEventReasonFailedBuildModel             = "FailedBuildModel"
+	IngressEventReasonFailedDeployModel            = "FailedDeployModel"
+	IngressEventReasonSuccessfullyReconciled       = "SuccessfullyReconciled"
+	IngressEventReasonListenerRuleLimitExceeded    = "ListenerRuleLimitExceeded"
+	IngressEventReasonLoadBalancerProvisioning     = "LoadBalancerProvisioning"
+	IngressEventReasonFailedProvisionLoadBalancer  = "FailedProvisionLoadBalancer"
+	IngressEventReasonBackendNotFound              = "BackendNotFound"
+	IngressEventReasonInsufficientSubnets          = "InsufficientSubnets"
+	IngressEventReasonWAFFailOpenWithoutWebACL     = "WAFFailOpenWithoutWebACL"
+	IngressEventReasonDuplicateRuleIgnored         = "DuplicateRuleIgnored"
+	IngressEventReasonHealthCheckProtocolMismatch  = "HealthCheckProtocolMismatch"
+	IngressEventReasonSecurityGroupDeletionDelayed = "SecurityGroupDeletionDelayed"
+	IngressEventReasonImmutableFieldChange         = "ImmutableFieldChange"
+	IngressEventReasonListenerRecreated            = "ListenerRecreated"
+	IngressEventReasonNamespaceTagsSkipped         = "NamespaceTagsSkipped"
 
 	// Service events
-	ServiceEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
-	ServiceEventReasonFailedRemoveFinalizer  = "FailedRemoveFinalizer"
-	ServiceEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
-	ServiceEventReasonFailedBuildModel       = "FailedBuildModel"
-	ServiceEventReasonFailedDeployModel      = "FailedDeployModel"
-	ServiceEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+	ServiceEventReasonFailedAddFinalizer              = "FailedAddFinalizer"
+	ServiceEventReasonFailedRemoveFinalizer           = "FailedRemoveFinalizer"
+	ServiceEventReasonFailedUpdateStatus              = "FailedUpdateStatus"
+	ServiceEventReasonFailedBuildModel                = "FailedBuildModel"
+	ServiceEventReasonFailedDeployModel               = "FailedDeployModel"
+	ServiceEventReasonSuccessfullyReconciled          = "SuccessfullyReconciled"
+	ServiceEventReasonLoadBalancerProvisioning        = "LoadBalancerProvisioning"
+	ServiceEventReasonFailedProvisionLoadBalancer     = "FailedProvisionLoadBalancer"
+	ServiceEventReasonCapacityReservationNotSupported = "CapacityReservationNotSupported"
+	ServiceEventReasonInsufficientSubnets             = "InsufficientSubnets"
+	ServiceEventReasonDeprecatedAnnotation            = "DeprecatedAnnotation"
+	ServiceEventReasonHealthCheckProtocolMismatch     = "HealthCheckProtocolMismatch"
+	ServiceEventReasonImmutableFieldChange            = "ImmutableFieldChange"
+	ServiceEventReasonNoServicePorts                  = "NoServicePorts"
+	ServiceEventReasonListenerRecreated               = "ListenerRecreated"
+	ServiceEventReasonNamespaceTagsSkipped            = "NamespaceTagsSkipped"
 
 	// TargetGroupBinding events
-	TargetGroupBindingEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
-	TargetGroupBindingEventReasonFailedRemoveFinalizer  = "FailedRemoveFinalizer"
-	TargetGroupBindingEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
-	TargetGroupBindingEventReasonFailedCleanup          = "FailedCleanup"
-	TargetGroupBindingEventReasonBackendNotFound        = "BackendNotFound"
-	TargetGroupBindingEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+	TargetGroupBindingEventReasonFailedAddFinalizer            = "FailedAddFinalizer"
+	TargetGroupBindingEventReasonFailedRemoveFinalizer         = "FailedRemoveFinalizer"
+	TargetGroupBindingEventReasonFailedUpdateStatus            = "FailedUpdateStatus"
+	TargetGroupBindingEventReasonFailedCleanup                 = "FailedCleanup"
+	TargetGroupBindingEventReasonBackendNotFound               = "BackendNotFound"
+	TargetGroupBindingEventReasonPartialSecurityGroupReconcile = "PartialSecurityGroupReconcile"
+	TargetGroupBindingEventReasonSuccessfullyReconciled        = "SuccessfullyReconciled"
 )
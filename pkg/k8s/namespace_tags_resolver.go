@@ -0,0 +1,124 @@
+package k8s
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maximum length of AWS tag key in characters.
+	maxTagKeyLength = 128
+	// prefix of AWS tag keys reserved by AWS.
+	reservedTagKeyPrefix = "aws:"
+	// MaxTagsPerResource is the maximum number of AWS tags per resource.
+	MaxTagsPerResource = 50
+	// number of tags the controller adds to track resources, i.e. cluster, stack and resource ID tags.
+	trackingTagsCount = 3
+)
+
+// tagSafePattern matches characters allowed in AWS tag keys and values.
+var tagSafePattern = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+
+// NamespaceTagsResolver resolves AWS tags from labels of Namespace.
+type NamespaceTagsResolver interface {
+	// Resolve returns tags from labels of namespace whose key matches any configured prefix.
+	// labels that are not tag-safe are skipped.
+	Resolve(ctx context.Context, namespace string) (map[string]string, error)
+}
+
+// NewDefaultNamespaceTagsResolver constructs new defaultNamespaceTagsResolver.
+func NewDefaultNamespaceTagsResolver(k8sClient client.Client, labelKeyPrefixes []string, logger logr.Logger) *defaultNamespaceTagsResolver {
+	return &defaultNamespaceTagsResolver{
+		k8sClient:        k8sClient,
+		labelKeyPrefixes: labelKeyPrefixes,
+		logger:           logger,
+	}
+}
+
+var _ NamespaceTagsResolver = &defaultNamespaceTagsResolver{}
+
+// default implementation for NamespaceTagsResolver.
+type defaultNamespaceTagsResolver struct {
+	k8sClient        client.Client
+	labelKeyPrefixes []string
+	logger           logr.Logger
+}
+
+func (r *defaultNamespaceTagsResolver) Resolve(ctx context.Context, namespace string) (map[string]string, error) {
+	if len(r.labelKeyPrefixes) == 0 {
+		return nil, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for labelKey, labelValue := range ns.Labels {
+		if !r.matchesLabelKeyPrefixes(labelKey) {
+			continue
+		}
+		if !isTagSafeKey(labelKey) || !tagSafePattern.MatchString(labelValue) {
+			r.logger.V(1).Info("skipping namespace label that is not tag-safe",
+				"namespace", namespace,
+				"label", labelKey)
+			continue
+		}
+		tags[labelKey] = labelValue
+	}
+	return tags, nil
+}
+
+func (r *defaultNamespaceTagsResolver) matchesLabelKeyPrefixes(labelKey string) bool {
+	for _, prefix := range r.labelKeyPrefixes {
+		if strings.HasPrefix(labelKey, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTagSafeKey checks whether key can be used as AWS tag key.
+func isTagSafeKey(key string) bool {
+	if len(key) == 0 || utf8.RuneCountInString(key) > maxTagKeyLength {
+		return false
+	}
+	if strings.HasPrefix(strings.ToLower(key), reservedTagKeyPrefix) {
+		return false
+	}
+	return tagSafePattern.MatchString(key)
+}
+
+// MergeNamespaceTags merges nsTags into tags with the lowest priority, and returns keys of namespace tags that are skipped.
+// namespace tags are skipped in sorted order of keys once the merged tags together with tracking tags would exceed
+// the AWS limit of tags per resource, so that the same namespace tags are applied across reconciles.
+func MergeNamespaceTags(tags map[string]string, nsTags map[string]string) (map[string]string, []string) {
+	mergedTags := make(map[string]string, len(tags)+len(nsTags))
+	for tagKey, tagValue := range tags {
+		mergedTags[tagKey] = tagValue
+	}
+	nsTagKeys := make([]string, 0, len(nsTags))
+	for tagKey := range nsTags {
+		if _, exists := mergedTags[tagKey]; !exists {
+			nsTagKeys = append(nsTagKeys, tagKey)
+		}
+	}
+	sort.Strings(nsTagKeys)
+
+	var skippedNSTagKeys []string
+	for _, tagKey := range nsTagKeys {
+		if len(mergedTags)+trackingTagsCount >= MaxTagsPerResource {
+			skippedNSTagKeys = append(skippedNSTagKeys, tagKey)
+			continue
+		}
+		mergedTags[tagKey] = nsTags[tagKey]
+	}
+	return mergedTags, skippedNSTagKeys
+}
@@ -0,0 +1,194 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultNamespaceTagsResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name             string
+		labelKeyPrefixes []string
+		nsLabels         map[string]string
+		namespace        string
+		want             map[string]string
+		wantErr          string
+	}{
+		{
+			name:             "no label key prefixes",
+			labelKeyPrefixes: nil,
+			nsLabels: map[string]string{
+				"cost-center": "1234",
+			},
+			namespace: "awesome-ns",
+			want:      nil,
+		},
+		{
+			name:             "labels matching prefixes become tags",
+			labelKeyPrefixes: []string{"cost-center", "example.com/"},
+			nsLabels: map[string]string{
+				"cost-center":       "1234",
+				"example.com/owner": "team-a",
+				"app":               "awesome-app",
+			},
+			namespace: "awesome-ns",
+			want: map[string]string{
+				"cost-center":       "1234",
+				"example.com/owner": "team-a",
+			},
+		},
+		{
+			name:             "labels that are not tag-safe are skipped",
+			labelKeyPrefixes: []string{"aws:", "owner", "example.com/"},
+			nsLabels: map[string]string{
+				"aws:owner": "team-a",
+				"owner":     "team-a",
+				"example.com/" + strings.Repeat("k", 120): "value",
+			},
+			namespace: "awesome-ns",
+			want: map[string]string{
+				"owner": "team-a",
+			},
+		},
+		{
+			name:             "namespace not found",
+			labelKeyPrefixes: []string{"owner"},
+			namespace:        "another-ns",
+			wantErr:          "namespaces \"another-ns\" not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			err := k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "awesome-ns",
+					Labels: tt.nsLabels,
+				},
+			})
+			assert.NoError(t, err)
+
+			r := NewDefaultNamespaceTagsResolver(k8sClient, tt.labelKeyPrefixes, &log.NullLogger{})
+			got, err := r.Resolve(ctx, tt.namespace)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_isTagSafeKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{
+			name: "label key with prefix",
+			key:  "example.com/cost-center",
+			want: true,
+		},
+		{
+			name: "empty key",
+			key:  "",
+			want: false,
+		},
+		{
+			name: "key reserved by AWS",
+			key:  "AWS:owner",
+			want: false,
+		},
+		{
+			name: "key exceeds maximum length",
+			key:  strings.Repeat("k", 129),
+			want: false,
+		},
+		{
+			name: "key with invalid characters",
+			key:  "owner*",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTagSafeKey(tt.key))
+		})
+	}
+}
+
+func TestMergeNamespaceTags(t *testing.T) {
+	buildTags := func(keyPrefix string, count int) map[string]string {
+		tags := make(map[string]string, count)
+		for i := 0; i < count; i++ {
+			tags[fmt.Sprintf("%s%02d", keyPrefix, i)] = "value"
+		}
+		return tags
+	}
+	tests := []struct {
+		name                 string
+		tags                 map[string]string
+		nsTags               map[string]string
+		wantTags             map[string]string
+		wantSkippedNSTagKeys []string
+	}{
+		{
+			name:   "namespace tags have lowest priority",
+			tags:   map[string]string{"team": "a"},
+			nsTags: map[string]string{"team": "b", "cost-center": "1234"},
+			wantTags: map[string]string{
+				"team":        "a",
+				"cost-center": "1234",
+			},
+		},
+		{
+			name:     "no namespace tags",
+			tags:     map[string]string{"team": "a"},
+			nsTags:   nil,
+			wantTags: map[string]string{"team": "a"},
+		},
+		{
+			name:     "namespace tags within limit",
+			tags:     buildTags("tag-", 40),
+			nsTags:   buildTags("ns-", 7),
+			wantTags: algorithm.MergeStringMap(buildTags("tag-", 40), buildTags("ns-", 7)),
+		},
+		{
+			name:                 "namespace tags exceeding limit are skipped in order of keys",
+			tags:                 buildTags("tag-", 40),
+			nsTags:               buildTags("ns-", 10),
+			wantTags:             algorithm.MergeStringMap(buildTags("tag-", 40), buildTags("ns-", 7)),
+			wantSkippedNSTagKeys: []string{"ns-07", "ns-08", "ns-09"},
+		},
+		{
+			name:                 "namespace tags are skipped when tags reach limit",
+			tags:                 buildTags("tag-", 47),
+			nsTags:               map[string]string{"cost-center": "1234"},
+			wantTags:             buildTags("tag-", 47),
+			wantSkippedNSTagKeys: []string{"cost-center"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTags, gotSkippedNSTagKeys := MergeNamespaceTags(tt.tags, tt.nsTags)
+			assert.Equal(t, tt.wantTags, gotTags)
+			assert.Equal(t, tt.wantSkippedNSTagKeys, gotSkippedNSTagKeys)
+		})
+	}
+}
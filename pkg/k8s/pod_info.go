@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"time"
 )
 
 const (
@@ -23,6 +25,12 @@ type PodInfo struct {
 	Conditions     []corev1.PodCondition
 	PodIP          string
 
+	// CreationTimestamp is the time when pod is created.
+	CreationTimestamp metav1.Time
+
+	// DeletionTimestamp is set when pod starts terminating.
+	DeletionTimestamp *metav1.Time
+
 	ENIInfos []PodENIInfo
 }
 
@@ -54,6 +62,27 @@ func (i *PodInfo) IsContainersReady() bool {
 	return exists && containersReadyCond.Status == corev1.ConditionTrue
 }
 
+// HasAllPodConditionsTrue returns whether podInfo has all these conditions with true status.
+func (i *PodInfo) HasAllPodConditionsTrue(conditionTypes []corev1.PodConditionType) bool {
+	for _, conditionType := range conditionTypes {
+		cond, exists := i.GetPodCondition(conditionType)
+		if !exists || cond.Status != corev1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
+
+// IsWithinGracePeriod returns whether podInfo is created within specified gracePeriod.
+func (i *PodInfo) IsWithinGracePeriod(gracePeriod time.Duration) bool {
+	return time.Since(i.CreationTimestamp.Time) < gracePeriod
+}
+
+// IsTerminating returns whether podInfo is terminating.
+func (i *PodInfo) IsTerminating() bool {
+	return i.DeletionTimestamp != nil
+}
+
 // GetPodCondition will get Pod's condition.
 func (i *PodInfo) GetPodCondition(conditionType corev1.PodConditionType) (corev1.PodCondition, bool) {
 	for _, cond := range i.Conditions {
@@ -103,6 +132,9 @@ func buildPodInfo(pod *corev1.Pod) PodInfo {
 		Conditions:     pod.Status.Conditions,
 		PodIP:          pod.Status.PodIP,
 
+		CreationTimestamp: pod.CreationTimestamp,
+		DeletionTimestamp: pod.DeletionTimestamp,
+
 		ENIInfos: podENIInfos,
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"testing"
+	"time"
 )
 
 func TestPodInfo_HasAnyOfReadinessGates(t *testing.T) {
@@ -145,6 +146,164 @@ func TestPodInfo_IsContainersReady(t *testing.T) {
 	}
 }
 
+func TestPodInfo_HasAllPodConditionsTrue(t *testing.T) {
+	tests := []struct {
+		name           string
+		pod            PodInfo
+		conditionTypes []corev1.PodConditionType
+		want           bool
+	}{
+		{
+			name: "pod have all conditions true",
+			pod: PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.ContainersReady,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   "example.com/warmed-up",
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+			conditionTypes: []corev1.PodConditionType{corev1.ContainersReady, "example.com/warmed-up"},
+			want:           true,
+		},
+		{
+			name: "pod have some conditions false",
+			pod: PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.ContainersReady,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   "example.com/warmed-up",
+						Status: corev1.ConditionFalse,
+					},
+				},
+			},
+			conditionTypes: []corev1.PodConditionType{corev1.ContainersReady, "example.com/warmed-up"},
+			want:           false,
+		},
+		{
+			name: "pod don't have some conditions",
+			pod: PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.ContainersReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+			conditionTypes: []corev1.PodConditionType{corev1.ContainersReady, "example.com/warmed-up"},
+			want:           false,
+		},
+		{
+			name: "no conditions required",
+			pod: PodInfo{
+				Key:        types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				Conditions: []corev1.PodCondition{},
+			},
+			conditionTypes: nil,
+			want:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pod.HasAllPodConditionsTrue(tt.conditionTypes)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPodInfo_IsTerminating(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	tests := []struct {
+		name string
+		pod  PodInfo
+		want bool
+	}{
+		{
+			name: "pod is terminating",
+			pod: PodInfo{
+				Key:               types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				DeletionTimestamp: &deletionTimestamp,
+			},
+			want: true,
+		},
+		{
+			name: "pod is not terminating",
+			pod: PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pod.IsTerminating()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPodInfo_IsWithinGracePeriod(t *testing.T) {
+	type args struct {
+		gracePeriod time.Duration
+	}
+	tests := []struct {
+		name string
+		pod  PodInfo
+		args args
+		want bool
+	}{
+		{
+			name: "pod created within grace period",
+			pod: PodInfo{
+				Key:               types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Second)),
+			},
+			args: args{
+				gracePeriod: 60 * time.Second,
+			},
+			want: true,
+		},
+		{
+			name: "pod created before grace period",
+			pod: PodInfo{
+				Key:               types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-90 * time.Second)),
+			},
+			args: args{
+				gracePeriod: 60 * time.Second,
+			},
+			want: false,
+		},
+		{
+			name: "zero grace period",
+			pod: PodInfo{
+				Key:               types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				CreationTimestamp: metav1.NewTime(time.Now()),
+			},
+			args: args{
+				gracePeriod: 0,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pod.IsWithinGracePeriod(tt.args.gracePeriod)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestPodInfo_GetPodCondition(t *testing.T) {
 	type args struct {
 		conditionType corev1.PodConditionType
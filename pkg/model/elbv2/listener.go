@@ -257,6 +257,10 @@ type ForwardActionConfig struct {
 	// The target group stickiness for the rule.
 	// +optional
 	TargetGroupStickinessConfig *TargetGroupStickinessConfig `json:"targetGroupStickinessConfig,omitempty"`
+
+	// [Application Load Balancer] The fixed response to serve instead of forwarding, while none of the target groups has healthy targets.
+	// +optional
+	NoHealthyTargetsFixedResponseConfig *FixedResponseActionConfig `json:"noHealthyTargetsFixedResponseConfig,omitempty"`
 }
 
 // Information about an action.
@@ -341,4 +345,7 @@ type ListenerSpec struct {
 type ListenerStatus struct {
 	// The Amazon Resource Name (ARN) of the listener.
 	ListenerARN string `json:"listenerARN"`
+
+	// Whether the listener is recreated, since the changes cannot be applied by modifying it in place.
+	Recreated bool `json:"recreated,omitempty"`
 }
@@ -106,6 +106,12 @@ type SubnetMapping struct {
 	SubnetID string `json:"subnetID"`
 }
 
+// Information about the minimum capacity reserved for a load balancer.
+type MinimumLoadBalancerCapacity struct {
+	// The number of capacity units.
+	CapacityUnits int64 `json:"capacityUnits"`
+}
+
 // Information about a load balancer attribute.
 type LoadBalancerAttribute struct {
 	// The name of the attribute.
@@ -148,6 +154,10 @@ type LoadBalancerSpec struct {
 	// +optional
 	LoadBalancerAttributes []LoadBalancerAttribute `json:"loadBalancerAttributes,omitempty"`
 
+	// [Network Load Balancers] The minimum capacity reserved for the load balancer.
+	// +optional
+	MinimumLoadBalancerCapacity *MinimumLoadBalancerCapacity `json:"minimumLoadBalancerCapacity,omitempty"`
+
 	// The tags.
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
@@ -160,4 +170,16 @@ type LoadBalancerStatus struct {
 
 	// The public DNS name of the load balancer.
 	DNSName string `json:"dnsName"`
+
+	// The state of the load balancer, e.g. provisioning or active.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// Whether capacity reservation isn't supported for the load balancer, e.g. not available for the account.
+	// +optional
+	CapacityReservationNotSupported bool `json:"capacityReservationNotSupported,omitempty"`
+
+	// The zonal DNS names of the load balancer, one per availability zone. Only available for network load balancers.
+	// +optional
+	ZonalDNSNames []string `json:"zonalDNSNames,omitempty"`
 }
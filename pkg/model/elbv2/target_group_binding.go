@@ -99,6 +99,10 @@ type TargetGroupBindingSpec struct {
 	// node selector for instance type target groups to only register certain nodes
 	// +optional
 	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// healthCheckGracePeriodSeconds is the duration since pod creation, during which unhealthy targets won't fail pod readiness gate.
+	// +optional
+	HealthCheckGracePeriodSeconds *int64 `json:"healthCheckGracePeriodSeconds,omitempty"`
 }
 
 // Template for TargetGroupBinding Custom Resource.
@@ -0,0 +1,27 @@
+package networking
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// DeduplicateCIDRs validates and deduplicates CIDRs, preserving the order of first occurrence.
+// CIDRs are compared by the network they denote, thus "10.0.0.1/24" and "10.0.0.0/24" are considered the same.
+func DeduplicateCIDRs(cidrs []string) ([]string, error) {
+	var result []string
+	seenNetworks := make(map[string]struct{}, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CIDR %v", cidr)
+		}
+		network := ipNet.String()
+		if _, seen := seenNetworks[network]; seen {
+			continue
+		}
+		seenNetworks[network] = struct{}{}
+		result = append(result, cidr)
+	}
+	return result, nil
+}
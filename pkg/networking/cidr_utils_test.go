@@ -0,0 +1,54 @@
+package networking
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicateCIDRs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cidrs   []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "no CIDRs",
+			cidrs: nil,
+			want:  nil,
+		},
+		{
+			name:  "distinct CIDRs",
+			cidrs: []string{"10.0.0.0/16", "192.168.0.0/24", "2001:db8::/32"},
+			want:  []string{"10.0.0.0/16", "192.168.0.0/24", "2001:db8::/32"},
+		},
+		{
+			name:  "duplicated CIDRs",
+			cidrs: []string{"10.0.0.0/16", "192.168.0.0/24", "10.0.0.0/16"},
+			want:  []string{"10.0.0.0/16", "192.168.0.0/24"},
+		},
+		{
+			name:  "CIDRs denoting same network",
+			cidrs: []string{"10.0.0.1/16", "10.0.0.0/16", "2001:db8::1/32", "2001:db8::/32"},
+			want:  []string{"10.0.0.1/16", "2001:db8::1/32"},
+		},
+		{
+			name:    "invalid CIDR",
+			cidrs:   []string{"10.0.0.0/16", "10.0.0.0"},
+			wantErr: errors.New("invalid CIDR 10.0.0.0: invalid CIDR address: 10.0.0.0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeduplicateCIDRs(tt.cidrs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -1,12 +1,189 @@
 package networking
 
 import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
+func Test_defaultPodENIInfoResolver_Resolve(t *testing.T) {
+	sgpPod := k8s.PodInfo{
+		Key:   types.NamespacedName{Namespace: "ns-1", Name: "sgp-pod"},
+		UID:   types.UID("sgp-pod-uuid"),
+		PodIP: "192.168.1.1",
+		ENIInfos: []k8s.PodENIInfo{
+			{
+				ENIID:     "eni-branch",
+				PrivateIP: "192.168.1.1",
+			},
+		},
+	}
+	regularPod := k8s.PodInfo{
+		Key:   types.NamespacedName{Namespace: "ns-1", Name: "regular-pod"},
+		UID:   types.UID("regular-pod-uuid"),
+		PodIP: "192.168.1.2",
+	}
+	type describeNetworkInterfacesAsListCall struct {
+		req  *ec2sdk.DescribeNetworkInterfacesInput
+		resp []*ec2sdk.NetworkInterface
+		err  error
+	}
+	type fields struct {
+		describeNetworkInterfacesAsListCalls []describeNetworkInterfacesAsListCall
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		pods    []k8s.PodInfo
+		want    map[types.NamespacedName]ENIInfo
+		wantErr error
+	}{
+		{
+			name: "pod with security groups for pods should be resolved to its branch ENI",
+			fields: fields{
+				describeNetworkInterfacesAsListCalls: []describeNetworkInterfacesAsListCall{
+					{
+						req: &ec2sdk.DescribeNetworkInterfacesInput{
+							NetworkInterfaceIds: awssdk.StringSlice([]string{"eni-branch"}),
+						},
+						resp: []*ec2sdk.NetworkInterface{
+							{
+								NetworkInterfaceId: awssdk.String("eni-branch"),
+								Groups: []*ec2sdk.GroupIdentifier{
+									{
+										GroupId: awssdk.String("sg-pod"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			pods: []k8s.PodInfo{sgpPod},
+			want: map[types.NamespacedName]ENIInfo{
+				sgpPod.Key: {
+					NetworkInterfaceID: "eni-branch",
+					SecurityGroups:     []string{"sg-pod"},
+				},
+			},
+		},
+		{
+			name: "pods with and without security groups for pods should be resolved to branch ENI and node ENI respectively",
+			fields: fields{
+				describeNetworkInterfacesAsListCalls: []describeNetworkInterfacesAsListCall{
+					{
+						req: &ec2sdk.DescribeNetworkInterfacesInput{
+							NetworkInterfaceIds: awssdk.StringSlice([]string{"eni-branch"}),
+						},
+						resp: []*ec2sdk.NetworkInterface{
+							{
+								NetworkInterfaceId: awssdk.String("eni-branch"),
+								Groups: []*ec2sdk.GroupIdentifier{
+									{
+										GroupId: awssdk.String("sg-pod"),
+									},
+								},
+							},
+						},
+					},
+					{
+						req: &ec2sdk.DescribeNetworkInterfacesInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+								{
+									Name:   awssdk.String("addresses.private-ip-address"),
+									Values: awssdk.StringSlice([]string{"192.168.1.2"}),
+								},
+							},
+						},
+						resp: []*ec2sdk.NetworkInterface{
+							{
+								NetworkInterfaceId: awssdk.String("eni-node"),
+								Groups: []*ec2sdk.GroupIdentifier{
+									{
+										GroupId: awssdk.String("sg-node"),
+									},
+								},
+								PrivateIpAddresses: []*ec2sdk.NetworkInterfacePrivateIpAddress{
+									{
+										PrivateIpAddress: awssdk.String("192.168.1.2"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			pods: []k8s.PodInfo{sgpPod, regularPod},
+			want: map[types.NamespacedName]ENIInfo{
+				sgpPod.Key: {
+					NetworkInterfaceID: "eni-branch",
+					SecurityGroups:     []string{"sg-pod"},
+				},
+				regularPod.Key: {
+					NetworkInterfaceID: "eni-node",
+					SecurityGroups:     []string{"sg-node"},
+				},
+			},
+		},
+		{
+			name: "pods cannot be resolved",
+			fields: fields{
+				describeNetworkInterfacesAsListCalls: []describeNetworkInterfacesAsListCall{
+					{
+						req: &ec2sdk.DescribeNetworkInterfacesInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+								{
+									Name:   awssdk.String("addresses.private-ip-address"),
+									Values: awssdk.StringSlice([]string{"192.168.1.2"}),
+								},
+							},
+						},
+						resp: nil,
+					},
+				},
+			},
+			pods:    []k8s.PodInfo{regularPod},
+			wantErr: errors.New("cannot resolve pod ENI for pods: [ns-1/regular-pod]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := services.NewMockEC2(ctrl)
+			for _, call := range tt.fields.describeNetworkInterfacesAsListCalls {
+				ec2Client.EXPECT().DescribeNetworkInterfacesAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			r := NewDefaultPodENIInfoResolver(ec2Client, "vpc-1", &log.NullLogger{})
+			got, err := r.Resolve(context.Background(), tt.pods)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_computePodENIInfoCacheKey(t *testing.T) {
 	type args struct {
 		pod k8s.PodInfo
@@ -11,8 +11,20 @@ import (
 )
 
 const (
+	// ipProtocolAll is the IpProtocol that permits traffic of all protocols on all ports.
+	ipProtocolAll = "-1"
+	// ipProtocolICMP is the IpProtocol for ICMP, where FromPort/ToPort denotes the ICMP type/code.
+	ipProtocolICMP = "icmp"
+	// ipProtocolICMPv6 is the IpProtocol for ICMPv6, where FromPort/ToPort denotes the ICMPv6 type/code.
+	ipProtocolICMPv6 = "icmpv6"
+
 	// the raw permission description
 	labelKeyRawDescription = "raw/description"
+
+	// IPPermissionLabelKeyResource is the permission label that identifies the resource owning the permission.
+	IPPermissionLabelKeyResource = "elbv2.k8s.aws/resource"
+	// IPPermissionLabelKeySource is the permission label that identifies the source of the permission.
+	IPPermissionLabelKeySource = "elbv2.k8s.aws/source"
 )
 
 // SecurityGroupInfo wraps necessary information about a SecurityGroup.
@@ -36,12 +48,18 @@ type IPPermissionInfo struct {
 	Labels map[string]string
 }
 
+// ipProtocolByNumber contains the IpProtocol names of protocol numbers, EC2 accepts both forms for these protocols.
+var ipProtocolByNumber = map[string]string{
+	"1":  ipProtocolICMP,
+	"6":  "tcp",
+	"17": "udp",
+	"58": ipProtocolICMPv6,
+}
+
 // HashCode returns the hashcode for the IPPermissionInfo.
 // The hashCode should only include the actual permission but not labels/descriptions.
 func (perm *IPPermissionInfo) HashCode() string {
-	protocol := awssdk.StringValue(perm.Permission.IpProtocol)
-	fromPort := awssdk.Int64Value(perm.Permission.FromPort)
-	toPort := awssdk.Int64Value(perm.Permission.ToPort)
+	protocol, fromPort, toPort := normalizeIPPermissionProtocolAndPorts(perm.Permission)
 	base := fmt.Sprintf("IpProtocol: %v, FromPort: %v, ToPort: %v", protocol, fromPort, toPort)
 	if len(perm.Permission.IpRanges) == 1 {
 		cidrIP := awssdk.StringValue(perm.Permission.IpRanges[0].CidrIp)
@@ -65,6 +83,58 @@ func (perm *IPPermissionInfo) HashCode() string {
 	return string(payload)
 }
 
+// normalizeIPPermissionProtocolAndPorts normalizes the protocol and ports of permission, so that permissions
+// equivalent to EC2 are identical regardless of how they are specified or returned by EC2:
+//   - protocol is lower-cased, and protocol numbers of icmp/tcp/udp/icmpv6 are converted to their names.
+//   - ports are ignored for all-protocol permissions, since they permit all ports.
+//   - unspecified ICMP type/code for icmp/icmpv6 permissions are treated as -1, i.e. all types/codes.
+func normalizeIPPermissionProtocolAndPorts(permission ec2sdk.IpPermission) (string, int64, int64) {
+	protocol := normalizeIPProtocol(awssdk.StringValue(permission.IpProtocol))
+	switch protocol {
+	case ipProtocolAll:
+		return protocol, -1, -1
+	case ipProtocolICMP, ipProtocolICMPv6:
+		fromPort := int64(-1)
+		if permission.FromPort != nil {
+			fromPort = awssdk.Int64Value(permission.FromPort)
+		}
+		toPort := int64(-1)
+		if permission.ToPort != nil {
+			toPort = awssdk.Int64Value(permission.ToPort)
+		}
+		return protocol, fromPort, toPort
+	default:
+		return protocol, awssdk.Int64Value(permission.FromPort), awssdk.Int64Value(permission.ToPort)
+	}
+}
+
+// normalizeIPProtocol normalizes the IpProtocol into lower-cased name if it has one.
+func normalizeIPProtocol(protocol string) string {
+	protocol = strings.ToLower(protocol)
+	if name, ok := ipProtocolByNumber[protocol]; ok {
+		return name
+	}
+	return protocol
+}
+
+// Description returns the description of the permission's source.
+// the description is computed from labels when the permission is constructed, so that rules owned by controller are identifiable.
+func (perm *IPPermissionInfo) Description() string {
+	if len(perm.Permission.IpRanges) == 1 {
+		return awssdk.StringValue(perm.Permission.IpRanges[0].Description)
+	}
+	if len(perm.Permission.Ipv6Ranges) == 1 {
+		return awssdk.StringValue(perm.Permission.Ipv6Ranges[0].Description)
+	}
+	if len(perm.Permission.PrefixListIds) == 1 {
+		return awssdk.StringValue(perm.Permission.PrefixListIds[0].Description)
+	}
+	if len(perm.Permission.UserIdGroupPairs) == 1 {
+		return awssdk.StringValue(perm.Permission.UserIdGroupPairs[0].Description)
+	}
+	return ""
+}
+
 // NewRawSecurityGroupInfo constructs new SecurityGroupInfo with raw ec2SDK's SecurityGroup object.
 func NewRawSecurityGroupInfo(sdkSG *ec2sdk.SecurityGroup) SecurityGroupInfo {
 	sgID := awssdk.StringValue(sdkSG.GroupId)
@@ -196,6 +266,12 @@ func NewIPPermissionLabelsForRawDescription(description string) map[string]strin
 	return map[string]string{labelKeyRawDescription: description}
 }
 
+// NewIPPermissionDescriptionForLabels constructs permission description from labels.
+// the description can be parsed back into labels once the permission is fetched from EC2.
+func NewIPPermissionDescriptionForLabels(labels map[string]string) string {
+	return buildIPPermissionDescriptionForLabels(labels)
+}
+
 // buildSecurityGroupTags generates the tags for securityGroup.
 func buildSecurityGroupTags(sdkSG *ec2sdk.SecurityGroup) map[string]string {
 	sgTags := make(map[string]string, len(sdkSG.Tags))
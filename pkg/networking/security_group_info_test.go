@@ -7,6 +7,54 @@ import (
 	"testing"
 )
 
+func TestIPPermissionInfo_Description(t *testing.T) {
+	tests := []struct {
+		name string
+		perm IPPermissionInfo
+		want string
+	}{
+		{
+			name: "IpRange permission",
+			perm: NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "192.168.0.0/16", map[string]string{"elbv2.k8s.aws/resource": "ns/ing"}),
+			want: "elbv2.k8s.aws/resource=ns/ing",
+		},
+		{
+			name: "Ipv6Range permission",
+			perm: NewCIDRv6IPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "::/0", map[string]string{"elbv2.k8s.aws/resource": "ns/ing"}),
+			want: "elbv2.k8s.aws/resource=ns/ing",
+		},
+		{
+			name: "PrefixListId permission",
+			perm: NewPrefixListIDPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "pl-xxxx", map[string]string{"elbv2.k8s.aws/resource": "ns/ing"}),
+			want: "elbv2.k8s.aws/resource=ns/ing",
+		},
+		{
+			name: "UserIdGroupPair permission",
+			perm: NewGroupIDIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "sg-xxxx", map[string]string{"elbv2.k8s.aws/resource": "ns/ing"}),
+			want: "elbv2.k8s.aws/resource=ns/ing",
+		},
+		{
+			name: "raw permission without description",
+			perm: NewRawIPPermission(ec2sdk.IpPermission{
+				IpProtocol: awssdk.String("tcp"),
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				IpRanges: []*ec2sdk.IpRange{
+					{
+						CidrIp: awssdk.String("192.168.0.0/16"),
+					},
+				},
+			}),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.perm.Description())
+		})
+	}
+}
+
 func TestIPPermissionInfo_HashCode(t *testing.T) {
 	type fields struct {
 		Permission ec2sdk.IpPermission
@@ -81,6 +129,52 @@ func TestIPPermissionInfo_HashCode(t *testing.T) {
 			},
 			want: "IpProtocol: tcp, FromPort: 80, ToPort: 8080, UserIdGroupPair: sg-xxxx",
 		},
+		{
+			name: "ICMP permission specified by protocol number",
+			fields: fields{
+				Permission: ec2sdk.IpPermission{
+					IpProtocol: awssdk.String("1"),
+					FromPort:   awssdk.Int64(8),
+					ToPort:     awssdk.Int64(0),
+					IpRanges: []*ec2sdk.IpRange{
+						{
+							CidrIp: awssdk.String("192.168.0.0/16"),
+						},
+					},
+				},
+			},
+			want: "IpProtocol: icmp, FromPort: 8, ToPort: 0, IpRange: 192.168.0.0/16",
+		},
+		{
+			name: "ICMPv6 permission without type and code",
+			fields: fields{
+				Permission: ec2sdk.IpPermission{
+					IpProtocol: awssdk.String("ICMPv6"),
+					Ipv6Ranges: []*ec2sdk.Ipv6Range{
+						{
+							CidrIpv6: awssdk.String("::/0"),
+						},
+					},
+				},
+			},
+			want: "IpProtocol: icmpv6, FromPort: -1, ToPort: -1, Ipv6Range: ::/0",
+		},
+		{
+			name: "all-protocol permission ignores ports",
+			fields: fields{
+				Permission: ec2sdk.IpPermission{
+					IpProtocol: awssdk.String("-1"),
+					FromPort:   awssdk.Int64(0),
+					ToPort:     awssdk.Int64(65535),
+					UserIdGroupPairs: []*ec2sdk.UserIdGroupPair{
+						{
+							GroupId: awssdk.String("sg-xxxx"),
+						},
+					},
+				},
+			},
+			want: "IpProtocol: -1, FromPort: -1, ToPort: -1, UserIdGroupPair: sg-xxxx",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -52,6 +52,9 @@ type SecurityGroupManager interface {
 
 	// RevokeSGIngress will revoke Ingress permissions from SecurityGroup.
 	RevokeSGIngress(ctx context.Context, sgID string, permissions []IPPermissionInfo) error
+
+	// UpdateSGIngressDescriptions will update the descriptions of existing Ingress permissions on SecurityGroup.
+	UpdateSGIngressDescriptions(ctx context.Context, sgID string, permissions []IPPermissionInfo) error
 }
 
 // NewDefaultSecurityGroupManager constructs new defaultSecurityGroupManager.
@@ -165,6 +168,25 @@ func (m *defaultSecurityGroupManager) RevokeSGIngress(ctx context.Context, sgID
 	return nil
 }
 
+func (m *defaultSecurityGroupManager) UpdateSGIngressDescriptions(ctx context.Context, sgID string, permissions []IPPermissionInfo) error {
+	sdkIPPermissions := buildSDKIPPermissions(permissions)
+	req := &ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressInput{
+		GroupId:       awssdk.String(sgID),
+		IpPermissions: sdkIPPermissions,
+	}
+	m.logger.Info("updating securityGroup ingress descriptions",
+		"securityGroupID", sgID,
+		"permission", sdkIPPermissions)
+	if _, err := m.ec2Client.UpdateSecurityGroupRuleDescriptionsIngressWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("updated securityGroup ingress descriptions",
+		"securityGroupID", sgID)
+
+	m.clearSGInfosFromCache(sgID)
+	return nil
+}
+
 func (m *defaultSecurityGroupManager) fetchSGInfosFromCache(sgIDs []string) map[string]SecurityGroupInfo {
 	m.sgInfoCacheMutex.RLock()
 	defer m.sgInfoCacheMutex.RUnlock()
@@ -2,11 +2,20 @@ package networking
 
 import (
 	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sort"
+	"strings"
+)
+
+const (
+	// EC2 rejects AuthorizeSecurityGroupIngress/RevokeSecurityGroupIngress calls with too many rules.
+	defaultMaxPermissionsPerCall = 60
 )
 
 // configuration options for SecurityGroup Reconcile options.
@@ -19,6 +28,27 @@ type SecurityGroupReconcileOptions struct {
 	// Whether only Authorize permissions.
 	// By default, it grants and revoke permission.
 	AuthorizeOnly bool
+
+	// Whether to revoke extra permissions before granting new permissions.
+	// By default, it grants new permissions before revoking extra permissions, so that there is no gap in allowed traffic
+	// when permissions are being replaced. However, the SecurityGroup needs room for both old and new permissions temporarily,
+	// so revoke first is more suitable when the SecurityGroup is close to its rules limit.
+	// Regardless of this option, it falls back to revoke first if granting hits the SecurityGroup rules limit.
+	RevokeFirst bool
+
+	// Whether to consolidate desired tcp/udp permissions with contiguous or overlapping port ranges into a single permission.
+	// Only permissions with same protocol, source and labels are consolidated.
+	// By default, desired permissions are reconciled as is.
+	ConsolidatePortRanges bool
+
+	// The max number of permissions to authorize or revoke per AWS API call.
+	// Permissions beyond this limit are authorized or revoked in multiple batches.
+	// By default, it's 60.
+	MaxPermissionsPerCall int
+
+	// Whether to only compute the permissions to change without applying them.
+	// By default, permissions changes are applied.
+	DryRun bool
 }
 
 // Apply SecurityGroupReconcileOption options
@@ -44,10 +74,91 @@ func WithAuthorizeOnly(authorizeOnly bool) SecurityGroupReconcileOption {
 	}
 }
 
+// WithRevokeFirst is a option that sets the RevokeFirst.
+func WithRevokeFirst(revokeFirst bool) SecurityGroupReconcileOption {
+	return func(opts *SecurityGroupReconcileOptions) {
+		opts.RevokeFirst = revokeFirst
+	}
+}
+
+// WithConsolidatePortRanges is a option that sets the ConsolidatePortRanges.
+func WithConsolidatePortRanges(consolidatePortRanges bool) SecurityGroupReconcileOption {
+	return func(opts *SecurityGroupReconcileOptions) {
+		opts.ConsolidatePortRanges = consolidatePortRanges
+	}
+}
+
+// WithMaxPermissionsPerCall is a option that sets the MaxPermissionsPerCall.
+func WithMaxPermissionsPerCall(maxPermissionsPerCall int) SecurityGroupReconcileOption {
+	return func(opts *SecurityGroupReconcileOptions) {
+		opts.MaxPermissionsPerCall = maxPermissionsPerCall
+	}
+}
+
+// WithDryRun is a option that sets the DryRun.
+func WithDryRun(dryRun bool) SecurityGroupReconcileOption {
+	return func(opts *SecurityGroupReconcileOptions) {
+		opts.DryRun = dryRun
+	}
+}
+
+// SecurityGroupReconcileResult describes the permissions changes of SecurityGroup rules reconcile.
+type SecurityGroupReconcileResult struct {
+	SecurityGroupID string
+	// the permissions to authorize.
+	PermissionsToGrant []IPPermissionInfo
+	// the permissions to revoke.
+	PermissionsToRevoke []IPPermissionInfo
+	// the existing permissions to backfill descriptions.
+	PermissionsToBackfillDescription []IPPermissionInfo
+	// whether the changes are computed without being applied.
+	DryRun bool
+}
+
+// SecurityGroupReconcilePhase is the phase of SecurityGroup rules reconcile.
+type SecurityGroupReconcilePhase string
+
+const (
+	SecurityGroupReconcilePhaseRevoke    SecurityGroupReconcilePhase = "revoke"
+	SecurityGroupReconcilePhaseAuthorize SecurityGroupReconcilePhase = "authorize"
+	// SecurityGroupReconcilePhaseBackfillDescription backfills descriptions on existing permissions without description.
+	SecurityGroupReconcilePhaseBackfillDescription SecurityGroupReconcilePhase = "backfill descriptions of"
+)
+
+// SecurityGroupReconcileError is the error when SecurityGroup rules reconcile failed in one of its phases.
+// it records the permissions already applied before the failure, so that the partial state left on SecurityGroup is clear.
+// SecurityGroup rules reconcile is idempotent, the next reconcile will converge towards the desired permissions.
+type SecurityGroupReconcileError struct {
+	SecurityGroupID string
+	// the phase that failed.
+	FailedPhase SecurityGroupReconcilePhase
+	// the number of permissions authorized before the failure.
+	AuthorizedPermissionCount int
+	// the number of permissions revoked before the failure.
+	RevokedPermissionCount int
+	// the underlying error.
+	Err error
+}
+
+func (e *SecurityGroupReconcileError) Error() string {
+	return fmt.Sprintf("failed to %v ingress permissions on securityGroup %v after %v permissions authorized and %v permissions revoked: %v",
+		e.FailedPhase, e.SecurityGroupID, e.AuthorizedPermissionCount, e.RevokedPermissionCount, e.Err)
+}
+
+func (e *SecurityGroupReconcileError) Unwrap() error {
+	return e.Err
+}
+
+// IsPartial checks whether some permissions were applied before the failure.
+func (e *SecurityGroupReconcileError) IsPartial() bool {
+	return e.AuthorizedPermissionCount > 0 || e.RevokedPermissionCount > 0
+}
+
 // SecurityGroupReconciler manages securityGroup rules on securityGroup.
 type SecurityGroupReconciler interface {
 	// ReconcileIngress will reconcile Ingress permission on SecurityGroup to be desiredPermission.
-	ReconcileIngress(ctx context.Context, sgID string, desiredPermissions []IPPermissionInfo, opts ...SecurityGroupReconcileOption) error
+	// it returns the permissions changes, which are only computed but not applied in DryRun mode.
+	ReconcileIngress(ctx context.Context, sgID string, desiredPermissions []IPPermissionInfo, opts ...SecurityGroupReconcileOption) (SecurityGroupReconcileResult, error)
 }
 
 // NewDefaultSecurityGroupReconciler constructs new defaultSecurityGroupReconciler.
@@ -66,34 +177,75 @@ type defaultSecurityGroupReconciler struct {
 	logger    logr.Logger
 }
 
-func (r *defaultSecurityGroupReconciler) ReconcileIngress(ctx context.Context, sgID string, desiredPermissions []IPPermissionInfo, opts ...SecurityGroupReconcileOption) error {
+func (r *defaultSecurityGroupReconciler) ReconcileIngress(ctx context.Context, sgID string, desiredPermissions []IPPermissionInfo, opts ...SecurityGroupReconcileOption) (SecurityGroupReconcileResult, error) {
 	reconcileOpts := SecurityGroupReconcileOptions{
-		PermissionSelector: labels.Everything(),
+		PermissionSelector:    labels.Everything(),
+		MaxPermissionsPerCall: defaultMaxPermissionsPerCall,
 	}
 	reconcileOpts.ApplyOptions(opts...)
+	if reconcileOpts.MaxPermissionsPerCall <= 0 {
+		return SecurityGroupReconcileResult{}, errors.Errorf("maxPermissionsPerCall must be positive: %v", reconcileOpts.MaxPermissionsPerCall)
+	}
 
 	sgInfoByID, err := r.sgManager.FetchSGInfosByID(ctx, []string{sgID})
 	if err != nil {
-		return err
+		return SecurityGroupReconcileResult{}, err
 	}
 	sgInfo := sgInfoByID[sgID]
-	if err := r.reconcileIngressWithSGInfo(ctx, sgInfo, desiredPermissions, reconcileOpts); err != nil {
+	result, err := r.reconcileIngressWithSGInfo(ctx, sgInfo, desiredPermissions, reconcileOpts)
+	if err != nil {
 		if !r.shouldRetryWithoutCache(err) {
-			return err
+			return SecurityGroupReconcileResult{}, err
 		}
 		sgInfoByID, err := r.sgManager.FetchSGInfosByID(ctx, []string{sgID}, WithReloadIgnoringCache())
 		if err != nil {
-			return err
+			return SecurityGroupReconcileResult{}, err
 		}
 		sgInfo := sgInfoByID[sgID]
-		if err := r.reconcileIngressWithSGInfo(ctx, sgInfo, desiredPermissions, reconcileOpts); err != nil {
-			return err
+		return r.reconcileIngressWithSGInfo(ctx, sgInfo, desiredPermissions, reconcileOpts)
+	}
+	return result, nil
+}
+
+func (r *defaultSecurityGroupReconciler) reconcileIngressWithSGInfo(ctx context.Context, sgInfo SecurityGroupInfo, desiredPermissions []IPPermissionInfo, reconcileOpts SecurityGroupReconcileOptions) (SecurityGroupReconcileResult, error) {
+	result := computeSecurityGroupReconcileResult(sgInfo, desiredPermissions, reconcileOpts)
+	if reconcileOpts.DryRun {
+		r.logger.Info("dry run securityGroup ingress reconcile",
+			"securityGroupID", result.SecurityGroupID,
+			"permissionsToGrant", buildSDKIPPermissions(result.PermissionsToGrant),
+			"permissionsToRevoke", buildSDKIPPermissions(result.PermissionsToRevoke),
+			"permissionsToBackfillDescription", buildSDKIPPermissions(result.PermissionsToBackfillDescription))
+		return result, nil
+	}
+
+	if reconcileOpts.RevokeFirst {
+		if err := r.revokeThenAuthorizeIngress(ctx, result.SecurityGroupID, result.PermissionsToRevoke, result.PermissionsToGrant, reconcileOpts.MaxPermissionsPerCall); err != nil {
+			return SecurityGroupReconcileResult{}, err
+		}
+	} else {
+		if err := r.authorizeThenRevokeIngress(ctx, result.SecurityGroupID, result.PermissionsToRevoke, result.PermissionsToGrant, reconcileOpts.MaxPermissionsPerCall); err != nil {
+			return SecurityGroupReconcileResult{}, err
 		}
 	}
-	return nil
+	if len(result.PermissionsToBackfillDescription) > 0 {
+		if _, err := r.applyIngressInBatches(ctx, result.SecurityGroupID, result.PermissionsToBackfillDescription, reconcileOpts.MaxPermissionsPerCall, r.sgManager.UpdateSGIngressDescriptions); err != nil {
+			return SecurityGroupReconcileResult{}, &SecurityGroupReconcileError{
+				SecurityGroupID:           result.SecurityGroupID,
+				FailedPhase:               SecurityGroupReconcilePhaseBackfillDescription,
+				AuthorizedPermissionCount: len(result.PermissionsToGrant),
+				RevokedPermissionCount:    len(result.PermissionsToRevoke),
+				Err:                       err,
+			}
+		}
+	}
+	return result, nil
 }
 
-func (r *defaultSecurityGroupReconciler) reconcileIngressWithSGInfo(ctx context.Context, sgInfo SecurityGroupInfo, desiredPermissions []IPPermissionInfo, reconcileOpts SecurityGroupReconcileOptions) error {
+// computeSecurityGroupReconcileResult computes the permissions changes to reconcile Ingress permission on SecurityGroup to be desiredPermissions.
+func computeSecurityGroupReconcileResult(sgInfo SecurityGroupInfo, desiredPermissions []IPPermissionInfo, reconcileOpts SecurityGroupReconcileOptions) SecurityGroupReconcileResult {
+	if reconcileOpts.ConsolidatePortRanges {
+		desiredPermissions = consolidateIPPermissionPortRanges(desiredPermissions)
+	}
 	extraPermissions := diffIPPermissionInfos(sgInfo.Ingress, desiredPermissions)
 	permissionsToRevoke := make([]IPPermissionInfo, 0, len(extraPermissions))
 	for _, permission := range extraPermissions {
@@ -102,24 +254,149 @@ func (r *defaultSecurityGroupReconciler) reconcileIngressWithSGInfo(ctx context.
 		}
 	}
 	permissionsToGrant := diffIPPermissionInfos(desiredPermissions, sgInfo.Ingress)
-	if len(permissionsToRevoke) > 0 && !reconcileOpts.AuthorizeOnly {
-		if err := r.sgManager.RevokeSGIngress(ctx, sgInfo.SecurityGroupID, permissionsToRevoke); err != nil {
-			return err
+	if reconcileOpts.AuthorizeOnly {
+		permissionsToRevoke = nil
+	}
+	return SecurityGroupReconcileResult{
+		SecurityGroupID:                  sgInfo.SecurityGroupID,
+		PermissionsToGrant:               permissionsToGrant,
+		PermissionsToRevoke:              permissionsToRevoke,
+		PermissionsToBackfillDescription: computeIPPermissionsToBackfillDescription(sgInfo.Ingress, desiredPermissions),
+		DryRun:                           reconcileOpts.DryRun,
+	}
+}
+
+// authorizeThenRevokeIngress grants new permissions before revoking extra permissions, so that there is no gap in allowed traffic.
+// if granting hits the SecurityGroup rules limit, it falls back to revoke extra permissions first to make room.
+func (r *defaultSecurityGroupReconciler) authorizeThenRevokeIngress(ctx context.Context, sgID string, permissionsToRevoke []IPPermissionInfo, permissionsToGrant []IPPermissionInfo, maxPermissionsPerCall int) error {
+	if len(permissionsToGrant) > 0 {
+		notGranted, err := r.applyIngressInBatches(ctx, sgID, permissionsToGrant, maxPermissionsPerCall, r.sgManager.AuthorizeSGIngress)
+		if err != nil {
+			if len(permissionsToRevoke) > 0 && r.isRulesLimitExceeded(err) {
+				r.logger.Info("securityGroup rules limit exceeded, revoking extra permissions first",
+					"securityGroupID", sgID)
+				return r.revokeThenAuthorizeIngress(ctx, sgID, permissionsToRevoke, notGranted, maxPermissionsPerCall)
+			}
+			return &SecurityGroupReconcileError{
+				SecurityGroupID:           sgID,
+				FailedPhase:               SecurityGroupReconcilePhaseAuthorize,
+				AuthorizedPermissionCount: len(permissionsToGrant) - len(notGranted),
+				Err:                       err,
+			}
+		}
+	}
+	if len(permissionsToRevoke) > 0 {
+		notRevoked, err := r.applyIngressInBatches(ctx, sgID, permissionsToRevoke, maxPermissionsPerCall, r.sgManager.RevokeSGIngress)
+		if err != nil {
+			return &SecurityGroupReconcileError{
+				SecurityGroupID:           sgID,
+				FailedPhase:               SecurityGroupReconcilePhaseRevoke,
+				AuthorizedPermissionCount: len(permissionsToGrant),
+				RevokedPermissionCount:    len(permissionsToRevoke) - len(notRevoked),
+				Err:                       err,
+			}
+		}
+	}
+	return nil
+}
+
+// revokeThenAuthorizeIngress revokes extra permissions before granting new permissions, so that the SecurityGroup rules limit is not exceeded.
+func (r *defaultSecurityGroupReconciler) revokeThenAuthorizeIngress(ctx context.Context, sgID string, permissionsToRevoke []IPPermissionInfo, permissionsToGrant []IPPermissionInfo, maxPermissionsPerCall int) error {
+	if len(permissionsToRevoke) > 0 {
+		notRevoked, err := r.applyIngressInBatches(ctx, sgID, permissionsToRevoke, maxPermissionsPerCall, r.sgManager.RevokeSGIngress)
+		if err != nil {
+			return &SecurityGroupReconcileError{
+				SecurityGroupID:        sgID,
+				FailedPhase:            SecurityGroupReconcilePhaseRevoke,
+				RevokedPermissionCount: len(permissionsToRevoke) - len(notRevoked),
+				Err:                    err,
+			}
 		}
 	}
 	if len(permissionsToGrant) > 0 {
-		if err := r.sgManager.AuthorizeSGIngress(ctx, sgInfo.SecurityGroupID, permissionsToGrant); err != nil {
-			return err
+		notGranted, err := r.applyIngressInBatches(ctx, sgID, permissionsToGrant, maxPermissionsPerCall, r.sgManager.AuthorizeSGIngress)
+		if err != nil {
+			return &SecurityGroupReconcileError{
+				SecurityGroupID:           sgID,
+				FailedPhase:               SecurityGroupReconcilePhaseAuthorize,
+				AuthorizedPermissionCount: len(permissionsToGrant) - len(notGranted),
+				RevokedPermissionCount:    len(permissionsToRevoke),
+				Err:                       err,
+			}
 		}
 	}
 	return nil
 }
 
+// applyIngressInBatches authorizes or revokes permissions with batches of at most maxPermissionsPerCall permissions.
+// a failed batch doesn't stop the remaining batches, unless the SecurityGroup rules limit is exceeded, in which case the remaining batches will fail as well.
+// it returns the permissions not applied, along with the errors from failed batches.
+func (r *defaultSecurityGroupReconciler) applyIngressInBatches(ctx context.Context, sgID string, permissions []IPPermissionInfo, maxPermissionsPerCall int,
+	apply func(ctx context.Context, sgID string, permissions []IPPermissionInfo) error) ([]IPPermissionInfo, error) {
+	var notApplied []IPPermissionInfo
+	var errs []error
+	for start := 0; start < len(permissions); start += maxPermissionsPerCall {
+		end := start + maxPermissionsPerCall
+		if end > len(permissions) {
+			end = len(permissions)
+		}
+		if err := apply(ctx, sgID, permissions[start:end]); err != nil {
+			errs = append(errs, err)
+			if r.isRulesLimitExceeded(err) {
+				notApplied = append(notApplied, permissions[start:]...)
+				break
+			}
+			notApplied = append(notApplied, permissions[start:end]...)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return notApplied, errs[0]
+	default:
+		return notApplied, &permissionBatchesError{errs: errs}
+	}
+}
+
+// permissionBatchesError aggregates the errors from multiple failed permission batches.
+type permissionBatchesError struct {
+	errs []error
+}
+
+func (e *permissionBatchesError) Error() string {
+	msgs := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%v batches failed: [%v]", len(e.errs), strings.Join(msgs, ", "))
+}
+
+// isRulesLimitExceeded tests whether the error is due to SecurityGroup rules limit exceeded.
+func (r *defaultSecurityGroupReconciler) isRulesLimitExceeded(err error) bool {
+	return hasAWSErrorCode(err, "RulesPerSecurityGroupLimitExceeded")
+}
+
 // shouldRetryWithoutCache tests whether we should retry SecurityGroup rules reconcile without cache.
 func (r *defaultSecurityGroupReconciler) shouldRetryWithoutCache(err error) bool {
+	return hasAWSErrorCode(err, "InvalidPermission.Duplicate", "InvalidPermission.NotFound")
+}
+
+// hasAWSErrorCode tests whether the error is an AWS error with one of codes.
+// for errors aggregated from multiple permission batches, it tests whether any of the batches failed with one of codes.
+func hasAWSErrorCode(err error, codes ...string) bool {
+	var batchesErr *permissionBatchesError
+	if errors.As(err, &batchesErr) {
+		for _, batchErr := range batchesErr.errs {
+			if hasAWSErrorCode(batchErr, codes...) {
+				return true
+			}
+		}
+		return false
+	}
 	var awsErr awserr.Error
 	if errors.As(err, &awsErr) {
-		return awsErr.Code() == "InvalidPermission.Duplicate" || awsErr.Code() == "InvalidPermission.NotFound"
+		return sets.NewString(codes...).Has(awsErr.Code())
 	}
 	return false
 }
@@ -143,3 +420,80 @@ func diffIPPermissionInfos(source []IPPermissionInfo, target []IPPermissionInfo)
 	}
 	return diffs
 }
+
+// computeIPPermissionsToBackfillDescription computes the desired permissions whose matching current permission has no description,
+// e.g. rules created before descriptions are generated, so that rules owned by controller are identifiable on SecurityGroup.
+// current permissions with a different description are left as is, since description is ignored when comparing permissions.
+func computeIPPermissionsToBackfillDescription(current []IPPermissionInfo, desired []IPPermissionInfo) []IPPermissionInfo {
+	currentByHashCode := make(map[string]IPPermissionInfo, len(current))
+	for _, perm := range current {
+		currentByHashCode[perm.HashCode()] = perm
+	}
+	var permissionsToBackfill []IPPermissionInfo
+	for _, perm := range desired {
+		currentPerm, exists := currentByHashCode[perm.HashCode()]
+		if !exists || len(currentPerm.Description()) != 0 || len(perm.Description()) == 0 {
+			continue
+		}
+		permissionsToBackfill = append(permissionsToBackfill, perm)
+	}
+	return permissionsToBackfill
+}
+
+// consolidateIPPermissionPortRanges consolidates tcp/udp permissions with contiguous or overlapping port ranges into a single permission.
+// permissions are only consolidated when they share the same protocol, source and labels, other permissions are kept as is.
+// since HashCode covers the port range, consolidated permissions are compared against existing permissions by their full port range.
+func consolidateIPPermissionPortRanges(permissions []IPPermissionInfo) []IPPermissionInfo {
+	var consolidatedPermissions []IPPermissionInfo
+	permissionsByGroupKey := make(map[string][]IPPermissionInfo)
+	for _, perm := range permissions {
+		groupKey, ok := buildIPPermissionPortRangeGroupKey(perm)
+		if !ok {
+			consolidatedPermissions = append(consolidatedPermissions, perm)
+			continue
+		}
+		permissionsByGroupKey[groupKey] = append(permissionsByGroupKey[groupKey], perm)
+	}
+	for _, groupKey := range sets.StringKeySet(permissionsByGroupKey).List() {
+		groupPermissions := permissionsByGroupKey[groupKey]
+		sort.Slice(groupPermissions, func(i, j int) bool {
+			return awssdk.Int64Value(groupPermissions[i].Permission.FromPort) < awssdk.Int64Value(groupPermissions[j].Permission.FromPort)
+		})
+		var current *IPPermissionInfo
+		for _, perm := range groupPermissions {
+			if current != nil && awssdk.Int64Value(perm.Permission.FromPort) <= awssdk.Int64Value(current.Permission.ToPort)+1 {
+				if awssdk.Int64Value(perm.Permission.ToPort) > awssdk.Int64Value(current.Permission.ToPort) {
+					current.Permission.ToPort = perm.Permission.ToPort
+				}
+				continue
+			}
+			if current != nil {
+				consolidatedPermissions = append(consolidatedPermissions, *current)
+			}
+			permCopy := perm
+			permCopy.Permission.FromPort = awssdk.Int64(awssdk.Int64Value(perm.Permission.FromPort))
+			permCopy.Permission.ToPort = awssdk.Int64(awssdk.Int64Value(perm.Permission.ToPort))
+			current = &permCopy
+		}
+		if current != nil {
+			consolidatedPermissions = append(consolidatedPermissions, *current)
+		}
+	}
+	return consolidatedPermissions
+}
+
+// buildIPPermissionPortRangeGroupKey builds the key to group permissions whose port ranges can be consolidated.
+// only tcp/udp permissions with port range are eligible for consolidation.
+func buildIPPermissionPortRangeGroupKey(perm IPPermissionInfo) (string, bool) {
+	protocol := normalizeIPProtocol(awssdk.StringValue(perm.Permission.IpProtocol))
+	if protocol != "tcp" && protocol != "udp" {
+		return "", false
+	}
+	if perm.Permission.FromPort == nil || perm.Permission.ToPort == nil {
+		return "", false
+	}
+	permWithoutPorts := perm
+	permWithoutPorts.Permission.FromPort = nil
+	permWithoutPorts.Permission.ToPort = nil
+	return fmt.Sprintf("%v, Labels: %v", permWithoutPorts.HashCode(), labels.Set(perm.Labels).String()), true
+}
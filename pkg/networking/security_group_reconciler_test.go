@@ -1,13 +1,635 @@
 package networking
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
+func Test_defaultSecurityGroupReconciler_ReconcileIngress(t *testing.T) {
+	permissionA := NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "192.168.1.1/32", map[string]string{"managed": "true"})
+	permissionB := NewCIDRIPPermission("tcp", awssdk.Int64(443), awssdk.Int64(443), "192.168.1.1/32", map[string]string{"managed": "true"})
+	permissionC := NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "192.168.1.1/32", map[string]string{"managed": "true"})
+	var manyPermissions []IPPermissionInfo
+	for i := 0; i < 150; i++ {
+		manyPermissions = append(manyPermissions, NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), fmt.Sprintf("10.0.%d.%d/32", i/100, i%100), map[string]string{"managed": "true"}))
+	}
+	permissionAWithoutDescription := NewRawIPPermission(ec2sdk.IpPermission{
+		IpProtocol: awssdk.String("tcp"),
+		FromPort:   awssdk.Int64(80),
+		ToPort:     awssdk.Int64(80),
+		IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("192.168.1.1/32")}},
+	})
+	permissionAWithOtherDescription := NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "192.168.1.1/32", map[string]string{"other": "true"})
+	// permissions are authorized or revoked in the order of their HashCode.
+	sortedManyPermissions := diffIPPermissionInfos(manyPermissions, nil)
+
+	// sgManagerCall is an expected call to RevokeSGIngress, UpdateSGIngressDescriptions or AuthorizeSGIngress, in order.
+	type sgManagerCall struct {
+		revoke             bool
+		updateDescriptions bool
+		permissions        []IPPermissionInfo
+		err                error
+	}
+	tests := []struct {
+		name               string
+		currentPermissions []IPPermissionInfo
+		desiredPermissions []IPPermissionInfo
+		opts               []SecurityGroupReconcileOption
+		sgManagerCalls     []sgManagerCall
+		wantErr            error
+	}{
+		{
+			name:               "authorize first by default",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionB, permissionC},
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: []IPPermissionInfo{permissionC},
+				},
+				{
+					revoke:      true,
+					permissions: []IPPermissionInfo{permissionA},
+				},
+			},
+		},
+		{
+			name:               "revoke first when configured",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionB, permissionC},
+			opts:               []SecurityGroupReconcileOption{WithRevokeFirst(true)},
+			sgManagerCalls: []sgManagerCall{
+				{
+					revoke:      true,
+					permissions: []IPPermissionInfo{permissionA},
+				},
+				{
+					permissions: []IPPermissionInfo{permissionC},
+				},
+			},
+		},
+		{
+			name:               "authorize only",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionB, permissionC},
+			opts:               []SecurityGroupReconcileOption{WithAuthorizeOnly(true)},
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: []IPPermissionInfo{permissionC},
+				},
+			},
+		},
+		{
+			name:               "authorize first falls back to revoke first when rules limit exceeded",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionC},
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: []IPPermissionInfo{permissionC},
+					err:         awserr.New("RulesPerSecurityGroupLimitExceeded", "", nil),
+				},
+				{
+					revoke:      true,
+					permissions: []IPPermissionInfo{permissionB, permissionA},
+				},
+				{
+					permissions: []IPPermissionInfo{permissionC},
+				},
+			},
+		},
+		{
+			name:               "authorize in batches of 60 permissions by default",
+			currentPermissions: nil,
+			desiredPermissions: manyPermissions,
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: sortedManyPermissions[0:60],
+				},
+				{
+					permissions: sortedManyPermissions[60:120],
+				},
+				{
+					permissions: sortedManyPermissions[120:150],
+				},
+			},
+		},
+		{
+			name:               "revoke in batches of configured size",
+			currentPermissions: manyPermissions,
+			desiredPermissions: nil,
+			opts:               []SecurityGroupReconcileOption{WithMaxPermissionsPerCall(100)},
+			sgManagerCalls: []sgManagerCall{
+				{
+					revoke:      true,
+					permissions: sortedManyPermissions[0:100],
+				},
+				{
+					revoke:      true,
+					permissions: sortedManyPermissions[100:150],
+				},
+			},
+		},
+		{
+			name:               "failed batch doesn't stop remaining batches",
+			currentPermissions: nil,
+			desiredPermissions: manyPermissions,
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: sortedManyPermissions[0:60],
+				},
+				{
+					permissions: sortedManyPermissions[60:120],
+					err:         errors.New("some error"),
+				},
+				{
+					permissions: sortedManyPermissions[120:150],
+				},
+			},
+			wantErr: &SecurityGroupReconcileError{
+				SecurityGroupID:           "sg-a",
+				FailedPhase:               SecurityGroupReconcilePhaseAuthorize,
+				AuthorizedPermissionCount: 90,
+				Err:                       errors.New("some error"),
+			},
+		},
+		{
+			name:               "errors of failed batches are aggregated",
+			currentPermissions: nil,
+			desiredPermissions: manyPermissions,
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: sortedManyPermissions[0:60],
+					err:         errors.New("some error"),
+				},
+				{
+					permissions: sortedManyPermissions[60:120],
+				},
+				{
+					permissions: sortedManyPermissions[120:150],
+					err:         errors.New("another error"),
+				},
+			},
+			wantErr: &SecurityGroupReconcileError{
+				SecurityGroupID:           "sg-a",
+				FailedPhase:               SecurityGroupReconcilePhaseAuthorize,
+				AuthorizedPermissionCount: 60,
+				Err: &permissionBatchesError{
+					errs: []error{errors.New("some error"), errors.New("another error")},
+				},
+			},
+		},
+		{
+			name:               "authorize in batches falls back to revoke first with remaining permissions when rules limit exceeded",
+			currentPermissions: []IPPermissionInfo{permissionA},
+			desiredPermissions: manyPermissions,
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: sortedManyPermissions[0:60],
+				},
+				{
+					permissions: sortedManyPermissions[60:120],
+					err:         awserr.New("RulesPerSecurityGroupLimitExceeded", "", nil),
+				},
+				{
+					revoke:      true,
+					permissions: []IPPermissionInfo{permissionA},
+				},
+				{
+					permissions: sortedManyPermissions[60:120],
+				},
+				{
+					permissions: sortedManyPermissions[120:150],
+				},
+			},
+		},
+		{
+			name:               "backfill descriptions on existing permissions without description",
+			currentPermissions: []IPPermissionInfo{permissionAWithoutDescription, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionA, permissionC},
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: []IPPermissionInfo{permissionC},
+				},
+				{
+					revoke:      true,
+					permissions: []IPPermissionInfo{permissionB},
+				},
+				{
+					updateDescriptions: true,
+					permissions:        []IPPermissionInfo{permissionA},
+				},
+			},
+		},
+		{
+			name:               "description-only changes are ignored",
+			currentPermissions: []IPPermissionInfo{permissionAWithOtherDescription, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionA, permissionB},
+		},
+		{
+			name:               "backfill descriptions failed",
+			currentPermissions: []IPPermissionInfo{permissionAWithoutDescription},
+			desiredPermissions: []IPPermissionInfo{permissionA},
+			sgManagerCalls: []sgManagerCall{
+				{
+					updateDescriptions: true,
+					permissions:        []IPPermissionInfo{permissionA},
+					err:                errors.New("some error"),
+				},
+			},
+			wantErr: &SecurityGroupReconcileError{
+				SecurityGroupID: "sg-a",
+				FailedPhase:     SecurityGroupReconcilePhaseBackfillDescription,
+				Err:             errors.New("some error"),
+			},
+		},
+		{
+			name: "consolidated permission matches existing permission",
+			currentPermissions: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8090), "192.168.1.1/32", map[string]string{"managed": "true"}),
+			},
+			desiredPermissions: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "192.168.1.1/32", map[string]string{"managed": "true"}),
+				NewCIDRIPPermission("tcp", awssdk.Int64(8081), awssdk.Int64(8089), "192.168.1.1/32", map[string]string{"managed": "true"}),
+				NewCIDRIPPermission("tcp", awssdk.Int64(8090), awssdk.Int64(8090), "192.168.1.1/32", map[string]string{"managed": "true"}),
+			},
+			opts: []SecurityGroupReconcileOption{WithConsolidatePortRanges(true)},
+		},
+		{
+			name: "consolidated permission replaces existing permissions",
+			currentPermissions: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "192.168.1.1/32", map[string]string{"managed": "true"}),
+			},
+			desiredPermissions: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "192.168.1.1/32", map[string]string{"managed": "true"}),
+				NewCIDRIPPermission("tcp", awssdk.Int64(8081), awssdk.Int64(8081), "192.168.1.1/32", map[string]string{"managed": "true"}),
+			},
+			opts: []SecurityGroupReconcileOption{WithConsolidatePortRanges(true)},
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: []IPPermissionInfo{
+						NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8081), "192.168.1.1/32", map[string]string{"managed": "true"}),
+					},
+				},
+				{
+					revoke: true,
+					permissions: []IPPermissionInfo{
+						NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "192.168.1.1/32", map[string]string{"managed": "true"}),
+					},
+				},
+			},
+		},
+		{
+			name:               "authorize first failed",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionC},
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: []IPPermissionInfo{permissionC},
+					err:         errors.New("some error"),
+				},
+			},
+			wantErr: &SecurityGroupReconcileError{
+				SecurityGroupID: "sg-a",
+				FailedPhase:     SecurityGroupReconcilePhaseAuthorize,
+				Err:             errors.New("some error"),
+			},
+		},
+		{
+			name:               "revoke failed after authorize succeeded",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionC},
+			sgManagerCalls: []sgManagerCall{
+				{
+					permissions: []IPPermissionInfo{permissionC},
+				},
+				{
+					revoke:      true,
+					permissions: []IPPermissionInfo{permissionB, permissionA},
+					err:         errors.New("some error"),
+				},
+			},
+			wantErr: &SecurityGroupReconcileError{
+				SecurityGroupID:           "sg-a",
+				FailedPhase:               SecurityGroupReconcilePhaseRevoke,
+				AuthorizedPermissionCount: 1,
+				Err:                       errors.New("some error"),
+			},
+		},
+		{
+			name:               "revoke first failed",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionC},
+			opts:               []SecurityGroupReconcileOption{WithRevokeFirst(true)},
+			sgManagerCalls: []sgManagerCall{
+				{
+					revoke:      true,
+					permissions: []IPPermissionInfo{permissionB, permissionA},
+					err:         errors.New("some error"),
+				},
+			},
+			wantErr: &SecurityGroupReconcileError{
+				SecurityGroupID: "sg-a",
+				FailedPhase:     SecurityGroupReconcilePhaseRevoke,
+				Err:             errors.New("some error"),
+			},
+		},
+		{
+			name:               "authorize failed after revoke first succeeded",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionC},
+			opts:               []SecurityGroupReconcileOption{WithRevokeFirst(true)},
+			sgManagerCalls: []sgManagerCall{
+				{
+					revoke:      true,
+					permissions: []IPPermissionInfo{permissionB, permissionA},
+				},
+				{
+					permissions: []IPPermissionInfo{permissionC},
+					err:         errors.New("some error"),
+				},
+			},
+			wantErr: &SecurityGroupReconcileError{
+				SecurityGroupID:        "sg-a",
+				FailedPhase:            SecurityGroupReconcilePhaseAuthorize,
+				RevokedPermissionCount: 2,
+				Err:                    errors.New("some error"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sgManager := NewMockSecurityGroupManager(ctrl)
+			sgManager.EXPECT().FetchSGInfosByID(gomock.Any(), []string{"sg-a"}).Return(map[string]SecurityGroupInfo{
+				"sg-a": {
+					SecurityGroupID: "sg-a",
+					Ingress:         tt.currentPermissions,
+				},
+			}, nil)
+			var calls []*gomock.Call
+			for _, call := range tt.sgManagerCalls {
+				if call.revoke {
+					calls = append(calls, sgManager.EXPECT().RevokeSGIngress(gomock.Any(), "sg-a", call.permissions).Return(call.err))
+				} else if call.updateDescriptions {
+					calls = append(calls, sgManager.EXPECT().UpdateSGIngressDescriptions(gomock.Any(), "sg-a", call.permissions).Return(call.err))
+				} else {
+					calls = append(calls, sgManager.EXPECT().AuthorizeSGIngress(gomock.Any(), "sg-a", call.permissions).Return(call.err))
+				}
+			}
+			gomock.InOrder(calls...)
+
+			r := NewDefaultSecurityGroupReconciler(sgManager, &log.NullLogger{})
+			opts := append([]SecurityGroupReconcileOption{WithPermissionSelector(labels.Everything())}, tt.opts...)
+			_, err := r.ReconcileIngress(context.Background(), "sg-a", tt.desiredPermissions, opts...)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultSecurityGroupReconciler_ReconcileIngress_dryRun(t *testing.T) {
+	permissionA := NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "192.168.1.1/32", map[string]string{"managed": "true"})
+	permissionB := NewCIDRIPPermission("tcp", awssdk.Int64(443), awssdk.Int64(443), "192.168.1.1/32", map[string]string{"managed": "true"})
+	permissionC := NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "192.168.1.1/32", map[string]string{"managed": "true"})
+	permissionD := NewCIDRIPPermission("tcp", awssdk.Int64(9090), awssdk.Int64(9090), "192.168.1.1/32", map[string]string{"managed": "true"})
+	permissionDWithoutDescription := NewRawIPPermission(ec2sdk.IpPermission{
+		IpProtocol: awssdk.String("tcp"),
+		FromPort:   awssdk.Int64(9090),
+		ToPort:     awssdk.Int64(9090),
+		IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("192.168.1.1/32")}},
+	})
+	tests := []struct {
+		name               string
+		currentPermissions []IPPermissionInfo
+		desiredPermissions []IPPermissionInfo
+		opts               []SecurityGroupReconcileOption
+		want               SecurityGroupReconcileResult
+	}{
+		{
+			name:               "dry run computes permissions changes",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB, permissionDWithoutDescription},
+			desiredPermissions: []IPPermissionInfo{permissionB, permissionC, permissionD},
+			want: SecurityGroupReconcileResult{
+				SecurityGroupID:                  "sg-a",
+				PermissionsToGrant:               []IPPermissionInfo{permissionC},
+				PermissionsToRevoke:              []IPPermissionInfo{permissionA},
+				PermissionsToBackfillDescription: []IPPermissionInfo{permissionD},
+				DryRun:                           true,
+			},
+		},
+		{
+			name:               "dry run with authorize only",
+			currentPermissions: []IPPermissionInfo{permissionA, permissionB},
+			desiredPermissions: []IPPermissionInfo{permissionB, permissionC},
+			opts:               []SecurityGroupReconcileOption{WithAuthorizeOnly(true)},
+			want: SecurityGroupReconcileResult{
+				SecurityGroupID:    "sg-a",
+				PermissionsToGrant: []IPPermissionInfo{permissionC},
+				DryRun:             true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// no AuthorizeSGIngress/RevokeSGIngress/UpdateSGIngressDescriptions calls are expected in dry run mode.
+			sgManager := NewMockSecurityGroupManager(ctrl)
+			sgManager.EXPECT().FetchSGInfosByID(gomock.Any(), []string{"sg-a"}).Return(map[string]SecurityGroupInfo{
+				"sg-a": {
+					SecurityGroupID: "sg-a",
+					Ingress:         tt.currentPermissions,
+				},
+			}, nil)
+
+			r := NewDefaultSecurityGroupReconciler(sgManager, &log.NullLogger{})
+			opts := append([]SecurityGroupReconcileOption{WithDryRun(true)}, tt.opts...)
+			got, err := r.ReconcileIngress(context.Background(), "sg-a", tt.desiredPermissions, opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultSecurityGroupReconciler_ReconcileIngress_icmpAndAllProtocol(t *testing.T) {
+	labelsManaged := map[string]string{"managed": "true"}
+	descriptionManaged := NewIPPermissionDescriptionForLabels(labelsManaged)
+	tests := []struct {
+		name              string
+		desiredPermission IPPermissionInfo
+		// the permission as returned by EC2 once desiredPermission is authorized.
+		authorizedSDKPermission ec2sdk.IpPermission
+	}{
+		{
+			name:              "ICMP ping permission specified by protocol number",
+			desiredPermission: NewCIDRIPPermission("1", awssdk.Int64(8), awssdk.Int64(0), "192.168.1.1/32", labelsManaged),
+			authorizedSDKPermission: ec2sdk.IpPermission{
+				IpProtocol: awssdk.String("icmp"),
+				FromPort:   awssdk.Int64(8),
+				ToPort:     awssdk.Int64(0),
+				IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("192.168.1.1/32"), Description: awssdk.String(descriptionManaged)}},
+			},
+		},
+		{
+			name:              "ICMP permission without type and code",
+			desiredPermission: NewCIDRIPPermission("ICMP", nil, nil, "192.168.1.1/32", labelsManaged),
+			authorizedSDKPermission: ec2sdk.IpPermission{
+				IpProtocol: awssdk.String("icmp"),
+				FromPort:   awssdk.Int64(-1),
+				ToPort:     awssdk.Int64(-1),
+				IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("192.168.1.1/32"), Description: awssdk.String(descriptionManaged)}},
+			},
+		},
+		{
+			name:              "ICMPv6 permission specified by name",
+			desiredPermission: NewCIDRv6IPPermission("icmpv6", awssdk.Int64(-1), awssdk.Int64(-1), "2001:db8::/32", labelsManaged),
+			authorizedSDKPermission: ec2sdk.IpPermission{
+				IpProtocol: awssdk.String("58"),
+				FromPort:   awssdk.Int64(-1),
+				ToPort:     awssdk.Int64(-1),
+				Ipv6Ranges: []*ec2sdk.Ipv6Range{{CidrIpv6: awssdk.String("2001:db8::/32"), Description: awssdk.String(descriptionManaged)}},
+			},
+		},
+		{
+			name:              "all-protocol permission with port range",
+			desiredPermission: NewGroupIDIPPermission("-1", awssdk.Int64(0), awssdk.Int64(65535), "sg-b", labelsManaged),
+			authorizedSDKPermission: ec2sdk.IpPermission{
+				IpProtocol:       awssdk.String("-1"),
+				UserIdGroupPairs: []*ec2sdk.UserIdGroupPair{{GroupId: awssdk.String("sg-b"), Description: awssdk.String(descriptionManaged)}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sgManager := NewMockSecurityGroupManager(ctrl)
+			authorizedSGInfo := NewRawSecurityGroupInfo(&ec2sdk.SecurityGroup{
+				GroupId:       awssdk.String("sg-a"),
+				IpPermissions: []*ec2sdk.IpPermission{&tt.authorizedSDKPermission},
+			})
+			// the permission is authorized on first pass, and no further changes are made on second pass.
+			gomock.InOrder(
+				sgManager.EXPECT().FetchSGInfosByID(gomock.Any(), []string{"sg-a"}).Return(map[string]SecurityGroupInfo{
+					"sg-a": {SecurityGroupID: "sg-a"},
+				}, nil),
+				sgManager.EXPECT().AuthorizeSGIngress(gomock.Any(), "sg-a", []IPPermissionInfo{tt.desiredPermission}).Return(nil),
+				sgManager.EXPECT().FetchSGInfosByID(gomock.Any(), []string{"sg-a"}).Return(map[string]SecurityGroupInfo{
+					"sg-a": authorizedSGInfo,
+				}, nil),
+			)
+
+			r := NewDefaultSecurityGroupReconciler(sgManager, &log.NullLogger{})
+			for pass := 0; pass < 2; pass++ {
+				_, err := r.ReconcileIngress(context.Background(), "sg-a", []IPPermissionInfo{tt.desiredPermission}, WithPermissionSelector(labels.Everything()))
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupReconcileError_Error(t *testing.T) {
+	err := &SecurityGroupReconcileError{
+		SecurityGroupID:        "sg-a",
+		FailedPhase:            SecurityGroupReconcilePhaseAuthorize,
+		RevokedPermissionCount: 2,
+		Err:                    awserr.New("InvalidPermission.Duplicate", "duplicated permission", nil),
+	}
+	assert.EqualError(t, err, "failed to authorize ingress permissions on securityGroup sg-a after 0 permissions authorized and 2 permissions revoked: InvalidPermission.Duplicate: duplicated permission")
+	assert.True(t, err.IsPartial())
+
+	var awsErr awserr.Error
+	assert.True(t, errors.As(err, &awsErr))
+	r := &defaultSecurityGroupReconciler{}
+	assert.True(t, r.shouldRetryWithoutCache(err))
+}
+
+func Test_consolidateIPPermissionPortRanges(t *testing.T) {
+	labelsA := map[string]string{"managed": "true"}
+	labelsB := map[string]string{"managed": "true", "other": "true"}
+	var ports8080To8090 []IPPermissionInfo
+	for port := int64(8090); port >= 8080; port-- {
+		ports8080To8090 = append(ports8080To8090, NewCIDRIPPermission("tcp", awssdk.Int64(port), awssdk.Int64(port), "10.0.0.0/16", labelsA))
+	}
+	tests := []struct {
+		name        string
+		permissions []IPPermissionInfo
+		want        []IPPermissionInfo
+	}{
+		{
+			name:        "contiguous ports 8080-8090 to one CIDR",
+			permissions: ports8080To8090,
+			want: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8090), "10.0.0.0/16", labelsA),
+			},
+		},
+		{
+			name: "overlapping and non-contiguous port ranges",
+			permissions: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8085), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("tcp", awssdk.Int64(8083), awssdk.Int64(8090), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("tcp", awssdk.Int64(8084), awssdk.Int64(8084), "10.0.0.0/16", labelsA),
+			},
+			want: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8090), "10.0.0.0/16", labelsA),
+			},
+		},
+		{
+			name: "different protocol, source or labels are not consolidated",
+			permissions: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("udp", awssdk.Int64(81), awssdk.Int64(81), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("tcp", awssdk.Int64(81), awssdk.Int64(81), "10.1.0.0/16", labelsA),
+				NewCIDRv6IPPermission("tcp", awssdk.Int64(81), awssdk.Int64(81), "::/0", labelsA),
+				NewCIDRIPPermission("tcp", awssdk.Int64(81), awssdk.Int64(81), "10.0.0.0/16", labelsB),
+			},
+			want: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("tcp", awssdk.Int64(81), awssdk.Int64(81), "10.0.0.0/16", labelsB),
+				NewCIDRIPPermission("tcp", awssdk.Int64(81), awssdk.Int64(81), "10.1.0.0/16", labelsA),
+				NewCIDRv6IPPermission("tcp", awssdk.Int64(81), awssdk.Int64(81), "::/0", labelsA),
+				NewCIDRIPPermission("udp", awssdk.Int64(81), awssdk.Int64(81), "10.0.0.0/16", labelsA),
+			},
+		},
+		{
+			name: "non tcp/udp permissions are kept as is",
+			permissions: []IPPermissionInfo{
+				NewCIDRIPPermission("icmp", awssdk.Int64(3), awssdk.Int64(4), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("icmp", awssdk.Int64(5), awssdk.Int64(5), "10.0.0.0/16", labelsA),
+			},
+			want: []IPPermissionInfo{
+				NewCIDRIPPermission("icmp", awssdk.Int64(3), awssdk.Int64(4), "10.0.0.0/16", labelsA),
+				NewCIDRIPPermission("icmp", awssdk.Int64(5), awssdk.Int64(5), "10.0.0.0/16", labelsA),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := consolidateIPPermissionPortRanges(tt.permissions)
+			assert.ElementsMatch(t, tt.want, got)
+			assert.Equal(t, []IPPermissionInfo(nil), diffIPPermissionInfos(got, tt.want))
+		})
+	}
+}
+
 func Test_defaultSecurityGroupReconciler_shouldRetryWithoutCache(t *testing.T) {
 	type args struct {
 		err error
@@ -38,6 +660,18 @@ func Test_defaultSecurityGroupReconciler_shouldRetryWithoutCache(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "should retry without cache when any failed batch got duplicated permission error",
+			args: args{
+				err: &permissionBatchesError{
+					errs: []error{
+						awserr.New("SomeOtherError", "", nil),
+						awserr.New("InvalidPermission.Duplicate", "", nil),
+					},
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
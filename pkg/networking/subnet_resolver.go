@@ -90,13 +90,16 @@ type SubnetsResolver interface {
 }
 
 // NewDefaultSubnetsResolver constructs new defaultSubnetsResolver.
-func NewDefaultSubnetsResolver(azInfoProvider AZInfoProvider, ec2Client services.EC2, vpcID string, clusterName string, logger logr.Logger) *defaultSubnetsResolver {
+func NewDefaultSubnetsResolver(azInfoProvider AZInfoProvider, ec2Client services.EC2, vpcID string, clusterName string,
+	disableAutoDiscovery bool, maxDiscoveredSubnets int, logger logr.Logger) *defaultSubnetsResolver {
 	return &defaultSubnetsResolver{
-		azInfoProvider: azInfoProvider,
-		ec2Client:      ec2Client,
-		vpcID:          vpcID,
-		clusterName:    clusterName,
-		logger:         logger,
+		azInfoProvider:       azInfoProvider,
+		ec2Client:            ec2Client,
+		vpcID:                vpcID,
+		clusterName:          clusterName,
+		disableAutoDiscovery: disableAutoDiscovery,
+		maxDiscoveredSubnets: maxDiscoveredSubnets,
+		logger:               logger,
 	}
 }
 
@@ -108,10 +111,17 @@ type defaultSubnetsResolver struct {
 	ec2Client      services.EC2
 	vpcID          string
 	clusterName    string
-	logger         logr.Logger
+	// when disabled, subnets must be specified explicitly and ResolveViaDiscovery always fails.
+	disableAutoDiscovery bool
+	// the maximum number of subnets chosen by ResolveViaDiscovery, 0 means unlimited.
+	maxDiscoveredSubnets int
+	logger               logr.Logger
 }
 
 func (r *defaultSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error) {
+	if r.disableAutoDiscovery {
+		return nil, errors.New("subnet auto-discovery is disabled, subnets must be specified explicitly via the subnets annotation")
+	}
 	resolveOpts := defaultSubnetsResolveOptions()
 	resolveOpts.ApplyOptions(opts)
 
@@ -168,6 +178,7 @@ func (r *defaultSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts .
 	if len(chosenSubnets) == 0 {
 		return nil, errors.New("unable to discover at least one subnet")
 	}
+	chosenSubnets = r.limitDiscoveredSubnets(chosenSubnets)
 	subnetLocale, err := r.validateSubnetsLocaleUniformity(ctx, chosenSubnets)
 	if err != nil {
 		return nil, err
@@ -179,6 +190,30 @@ func (r *defaultSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts .
 	return chosenSubnets, nil
 }
 
+// limitDiscoveredSubnets chooses at most maxDiscoveredSubnets subnets, in the lexical order of AZ and then subnetID.
+// the given subnets are expected to be in distinct AZs. The choice must be stable across reconciles, otherwise the
+// load balancer's subnets would keep changing, which isn't even possible for NLBs.
+func (r *defaultSubnetsResolver) limitDiscoveredSubnets(subnets []*ec2sdk.Subnet) []*ec2sdk.Subnet {
+	if r.maxDiscoveredSubnets <= 0 || len(subnets) <= r.maxDiscoveredSubnets {
+		return subnets
+	}
+	sort.Slice(subnets, func(i, j int) bool {
+		azI := awssdk.StringValue(subnets[i].AvailabilityZone)
+		azJ := awssdk.StringValue(subnets[j].AvailabilityZone)
+		if azI != azJ {
+			return azI < azJ
+		}
+		return awssdk.StringValue(subnets[i].SubnetId) < awssdk.StringValue(subnets[j].SubnetId)
+	})
+	excludedAZs := make([]string, 0, len(subnets)-r.maxDiscoveredSubnets)
+	for _, subnet := range subnets[r.maxDiscoveredSubnets:] {
+		excludedAZs = append(excludedAZs, awssdk.StringValue(subnet.AvailabilityZone))
+	}
+	r.logger.Info("discovered subnets exceed the maximum subnets per load balancer", "maxSubnets", r.maxDiscoveredSubnets,
+		"excludedAvailabilityZones", excludedAZs)
+	return subnets[:r.maxDiscoveredSubnets]
+}
+
 func (r *defaultSubnetsResolver) ResolveViaNameOrIDSlice(ctx context.Context, subnetNameOrIDs []string, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error) {
 	resolveOpts := defaultSubnetsResolveOptions()
 	resolveOpts.ApplyOptions(opts)
@@ -253,6 +288,7 @@ func (r *defaultSubnetsResolver) validateSubnetsAZExclusivity(subnets []*ec2sdk.
 			for _, subnet := range subnets {
 				subnetIDs = append(subnetIDs, awssdk.StringValue(subnet.SubnetId))
 			}
+			sort.Strings(subnetIDs)
 			return errors.Errorf("multiple subnets in same Availability Zone %v: %v", az, subnetIDs)
 		}
 	}
@@ -277,11 +313,30 @@ func (r *defaultSubnetsResolver) validateSubnetsLocaleUniformity(ctx context.Con
 	return subnetLocaleType(subnetLocale), nil
 }
 
+// InsufficientSubnetsError is returned when resolved subnets don't meet the minimal count requirement of load balancer.
+type InsufficientSubnetsError struct {
+	// The Load Balancer Type.
+	LBType elbv2model.LoadBalancerType
+	// The minimal required count of subnets.
+	MinimalCount int
+	// The Availability Zones of resolved subnets.
+	AvailabilityZones []string
+}
+
+func (e *InsufficientSubnetsError) Error() string {
+	return fmt.Sprintf("%v load balancer requires subnets in at least %v Availability Zones, found %v: %v",
+		e.LBType, e.MinimalCount, len(e.AvailabilityZones), e.AvailabilityZones)
+}
+
 // validateSubnetsMinimalCount validates subnets meets minimal count requirement.
 func (r *defaultSubnetsResolver) validateSubnetsMinimalCount(subnets []*ec2sdk.Subnet, subnetLocale subnetLocaleType, resolveOpts SubnetsResolveOptions) error {
 	minimalCount := r.computeSubnetsMinimalCount(subnetLocale, resolveOpts)
 	if len(subnets) < minimalCount {
-		return errors.Errorf("subnets count less than minimal required count: %v < %v", len(subnets), minimalCount)
+		return &InsufficientSubnetsError{
+			LBType:            resolveOpts.LBType,
+			MinimalCount:      minimalCount,
+			AvailabilityZones: sets.StringKeySet(mapSDKSubnetsByAZ(subnets)).List(),
+		}
 	}
 	return nil
 }
@@ -29,6 +29,8 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 	type fields struct {
 		vpcID                      string
 		clusterName                string
+		disableAutoDiscovery       bool
+		maxDiscoveredSubnets       int
 		describeSubnetsAsListCalls []describeSubnetsAsListCall
 		fetchAZInfosCalls          []fetchAZInfosCall
 	}
@@ -119,6 +121,102 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ALB with more eligible AZs than maxDiscoveredSubnets - chosen by AZ regardless of available IP addresses",
+			fields: fields{
+				vpcID:                "vpc-1",
+				clusterName:          "kube-cluster",
+				maxDiscoveredSubnets: 2,
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("tag:kubernetes.io/role/elb"),
+									Values: awssdk.StringSlice([]string{"", "1"}),
+								},
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+							},
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:                awssdk.String("subnet-1"),
+								AvailabilityZone:        awssdk.String("us-west-2a"),
+								AvailabilityZoneId:      awssdk.String("usw2-az1"),
+								VpcId:                   awssdk.String("vpc-1"),
+								AvailableIpAddressCount: awssdk.Int64(10),
+							},
+							{
+								SubnetId:                awssdk.String("subnet-2"),
+								AvailabilityZone:        awssdk.String("us-west-2b"),
+								AvailabilityZoneId:      awssdk.String("usw2-az2"),
+								VpcId:                   awssdk.String("vpc-1"),
+								AvailableIpAddressCount: awssdk.Int64(200),
+							},
+							{
+								SubnetId:                awssdk.String("subnet-3"),
+								AvailabilityZone:        awssdk.String("us-west-2c"),
+								AvailabilityZoneId:      awssdk.String("usw2-az3"),
+								VpcId:                   awssdk.String("vpc-1"),
+								AvailableIpAddressCount: awssdk.Int64(50),
+							},
+							{
+								SubnetId:                awssdk.String("subnet-4"),
+								AvailabilityZone:        awssdk.String("us-west-2d"),
+								AvailabilityZoneId:      awssdk.String("usw2-az4"),
+								VpcId:                   awssdk.String("vpc-1"),
+								AvailableIpAddressCount: awssdk.Int64(30),
+							},
+						},
+					},
+				},
+				fetchAZInfosCalls: []fetchAZInfosCall{
+					{
+						availabilityZoneIDs: []string{"usw2-az2"},
+						azInfoByAZID: map[string]ec2sdk.AvailabilityZone{
+							"usw2-az2": {
+								ZoneId:   awssdk.String("usw2-az2"),
+								ZoneType: awssdk.String("availability-zone"),
+							},
+						},
+					},
+					{
+						availabilityZoneIDs: []string{"usw2-az1"},
+						azInfoByAZID: map[string]ec2sdk.AvailabilityZone{
+							"usw2-az1": {
+								ZoneId:   awssdk.String("usw2-az1"),
+								ZoneType: awssdk.String("availability-zone"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternetFacing),
+				},
+			},
+			want: []*ec2sdk.Subnet{
+				{
+					SubnetId:                awssdk.String("subnet-1"),
+					AvailabilityZone:        awssdk.String("us-west-2a"),
+					AvailabilityZoneId:      awssdk.String("usw2-az1"),
+					VpcId:                   awssdk.String("vpc-1"),
+					AvailableIpAddressCount: awssdk.Int64(10),
+				},
+				{
+					SubnetId:                awssdk.String("subnet-2"),
+					AvailabilityZone:        awssdk.String("us-west-2b"),
+					AvailabilityZoneId:      awssdk.String("usw2-az2"),
+					VpcId:                   awssdk.String("vpc-1"),
+					AvailableIpAddressCount: awssdk.Int64(200),
+				},
+			},
+		},
 		{
 			name: "ALB internal",
 			fields: fields{
@@ -330,7 +428,7 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
 				},
 			},
-			wantErr: errors.New("subnets count less than minimal required count: 1 < 2"),
+			wantErr: errors.New("application load balancer requires subnets in at least 2 Availability Zones, found 1: [us-west-2a]"),
 		},
 		{
 			name: "ALB with one matching local-zone subnet",
@@ -886,6 +984,21 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "auto-discovery disabled",
+			fields: fields{
+				vpcID:                "vpc-1",
+				clusterName:          "kube-cluster",
+				disableAutoDiscovery: true,
+			},
+			args: args{
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternetFacing),
+				},
+			},
+			wantErr: errors.New("subnet auto-discovery is disabled, subnets must be specified explicitly via the subnets annotation"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -904,11 +1017,13 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 			}
 
 			r := &defaultSubnetsResolver{
-				azInfoProvider: azInfoProvider,
-				ec2Client:      ec2Client,
-				vpcID:          tt.fields.vpcID,
-				clusterName:    tt.fields.clusterName,
-				logger:         &log.NullLogger{},
+				azInfoProvider:       azInfoProvider,
+				ec2Client:            ec2Client,
+				vpcID:                tt.fields.vpcID,
+				clusterName:          tt.fields.clusterName,
+				disableAutoDiscovery: tt.fields.disableAutoDiscovery,
+				maxDiscoveredSubnets: tt.fields.maxDiscoveredSubnets,
+				logger:               &log.NullLogger{},
 			}
 
 			got, err := r.ResolveViaDiscovery(context.Background(), tt.args.opts...)
@@ -1283,6 +1398,61 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 			},
 			wantErr: errors.New("multiple subnets in same Availability Zone us-west-2a: [subnet-1 subnet-3]"),
 		},
+		{
+			name: "multiple subnet in same AZ specified by ID and name",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-3", "subnet-2"}),
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-3"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("tag:Name"),
+									Values: awssdk.StringSlice([]string{"my-subnet"}),
+								},
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+							},
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"subnet-3", "subnet-2", "my-subnet"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			wantErr: errors.New("multiple subnets in same Availability Zone us-west-2a: [subnet-1 subnet-3]"),
+		},
 		{
 			name: "multiple subnet locales",
 			fields: fields{
@@ -1370,7 +1540,7 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
 				},
 			},
-			wantErr: errors.New("subnets count less than minimal required count: 1 < 2"),
+			wantErr: errors.New("application load balancer requires subnets in at least 2 Availability Zones, found 1: [us-west-2a]"),
 		},
 		{
 			name: "ALB with one local-zone subnet",
@@ -1724,6 +1894,95 @@ func Test_defaultSubnetsResolver_buildSDKSubnetLocaleType(t *testing.T) {
 	}
 }
 
+func Test_defaultSubnetsResolver_validateSubnetsMinimalCount(t *testing.T) {
+	type args struct {
+		subnets      []*ec2sdk.Subnet
+		subnetLocale subnetLocaleType
+		resolveOpts  SubnetsResolveOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ALB with subnets in two availability zones",
+			args: args{
+				subnets: []*ec2sdk.Subnet{
+					{
+						SubnetId:         awssdk.String("subnet-1"),
+						AvailabilityZone: awssdk.String("us-west-2a"),
+					},
+					{
+						SubnetId:         awssdk.String("subnet-2"),
+						AvailabilityZone: awssdk.String("us-west-2b"),
+					},
+				},
+				subnetLocale: subnetLocaleTypeAvailabilityZone,
+				resolveOpts:  SubnetsResolveOptions{LBType: elbv2model.LoadBalancerTypeApplication},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ALB with subnet in single availability zone",
+			args: args{
+				subnets: []*ec2sdk.Subnet{
+					{
+						SubnetId:         awssdk.String("subnet-1"),
+						AvailabilityZone: awssdk.String("us-west-2a"),
+					},
+				},
+				subnetLocale: subnetLocaleTypeAvailabilityZone,
+				resolveOpts:  SubnetsResolveOptions{LBType: elbv2model.LoadBalancerTypeApplication},
+			},
+			wantErr: &InsufficientSubnetsError{
+				LBType:            elbv2model.LoadBalancerTypeApplication,
+				MinimalCount:      2,
+				AvailabilityZones: []string{"us-west-2a"},
+			},
+		},
+		{
+			name: "ALB with subnet in single local zone",
+			args: args{
+				subnets: []*ec2sdk.Subnet{
+					{
+						SubnetId:         awssdk.String("subnet-1"),
+						AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
+					},
+				},
+				subnetLocale: subnetLocaleTypeLocalZone,
+				resolveOpts:  SubnetsResolveOptions{LBType: elbv2model.LoadBalancerTypeApplication},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "NLB with subnet in single availability zone",
+			args: args{
+				subnets: []*ec2sdk.Subnet{
+					{
+						SubnetId:         awssdk.String("subnet-1"),
+						AvailabilityZone: awssdk.String("us-west-2a"),
+					},
+				},
+				subnetLocale: subnetLocaleTypeAvailabilityZone,
+				resolveOpts:  SubnetsResolveOptions{LBType: elbv2model.LoadBalancerTypeNetwork},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &defaultSubnetsResolver{}
+			err := r.validateSubnetsMinimalCount(tt.args.subnets, tt.args.subnetLocale, tt.args.resolveOpts)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_sortSubnetsByID(t *testing.T) {
 	type args struct {
 		subnets []*ec2sdk.Subnet
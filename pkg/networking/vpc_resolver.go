@@ -2,10 +2,14 @@ package networking
 
 import (
 	"context"
+	"sync"
+	"time"
+
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 )
 
@@ -16,11 +20,14 @@ type VPCResolver interface {
 }
 
 // NewDefaultVPCResolver constructs a new defaultVPCResolver
-func NewDefaultVPCResolver(ec2Client services.EC2, vpcID string, logger logr.Logger) *defaultVPCResolver {
+// VPC CIDRs are cached for vpcCacheTTL, 0 disables the cache.
+func NewDefaultVPCResolver(ec2Client services.EC2, vpcID string, vpcCacheTTL time.Duration, logger logr.Logger) *defaultVPCResolver {
 	return &defaultVPCResolver{
-		ec2Client: ec2Client,
-		vpcID:     vpcID,
-		logger:    logger,
+		ec2Client:     ec2Client,
+		vpcID:         vpcID,
+		vpcCIDRsCache: cache.NewExpiring(),
+		vpcCacheTTL:   vpcCacheTTL,
+		logger:        logger,
 	}
 }
 
@@ -30,10 +37,47 @@ var _ VPCResolver = &defaultVPCResolver{}
 type defaultVPCResolver struct {
 	ec2Client services.EC2
 	vpcID     string
-	logger    logr.Logger
+
+	vpcCIDRsCache      *cache.Expiring
+	vpcCIDRsCacheMutex sync.RWMutex
+	vpcCacheTTL        time.Duration
+
+	logger logr.Logger
 }
 
 func (r *defaultVPCResolver) ResolveCIDRs(ctx context.Context) ([]string, error) {
+	if r.vpcCacheTTL <= 0 {
+		return r.resolveCIDRsFromAWS(ctx)
+	}
+	if vpcCIDRs, exists := r.fetchCIDRsFromCache(); exists {
+		return vpcCIDRs, nil
+	}
+	vpcCIDRs, err := r.resolveCIDRsFromAWS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.saveCIDRsToCache(vpcCIDRs)
+	return vpcCIDRs, nil
+}
+
+func (r *defaultVPCResolver) fetchCIDRsFromCache() ([]string, bool) {
+	r.vpcCIDRsCacheMutex.RLock()
+	defer r.vpcCIDRsCacheMutex.RUnlock()
+
+	if rawCacheItem, exists := r.vpcCIDRsCache.Get(r.vpcID); exists {
+		return rawCacheItem.([]string), true
+	}
+	return nil, false
+}
+
+func (r *defaultVPCResolver) saveCIDRsToCache(vpcCIDRs []string) {
+	r.vpcCIDRsCacheMutex.Lock()
+	defer r.vpcCIDRsCacheMutex.Unlock()
+
+	r.vpcCIDRsCache.Set(r.vpcID, vpcCIDRs, r.vpcCacheTTL)
+}
+
+func (r *defaultVPCResolver) resolveCIDRsFromAWS(ctx context.Context) ([]string, error) {
 	vpcs, err := r.ec2Client.DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{
 		VpcIds: []*string{awssdk.String(r.vpcID)},
 	})
@@ -8,7 +8,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_defaultVPCResolver_ResolveCIDRs(t *testing.T) {
@@ -123,3 +125,51 @@ func Test_defaultVPCResolver_ResolveCIDRs(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultVPCResolver_ResolveCIDRs_withCache(t *testing.T) {
+	describeVpcsInput := &ec2sdk.DescribeVpcsInput{
+		VpcIds: []*string{awssdk.String("vpc-01xxx2")},
+	}
+	describeVpcsOutput := &ec2sdk.DescribeVpcsOutput{
+		Vpcs: []*ec2sdk.Vpc{
+			{
+				CidrBlockAssociationSet: []*ec2sdk.VpcCidrBlockAssociation{
+					{
+						CidrBlock: awssdk.String("192.160.0.0/16"),
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                  string
+		vpcCacheTTL           time.Duration
+		wantDescribeVpcsCalls int
+	}{
+		{
+			name:                  "VPC CIDRs are cached",
+			vpcCacheTTL:           10 * time.Minute,
+			wantDescribeVpcsCalls: 1,
+		},
+		{
+			name:                  "cache disabled",
+			vpcCacheTTL:           0,
+			wantDescribeVpcsCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := services.NewMockEC2(ctrl)
+			ec2Client.EXPECT().DescribeVpcsWithContext(gomock.Any(), describeVpcsInput).Return(describeVpcsOutput, nil).Times(tt.wantDescribeVpcsCalls)
+			vpcResolver := NewDefaultVPCResolver(ec2Client, "vpc-01xxx2", tt.vpcCacheTTL, &log.NullLogger{})
+			for i := 0; i < 2; i++ {
+				got, err := vpcResolver.ResolveCIDRs(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"192.160.0.0/16"}, got)
+			}
+		})
+	}
+}
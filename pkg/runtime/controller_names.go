@@ -0,0 +1,19 @@
+package runtime
+
+const (
+	// ControllerNameIngress is the name of Ingress controller.
+	ControllerNameIngress = "ingress"
+	// ControllerNameService is the name of Service controller.
+	ControllerNameService = "service"
+	// ControllerNameTargetGroupBinding is the name of TargetGroupBinding controller.
+	ControllerNameTargetGroupBinding = "targetGroupBinding"
+)
+
+// ControllerNames are the names of all controllers.
+// controller-runtime names the workqueue of each controller after the controller name, they must be distinct so that
+// the workqueue metrics(e.g. workqueue_depth) and reconcile metrics of each controller are separable by the name/controller label.
+var ControllerNames = []string{
+	ControllerNameIngress,
+	ControllerNameService,
+	ControllerNameTargetGroupBinding,
+}
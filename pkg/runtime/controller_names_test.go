@@ -0,0 +1,14 @@
+package runtime
+
+import (
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"testing"
+)
+
+func Test_ControllerNames(t *testing.T) {
+	assert.Equal(t, len(ControllerNames), sets.NewString(ControllerNames...).Len(), "controller names must be distinct: %v", ControllerNames)
+	for _, name := range ControllerNames {
+		assert.NotEmpty(t, name)
+	}
+}
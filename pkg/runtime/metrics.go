@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricSubsystemController = "awslbc"
+	metricReconcileErrors     = "reconcile_errors_total"
+
+	labelController = "controller"
+	labelReason     = "reason"
+
+	reconcileErrorReasonUnknown = "Unknown"
+)
+
+var reconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: metricSubsystemController,
+	Name:      metricReconcileErrors,
+	Help:      "Total number of reconcile errors per controller and reason",
+}, []string{labelController, labelReason})
+
+func init() {
+	metrics.Registry.MustRegister(reconcileErrorsTotal)
+}
+
+// recordReconcileError records the reconcile error for controller.
+func recordReconcileError(controllerName string, err error) {
+	reconcileErrorsTotal.WithLabelValues(controllerName, classifyReconcileError(err)).Inc()
+}
+
+// classifyReconcileError classifies reconcile error into a reason with bounded cardinality.
+//   - for AWS API errors, the reason is the AWS error code.
+//   - for Kubernetes API errors, the reason is the StatusReason.
+//   - otherwise, the reason is "Unknown".
+func classifyReconcileError(err error) string {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code()
+	}
+	if reason := apierrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	return reconcileErrorReasonUnknown
+}
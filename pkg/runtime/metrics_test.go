@@ -0,0 +1,45 @@
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"testing"
+)
+
+func Test_classifyReconcileError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "AWS API error",
+			err:  awserr.New("Throttling", "rate exceeded", nil),
+			want: "Throttling",
+		},
+		{
+			name: "wrapped AWS API error",
+			err:  errors.Wrap(awserr.New("InvalidParameter", "some message", nil), "failed to create targetGroup"),
+			want: "InvalidParameter",
+		},
+		{
+			name: "Kubernetes API error",
+			err:  apierrors.NewConflict(schema.GroupResource{Resource: "services"}, "awesome-svc", errors.New("some error")),
+			want: "Conflict",
+		},
+		{
+			name: "other error",
+			err:  errors.New("some error"),
+			want: "Unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyReconcileError(tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
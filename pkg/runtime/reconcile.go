@@ -7,7 +7,8 @@ import (
 )
 
 // HandleReconcileError will handle errors from reconcile handlers, which respects runtime errors.
-func HandleReconcileError(err error, log logr.Logger) (ctrl.Result, error) {
+// errors other than runtime errors are recorded in the reconcile errors metric of controllerName.
+func HandleReconcileError(err error, controllerName string, log logr.Logger) (ctrl.Result, error) {
 	if err == nil {
 		return ctrl.Result{}, nil
 	}
@@ -24,5 +25,6 @@ func HandleReconcileError(err error, log logr.Logger) (ctrl.Result, error) {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	recordReconcileError(controllerName, err)
 	return ctrl.Result{}, err
 }
@@ -58,7 +58,7 @@ func TestHandleReconcileError(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := HandleReconcileError(tt.args.err, &log.NullLogger{})
+			got, err := HandleReconcileError(tt.args.err, "some-controller", &log.NullLogger{})
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
@@ -0,0 +1,59 @@
+package runtime
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ReconcileThrottler enforces a minimum interval between reconciles of the same object.
+type ReconcileThrottler interface {
+	// Throttle returns the duration to wait before the object of req can be reconciled again.
+	// zero is returned if the object can be reconciled now, and the reconcile is recorded.
+	Throttle(req ctrl.Request) time.Duration
+}
+
+// NewDefaultReconcileThrottler constructs new defaultReconcileThrottler.
+// zero minInterval disables the throttling.
+func NewDefaultReconcileThrottler(minInterval time.Duration) *defaultReconcileThrottler {
+	return newReconcileThrottlerWithClock(minInterval, clock.RealClock{})
+}
+
+func newReconcileThrottlerWithClock(minInterval time.Duration, clock clock.Clock) *defaultReconcileThrottler {
+	return &defaultReconcileThrottler{
+		minInterval:       minInterval,
+		clock:             clock,
+		lastReconcileTime: cache.NewExpiringWithClock(clock),
+	}
+}
+
+var _ ReconcileThrottler = &defaultReconcileThrottler{}
+
+// defaultReconcileThrottler remembers the last reconcile time per object until minInterval elapses.
+type defaultReconcileThrottler struct {
+	minInterval time.Duration
+	clock       clock.Clock
+
+	mutex             sync.Mutex
+	lastReconcileTime *cache.Expiring
+}
+
+func (t *defaultReconcileThrottler) Throttle(req ctrl.Request) time.Duration {
+	if t.minInterval <= 0 {
+		return 0
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.clock.Now()
+	if rawLastReconcileTime, exists := t.lastReconcileTime.Get(req.NamespacedName); exists {
+		if wait := rawLastReconcileTime.(time.Time).Add(t.minInterval).Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	t.lastReconcileTime.Set(req.NamespacedName, now, t.minInterval)
+	return 0
+}
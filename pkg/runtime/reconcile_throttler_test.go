@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func Test_defaultReconcileThrottler_Throttle(t *testing.T) {
+	reqA := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}
+	reqB := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "b"}}
+	type update struct {
+		req     ctrl.Request
+		elapsed time.Duration
+	}
+	tests := []struct {
+		name        string
+		minInterval time.Duration
+		updates     []update
+		want        []time.Duration
+	}{
+		{
+			name:        "throttling disabled",
+			minInterval: 0,
+			updates: []update{
+				{req: reqA},
+				{req: reqA, elapsed: 10 * time.Millisecond},
+				{req: reqA, elapsed: 10 * time.Millisecond},
+			},
+			want: []time.Duration{0, 0, 0},
+		},
+		{
+			name:        "rapid updates of same object are throttled",
+			minInterval: 1 * time.Second,
+			updates: []update{
+				{req: reqA},
+				{req: reqA, elapsed: 100 * time.Millisecond},
+				{req: reqA, elapsed: 100 * time.Millisecond},
+				{req: reqA, elapsed: 300 * time.Millisecond},
+				{req: reqA, elapsed: 500 * time.Millisecond},
+				{req: reqA, elapsed: 100 * time.Millisecond},
+			},
+			want: []time.Duration{0, 900 * time.Millisecond, 800 * time.Millisecond, 500 * time.Millisecond, 0, 900 * time.Millisecond},
+		},
+		{
+			name:        "busy object doesn't throttle other objects",
+			minInterval: 1 * time.Second,
+			updates: []update{
+				{req: reqA},
+				{req: reqA, elapsed: 100 * time.Millisecond},
+				{req: reqB},
+				{req: reqA, elapsed: 100 * time.Millisecond},
+				{req: reqB, elapsed: 100 * time.Millisecond},
+			},
+			want: []time.Duration{0, 900 * time.Millisecond, 0, 800 * time.Millisecond, 800 * time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClock := clock.NewFakeClock(time.Now())
+			throttler := newReconcileThrottlerWithClock(tt.minInterval, fakeClock)
+			var got []time.Duration
+			for _, u := range tt.updates {
+				fakeClock.Step(u.elapsed)
+				got = append(got, throttler.Throttle(u.req))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,3 +18,23 @@ func RetryImmediateOnError(interval time.Duration, timeout time.Duration, retrya
 		return true, nil
 	})
 }
+
+// RetryWithBackoffOnError tries to run fn with exponential backoff until it succeeds, a non-retryable error occurs or the backoff steps are exhausted.
+// the last retryable error is returned if backoff steps are exhausted.
+func RetryWithBackoffOnError(backoff wait.Backoff, retryable func(error) bool, fn func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		lastErr = fn()
+		if lastErr != nil {
+			if retryable(lastErr) {
+				return false, nil
+			}
+			return false, lastErr
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
@@ -3,6 +3,7 @@ package runtime
 import (
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"testing"
 	"time"
 )
@@ -104,3 +105,86 @@ func Test_RetryImmediateOnError(t *testing.T) {
 		})
 	}
 }
+
+func Test_RetryWithBackoffOnError(t *testing.T) {
+	retryable := func(err error) bool {
+		return err.Error() == "retryable"
+	}
+
+	failureAfterRetryCountFnGen := func(x int) func() error {
+		return func() error {
+			x = x - 1
+			if x < 0 {
+				return errors.New("failure")
+			}
+			return errors.New("retryable")
+		}
+	}
+	successAfterRetryCountFnGen := func(x int) func() error {
+		return func() error {
+			x = x - 1
+			if x < 0 {
+				return nil
+			}
+			return errors.New("retryable")
+		}
+	}
+
+	type args struct {
+		backoff   wait.Backoff
+		retryable func(error) bool
+		fn        func() error
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name: "retry 2 times before failure",
+			args: args{
+				backoff:   wait.Backoff{Duration: 1 * time.Millisecond, Factor: 2, Steps: 5},
+				retryable: retryable,
+				fn:        failureAfterRetryCountFnGen(2),
+			},
+			wantCount: 3,
+			wantErr:   errors.New("failure"),
+		},
+		{
+			name: "retry 2 times before success",
+			args: args{
+				backoff:   wait.Backoff{Duration: 1 * time.Millisecond, Factor: 2, Steps: 5},
+				retryable: retryable,
+				fn:        successAfterRetryCountFnGen(2),
+			},
+			wantCount: 3,
+			wantErr:   nil,
+		},
+		{
+			name: "retry 4 times before success - but steps exhausted after 2nd retry",
+			args: args{
+				backoff:   wait.Backoff{Duration: 1 * time.Millisecond, Factor: 2, Steps: 3},
+				retryable: retryable,
+				fn:        successAfterRetryCountFnGen(4),
+			},
+			wantCount: 3,
+			wantErr:   errors.New("retryable"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := 0
+			err := RetryWithBackoffOnError(tt.args.backoff, tt.args.retryable, func() error {
+				count += 1
+				return tt.args.fn()
+			})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
@@ -42,6 +42,18 @@ func Test_defaultModelBuilderTask_buildListenerALPNPolicy(t *testing.T) {
 			},
 			listenerProtocol: elbv2model.ProtocolTLS,
 		},
+		{
+			name: "Service with annotation, non-tls listener",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-alpn-policy": "HTTP2Only",
+					},
+				},
+			},
+			listenerProtocol: elbv2model.ProtocolTCP,
+			targetProtocol:   elbv2model.ProtocolTLS,
+		},
 		{
 			name: "Service with annotation, TLS targets",
 			svc: &corev1.Service{
@@ -14,8 +14,11 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 )
@@ -25,9 +28,30 @@ const (
 	lbAttrsAccessLogsS3Bucket            = "access_logs.s3.bucket"
 	lbAttrsAccessLogsS3Prefix            = "access_logs.s3.prefix"
 	lbAttrsLoadBalancingCrossZoneEnabled = "load_balancing.cross_zone.enabled"
+	lbAttrsDeletionProtectionEnabled     = "deletion_protection.enabled"
+	lbAttrsIPv6DenyAllIGWTraffic         = "ipv6.deny_all_igw_traffic"
+	lbAttrsZonalShiftConfigEnabled       = "zonal_shift.config.enabled"
+	lbAttrsDNSRecordClientRoutingPolicy  = "dns_record.client_routing_policy"
 	resourceIDLoadBalancer               = "LoadBalancer"
 )
 
+// lbAttrsKeyPattern is the syntax of loadBalancer attribute keys accepted by ELBV2.
+var lbAttrsKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9._]{1,256}$`)
+
+// lbAttrsValueValidators are value validators for known NLB attributes.
+// attributes without validator are passed through to ELBV2 as is.
+var lbAttrsValueValidators = map[string]func(value string) error{
+	lbAttrsAccessLogsS3Enabled:           validateBoolLBAttributeValue,
+	lbAttrsAccessLogsS3Bucket:            validateNoopLBAttributeValue,
+	lbAttrsAccessLogsS3Prefix:            validateNoopLBAttributeValue,
+	lbAttrsLoadBalancingCrossZoneEnabled: validateBoolLBAttributeValue,
+	lbAttrsDeletionProtectionEnabled:     validateBoolLBAttributeValue,
+	lbAttrsIPv6DenyAllIGWTraffic:         validateBoolLBAttributeValue,
+	lbAttrsZonalShiftConfigEnabled:       validateBoolLBAttributeValue,
+	lbAttrsDNSRecordClientRoutingPolicy: validateEnumLBAttributeValue("availability_zone_affinity",
+		"partial_availability_zone_affinity", "any_availability_zone"),
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancer(ctx context.Context, scheme elbv2model.LoadBalancerScheme) error {
 	spec, err := t.buildLoadBalancerSpec(ctx, scheme)
 	if err != nil {
@@ -46,6 +70,10 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, schem
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
+	minimumLBCapacity, err := t.buildLoadBalancerMinimumCapacity(ctx)
+	if err != nil {
+		return elbv2model.LoadBalancerSpec{}, err
+	}
 	tags, err := t.buildLoadBalancerTags(ctx)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
@@ -56,13 +84,14 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, schem
 	}
 	name := t.buildLoadBalancerName(ctx, scheme)
 	spec := elbv2model.LoadBalancerSpec{
-		Name:                   name,
-		Type:                   elbv2model.LoadBalancerTypeNetwork,
-		Scheme:                 &scheme,
-		IPAddressType:          &ipAddressType,
-		SubnetMappings:         subnetMappings,
-		LoadBalancerAttributes: lbAttributes,
-		Tags:                   tags,
+		Name:                        name,
+		Type:                        elbv2model.LoadBalancerTypeNetwork,
+		Scheme:                      &scheme,
+		IPAddressType:               &ipAddressType,
+		SubnetMappings:              subnetMappings,
+		LoadBalancerAttributes:      lbAttributes,
+		MinimumLoadBalancerCapacity: minimumLBCapacity,
+		Tags:                        tags,
 	}
 	return spec, nil
 }
@@ -83,19 +112,44 @@ func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context
 	}
 }
 
+// buildLoadBalancerScheme builds the scheme from the scheme annotation, or the deprecated internal annotation as an alias.
+// the scheme annotation takes precedence if both are specified, with a warning event emitted upon conflict.
 func (t *defaultModelBuildTask) buildLoadBalancerScheme(ctx context.Context) (elbv2model.LoadBalancerScheme, bool, error) {
+	legacyScheme, explicitLegacyScheme, err := t.buildLoadBalancerSchemeLegacyAnnotation(ctx)
+	if err != nil {
+		return "", false, err
+	}
 	rawScheme := ""
-	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixScheme, &rawScheme, t.service.Annotations); exists {
-		switch rawScheme {
-		case string(elbv2model.LoadBalancerSchemeInternetFacing):
-			return elbv2model.LoadBalancerSchemeInternetFacing, true, nil
-		case string(elbv2model.LoadBalancerSchemeInternal):
-			return elbv2model.LoadBalancerSchemeInternal, true, nil
-		default:
-			return "", false, errors.Errorf("unknown scheme: %v", rawScheme)
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixScheme, &rawScheme, t.service.Annotations); !exists {
+		if explicitLegacyScheme {
+			t.recordDeprecatedAnnotationEvent(fmt.Sprintf("annotation %v is deprecated, use %v: %v instead",
+				annotations.SvcLBSuffixInternal, annotations.SvcLBSuffixScheme, legacyScheme))
 		}
+		return legacyScheme, explicitLegacyScheme, nil
+	}
+
+	var scheme elbv2model.LoadBalancerScheme
+	switch rawScheme {
+	case string(elbv2model.LoadBalancerSchemeInternetFacing):
+		scheme = elbv2model.LoadBalancerSchemeInternetFacing
+	case string(elbv2model.LoadBalancerSchemeInternal):
+		scheme = elbv2model.LoadBalancerSchemeInternal
+	default:
+		return "", false, errors.Errorf("unknown scheme: %v", rawScheme)
 	}
-	return t.buildLoadBalancerSchemeLegacyAnnotation(ctx)
+	if explicitLegacyScheme && legacyScheme != scheme {
+		t.recordDeprecatedAnnotationEvent(fmt.Sprintf("annotation %v is deprecated and conflicts with %v, scheme %v takes precedence",
+			annotations.SvcLBSuffixInternal, annotations.SvcLBSuffixScheme, scheme))
+	}
+	return scheme, true, nil
+}
+
+func (t *defaultModelBuildTask) recordDeprecatedAnnotationEvent(message string) {
+	t.logger.Info(message, "service", k8s.NamespacedName(t.service))
+	if t.eventRecorder == nil {
+		return
+	}
+	t.eventRecorder.Event(t.service, corev1.EventTypeWarning, k8s.ServiceEventReasonDeprecatedAnnotation, message)
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerSchemeLegacyAnnotation(_ context.Context) (elbv2model.LoadBalancerScheme, bool, error) {
@@ -150,7 +204,35 @@ func (t *defaultModelBuildTask) buildAdditionalResourceTags(_ context.Context) (
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerTags(ctx context.Context) (map[string]string, error) {
-	return t.buildAdditionalResourceTags(ctx)
+	return t.buildAdditionalResourceTagsWithNamespaceTags(ctx)
+}
+
+// buildAdditionalResourceTagsWithNamespaceTags builds the AWS Tags with labels of Service's Namespace at lowest priority. e.g. LoadBalancer, TargetGroup
+// tags with external managed keys are skipped, instead of failing the Service.
+// tags exceeding the AWS limit of tags per resource are skipped as well, with an event recorded on the Service.
+func (t *defaultModelBuildTask) buildAdditionalResourceTagsWithNamespaceTags(ctx context.Context) (map[string]string, error) {
+	additionalTags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if t.namespaceTagsResolver == nil {
+		return additionalTags, nil
+	}
+	nsTags, err := t.namespaceTagsResolver.Resolve(ctx, t.service.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed build tags for Namespace %v", t.service.Namespace)
+	}
+	for tagKey := range nsTags {
+		if t.externalManagedTags.Has(tagKey) {
+			delete(nsTags, tagKey)
+		}
+	}
+	mergedTags, skippedNSTagKeys := k8s.MergeNamespaceTags(additionalTags, nsTags)
+	if len(skippedNSTagKeys) != 0 {
+		t.eventRecorder.Eventf(t.service, corev1.EventTypeWarning, k8s.ServiceEventReasonNamespaceTagsSkipped,
+			"namespace tags %v are skipped since they exceed the limit of %v tags per AWS resource", skippedNSTagKeys, k8s.MaxTagsPerResource)
+	}
+	return mergedTags, nil
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Context, scheme elbv2model.LoadBalancerScheme, ec2Subnets []*ec2.Subnet) ([]elbv2model.SubnetMapping, error) {
@@ -241,9 +323,75 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 		return []elbv2model.LoadBalancerAttribute{}, err
 	}
 	mergedAttributes := algorithm.MergeStringMap(specificAttributes, loadBalancerAttributes)
+	if err := t.validateLoadBalancerAttributes(mergedAttributes); err != nil {
+		return []elbv2model.LoadBalancerAttribute{}, err
+	}
 	return makeAttributesSliceFromMap(mergedAttributes), nil
 }
 
+// validateLoadBalancerAttributes validates values of known loadBalancer attributes.
+// unknown attributes with valid key are passed through with a warning, so that newly introduced NLB attributes can be used.
+func (t *defaultModelBuildTask) validateLoadBalancerAttributes(attributes map[string]string) error {
+	var unknownAttrKeys []string
+	for _, attrKey := range sets.StringKeySet(attributes).List() {
+		if !lbAttrsKeyPattern.MatchString(attrKey) {
+			return errors.Errorf("invalid load balancer attribute key: %v", attrKey)
+		}
+		validator, known := lbAttrsValueValidators[attrKey]
+		if !known {
+			unknownAttrKeys = append(unknownAttrKeys, attrKey)
+			continue
+		}
+		if err := validator(attributes[attrKey]); err != nil {
+			return errors.Wrapf(err, "invalid load balancer attribute %v", attrKey)
+		}
+	}
+	if len(unknownAttrKeys) != 0 {
+		t.logger.Info("passing through unknown load balancer attributes",
+			"service", k8s.NamespacedName(t.service),
+			"attributes", unknownAttrKeys)
+	}
+	return nil
+}
+
+func validateBoolLBAttributeValue(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return errors.Errorf("expect boolean value, got %v", value)
+	}
+	return nil
+}
+
+func validateNoopLBAttributeValue(_ string) error {
+	return nil
+}
+
+func validateEnumLBAttributeValue(allowedValues ...string) func(value string) error {
+	allowedValueSet := sets.NewString(allowedValues...)
+	return func(value string) error {
+		if !allowedValueSet.Has(value) {
+			return errors.Errorf("expect one of %v, got %v", allowedValueSet.List(), value)
+		}
+		return nil
+	}
+}
+
+func (t *defaultModelBuildTask) buildLoadBalancerMinimumCapacity(_ context.Context) (*elbv2model.MinimumLoadBalancerCapacity, error) {
+	var capacityUnits int64
+	exists, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixMinimumLBCapacity, &capacityUnits, t.service.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	if capacityUnits <= 0 {
+		return nil, errors.Errorf("minimum load balancer capacity must be a positive integer: %v", capacityUnits)
+	}
+	return &elbv2model.MinimumLoadBalancerCapacity{
+		CapacityUnits: capacityUnits,
+	}, nil
+}
+
 func makeAttributesSliceFromMap(loadBalancerAttributesMap map[string]string) []elbv2model.LoadBalancerAttribute {
 	attributes := make([]elbv2model.LoadBalancerAttribute, 0, len(loadBalancerAttributesMap))
 	for attrKey, attrValue := range loadBalancerAttributesMap {
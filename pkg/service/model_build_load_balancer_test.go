@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"testing"
 
@@ -15,10 +16,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-const lbAttrsDeletionProtectionEnabled = "deletion_protection.enabled"
-
 func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
 	tests := []struct {
 		testName  string
@@ -144,6 +144,86 @@ func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName: "Known NLB attributes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-attributes": "zonal_shift.config.enabled=true,ipv6.deny_all_igw_traffic=false," +
+							"dns_record.client_routing_policy=partial_availability_zone_affinity",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   lbAttrsDNSRecordClientRoutingPolicy,
+					Value: "partial_availability_zone_affinity",
+				},
+				{
+					Key:   lbAttrsIPv6DenyAllIGWTraffic,
+					Value: "false",
+				},
+				{
+					Key:   lbAttrsZonalShiftConfigEnabled,
+					Value: "true",
+				},
+			},
+		},
+		{
+			testName: "Unknown NLB attributes passed through",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-attributes": "deletion_protection.enabled=true,some_new.attribute.enabled=whatever",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   lbAttrsDeletionProtectionEnabled,
+					Value: "true",
+				},
+				{
+					Key:   "some_new.attribute.enabled",
+					Value: "whatever",
+				},
+			},
+		},
+		{
+			testName: "Known NLB attribute with invalid boolean value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-attributes": "zonal_shift.config.enabled=yes",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "Known NLB attribute with invalid enum value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-attributes": "dns_record.client_routing_policy=nearest",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "Unknown NLB attribute with invalid key",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-attributes": "some-new/attribute=true",
+					},
+				},
+			},
+			wantError: true,
+		},
 		{
 			testName: "Annotation invalid",
 			svc: &corev1.Service{
@@ -177,6 +257,7 @@ func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
 				defaultHealthCheckTimeout:            10,
 				defaultHealthCheckHealthyThreshold:   3,
 				defaultHealthCheckUnhealthyThreshold: 3,
+				logger:                               &log.NullLogger{},
 			}
 			lbAttributes, err := builder.buildLoadBalancerAttributes(context.Background())
 			if tt.wantError {
@@ -669,6 +750,124 @@ func Test_defaultModelBuildTask_buildLoadBalancerIPAddressType(t *testing.T) {
 	}
 }
 
+func Test_defaultModelBuildTask_buildLoadBalancerScheme(t *testing.T) {
+	tests := []struct {
+		name         string
+		service      *corev1.Service
+		want         elbv2.LoadBalancerScheme
+		wantExplicit bool
+		wantEvents   []string
+		wantErr      error
+	}{
+		{
+			name: "scheme annotation specified",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing"},
+				},
+			},
+			want:         elbv2.LoadBalancerSchemeInternetFacing,
+			wantExplicit: true,
+		},
+		{
+			name: "legacy internal annotation specified",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "false"},
+				},
+			},
+			want:         elbv2.LoadBalancerSchemeInternetFacing,
+			wantExplicit: true,
+			wantEvents: []string{
+				"Warning DeprecatedAnnotation annotation aws-load-balancer-internal is deprecated, use aws-load-balancer-scheme: internet-facing instead",
+			},
+		},
+		{
+			name: "both annotations specified and agree",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-scheme":   "internal",
+						"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+					},
+				},
+			},
+			want:         elbv2.LoadBalancerSchemeInternal,
+			wantExplicit: true,
+		},
+		{
+			name: "both annotations specified and conflict",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-scheme":   "internet-facing",
+						"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+					},
+				},
+			},
+			want:         elbv2.LoadBalancerSchemeInternetFacing,
+			wantExplicit: true,
+			wantEvents: []string{
+				"Warning DeprecatedAnnotation annotation aws-load-balancer-internal is deprecated and conflicts with aws-load-balancer-scheme, scheme internet-facing takes precedence",
+			},
+		},
+		{
+			name: "invalid legacy internal annotation",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-scheme":   "internal",
+						"service.beta.kubernetes.io/aws-load-balancer-internal": "yes-please",
+					},
+				},
+			},
+			wantErr: errors.New("failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-internal: yes-please: strconv.ParseBool: parsing \"yes-please\": invalid syntax"),
+		},
+		{
+			name: "invalid scheme annotation",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-scheme": "public"},
+				},
+			},
+			wantErr: errors.New("unknown scheme: public"),
+		},
+		{
+			name: "no annotation specified",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{},
+			},
+			want:         elbv2.LoadBalancerSchemeInternal,
+			wantExplicit: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			builder := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				eventRecorder:    eventRecorder,
+				service:          tt.service,
+				logger:           &log.NullLogger{},
+			}
+			got, gotExplicit, err := builder.buildLoadBalancerScheme(context.Background())
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantExplicit, gotExplicit)
+				assert.Equal(t, tt.wantEvents, gotEvents)
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuildTask_buildAdditionalResourceTags(t *testing.T) {
 	type fields struct {
 		service             *corev1.Service
@@ -849,3 +1048,73 @@ func Test_defaultModelBuildTask_buildLoadBalancerName(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildLoadBalancerMinimumCapacity(t *testing.T) {
+	tests := []struct {
+		name    string
+		service *corev1.Service
+		want    *elbv2.MinimumLoadBalancerCapacity
+		wantErr error
+	}{
+		{
+			name: "minimum capacity not specified",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "minimum capacity specified",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-minimum-load-balancer-capacity": "1500",
+					},
+				},
+			},
+			want: &elbv2.MinimumLoadBalancerCapacity{
+				CapacityUnits: 1500,
+			},
+		},
+		{
+			name: "minimum capacity is zero",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-minimum-load-balancer-capacity": "0",
+					},
+				},
+			},
+			wantErr: errors.New("minimum load balancer capacity must be a positive integer: 0"),
+		},
+		{
+			name: "minimum capacity isn't an integer",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-minimum-load-balancer-capacity": "large",
+					},
+				},
+			},
+			wantErr: errors.New("failed to parse int64 annotation, service.beta.kubernetes.io/aws-load-balancer-minimum-load-balancer-capacity: large: strconv.ParseInt: parsing \"large\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			builder := &defaultModelBuildTask{
+				annotationParser: parser,
+				service:          tt.service,
+			}
+			got, err := builder.buildLoadBalancerMinimumCapacity(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -20,12 +20,17 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 )
 
 const (
 	tgAttrsProxyProtocolV2Enabled  = "proxy_protocol_v2.enabled"
 	tgAttrsPreserveClientIPEnabled = "preserve_client_ip.enabled"
+	tgAttrsStickinessEnabled       = "stickiness.enabled"
+	tgAttrsStickinessType          = "stickiness.type"
+	tgStickinessTypeSourceIP       = "source_ip"
 	healthCheckPortTrafficPort     = "traffic-port"
+	maxHealthCheckPathLength       = 1024
 )
 
 func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev1.ServicePort, tgProtocol elbv2model.Protocol, scheme elbv2model.LoadBalancerScheme) (*elbv2model.TargetGroup, error) {
@@ -42,6 +47,7 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev
 	if err != nil {
 		return nil, err
 	}
+	t.checkTargetGroupHealthCheckProtocol(port, tgProtocol, healthCheckConfig)
 	tgAttrs, err := t.buildTargetGroupAttributes(ctx)
 	if err != nil {
 		return nil, err
@@ -97,7 +103,14 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigDefault(ctx con
 	}
 	var healthCheckPathPtr *string
 	if healthCheckProtocol != elbv2model.ProtocolTCP {
-		healthCheckPathPtr = t.buildTargetGroupHealthCheckPath(ctx, t.defaultHealthCheckPath)
+		healthCheckPathPtr, err = t.buildTargetGroupHealthCheckPath(ctx, t.defaultHealthCheckPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	matcher, err := t.buildTargetGroupHealthCheckMatcher(ctx, healthCheckProtocol)
+	if err != nil {
+		return nil, err
 	}
 	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx, t.defaultHealthCheckPort)
 	if err != nil {
@@ -119,6 +132,7 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigDefault(ctx con
 		Port:                    &healthCheckPort,
 		Protocol:                &healthCheckProtocol,
 		Path:                    healthCheckPathPtr,
+		Matcher:                 matcher,
 		IntervalSeconds:         &intervalSeconds,
 		HealthyThresholdCount:   &healthyThresholdCount,
 		UnhealthyThresholdCount: &unhealthyThresholdCount,
@@ -132,7 +146,14 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigForInstanceMode
 	}
 	var healthCheckPathPtr *string
 	if healthCheckProtocol != elbv2model.ProtocolTCP {
-		healthCheckPathPtr = t.buildTargetGroupHealthCheckPath(ctx, t.defaultHealthCheckPathForInstanceModeLocal)
+		healthCheckPathPtr, err = t.buildTargetGroupHealthCheckPath(ctx, t.defaultHealthCheckPathForInstanceModeLocal)
+		if err != nil {
+			return nil, err
+		}
+	}
+	matcher, err := t.buildTargetGroupHealthCheckMatcher(ctx, healthCheckProtocol)
+	if err != nil {
+		return nil, err
 	}
 	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx, t.defaultHealthCheckPortForInstanceModeLocal)
 	if err != nil {
@@ -154,6 +175,7 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigForInstanceMode
 		Port:                    &healthCheckPort,
 		Protocol:                &healthCheckProtocol,
 		Path:                    healthCheckPathPtr,
+		Matcher:                 matcher,
 		IntervalSeconds:         &intervalSeconds,
 		HealthyThresholdCount:   &healthyThresholdCount,
 		UnhealthyThresholdCount: &unhealthyThresholdCount,
@@ -212,6 +234,14 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([
 			return nil, errors.Wrapf(err, "failed to parse attribute %v=%v", tgAttrsPreserveClientIPEnabled, rawPreserveIPEnabled)
 		}
 	}
+	if rawStickinessEnabled, ok := rawAttributes[tgAttrsStickinessEnabled]; ok {
+		if _, err := strconv.ParseBool(rawStickinessEnabled); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse attribute %v=%v", tgAttrsStickinessEnabled, rawStickinessEnabled)
+		}
+	}
+	if stickinessType, ok := rawAttributes[tgAttrsStickinessType]; ok && stickinessType != tgStickinessTypeSourceIP {
+		return nil, errors.Errorf("invalid attribute %v=%v, only %v is supported for NLB", tgAttrsStickinessType, stickinessType, tgStickinessTypeSourceIP)
+	}
 	attributes := make([]elbv2model.TargetGroupAttribute, 0, len(rawAttributes))
 	for attrKey, attrValue := range rawAttributes {
 		attributes = append(attributes, elbv2model.TargetGroupAttribute{
@@ -260,6 +290,28 @@ func (t *defaultModelBuildTask) buildTargetGroupPort(_ context.Context, targetTy
 	return 1
 }
 
+// checkTargetGroupHealthCheckProtocol emits an event when plaintext HTTP health checks are used against the traffic port of TLS targetGroup.
+// AWS accepts such configuration, but health checks will fail if targets only serve TLS on the traffic port.
+// health checks against a different port are not checked, since that port might serve a different protocol.
+func (t *defaultModelBuildTask) checkTargetGroupHealthCheckProtocol(port corev1.ServicePort, tgProtocol elbv2model.Protocol, healthCheckConfig *elbv2model.TargetGroupHealthCheckConfig) {
+	if healthCheckConfig == nil || healthCheckConfig.Port == nil || healthCheckConfig.Port.String() != healthCheckPortTrafficPort || healthCheckConfig.Protocol == nil {
+		return
+	}
+	healthCheckProtocol := *healthCheckConfig.Protocol
+	if tgProtocol != elbv2model.ProtocolTLS || healthCheckProtocol != elbv2model.ProtocolHTTP {
+		return
+	}
+	t.logger.Info("health check protocol mismatches targetGroup protocol",
+		"service", k8s.NamespacedName(t.service), "port", port.Port,
+		"protocol", tgProtocol, "healthCheckProtocol", healthCheckProtocol)
+	if t.eventRecorder == nil {
+		return
+	}
+	t.eventRecorder.Eventf(t.service, corev1.EventTypeWarning, k8s.ServiceEventReasonHealthCheckProtocolMismatch,
+		"targetGroup for port %v uses protocol %v, but health checks use protocol %v against traffic-port, health checks will fail if targets only serve %v, consider %v health checks",
+		port.Port, tgProtocol, healthCheckProtocol, tgProtocol, elbv2model.ProtocolHTTPS)
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Context, defaultHealthCheckPort string) (intstr.IntOrString, error) {
 	rawHealthCheckPort := defaultHealthCheckPort
 	t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixHCPort, &rawHealthCheckPort, t.service.Annotations)
@@ -288,10 +340,26 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckProtocol(_ context.Co
 	}
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Context, defaultHealthCheckPath string) *string {
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Context, defaultHealthCheckPath string) (*string, error) {
 	healthCheckPath := defaultHealthCheckPath
 	t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixHCPath, &healthCheckPath, t.service.Annotations)
-	return &healthCheckPath
+	if !strings.HasPrefix(healthCheckPath, "/") || len(healthCheckPath) > maxHealthCheckPathLength {
+		return nil, errors.Errorf("health check path must start with / and be at most %v characters: %v", maxHealthCheckPathLength, healthCheckPath)
+	}
+	return &healthCheckPath, nil
+}
+
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, healthCheckProtocol elbv2model.Protocol) (*elbv2model.HealthCheckMatcher, error) {
+	var rawSuccessCodes string
+	if !t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixHCSuccessCodes, &rawSuccessCodes, t.service.Annotations) {
+		return nil, nil
+	}
+	if healthCheckProtocol == elbv2model.ProtocolTCP {
+		return nil, errors.Errorf("health check success codes are not supported for %v health check protocol", healthCheckProtocol)
+	}
+	return &elbv2model.HealthCheckMatcher{
+		HTTPCode: &rawSuccessCodes,
+	}, nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckIntervalSeconds(_ context.Context, defaultHealthCheckInterval int64) (int64, error) {
@@ -332,6 +400,9 @@ func (t *defaultModelBuildTask) buildTargetType(_ context.Context) (elbv2model.T
 	_ = t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixLoadBalancerType, &lbType, t.service.Annotations)
 	var lbTargetType string
 	_ = t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixTargetType, &lbTargetType, t.service.Annotations)
+	if lbType == LoadBalancerTypeNLBIP && len(lbTargetType) != 0 && lbTargetType != LoadBalancerTargetTypeIP {
+		return "", errors.Errorf("conflicting target type \"%v\" for load balancer type \"%v\"", lbTargetType, lbType)
+	}
 	if lbType == LoadBalancerTypeNLBIP || (lbType == LoadBalancerTypeExternal && lbTargetType == LoadBalancerTargetTypeIP) {
 		return elbv2model.TargetTypeIP, nil
 	}
@@ -349,7 +420,7 @@ func (t *defaultModelBuildTask) buildTargetGroupResourceID(svcKey types.Namespac
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupTags(ctx context.Context) (map[string]string, error) {
-	return t.buildAdditionalResourceTags(ctx)
+	return t.buildAdditionalResourceTagsWithNamespaceTags(ctx)
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, targetGroup *elbv2model.TargetGroup, preserveClientIP bool,
@@ -367,6 +438,10 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 	if err != nil {
 		return elbv2model.TargetGroupBindingResourceSpec{}, err
 	}
+	hcGracePeriodSeconds, err := t.buildTargetGroupBindingHealthCheckGracePeriod(ctx, targetGroup.Spec.TargetType)
+	if err != nil {
+		return elbv2model.TargetGroupBindingResourceSpec{}, err
+	}
 	targetPort := port.TargetPort
 	targetType := elbv2api.TargetType(targetGroup.Spec.TargetType)
 	if targetType == elbv2api.TargetTypeInstance {
@@ -379,7 +454,10 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 			return elbv2model.TargetGroupBindingResourceSpec{}, err
 		}
 	}
-	tgbNetworking := t.buildTargetGroupBindingNetworking(ctx, targetPort, preserveClientIP, *hc.Port, port.Protocol, defaultSourceRanges)
+	tgbNetworking, err := t.buildTargetGroupBindingNetworking(ctx, targetPort, preserveClientIP, *hc.Port, port.Protocol, defaultSourceRanges)
+	if err != nil {
+		return elbv2model.TargetGroupBindingResourceSpec{}, err
+	}
 	return elbv2model.TargetGroupBindingResourceSpec{
 		Template: elbv2model.TargetGroupBindingTemplate{
 			ObjectMeta: metav1.ObjectMeta{
@@ -393,27 +471,33 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 					Name: t.service.Name,
 					Port: intstr.FromInt(int(port.Port)),
 				},
-				Networking:   tgbNetworking,
-				NodeSelector: nodeSelector,
+				Networking:                    tgbNetworking,
+				NodeSelector:                  nodeSelector,
+				HealthCheckGracePeriodSeconds: hcGracePeriodSeconds,
 			},
 		},
 	}, nil
 }
 
-func (t *defaultModelBuildTask) buildPeersFromSourceRanges(_ context.Context, defaultSourceRanges []string) ([]elbv2model.NetworkingPeer, bool) {
+// buildPeersFromSourceRanges builds the networking peers from source ranges, along with whether custom source ranges are configured.
+// the load-balancer-source-ranges annotation takes precedence over spec.loadBalancerSourceRanges if both are specified,
+// and overlapping CIDRs are deduplicated so that the same CIDR doesn't result in duplicated security group rules.
+func (t *defaultModelBuildTask) buildPeersFromSourceRanges(_ context.Context, defaultSourceRanges []string) ([]elbv2model.NetworkingPeer, bool, error) {
 	var sourceRanges []string
-	var peers []elbv2model.NetworkingPeer
 	customSourceRangesConfigured := true
-	for _, cidr := range t.service.Spec.LoadBalancerSourceRanges {
-		sourceRanges = append(sourceRanges, cidr)
-	}
+	t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourceRanges, &sourceRanges, t.service.Annotations)
 	if len(sourceRanges) == 0 {
-		t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourceRanges, &sourceRanges, t.service.Annotations)
+		sourceRanges = t.service.Spec.LoadBalancerSourceRanges
 	}
 	if len(sourceRanges) == 0 {
 		sourceRanges = defaultSourceRanges
 		customSourceRangesConfigured = false
 	}
+	sourceRanges, err := networking.DeduplicateCIDRs(sourceRanges)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "invalid source ranges")
+	}
+	var peers []elbv2model.NetworkingPeer
 	for _, cidr := range sourceRanges {
 		peers = append(peers, elbv2model.NetworkingPeer{
 			IPBlock: &elbv2api.IPBlock{
@@ -421,11 +505,11 @@ func (t *defaultModelBuildTask) buildPeersFromSourceRanges(_ context.Context, de
 			},
 		})
 	}
-	return peers, customSourceRangesConfigured
+	return peers, customSourceRangesConfigured, nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Context, tgPort intstr.IntOrString, preserveClientIP bool,
-	hcPort intstr.IntOrString, tgProtocol corev1.Protocol, defaultSourceRanges []string) *elbv2model.TargetGroupBindingNetworking {
+	hcPort intstr.IntOrString, tgProtocol corev1.Protocol, defaultSourceRanges []string) (*elbv2model.TargetGroupBindingNetworking, error) {
 	var fromVPC []elbv2model.NetworkingPeer
 	for _, subnet := range t.ec2Subnets {
 		fromVPC = append(fromVPC, elbv2model.NetworkingPeer{
@@ -447,7 +531,11 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Co
 	trafficSource := fromVPC
 	customSourceRangesConfigured := false
 	if networkingProtocol == elbv2api.NetworkingProtocolUDP || preserveClientIP {
-		trafficSource, customSourceRangesConfigured = t.buildPeersFromSourceRanges(ctx, defaultSourceRanges)
+		var err error
+		trafficSource, customSourceRangesConfigured, err = t.buildPeersFromSourceRanges(ctx, defaultSourceRanges)
+		if err != nil {
+			return nil, err
+		}
 	}
 	tgbNetworking := &elbv2model.TargetGroupBindingNetworking{
 		Ingress: []elbv2model.NetworkingIngressRule{
@@ -461,7 +549,7 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Co
 		preserveClientIP, customSourceRangesConfigured); len(hcIngressRules) > 0 {
 		tgbNetworking.Ingress = append(tgbNetworking.Ingress, hcIngressRules...)
 	}
-	return tgbNetworking
+	return tgbNetworking, nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBindingNodeSelector(_ context.Context, targetType elbv2model.TargetType) (*metav1.LabelSelector, error) {
@@ -480,6 +568,24 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNodeSelector(_ context.Co
 	}, nil
 }
 
+func (t *defaultModelBuildTask) buildTargetGroupBindingHealthCheckGracePeriod(_ context.Context, targetType elbv2model.TargetType) (*int64, error) {
+	if targetType != elbv2model.TargetTypeIP {
+		return nil, nil
+	}
+	var rawGracePeriod int64
+	exists, err := t.annotationParser.ParseInt64Annotation(annotations.SvcLBSuffixHCGracePeriod, &rawGracePeriod, t.service.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	if rawGracePeriod < 0 {
+		return nil, errors.Errorf("healthcheck grace period must be non-negative: %v", rawGracePeriod)
+	}
+	return &rawGracePeriod, nil
+}
+
 func (t *defaultModelBuildTask) buildHealthCheckNetworkingIngressRules(trafficSource, hcSource []elbv2model.NetworkingPeer, tgPort, hcPort intstr.IntOrString,
 	tgProtocol corev1.Protocol, preserveClientIP, customSoureRanges bool) []elbv2model.NetworkingIngressRule {
 	if tgProtocol != corev1.ProtocolUDP &&
@@ -13,9 +13,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
@@ -125,6 +127,52 @@ func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			testName: "source ip stickiness",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": "stickiness.enabled=true,stickiness.type=source_ip",
+					},
+				},
+			},
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   tgAttrsStickinessEnabled,
+					Value: "true",
+				},
+				{
+					Key:   tgAttrsStickinessType,
+					Value: "source_ip",
+				},
+			},
+		},
+		{
+			testName: "stickiness enabled attribute parse error",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": "stickiness.enabled=yes",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "stickiness type unsupported for NLB",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": "stickiness.enabled=true,stickiness.type=lb_cookie",
+					},
+				},
+			},
+			wantError: true,
+		},
 		{
 			testName: "IP enabled attribute parse error",
 			svc: &corev1.Service{
@@ -165,11 +213,12 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 	port8888 := intstr.FromInt(8888)
 	port31223 := intstr.FromInt(31223)
 	tests := []struct {
-		testName   string
-		svc        *corev1.Service
-		targetType elbv2.TargetType
-		wantError  bool
-		wantValue  *elbv2.TargetGroupHealthCheckConfig
+		testName               string
+		svc                    *corev1.Service
+		targetType             elbv2.TargetType
+		defaultHealthCheckPath string
+		wantError              bool
+		wantValue              *elbv2.TargetGroupHealthCheckConfig
 	}{
 		{
 			testName: "Default config",
@@ -214,6 +263,66 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 			},
 			targetType: elbv2.TargetTypeInstance,
 		},
+		{
+			testName: "HTTP health check with success codes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":      "HTTP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "200-399",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
+				Path:                    aws.String("/"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200-399")},
+				IntervalSeconds:         aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+			targetType: elbv2.TargetTypeIP,
+		},
+		{
+			testName: "TCP health check with success codes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "200-399",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeIP,
+			wantError:  true,
+		},
+		{
+			testName: "traffic policy local, target type Instance, success codes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes": "200",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:                  corev1.ServiceTypeLoadBalancer,
+					ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+					HealthCheckNodePort:   31223,
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &port31223,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolHTTP))),
+				Path:                    aws.String("/healthz"),
+				Matcher:                 &elbv2.HealthCheckMatcher{HTTPCode: aws.String("200")},
+				IntervalSeconds:         aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(2),
+				UnhealthyThresholdCount: aws.Int64(2),
+			},
+			targetType: elbv2.TargetTypeInstance,
+		},
 		{
 			testName: "default path",
 			svc: &corev1.Service{
@@ -234,6 +343,80 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 			},
 			targetType: elbv2.TargetTypeIP,
 		},
+		{
+			testName: "configured default path",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "HTTP",
+					},
+				},
+			},
+			defaultHealthCheckPath: "/healthz",
+			wantError:              false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTP")),
+				Path:                    aws.String("/healthz"),
+				IntervalSeconds:         aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+			targetType: elbv2.TargetTypeIP,
+		},
+		{
+			testName: "configured default path overridden by annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "HTTPS",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-path":     "/ready",
+					},
+				},
+			},
+			defaultHealthCheckPath: "/healthz",
+			wantError:              false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String("HTTPS")),
+				Path:                    aws.String("/ready"),
+				IntervalSeconds:         aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+			targetType: elbv2.TargetTypeIP,
+		},
+		{
+			testName: "configured default path ignored for TCP health check",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			defaultHealthCheckPath: "/healthz",
+			wantError:              false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolTCP))),
+				IntervalSeconds:         aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+			targetType: elbv2.TargetTypeIP,
+		},
+		{
+			testName: "invalid path",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "HTTP",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-path":     "healthz",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeIP,
+			wantError:  true,
+		},
 		{
 			testName: "invalid values",
 			svc: &corev1.Service{
@@ -309,6 +492,24 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 			},
 			targetType: elbv2.TargetTypeInstance,
 		},
+		{
+			testName: "traffic policy cluster, target type Instance, default healthcheck",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:                  corev1.ServiceTypeLoadBalancer,
+					ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeCluster,
+				},
+			},
+			wantError: false,
+			wantValue: &elbv2.TargetGroupHealthCheckConfig{
+				Port:                    &trafficPort,
+				Protocol:                (*elbv2.Protocol)(aws.String(string(elbv2.ProtocolTCP))),
+				IntervalSeconds:         aws.Int64(10),
+				HealthyThresholdCount:   aws.Int64(3),
+				UnhealthyThresholdCount: aws.Int64(3),
+			},
+			targetType: elbv2.TargetTypeInstance,
+		},
 		{
 			testName: "traffic policy local, target type Instance, override default",
 			svc: &corev1.Service{
@@ -342,6 +543,10 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			defaultHealthCheckPath := "/"
+			if tt.defaultHealthCheckPath != "" {
+				defaultHealthCheckPath = tt.defaultHealthCheckPath
+			}
 			builder := &defaultModelBuildTask{
 				service:                              tt.svc,
 				annotationParser:                     parser,
@@ -351,7 +556,7 @@ func Test_defaultModelBuilderTask_buildTargetHealthCheck(t *testing.T) {
 				defaultProxyProtocolV2Enabled:        false,
 				defaultHealthCheckProtocol:           elbv2.ProtocolTCP,
 				defaultHealthCheckPort:               healthCheckPortTrafficPort,
-				defaultHealthCheckPath:               "/",
+				defaultHealthCheckPath:               defaultHealthCheckPath,
 				defaultHealthCheckInterval:           10,
 				defaultHealthCheckTimeout:            10,
 				defaultHealthCheckHealthyThreshold:   3,
@@ -392,6 +597,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 		preserveClientIP    bool
 		defaultSourceRanges []string
 		want                *elbv2.TargetGroupBindingNetworking
+		wantErr             error
 	}{
 		{
 			name: "udp-service with source ranges",
@@ -503,6 +709,81 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 				},
 			},
 		},
+		{
+			name: "udp-service with overlapping source ranges from both annotation and spec",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/load-balancer-source-ranges": "1.2.3.4/24, 5.6.7.8/18, 1.2.3.0/24, 5.6.7.8/18",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					LoadBalancerSourceRanges: []string{"10.0.0.0/16", "1.2.3.4/24"},
+				},
+			},
+			tgPort: port80,
+			hcPort: port808,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
+			tgProtocol: corev1.ProtocolUDP,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "1.2.3.4/24",
+								},
+							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "5.6.7.8/18",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolUDP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port808,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "udp-service with invalid source ranges",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					LoadBalancerSourceRanges: []string{"10.0.0.0/16", "1.2.3.4"},
+				},
+			},
+			tgPort: port80,
+			hcPort: port808,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
+			tgProtocol: corev1.ProtocolUDP,
+			wantErr:    errors.New("invalid source ranges: invalid CIDR 1.2.3.4: invalid CIDR address: 1.2.3.4"),
+		},
 		{
 			name:                "udp-service with no source ranges configuration",
 			svc:                 &corev1.Service{},
@@ -874,8 +1155,13 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 		t.Run(tt.name, func(t *testing.T) {
 			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
 			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: parser, ec2Subnets: tt.subnets}
-			got := builder.buildTargetGroupBindingNetworking(context.Background(), tt.tgPort, tt.preserveClientIP, tt.hcPort, tt.tgProtocol, tt.defaultSourceRanges)
-			assert.Equal(t, tt.want, got)
+			got, err := builder.buildTargetGroupBindingNetworking(context.Background(), tt.tgPort, tt.preserveClientIP, tt.hcPort, tt.tgProtocol, tt.defaultSourceRanges)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
 		})
 	}
 }
@@ -998,6 +1284,30 @@ func Test_defaultModelBuilder_buildTargetType(t *testing.T) {
 			},
 			want: elbv2.TargetTypeIP,
 		},
+		{
+			testName: "lb type nlb-ip, target ip",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":            "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "ip",
+					},
+				},
+			},
+			want: elbv2.TargetTypeIP,
+		},
+		{
+			testName: "lb type nlb-ip, conflicting target instance",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":            "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "instance",
+					},
+				},
+			},
+			wantErr: errors.New("conflicting target type \"instance\" for load balancer type \"nlb-ip\""),
+		},
 		{
 			testName: "lb type external, target instance",
 			svc: &corev1.Service{
@@ -1154,6 +1464,85 @@ func Test_defaultModelBuilder_buildTargetGroupBindingNodeSelector(t *testing.T)
 	}
 }
 
+func Test_defaultModelBuilder_buildTargetGroupBindingHealthCheckGracePeriod(t *testing.T) {
+	tests := []struct {
+		testName   string
+		svc        *corev1.Service
+		targetType elbv2.TargetType
+		want       *int64
+		wantErr    error
+	}{
+		{
+			testName:   "IP target without annotation",
+			targetType: elbv2.TargetTypeIP,
+			svc:        &corev1.Service{},
+		},
+		{
+			testName:   "IP target with annotation",
+			targetType: elbv2.TargetTypeIP,
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-grace-period-seconds": "120",
+					},
+				},
+			},
+			want: aws.Int64(120),
+		},
+		{
+			testName:   "Instance target with annotation",
+			targetType: elbv2.TargetTypeInstance,
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-grace-period-seconds": "120",
+					},
+				},
+			},
+		},
+		{
+			testName:   "IP target with negative grace period",
+			targetType: elbv2.TargetTypeIP,
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-grace-period-seconds": "-1",
+					},
+				},
+			},
+			wantErr: errors.New("healthcheck grace period must be non-negative: -1"),
+		},
+		{
+			testName:   "IP target with invalid grace period",
+			targetType: elbv2.TargetTypeIP,
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-grace-period-seconds": "1m",
+					},
+				},
+			},
+			wantErr: errors.New("failed to parse int64 annotation, service.beta.kubernetes.io/aws-load-balancer-healthcheck-grace-period-seconds: 1m: strconv.ParseInt: parsing \"1m\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			builder := &defaultModelBuildTask{
+				annotationParser: parser,
+				service:          tt.svc,
+			}
+			got, err := builder.buildTargetGroupBindingHealthCheckGracePeriod(context.Background(), tt.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuilder_buildTargetGroupHealthCheckPort(t *testing.T) {
 	tests := []struct {
 		testName    string
@@ -1222,3 +1611,83 @@ func Test_defaultModelBuilder_buildTargetGroupHealthCheckPort(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_checkTargetGroupHealthCheckProtocol(t *testing.T) {
+	trafficPort := intstr.FromString("traffic-port")
+	customPort := intstr.FromInt(8080)
+	protocolTCP := elbv2.ProtocolTCP
+	protocolHTTP := elbv2.ProtocolHTTP
+	protocolHTTPS := elbv2.ProtocolHTTPS
+	tests := []struct {
+		name              string
+		tgProtocol        elbv2.Protocol
+		healthCheckConfig *elbv2.TargetGroupHealthCheckConfig
+		wantEvents        []string
+	}{
+		{
+			name:       "TLS targetGroup with TCP health check",
+			tgProtocol: elbv2.ProtocolTLS,
+			healthCheckConfig: &elbv2.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: &protocolTCP,
+			},
+		},
+		{
+			name:       "TLS targetGroup with HTTPS health check",
+			tgProtocol: elbv2.ProtocolTLS,
+			healthCheckConfig: &elbv2.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: &protocolHTTPS,
+			},
+		},
+		{
+			name:       "TCP targetGroup with HTTP health check",
+			tgProtocol: elbv2.ProtocolTCP,
+			healthCheckConfig: &elbv2.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: &protocolHTTP,
+			},
+		},
+		{
+			name:       "TLS targetGroup with HTTP health check against traffic-port",
+			tgProtocol: elbv2.ProtocolTLS,
+			healthCheckConfig: &elbv2.TargetGroupHealthCheckConfig{
+				Port:     &trafficPort,
+				Protocol: &protocolHTTP,
+			},
+			wantEvents: []string{
+				"Warning HealthCheckProtocolMismatch targetGroup for port 443 uses protocol TLS, but health checks use protocol HTTP against traffic-port, health checks will fail if targets only serve TLS, consider HTTPS health checks",
+			},
+		},
+		{
+			name:       "TLS targetGroup with HTTP health check against custom port",
+			tgProtocol: elbv2.ProtocolTLS,
+			healthCheckConfig: &elbv2.TargetGroupHealthCheckConfig{
+				Port:     &customPort,
+				Protocol: &protocolHTTP,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-svc",
+					},
+				},
+				eventRecorder: eventRecorder,
+				logger:        &log.NullLogger{},
+			}
+			task.checkTargetGroupHealthCheckProtocol(corev1.ServicePort{Port: 443}, tt.tgProtocol, tt.healthCheckConfig)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
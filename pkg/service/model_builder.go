@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"strconv"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
@@ -21,8 +24,27 @@ const (
 	LoadBalancerTypeExternal       = "external"
 	LoadBalancerTargetTypeIP       = "ip"
 	LoadBalancerTargetTypeInstance = "instance"
+
+	// ServiceFinalizer is the finalizer added to Services reconciled by this controller.
+	ServiceFinalizer = "service.k8s.aws/resources"
 )
 
+// IsServiceIgnored checks whether the Service is opted out of the controller via annotation.
+func IsServiceIgnored(service *corev1.Service) bool {
+	ignored, _ := strconv.ParseBool(service.Annotations[annotations.ServiceIgnore])
+	return ignored
+}
+
+// NoServicePortsError is returned when the Service doesn't have any ports to build listeners for.
+type NoServicePortsError struct {
+	// The Service without ports.
+	Service string
+}
+
+func (e *NoServicePortsError) Error() string {
+	return fmt.Sprintf("service %v has no ports, at least one port is required to provision load balancer listeners", e.Service)
+}
+
 // ModelBuilder builds the model stack for the service resource.
 type ModelBuilder interface {
 	// Build model stack for service
@@ -30,46 +52,58 @@ type ModelBuilder interface {
 }
 
 // NewDefaultModelBuilder construct a new defaultModelBuilder
-func NewDefaultModelBuilder(annotationParser annotations.Parser, subnetsResolver networking.SubnetsResolver,
+func NewDefaultModelBuilder(annotationParser annotations.Parser, eventRecorder record.EventRecorder, subnetsResolver networking.SubnetsResolver,
 	vpcResolver networking.VPCResolver, trackingProvider tracking.Provider, elbv2TaggingManager elbv2deploy.TaggingManager,
-	clusterName string, defaultTags map[string]string, externalManagedTags []string, defaultSSLPolicy string) *defaultModelBuilder {
+	namespaceTagsResolver k8s.NamespaceTagsResolver, clusterName string, defaultTags map[string]string, externalManagedTags []string, defaultSSLPolicy string, defaultHealthCheckPath string,
+	logger logr.Logger) *defaultModelBuilder {
 	return &defaultModelBuilder{
-		annotationParser:    annotationParser,
-		subnetsResolver:     subnetsResolver,
-		vpcResolver:         vpcResolver,
-		trackingProvider:    trackingProvider,
-		elbv2TaggingManager: elbv2TaggingManager,
-		clusterName:         clusterName,
-		defaultTags:         defaultTags,
-		externalManagedTags: sets.NewString(externalManagedTags...),
-		defaultSSLPolicy:    defaultSSLPolicy,
+		annotationParser:       annotationParser,
+		eventRecorder:          eventRecorder,
+		subnetsResolver:        subnetsResolver,
+		vpcResolver:            vpcResolver,
+		trackingProvider:       trackingProvider,
+		elbv2TaggingManager:    elbv2TaggingManager,
+		namespaceTagsResolver:  namespaceTagsResolver,
+		clusterName:            clusterName,
+		defaultTags:            defaultTags,
+		externalManagedTags:    sets.NewString(externalManagedTags...),
+		defaultSSLPolicy:       defaultSSLPolicy,
+		defaultHealthCheckPath: defaultHealthCheckPath,
+		logger:                 logger,
 	}
 }
 
 var _ ModelBuilder = &defaultModelBuilder{}
 
 type defaultModelBuilder struct {
-	annotationParser    annotations.Parser
-	subnetsResolver     networking.SubnetsResolver
-	vpcResolver         networking.VPCResolver
-	trackingProvider    tracking.Provider
-	elbv2TaggingManager elbv2deploy.TaggingManager
-
-	clusterName         string
-	defaultTags         map[string]string
-	externalManagedTags sets.String
-	defaultSSLPolicy    string
+	annotationParser      annotations.Parser
+	eventRecorder         record.EventRecorder
+	subnetsResolver       networking.SubnetsResolver
+	vpcResolver           networking.VPCResolver
+	trackingProvider      tracking.Provider
+	elbv2TaggingManager   elbv2deploy.TaggingManager
+	namespaceTagsResolver k8s.NamespaceTagsResolver
+
+	clusterName            string
+	defaultTags            map[string]string
+	externalManagedTags    sets.String
+	defaultSSLPolicy       string
+	defaultHealthCheckPath string
+	logger                 logr.Logger
 }
 
 func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service) (core.Stack, *elbv2model.LoadBalancer, error) {
 	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(service)))
 	task := &defaultModelBuildTask{
-		clusterName:         b.clusterName,
-		annotationParser:    b.annotationParser,
-		subnetsResolver:     b.subnetsResolver,
-		vpcResolver:         b.vpcResolver,
-		trackingProvider:    b.trackingProvider,
-		elbv2TaggingManager: b.elbv2TaggingManager,
+		clusterName:           b.clusterName,
+		annotationParser:      b.annotationParser,
+		eventRecorder:         b.eventRecorder,
+		subnetsResolver:       b.subnetsResolver,
+		vpcResolver:           b.vpcResolver,
+		trackingProvider:      b.trackingProvider,
+		elbv2TaggingManager:   b.elbv2TaggingManager,
+		namespaceTagsResolver: b.namespaceTagsResolver,
+		logger:                b.logger,
 
 		service:   service,
 		stack:     stack,
@@ -86,7 +120,7 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 		defaultProxyProtocolV2Enabled:        false,
 		defaultHealthCheckProtocol:           elbv2model.ProtocolTCP,
 		defaultHealthCheckPort:               healthCheckPortTrafficPort,
-		defaultHealthCheckPath:               "/",
+		defaultHealthCheckPath:               b.defaultHealthCheckPath,
 		defaultHealthCheckInterval:           10,
 		defaultHealthCheckTimeout:            10,
 		defaultHealthCheckHealthyThreshold:   3,
@@ -108,12 +142,15 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 }
 
 type defaultModelBuildTask struct {
-	clusterName         string
-	annotationParser    annotations.Parser
-	subnetsResolver     networking.SubnetsResolver
-	vpcResolver         networking.VPCResolver
-	trackingProvider    tracking.Provider
-	elbv2TaggingManager elbv2deploy.TaggingManager
+	clusterName           string
+	annotationParser      annotations.Parser
+	eventRecorder         record.EventRecorder
+	subnetsResolver       networking.SubnetsResolver
+	vpcResolver           networking.VPCResolver
+	trackingProvider      tracking.Provider
+	elbv2TaggingManager   elbv2deploy.TaggingManager
+	namespaceTagsResolver k8s.NamespaceTagsResolver
+	logger                logr.Logger
 
 	service *corev1.Service
 
@@ -159,6 +196,9 @@ func (t *defaultModelBuildTask) run(ctx context.Context) error {
 }
 
 func (t *defaultModelBuildTask) buildModel(ctx context.Context) error {
+	if len(t.service.Spec.Ports) == 0 {
+		return &NoServicePortsError{Service: k8s.NamespacedName(t.service).String()}
+	}
 	scheme, explicitScheme, err := t.buildLoadBalancerScheme(ctx)
 	if err != nil {
 		return err
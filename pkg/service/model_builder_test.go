@@ -14,11 +14,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func Test_defaultModelBuilderTask_Build(t *testing.T) {
@@ -1889,6 +1891,17 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "instance",
 					},
 				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       80,
+							TargetPort: intstr.FromInt(80),
+							Protocol:   corev1.ProtocolTCP,
+							NodePort:   32332,
+						},
+					},
+				},
 				Status: corev1.ServiceStatus{
 					LoadBalancer: corev1.LoadBalancerStatus{
 						Ingress: []corev1.LoadBalancerIngress{
@@ -1961,8 +1974,8 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 			for _, call := range tt.resolveCIDRsCalls {
 				vpcResolver.EXPECT().ResolveCIDRs(gomock.Any()).Return(call.cidrs, call.err).AnyTimes()
 			}
-			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, vpcResolver, trackingProvider, elbv2TaggingManager,
-				"my-cluster", nil, nil, "ELBSecurityPolicy-2016-08")
+			builder := NewDefaultModelBuilder(annotationParser, record.NewFakeRecorder(10), subnetsResolver, vpcResolver, trackingProvider, elbv2TaggingManager,
+				nil, "my-cluster", nil, nil, "ELBSecurityPolicy-2016-08", "/", &log.NullLogger{})
 			ctx := context.Background()
 			stack, _, err := builder.Build(ctx, tt.svc)
 			if tt.wantError {
@@ -1979,3 +1992,32 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuilderTask_Build_withoutPorts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "no-ports",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":            "external",
+				"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "ip",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: map[string]string{"app": "hello"},
+			Ports:    []corev1.ServicePort{},
+		},
+	}
+	annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+	trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "my-cluster")
+	builder := NewDefaultModelBuilder(annotationParser, record.NewFakeRecorder(10), networking.NewMockSubnetsResolver(ctrl), networking.NewMockVPCResolver(ctrl),
+		trackingProvider, elbv2.NewMockTaggingManager(ctrl), nil, "my-cluster", nil, nil, "ELBSecurityPolicy-2016-08", "/", &log.NullLogger{})
+	_, _, err := builder.Build(context.Background(), svc)
+	assert.EqualError(t, err, "service default/no-ports has no ports, at least one port is required to provision load balancer listeners")
+	var noServicePortsErr *NoServicePortsError
+	assert.True(t, errors.As(err, &noServicePortsErr))
+}
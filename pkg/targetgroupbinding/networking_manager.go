@@ -42,7 +42,8 @@ type NetworkingManager interface {
 
 // NewDefaultNetworkingManager constructs defaultNetworkingManager.
 func NewDefaultNetworkingManager(k8sClient client.Client, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
-	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler, vpcID string, clusterName string, logger logr.Logger) *defaultNetworkingManager {
+	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler, vpcID string, clusterName string,
+	deferSGRuleCleanup bool, logger logr.Logger) *defaultNetworkingManager {
 
 	return &defaultNetworkingManager{
 		k8sClient:       k8sClient,
@@ -54,6 +55,8 @@ func NewDefaultNetworkingManager(k8sClient client.Client, podENIResolver network
 		clusterName:     clusterName,
 		logger:          logger,
 
+		deferSGRuleCleanup: deferSGRuleCleanup,
+
 		mutex:                         sync.Mutex{},
 		ingressPermissionsPerSGByTGB:  make(map[types.NamespacedName]map[string][]networking.IPPermissionInfo),
 		trackedEndpointSGs:            sets.NewString(),
@@ -72,6 +75,10 @@ type defaultNetworkingManager struct {
 	clusterName     string
 	logger          logr.Logger
 
+	// whether to defer revoking ingress permissions of deleted TargetGroupBindings.
+	// when enabled, these permissions are left in place and only garbage collected by later reconciles of other TargetGroupBindings.
+	deferSGRuleCleanup bool
+
 	// mutex will serialize our TargetGroup's networking reconcile requests.
 	mutex sync.Mutex
 	// ingressPermissionsPerSGByTGB are calculated ingress permissions per SecurityGroup needed by each TargetGroupBindings.
@@ -111,6 +118,15 @@ func (m *defaultNetworkingManager) ReconcileForNodePortEndpoints(ctx context.Con
 }
 
 func (m *defaultNetworkingManager) Cleanup(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if m.deferSGRuleCleanup {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		tgbKey := k8s.NamespacedName(tgb)
+		delete(m.ingressPermissionsPerSGByTGB, tgbKey)
+		m.logger.Info("deferred cleanup of securityGroup rules", "targetGroupBinding", tgbKey)
+		return nil
+	}
 	return m.reconcileWithIngressPermissionsPerSG(ctx, tgb, nil)
 }
 
@@ -194,7 +210,7 @@ func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx cont
 
 	permissionSelector := labels.SelectorFromSet(labels.Set{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue})
 	for sgID, permissions := range aggregatedIngressPermissionsPerSG {
-		if err := m.sgReconciler.ReconcileIngress(ctx, sgID, permissions,
+		if _, err := m.sgReconciler.ReconcileIngress(ctx, sgID, permissions,
 			networking.WithPermissionSelector(permissionSelector),
 			networking.WithAuthorizeOnly(!computedForAllTGBs)); err != nil {
 			return err
@@ -380,7 +396,7 @@ func (m *defaultNetworkingManager) gcIngressPermissionsFromUnusedEndpointSGs(ctx
 
 	permissionSelector := labels.SelectorFromSet(labels.Set{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue})
 	for sgID := range unusedEndpointSGs {
-		err := m.sgReconciler.ReconcileIngress(ctx, sgID, nil,
+		_, err := m.sgReconciler.ReconcileIngress(ctx, sgID, nil,
 			networking.WithPermissionSelector(permissionSelector))
 		if err != nil {
 			if isEC2SecurityGroupNotFoundError(err) {
@@ -5,13 +5,19 @@ import (
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -859,3 +865,89 @@ func Test_defaultNetworkingManager_computeAggregatedIngressPermissionsPerSG(t *t
 		})
 	}
 }
+
+func Test_defaultNetworkingManager_Cleanup(t *testing.T) {
+	tgb1Key := types.NamespacedName{Namespace: "ns-1", Name: "tgb-1"}
+	permissionsForSGA := []networking.IPPermissionInfo{
+		{
+			Permission: ec2sdk.IpPermission{
+				IpProtocol: awssdk.String("tcp"),
+				FromPort:   awssdk.Int64(8080),
+				ToPort:     awssdk.Int64(8080),
+				IpRanges: []*ec2sdk.IpRange{
+					{
+						CidrIp: awssdk.String("192.168.0.0/16"),
+					},
+				},
+			},
+		},
+	}
+	type reconcileIngressCall struct {
+		sgID               string
+		desiredPermissions []networking.IPPermissionInfo
+	}
+	type fields struct {
+		deferSGRuleCleanup    bool
+		reconcileIngressCalls []reconcileIngressCall
+	}
+	tests := []struct {
+		name                             string
+		fields                           fields
+		wantIngressPermissionsPerSGByTGB map[types.NamespacedName]map[string][]networking.IPPermissionInfo
+	}{
+		{
+			name: "securityGroup rules should be revoked on cleanup",
+			fields: fields{
+				deferSGRuleCleanup: false,
+				reconcileIngressCalls: []reconcileIngressCall{
+					{
+						sgID:               "sg-a",
+						desiredPermissions: nil,
+					},
+				},
+			},
+			wantIngressPermissionsPerSGByTGB: map[types.NamespacedName]map[string][]networking.IPPermissionInfo{},
+		},
+		{
+			name: "securityGroup rules should persist when cleanup is deferred",
+			fields: fields{
+				deferSGRuleCleanup:    true,
+				reconcileIngressCalls: nil,
+			},
+			wantIngressPermissionsPerSGByTGB: map[types.NamespacedName]map[string][]networking.IPPermissionInfo{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sgReconciler := networking.NewMockSecurityGroupReconciler(ctrl)
+			for _, call := range tt.fields.reconcileIngressCalls {
+				sgReconciler.EXPECT().ReconcileIngress(gomock.Any(), call.sgID, call.desiredPermissions, gomock.Any()).Return(networking.SecurityGroupReconcileResult{}, nil)
+			}
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			m := NewDefaultNetworkingManager(k8sClient, nil, nil, nil, sgReconciler, "vpc-1", "cluster-1",
+				tt.fields.deferSGRuleCleanup, &log.NullLogger{})
+			m.ingressPermissionsPerSGByTGB[tgb1Key] = map[string][]networking.IPPermissionInfo{
+				"sg-a": permissionsForSGA,
+			}
+			m.trackedEndpointSGs.Insert("sg-a")
+			m.trackedEndpointSGsInitialized = true
+
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tgb1Key.Namespace,
+					Name:      tgb1Key.Name,
+				},
+			}
+			err := m.Cleanup(context.Background(), tgb)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantIngressPermissionsPerSGByTGB, m.ingressPermissionsPerSGByTGB)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
@@ -27,7 +28,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const defaultTargetHealthRequeueDuration = 15 * time.Second
+const (
+	// targetHealthReasonHealthCheckGracePeriod is the pod condition reason for unhealthy targets considered ready within health check grace period.
+	targetHealthReasonHealthCheckGracePeriod = "HealthCheckGracePeriod"
+)
 
 // ResourceManager manages the TargetGroupBinding resource.
 type ResourceManager interface {
@@ -36,22 +40,26 @@ type ResourceManager interface {
 }
 
 // NewDefaultResourceManager constructs new defaultResourceManager.
-func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELBV2,
+func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELBV2, assumeRoleELBV2Provider AssumeRoleELBV2Provider,
 	podInfoRepo k8s.PodInfoRepo, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
 	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler,
-	vpcID string, clusterName string, eventRecorder record.EventRecorder, logger logr.Logger) *defaultResourceManager {
-	targetsManager := NewCachedTargetsManager(elbv2Client, logger)
-	endpointResolver := backend.NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger)
-	networkingManager := NewDefaultNetworkingManager(k8sClient, podENIResolver, nodeENIResolver, sgManager, sgReconciler, vpcID, clusterName, logger)
+	vpcID string, clusterName string, endpointSliceEnabled bool, deferSGRuleCleanup bool,
+	targetHealthRequeueInterval time.Duration, targetHealthRequeueMaxInterval time.Duration,
+	eventRecorder record.EventRecorder, logger logr.Logger) *defaultResourceManager {
+	targetsManagerProvider := NewDefaultTargetsManagerProvider(elbv2Client, assumeRoleELBV2Provider, logger)
+	endpointResolver := backend.NewDefaultEndpointResolver(k8sClient, podInfoRepo, endpointSliceEnabled, logger)
+	networkingManager := NewDefaultNetworkingManager(k8sClient, podENIResolver, nodeENIResolver, sgManager, sgReconciler, vpcID, clusterName, deferSGRuleCleanup, logger)
 	return &defaultResourceManager{
-		k8sClient:         k8sClient,
-		targetsManager:    targetsManager,
-		endpointResolver:  endpointResolver,
-		networkingManager: networkingManager,
-		eventRecorder:     eventRecorder,
-		logger:            logger,
+		k8sClient:              k8sClient,
+		elbv2Client:            elbv2Client,
+		targetsManagerProvider: targetsManagerProvider,
+		endpointResolver:       endpointResolver,
+		networkingManager:      networkingManager,
+		eventRecorder:          eventRecorder,
+		logger:                 logger,
 
-		targetHealthRequeueDuration: defaultTargetHealthRequeueDuration,
+		targetHealthRequeueInterval: targetHealthRequeueInterval,
+		targetHealthRequeueBackoff:  workqueue.NewItemExponentialFailureRateLimiter(targetHealthRequeueInterval, targetHealthRequeueMaxInterval),
 	}
 }
 
@@ -59,20 +67,27 @@ var _ ResourceManager = &defaultResourceManager{}
 
 // default implementation for ResourceManager.
 type defaultResourceManager struct {
-	k8sClient         client.Client
-	targetsManager    TargetsManager
-	endpointResolver  backend.EndpointResolver
-	networkingManager NetworkingManager
-	eventRecorder     record.EventRecorder
-	logger            logr.Logger
-
-	targetHealthRequeueDuration time.Duration
+	k8sClient              client.Client
+	elbv2Client            services.ELBV2
+	targetsManagerProvider TargetsManagerProvider
+	endpointResolver       backend.EndpointResolver
+	networkingManager      NetworkingManager
+	eventRecorder          record.EventRecorder
+	logger                 logr.Logger
+
+	// targetHealthRequeueInterval is the maximum requeue interval while pods need further probe or targets are being registered.
+	targetHealthRequeueInterval time.Duration
+	// targetHealthRequeueBackoff computes the requeue interval per TargetGroupBinding to monitor targets that are not healthy yet.
+	targetHealthRequeueBackoff workqueue.RateLimiter
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if tgb.Spec.TargetType == nil {
 		return errors.Errorf("targetType is not specified: %v", k8s.NamespacedName(tgb).String())
 	}
+	if err := m.reconcileTargetGroupOwnership(ctx, tgb); err != nil {
+		return err
+	}
 	if *tgb.Spec.TargetType == elbv2api.TargetTypeIP {
 		return m.reconcileWithIPTargetType(ctx, tgb)
 	}
@@ -80,12 +95,16 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.Ta
 }
 
 func (m *defaultResourceManager) Cleanup(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	m.targetHealthRequeueBackoff.Forget(k8s.NamespacedName(tgb))
 	if err := m.cleanupTargets(ctx, tgb); err != nil {
 		return err
 	}
 	if err := m.networkingManager.Cleanup(ctx, tgb); err != nil {
 		return err
 	}
+	if err := m.cleanupTargetGroupOwnership(ctx, tgb); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -96,6 +115,9 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 	resolveOpts := []backend.EndpointResolveOption{
 		backend.WithPodReadinessGate(targetHealthCondType),
 	}
+	if tgb.Spec.RequiredPodConditionType != nil {
+		resolveOpts = append(resolveOpts, backend.WithPodRequiredCondition(*tgb.Spec.RequiredPodConditionType))
+	}
 	endpoints, containsPotentialReadyEndpoints, err := m.endpointResolver.ResolvePodEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, resolveOpts...)
 	if err != nil {
 		if errors.Is(err, backend.ErrNotFound) {
@@ -105,41 +127,41 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return err
 	}
 
+	targetsManager, err := m.targetsManagerProvider.ProvideTargetsManager(ctx, tgb)
+	if err != nil {
+		return err
+	}
 	tgARN := tgb.Spec.TargetGroupARN
-	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
+	tgARNs := BuildTargetGroupARNs(tgb)
+	notDrainingTargetsByTG, err := m.listNotDrainingTargets(ctx, targetsManager, tgARNs)
 	if err != nil {
 		return err
 	}
-	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
-	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchPodEndpointWithTargets(endpoints, notDrainingTargets)
+	// targetHealth conditions of pods reflect the targets of the primary TargetGroup.
+	matchedEndpointAndTargets, unmatchedEndpoints, _ := matchPodEndpointWithTargets(endpoints, notDrainingTargetsByTG[tgARN])
 
 	if err := m.networkingManager.ReconcileForPodEndpoints(ctx, tgb, endpoints); err != nil {
+		m.recordPartialSecurityGroupReconcile(tgb, err)
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgARN, unmatchedTargets); err != nil {
-		return err
-	}
-	if err := m.registerPodEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
+	if err := m.syncTargetsWithPodEndpoints(ctx, targetsManager, tgARNs, notDrainingTargetsByTG, endpoints); err != nil {
 		return err
 	}
+	recordObservedTargets(tgb, tgARN, len(endpoints))
 
-	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints)
+	hcGracePeriod := buildHealthCheckGracePeriod(tgb)
+	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, targetHealthCondType, hcGracePeriod, matchedEndpointAndTargets, unmatchedEndpoints)
 	if err != nil {
 		return err
 	}
 
-	if anyPodNeedFurtherProbe {
-		if containsTargetsInInitialState(matchedEndpointAndTargets) || len(unmatchedEndpoints) != 0 {
-			return runtime.NewRequeueNeededAfter("monitor targetHealth", m.targetHealthRequeueDuration)
-		}
-		return runtime.NewRequeueNeeded("monitor targetHealth")
+	if err := m.requeueForTargetHealth(tgb, anyPodNeedFurtherProbe, matchedEndpointAndTargets, unmatchedEndpoints); err != nil {
+		return err
 	}
 
 	if containsPotentialReadyEndpoints {
 		return runtime.NewRequeueNeeded("monitor potential ready endpoints")
 	}
-
-	_ = drainingTargets
 	return nil
 }
 
@@ -159,36 +181,105 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 		}
 		return err
 	}
+	targetsManager, err := m.targetsManagerProvider.ProvideTargetsManager(ctx, tgb)
+	if err != nil {
+		return err
+	}
 	tgARN := tgb.Spec.TargetGroupARN
-	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
+	tgARNs := BuildTargetGroupARNs(tgb)
+	notDrainingTargetsByTG, err := m.listNotDrainingTargets(ctx, targetsManager, tgARNs)
 	if err != nil {
 		return err
 	}
-	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
-	_, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
 
 	if err := m.networkingManager.ReconcileForNodePortEndpoints(ctx, tgb, endpoints); err != nil {
+		m.recordPartialSecurityGroupReconcile(tgb, err)
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgARN, unmatchedTargets); err != nil {
+	if err := m.syncTargetsWithNodePortEndpoints(ctx, targetsManager, tgARNs, notDrainingTargetsByTG, endpoints); err != nil {
 		return err
 	}
-	if err := m.registerNodePortEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
-		return err
+	recordObservedTargets(tgb, tgARN, len(endpoints))
+	return nil
+}
+
+// listNotDrainingTargets lists the targets that are not draining for each TargetGroup.
+func (m *defaultResourceManager) listNotDrainingTargets(ctx context.Context, targetsManager TargetsManager, tgARNs []string) (map[string][]TargetInfo, error) {
+	notDrainingTargetsByTG := make(map[string][]TargetInfo, len(tgARNs))
+	for _, tgARN := range tgARNs {
+		targets, err := targetsManager.ListTargets(ctx, tgARN)
+		if err != nil {
+			return nil, err
+		}
+		notDrainingTargets, _ := partitionTargetsByDrainingStatus(targets)
+		notDrainingTargetsByTG[tgARN] = notDrainingTargets
+	}
+	return notDrainingTargetsByTG, nil
+}
+
+// syncTargetsWithPodEndpoints deregisters unmatched targets and registers unmatched endpoints for each TargetGroup,
+// so that all TargetGroups of TargetGroupBinding receive the same pod endpoints as targets.
+func (m *defaultResourceManager) syncTargetsWithPodEndpoints(ctx context.Context, targetsManager TargetsManager, tgARNs []string,
+	notDrainingTargetsByTG map[string][]TargetInfo, endpoints []backend.PodEndpoint) error {
+	for _, tgARN := range tgARNs {
+		_, unmatchedEndpoints, unmatchedTargets := matchPodEndpointWithTargets(endpoints, notDrainingTargetsByTG[tgARN])
+		if err := m.deregisterTargets(ctx, targetsManager, tgARN, unmatchedTargets); err != nil {
+			return err
+		}
+		if err := m.registerPodEndpoints(ctx, targetsManager, tgARN, unmatchedEndpoints); err != nil {
+			return err
+		}
 	}
-	_ = drainingTargets
 	return nil
 }
 
+// syncTargetsWithNodePortEndpoints deregisters unmatched targets and registers unmatched endpoints for each TargetGroup,
+// so that all TargetGroups of TargetGroupBinding receive the same nodePort endpoints as targets.
+func (m *defaultResourceManager) syncTargetsWithNodePortEndpoints(ctx context.Context, targetsManager TargetsManager, tgARNs []string,
+	notDrainingTargetsByTG map[string][]TargetInfo, endpoints []backend.NodePortEndpoint) error {
+	for _, tgARN := range tgARNs {
+		_, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargetsByTG[tgARN])
+		if err := m.deregisterTargets(ctx, targetsManager, tgARN, unmatchedTargets); err != nil {
+			return err
+		}
+		if err := m.registerNodePortEndpoints(ctx, targetsManager, tgARN, unmatchedEndpoints); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordPartialSecurityGroupReconcile emits an event when SecurityGroup rules are left partially reconciled by networking reconcile failure.
+func (m *defaultResourceManager) recordPartialSecurityGroupReconcile(tgb *elbv2api.TargetGroupBinding, err error) {
+	var sgReconcileErr *networking.SecurityGroupReconcileError
+	if !errors.As(err, &sgReconcileErr) || !sgReconcileErr.IsPartial() {
+		return
+	}
+	m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonPartialSecurityGroupReconcile, sgReconcileErr.Error())
+}
+
 func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	targets, err := m.targetsManager.ListTargets(ctx, tgb.Spec.TargetGroupARN)
+	targetsManager, err := m.targetsManagerProvider.ProvideTargetsManager(ctx, tgb)
+	if err != nil {
+		return err
+	}
+	for _, tgARN := range BuildTargetGroupARNs(tgb) {
+		if err := m.cleanupTargetsForTargetGroup(ctx, targetsManager, tgARN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *defaultResourceManager) cleanupTargetsForTargetGroup(ctx context.Context, targetsManager TargetsManager, tgARN string) error {
+	targets, err := targetsManager.ListTargets(ctx, tgARN)
 	if err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
 			return nil
 		}
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgb.Spec.TargetGroupARN, targets); err != nil {
+	if err := m.deregisterTargets(ctx, targetsManager, tgARN, targets); err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
 			return nil
 		}
@@ -197,16 +288,44 @@ func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2a
 	return nil
 }
 
+// recordObservedTargets records the TargetGroup and the number of targets registered to it into TargetGroupBinding's status.
+// the status is only changed in memory, it's up to the caller to persist it.
+func recordObservedTargets(tgb *elbv2api.TargetGroupBinding, tgARN string, targetCount int) {
+	tgb.Status.TargetGroupARN = tgARN
+	tgb.Status.TargetCount = awssdk.Int64(int64(targetCount))
+}
+
+// requeueForTargetHealth requests requeue when pods need further targetHealth probe or targets are not healthy yet,
+// so that targetHealth conditions converge promptly. The requeue interval backs off exponentially per TargetGroupBinding,
+// and resets once all targets are healthy.
+// pods that need further probe and targets being registered are still probed every targetHealthRequeueInterval at least,
+// so that pod readiness isn't delayed by the backoff.
+func (m *defaultResourceManager) requeueForTargetHealth(tgb *elbv2api.TargetGroupBinding, anyPodNeedFurtherProbe bool,
+	matchedEndpointAndTargets []podEndpointAndTargetPair, unmatchedEndpoints []backend.PodEndpoint) error {
+	tgbKey := k8s.NamespacedName(tgb)
+	if !anyPodNeedFurtherProbe && len(unmatchedEndpoints) == 0 && !containsTargetsNotYetHealthy(matchedEndpointAndTargets) {
+		m.targetHealthRequeueBackoff.Forget(tgbKey)
+		return nil
+	}
+	requeueAfter := m.targetHealthRequeueBackoff.When(tgbKey)
+	if anyPodNeedFurtherProbe || len(unmatchedEndpoints) != 0 || containsTargetsInInitialState(matchedEndpointAndTargets) {
+		if requeueAfter > m.targetHealthRequeueInterval {
+			requeueAfter = m.targetHealthRequeueInterval
+		}
+	}
+	return runtime.NewRequeueNeededAfter("monitor targetHealth", requeueAfter)
+}
+
 // updateTargetHealthPodCondition will updates pod's targetHealth condition for matchedEndpointAndTargets and unmatchedEndpoints.
 // returns whether further probe is needed or not
-func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Context, targetHealthCondType corev1.PodConditionType,
+func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Context, targetHealthCondType corev1.PodConditionType, hcGracePeriod time.Duration,
 	matchedEndpointAndTargets []podEndpointAndTargetPair, unmatchedEndpoints []backend.PodEndpoint) (bool, error) {
 	anyPodNeedFurtherProbe := false
 
 	for _, endpointAndTarget := range matchedEndpointAndTargets {
 		pod := endpointAndTarget.endpoint.Pod
 		targetHealth := endpointAndTarget.target.TargetHealth
-		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType)
+		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType, hcGracePeriod)
 		if err != nil {
 			return false, err
 		}
@@ -222,7 +341,7 @@ func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Cont
 			Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
 			Description: awssdk.String("Target registration is in progress"),
 		}
-		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType)
+		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType, hcGracePeriod)
 		if err != nil {
 			return false, err
 		}
@@ -234,15 +353,18 @@ func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Cont
 }
 
 // updateTargetHealthPodConditionForPod updates pod's targetHealth condition for a single pod and its matched target.
+// unhealthy targets of pods created within hcGracePeriod are considered ready with HealthCheckGracePeriod reason,
+// and are probed further so that the condition reflects targetHealth once the grace period elapses.
 // returns whether further probe is needed or not.
 func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx context.Context, pod k8s.PodInfo,
-	targetHealth *elbv2sdk.TargetHealth, targetHealthCondType corev1.PodConditionType) (bool, error) {
+	targetHealth *elbv2sdk.TargetHealth, targetHealthCondType corev1.PodConditionType, hcGracePeriod time.Duration) (bool, error) {
 	if !pod.HasAnyOfReadinessGates([]corev1.PodConditionType{targetHealthCondType}) {
 		return false, nil
 	}
 
 	targetHealthCondStatus := corev1.ConditionUnknown
 	var reason, message string
+	withinGracePeriod := false
 	if targetHealth != nil {
 		if awssdk.StringValue(targetHealth.State) == elbv2sdk.TargetHealthStateEnumHealthy {
 			targetHealthCondStatus = corev1.ConditionTrue
@@ -252,8 +374,14 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 
 		reason = awssdk.StringValue(targetHealth.Reason)
 		message = awssdk.StringValue(targetHealth.Description)
+		if awssdk.StringValue(targetHealth.State) == elbv2sdk.TargetHealthStateEnumUnhealthy && pod.IsWithinGracePeriod(hcGracePeriod) {
+			withinGracePeriod = true
+			targetHealthCondStatus = corev1.ConditionTrue
+			reason = targetHealthReasonHealthCheckGracePeriod
+			message = fmt.Sprintf("Target is within health check grace period of %v", hcGracePeriod)
+		}
 	}
-	needFurtherProbe := targetHealthCondStatus != corev1.ConditionTrue
+	needFurtherProbe := targetHealthCondStatus != corev1.ConditionTrue || withinGracePeriod
 
 	existingTargetHealthCond, exists := pod.GetPodCondition(targetHealthCondType)
 	// we skip patch pod if it matches current computed status/reason/message.
@@ -295,15 +423,15 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 	return needFurtherProbe, nil
 }
 
-func (m *defaultResourceManager) deregisterTargets(ctx context.Context, tgARN string, targets []TargetInfo) error {
+func (m *defaultResourceManager) deregisterTargets(ctx context.Context, targetsManager TargetsManager, tgARN string, targets []TargetInfo) error {
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(targets))
 	for _, target := range targets {
 		sdkTargets = append(sdkTargets, target.Target)
 	}
-	return m.targetsManager.DeregisterTargets(ctx, tgARN, sdkTargets)
+	return targetsManager.DeregisterTargets(ctx, tgARN, sdkTargets)
 }
 
-func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, tgARN string, endpoints []backend.PodEndpoint) error {
+func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, targetsManager TargetsManager, tgARN string, endpoints []backend.PodEndpoint) error {
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		sdkTargets = append(sdkTargets, elbv2sdk.TargetDescription{
@@ -311,10 +439,10 @@ func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, tgARN
 			Port: awssdk.Int64(endpoint.Port),
 		})
 	}
-	return m.targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
+	return targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
 }
 
-func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context, tgARN string, endpoints []backend.NodePortEndpoint) error {
+func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context, targetsManager TargetsManager, tgARN string, endpoints []backend.NodePortEndpoint) error {
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		sdkTargets = append(sdkTargets, elbv2sdk.TargetDescription{
@@ -322,7 +450,7 @@ func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context,
 			Port: awssdk.Int64(endpoint.Port),
 		})
 	}
-	return m.targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
+	return targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
 }
 
 type podEndpointAndTargetPair struct {
@@ -352,6 +480,19 @@ func containsTargetsInInitialState(matchedEndpointAndTargets []podEndpointAndTar
 	return false
 }
 
+// containsTargetsNotYetHealthy checks whether there are targets in initial or unhealthy state.
+func containsTargetsNotYetHealthy(matchedEndpointAndTargets []podEndpointAndTargetPair) bool {
+	if containsTargetsInInitialState(matchedEndpointAndTargets) {
+		return true
+	}
+	for _, endpointAndTarget := range matchedEndpointAndTargets {
+		if endpointAndTarget.target.IsUnhealthy() {
+			return true
+		}
+	}
+	return false
+}
+
 func matchPodEndpointWithTargets(endpoints []backend.PodEndpoint, targets []TargetInfo) ([]podEndpointAndTargetPair, []backend.PodEndpoint, []TargetInfo) {
 	var matchedEndpointAndTargets []podEndpointAndTargetPair
 	var unmatchedEndpoints []backend.PodEndpoint
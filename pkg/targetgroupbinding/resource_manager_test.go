@@ -4,19 +4,29 @@ import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	ctrlruntime "sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing.T) {
@@ -28,6 +38,7 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 		pod                  k8s.PodInfo
 		targetHealth         *elbv2sdk.TargetHealth
 		targetHealthCondType corev1.PodConditionType
+		hcGracePeriod        time.Duration
 	}
 
 	tests := []struct {
@@ -368,6 +379,136 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 				},
 			},
 		},
+		{
+			name: "pod contains readinessGate and targetHealth is unhealthy within health check grace period",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Second)),
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State:       awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+					Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks),
+					Description: awssdk.String("Health checks failed"),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+				hcGracePeriod:        60 * time.Second,
+			},
+			want: true,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    "target-health.elbv2.k8s.aws/my-tgb",
+							Status:  corev1.ConditionTrue,
+							Reason:  "HealthCheckGracePeriod",
+							Message: "Target is within health check grace period of 1m0s",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod contains readinessGate and targetHealth is unhealthy after health check grace period",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-90 * time.Second)),
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State:       awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+					Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks),
+					Description: awssdk.String("Health checks failed"),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+				hcGracePeriod:        60 * time.Second,
+			},
+			want: true,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    "target-health.elbv2.k8s.aws/my-tgb",
+							Status:  corev1.ConditionFalse,
+							Reason:  elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks,
+							Message: "Health checks failed",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -387,7 +528,7 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 			}
 
 			got, err := m.updateTargetHealthPodConditionForPod(context.Background(),
-				tt.args.pod, tt.args.targetHealth, tt.args.targetHealthCondType)
+				tt.args.pod, tt.args.targetHealth, tt.args.targetHealthCondType, tt.args.hcGracePeriod)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
@@ -500,3 +641,357 @@ func Test_buildPodConditionPatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultResourceManager_requeueForTargetHealth(t *testing.T) {
+	initialTarget := podEndpointAndTargetPair{
+		target: TargetInfo{
+			TargetHealth: &elbv2sdk.TargetHealth{
+				State:  awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
+				Reason: awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
+			},
+		},
+	}
+	unhealthyTarget := podEndpointAndTargetPair{
+		target: TargetInfo{
+			TargetHealth: &elbv2sdk.TargetHealth{
+				State:  awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+				Reason: awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks),
+			},
+		},
+	}
+	healthyTarget := podEndpointAndTargetPair{
+		target: TargetInfo{
+			TargetHealth: &elbv2sdk.TargetHealth{
+				State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+			},
+		},
+	}
+	type reconcileResult struct {
+		anyPodNeedFurtherProbe    bool
+		matchedEndpointAndTargets []podEndpointAndTargetPair
+		wantRequeueAfter          time.Duration
+	}
+	tests := []struct {
+		name             string
+		reconcileResults []reconcileResult
+	}{
+		{
+			name: "requeue when targets are in initial state",
+			reconcileResults: []reconcileResult{
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{initialTarget, healthyTarget},
+					wantRequeueAfter:          10 * time.Second,
+				},
+			},
+		},
+		{
+			name: "requeue backs off while targets are not healthy yet",
+			reconcileResults: []reconcileResult{
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{initialTarget},
+					wantRequeueAfter:          10 * time.Second,
+				},
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{unhealthyTarget},
+					wantRequeueAfter:          20 * time.Second,
+				},
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{unhealthyTarget},
+					wantRequeueAfter:          30 * time.Second,
+				},
+			},
+		},
+		{
+			name: "requeue backoff is capped while targets are in initial state",
+			reconcileResults: []reconcileResult{
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{unhealthyTarget},
+					wantRequeueAfter:          10 * time.Second,
+				},
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{unhealthyTarget},
+					wantRequeueAfter:          20 * time.Second,
+				},
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{initialTarget, unhealthyTarget},
+					wantRequeueAfter:          10 * time.Second,
+				},
+			},
+		},
+		{
+			name: "requeue backoff is capped while pods need further probe",
+			reconcileResults: []reconcileResult{
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{unhealthyTarget},
+					wantRequeueAfter:          10 * time.Second,
+				},
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{unhealthyTarget},
+					wantRequeueAfter:          20 * time.Second,
+				},
+				{
+					anyPodNeedFurtherProbe:    true,
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{unhealthyTarget},
+					wantRequeueAfter:          10 * time.Second,
+				},
+			},
+		},
+		{
+			name: "requeue backoff resets once targets are healthy",
+			reconcileResults: []reconcileResult{
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{initialTarget},
+					wantRequeueAfter:          10 * time.Second,
+				},
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{healthyTarget},
+					wantRequeueAfter:          0,
+				},
+				{
+					matchedEndpointAndTargets: []podEndpointAndTargetPair{initialTarget},
+					wantRequeueAfter:          10 * time.Second,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				targetHealthRequeueInterval: 10 * time.Second,
+				targetHealthRequeueBackoff:  workqueue.NewItemExponentialFailureRateLimiter(10*time.Second, 30*time.Second),
+			}
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "tgb",
+				},
+			}
+			for _, result := range tt.reconcileResults {
+				err := m.requeueForTargetHealth(tgb, result.anyPodNeedFurtherProbe, result.matchedEndpointAndTargets, nil)
+				if result.wantRequeueAfter == 0 {
+					assert.NoError(t, err)
+					continue
+				}
+				var requeueNeededAfter *ctrlruntime.RequeueNeededAfter
+				assert.True(t, errors.As(err, &requeueNeededAfter))
+				assert.Equal(t, result.wantRequeueAfter, requeueNeededAfter.Duration())
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_recordPartialSecurityGroupReconcile(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantEvents []string
+	}{
+		{
+			name: "revoke failed after permissions authorized",
+			err: &networking.SecurityGroupReconcileError{
+				SecurityGroupID:           "sg-a",
+				FailedPhase:               networking.SecurityGroupReconcilePhaseRevoke,
+				AuthorizedPermissionCount: 1,
+				Err:                       errors.New("some error"),
+			},
+			wantEvents: []string{
+				"Warning PartialSecurityGroupReconcile failed to revoke ingress permissions on securityGroup sg-a after 1 permissions authorized and 0 permissions revoked: some error",
+			},
+		},
+		{
+			name: "authorize failed after permissions revoked",
+			err: &networking.SecurityGroupReconcileError{
+				SecurityGroupID:        "sg-a",
+				FailedPhase:            networking.SecurityGroupReconcilePhaseAuthorize,
+				RevokedPermissionCount: 2,
+				Err:                    errors.New("some error"),
+			},
+			wantEvents: []string{
+				"Warning PartialSecurityGroupReconcile failed to authorize ingress permissions on securityGroup sg-a after 0 permissions authorized and 2 permissions revoked: some error",
+			},
+		},
+		{
+			name: "revoke failed without permissions applied",
+			err: &networking.SecurityGroupReconcileError{
+				SecurityGroupID: "sg-a",
+				FailedPhase:     networking.SecurityGroupReconcilePhaseRevoke,
+				Err:             errors.New("some error"),
+			},
+		},
+		{
+			name: "other error",
+			err:  errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			m := &defaultResourceManager{
+				eventRecorder: eventRecorder,
+			}
+			m.recordPartialSecurityGroupReconcile(&elbv2api.TargetGroupBinding{}, tt.err)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultResourceManager_syncTargetsWithNodePortEndpoints(t *testing.T) {
+	type describeTargetHealthWithContextCall struct {
+		req  *elbv2sdk.DescribeTargetHealthInput
+		resp *elbv2sdk.DescribeTargetHealthOutput
+	}
+	type registerTargetsWithContextCall struct {
+		req *elbv2sdk.RegisterTargetsInput
+	}
+	type deregisterTargetsWithContextCall struct {
+		req *elbv2sdk.DeregisterTargetsInput
+	}
+	endpoints := []backend.NodePortEndpoint{
+		{InstanceID: "i-1", Port: 30080},
+		{InstanceID: "i-2", Port: 30080},
+	}
+	tests := []struct {
+		name                                 string
+		tgb                                  *elbv2api.TargetGroupBinding
+		describeTargetHealthWithContextCalls []describeTargetHealthWithContextCall
+		registerTargetsWithContextCalls      []registerTargetsWithContextCall
+		deregisterTargetsWithContextCalls    []deregisterTargetsWithContextCall
+	}{
+		{
+			name: "service bound to single targetGroup",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{
+						TargetGroupArn: awssdk.String("tg-1"),
+					},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-1"), Port: awssdk.Int64(30080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+						},
+					},
+				},
+			},
+			registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+				{
+					req: &elbv2sdk.RegisterTargetsInput{
+						TargetGroupArn: awssdk.String("tg-1"),
+						Targets: []*elbv2sdk.TargetDescription{
+							{Id: awssdk.String("i-2"), Port: awssdk.Int64(30080)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "service bound to two targetGroups",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN:            "tg-1",
+					AdditionalTargetGroupARNs: []string{"tg-2"},
+				},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{
+						TargetGroupArn: awssdk.String("tg-1"),
+					},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-1"), Port: awssdk.Int64(30080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-3"), Port: awssdk.Int64(30080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+						},
+					},
+				},
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{
+						TargetGroupArn: awssdk.String("tg-2"),
+					},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-2"), Port: awssdk.Int64(30080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumDraining)},
+							},
+						},
+					},
+				},
+			},
+			registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+				{
+					req: &elbv2sdk.RegisterTargetsInput{
+						TargetGroupArn: awssdk.String("tg-1"),
+						Targets: []*elbv2sdk.TargetDescription{
+							{Id: awssdk.String("i-2"), Port: awssdk.Int64(30080)},
+						},
+					},
+				},
+				{
+					req: &elbv2sdk.RegisterTargetsInput{
+						TargetGroupArn: awssdk.String("tg-2"),
+						Targets: []*elbv2sdk.TargetDescription{
+							{Id: awssdk.String("i-1"), Port: awssdk.Int64(30080)},
+							{Id: awssdk.String("i-2"), Port: awssdk.Int64(30080)},
+						},
+					},
+				},
+			},
+			deregisterTargetsWithContextCalls: []deregisterTargetsWithContextCall{
+				{
+					req: &elbv2sdk.DeregisterTargetsInput{
+						TargetGroupArn: awssdk.String("tg-1"),
+						Targets: []*elbv2sdk.TargetDescription{
+							{Id: awssdk.String("i-3"), Port: awssdk.Int64(30080)},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeTargetHealthWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.registerTargetsWithContextCalls {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.RegisterTargetsOutput{}, nil)
+			}
+			for _, call := range tt.deregisterTargetsWithContextCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeregisterTargetsOutput{}, nil)
+			}
+			targetsManager := NewCachedTargetsManager(elbv2Client, &log.NullLogger{})
+			m := &defaultResourceManager{
+				logger: &log.NullLogger{},
+			}
+
+			ctx := context.Background()
+			tgARNs := BuildTargetGroupARNs(tt.tgb)
+			notDrainingTargetsByTG, err := m.listNotDrainingTargets(ctx, targetsManager, tgARNs)
+			assert.NoError(t, err)
+			err = m.syncTargetsWithNodePortEndpoints(ctx, targetsManager, tgARNs, notDrainingTargetsByTG, endpoints)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package targetgroupbinding
+
+import (
+	"context"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+const (
+	// AnnotationAdoptTargetGroup is the annotation to adopt a pre-existing TargetGroup by its ARN or name.
+	AnnotationAdoptTargetGroup = "elbv2.k8s.aws/adopt-target-group"
+
+	// tagKeyTargetGroupBindingOwner is the tag on adopted TargetGroup that identifies the TargetGroupBinding owning it.
+	tagKeyTargetGroupBindingOwner = "elbv2.k8s.aws/target-group-binding"
+)
+
+// IsTargetGroupAdopted checks whether the TargetGroup of TargetGroupBinding is adopted via annotation.
+func IsTargetGroupAdopted(tgb *elbv2api.TargetGroupBinding) bool {
+	_, exists := tgb.Annotations[AnnotationAdoptTargetGroup]
+	return exists
+}
+
+// reconcileTargetGroupOwnership ensures the adopted TargetGroup is tagged as owned by TargetGroupBinding.
+// the ownership tag is restored if it's removed externally, and TargetGroup owned by another TargetGroupBinding is rejected.
+func (m *defaultResourceManager) reconcileTargetGroupOwnership(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if !IsTargetGroupAdopted(tgb) {
+		return nil
+	}
+	tgARN := tgb.Spec.TargetGroupARN
+	owner := k8s.NamespacedName(tgb).String()
+	tags, err := m.describeTargetGroupTags(ctx, tgARN)
+	if err != nil {
+		return err
+	}
+	if currentOwner, exists := tags[tagKeyTargetGroupBindingOwner]; exists {
+		if currentOwner != owner {
+			return errors.Errorf("targetGroup %v is owned by another TargetGroupBinding: %v", tgARN, currentOwner)
+		}
+		return nil
+	}
+
+	req := &elbv2sdk.AddTagsInput{
+		ResourceArns: awssdk.StringSlice([]string{tgARN}),
+		Tags: []*elbv2sdk.Tag{
+			{
+				Key:   awssdk.String(tagKeyTargetGroupBindingOwner),
+				Value: awssdk.String(owner),
+			},
+		},
+	}
+	m.logger.Info("adding targetGroup ownership tag",
+		"arn", tgARN,
+		"owner", owner)
+	if _, err := m.elbv2Client.AddTagsWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("added targetGroup ownership tag",
+		"arn", tgARN)
+	return nil
+}
+
+// cleanupTargetGroupOwnership removes the ownership tag from adopted TargetGroup, so that it can be adopted again.
+// the ownership tag is only removed when TargetGroupBinding is being deleted, since targets are also cleaned up when backend is not found.
+func (m *defaultResourceManager) cleanupTargetGroupOwnership(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if !IsTargetGroupAdopted(tgb) || tgb.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	tgARN := tgb.Spec.TargetGroupARN
+	tags, err := m.describeTargetGroupTags(ctx, tgARN)
+	if err != nil {
+		if isELBV2TargetGroupNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if tags[tagKeyTargetGroupBindingOwner] != k8s.NamespacedName(tgb).String() {
+		return nil
+	}
+
+	req := &elbv2sdk.RemoveTagsInput{
+		ResourceArns: awssdk.StringSlice([]string{tgARN}),
+		TagKeys:      awssdk.StringSlice([]string{tagKeyTargetGroupBindingOwner}),
+	}
+	m.logger.Info("removing targetGroup ownership tag",
+		"arn", tgARN)
+	if _, err := m.elbv2Client.RemoveTagsWithContext(ctx, req); err != nil {
+		if isELBV2TargetGroupNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	m.logger.Info("removed targetGroup ownership tag",
+		"arn", tgARN)
+	return nil
+}
+
+func (m *defaultResourceManager) describeTargetGroupTags(ctx context.Context, tgARN string) (map[string]string, error) {
+	req := &elbv2sdk.DescribeTagsInput{
+		ResourceArns: awssdk.StringSlice([]string{tgARN}),
+	}
+	resp, err := m.elbv2Client.DescribeTagsWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for _, tagDescription := range resp.TagDescriptions {
+		for _, tag := range tagDescription.Tags {
+			tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+		}
+	}
+	return tags, nil
+}
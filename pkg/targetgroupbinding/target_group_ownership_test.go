@@ -0,0 +1,395 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_defaultResourceManager_reconcileTargetGroupOwnership(t *testing.T) {
+	type describeTagsWithContextCall struct {
+		req  *elbv2sdk.DescribeTagsInput
+		resp *elbv2sdk.DescribeTagsOutput
+		err  error
+	}
+	type addTagsWithContextCall struct {
+		req  *elbv2sdk.AddTagsInput
+		resp *elbv2sdk.AddTagsOutput
+		err  error
+	}
+	type fields struct {
+		describeTagsWithContextCalls []describeTagsWithContextCall
+		addTagsWithContextCalls      []addTagsWithContextCall
+	}
+
+	adoptedTGB := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "tgb-1",
+			Annotations: map[string]string{
+				"elbv2.k8s.aws/adopt-target-group": "my-tg",
+			},
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+		},
+	}
+	describeTagsReq := &elbv2sdk.DescribeTagsInput{
+		ResourceArns: awssdk.StringSlice([]string{"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"}),
+	}
+	addTagsReq := &elbv2sdk.AddTagsInput{
+		ResourceArns: awssdk.StringSlice([]string{"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"}),
+		Tags: []*elbv2sdk.Tag{
+			{
+				Key:   awssdk.String("elbv2.k8s.aws/target-group-binding"),
+				Value: awssdk.String("ns-1/tgb-1"),
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		tgb     *elbv2api.TargetGroupBinding
+		wantErr error
+	}{
+		{
+			name:   "targetGroup isn't adopted",
+			fields: fields{},
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "tgb-1",
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+				},
+			},
+		},
+		{
+			name: "adopt targetGroup without ownership tag",
+			fields: fields{
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						req: describeTagsReq,
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"),
+									Tags: []*elbv2sdk.Tag{
+										{
+											Key:   awssdk.String("team"),
+											Value: awssdk.String("platform"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				addTagsWithContextCalls: []addTagsWithContextCall{
+					{
+						req:  addTagsReq,
+						resp: &elbv2sdk.AddTagsOutput{},
+					},
+				},
+			},
+			tgb: adoptedTGB,
+		},
+		{
+			name: "adopted targetGroup already owned",
+			fields: fields{
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						req: describeTagsReq,
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"),
+									Tags: []*elbv2sdk.Tag{
+										{
+											Key:   awssdk.String("elbv2.k8s.aws/target-group-binding"),
+											Value: awssdk.String("ns-1/tgb-1"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			tgb: adoptedTGB,
+		},
+		{
+			name: "adopted targetGroup owned by another targetGroupBinding",
+			fields: fields{
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						req: describeTagsReq,
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"),
+									Tags: []*elbv2sdk.Tag{
+										{
+											Key:   awssdk.String("elbv2.k8s.aws/target-group-binding"),
+											Value: awssdk.String("ns-2/tgb-2"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			tgb:     adoptedTGB,
+			wantErr: errors.New("targetGroup arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef is owned by another TargetGroupBinding: ns-2/tgb-2"),
+		},
+		{
+			name: "describeTags fails",
+			fields: fields{
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						req: describeTagsReq,
+						err: errors.New("some error"),
+					},
+				},
+			},
+			tgb:     adoptedTGB,
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTagsWithContextCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.addTagsWithContextCalls {
+				elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultResourceManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.reconcileTargetGroupOwnership(context.Background(), tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_reconcileTargetGroupOwnership_driftCorrection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "tgb-1",
+			Annotations: map[string]string{
+				"elbv2.k8s.aws/adopt-target-group": tgARN,
+			},
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: tgARN,
+		},
+	}
+	ownedTagDescriptions := []*elbv2sdk.TagDescription{
+		{
+			ResourceArn: awssdk.String(tgARN),
+			Tags: []*elbv2sdk.Tag{
+				{
+					Key:   awssdk.String("elbv2.k8s.aws/target-group-binding"),
+					Value: awssdk.String("ns-1/tgb-1"),
+				},
+			},
+		},
+	}
+	elbv2Client := services.NewMockELBV2(ctrl)
+	gomock.InOrder(
+		// first reconcile: ownership tag present.
+		elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTagsOutput{
+			TagDescriptions: ownedTagDescriptions,
+		}, nil),
+		// second reconcile: ownership tag removed externally, will be restored.
+		elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTagsOutput{
+			TagDescriptions: []*elbv2sdk.TagDescription{
+				{
+					ResourceArn: awssdk.String(tgARN),
+				},
+			},
+		}, nil),
+		elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), &elbv2sdk.AddTagsInput{
+			ResourceArns: awssdk.StringSlice([]string{tgARN}),
+			Tags:         ownedTagDescriptions[0].Tags,
+		}).Return(&elbv2sdk.AddTagsOutput{}, nil),
+	)
+	m := &defaultResourceManager{
+		elbv2Client: elbv2Client,
+		logger:      &log.NullLogger{},
+	}
+	assert.NoError(t, m.reconcileTargetGroupOwnership(context.Background(), tgb))
+	assert.NoError(t, m.reconcileTargetGroupOwnership(context.Background(), tgb))
+}
+
+func Test_defaultResourceManager_cleanupTargetGroupOwnership(t *testing.T) {
+	type describeTagsWithContextCall struct {
+		resp *elbv2sdk.DescribeTagsOutput
+		err  error
+	}
+	type removeTagsWithContextCall struct {
+		req  *elbv2sdk.RemoveTagsInput
+		resp *elbv2sdk.RemoveTagsOutput
+		err  error
+	}
+	type fields struct {
+		describeTagsWithContextCalls []describeTagsWithContextCall
+		removeTagsWithContextCalls   []removeTagsWithContextCall
+	}
+
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"
+	deletionTimestamp := metav1.NewTime(time.Now())
+	deletingTGB := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "ns-1",
+			Name:              "tgb-1",
+			DeletionTimestamp: &deletionTimestamp,
+			Annotations: map[string]string{
+				"elbv2.k8s.aws/adopt-target-group": "my-tg",
+			},
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: tgARN,
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		tgb     *elbv2api.TargetGroupBinding
+		wantErr error
+	}{
+		{
+			name:   "targetGroupBinding isn't being deleted",
+			fields: fields{},
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "tgb-1",
+					Annotations: map[string]string{
+						"elbv2.k8s.aws/adopt-target-group": "my-tg",
+					},
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: tgARN,
+				},
+			},
+		},
+		{
+			name: "remove ownership tag owned by targetGroupBinding",
+			fields: fields{
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String(tgARN),
+									Tags: []*elbv2sdk.Tag{
+										{
+											Key:   awssdk.String("elbv2.k8s.aws/target-group-binding"),
+											Value: awssdk.String("ns-1/tgb-1"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				removeTagsWithContextCalls: []removeTagsWithContextCall{
+					{
+						req: &elbv2sdk.RemoveTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{tgARN}),
+							TagKeys:      awssdk.StringSlice([]string{"elbv2.k8s.aws/target-group-binding"}),
+						},
+						resp: &elbv2sdk.RemoveTagsOutput{},
+					},
+				},
+			},
+			tgb: deletingTGB,
+		},
+		{
+			name: "ownership tag owned by another targetGroupBinding is retained",
+			fields: fields{
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String(tgARN),
+									Tags: []*elbv2sdk.Tag{
+										{
+											Key:   awssdk.String("elbv2.k8s.aws/target-group-binding"),
+											Value: awssdk.String("ns-2/tgb-2"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			tgb: deletingTGB,
+		},
+		{
+			name: "targetGroup already deleted",
+			fields: fields{
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						err: awserr.New("TargetGroupNotFound", "", nil),
+					},
+				},
+			},
+			tgb: deletingTGB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTagsWithContextCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.removeTagsWithContextCalls {
+				elbv2Client.EXPECT().RemoveTagsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultResourceManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.cleanupTargetGroupOwnership(context.Background(), tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
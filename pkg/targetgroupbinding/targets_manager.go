@@ -3,10 +3,15 @@ package targetgroupbinding
 import (
 	"context"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sync"
 	"time"
 )
@@ -17,6 +22,18 @@ const (
 	defaultDeregisterTargetsChunkSize = 200
 )
 
+// eventualConsistencyErrorCodes are the ELBV2 API error codes caused by eventual consistency of TargetGroup creation.
+var eventualConsistencyErrorCodes = sets.NewString("TargetGroupNotFound")
+
+// defaultRegisterTargetsBackoff is the backoff to retry registerTargets API call on eventual consistency errors.
+// a TargetGroup may not be visible to registerTargets API call right after it's created.
+var defaultRegisterTargetsBackoff = wait.Backoff{
+	Duration: 200 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+	Steps:    5,
+}
+
 // TargetsManager is an abstraction around ELBV2's targets API.
 type TargetsManager interface {
 	// Register Targets into TargetGroup.
@@ -37,6 +54,7 @@ func NewCachedTargetsManager(elbv2Client services.ELBV2, logger logr.Logger) *ca
 		targetsCacheTTL:            defaultTargetsCacheTTL,
 		registerTargetsChunkSize:   defaultRegisterTargetsChunkSize,
 		deregisterTargetsChunkSize: defaultDeregisterTargetsChunkSize,
+		registerTargetsBackoff:     defaultRegisterTargetsBackoff,
 		logger:                     logger,
 	}
 }
@@ -63,6 +81,8 @@ type cachedTargetsManager struct {
 	registerTargetsChunkSize int
 	// chunk size for deregisterTargets API call.
 	deregisterTargetsChunkSize int
+	// backoff for registerTargets API call on eventual consistency errors.
+	registerTargetsBackoff wait.Backoff
 
 	logger logr.Logger
 }
@@ -85,8 +105,10 @@ func (m *cachedTargetsManager) RegisterTargets(ctx context.Context, tgARN string
 		m.logger.Info("registering targets",
 			"arn", tgARN,
 			"targets", targetsChunk)
-		_, err := m.elbv2Client.RegisterTargetsWithContext(ctx, req)
-		if err != nil {
+		if err := runtime.RetryWithBackoffOnError(m.registerTargetsBackoff, isEventualConsistencyError, func() error {
+			_, err := m.elbv2Client.RegisterTargetsWithContext(ctx, req)
+			return err
+		}); err != nil {
 			return err
 		}
 		m.logger.Info("registered targets",
@@ -296,3 +318,12 @@ func pointerizeTargetDescriptions(targets []elbv2sdk.TargetDescription) []*elbv2
 func cloneTargetInfoSlice(targets []TargetInfo) []TargetInfo {
 	return append(targets[:0:0], targets...)
 }
+
+// isEventualConsistencyError checks whether the error is caused by eventual consistency of TargetGroup creation.
+func isEventualConsistencyError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return eventualConsistencyErrorCodes.Has(awsErr.Code())
+	}
+	return false
+}
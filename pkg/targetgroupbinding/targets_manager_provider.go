@@ -0,0 +1,89 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+// AssumeRoleELBV2Provider provides ELBV2 clients with credentials of assumed IAM role.
+type AssumeRoleELBV2Provider interface {
+	// AssumeRoleELBV2 provides API to AWS ELBV2 with credentials of specified IAM role assumed.
+	AssumeRoleELBV2(roleARN string) (services.ELBV2, error)
+}
+
+// TargetsManagerProvider provides TargetsManager for TargetGroupBindings.
+type TargetsManagerProvider interface {
+	// ProvideTargetsManager returns the TargetsManager to manage targets for TargetGroupBinding.
+	ProvideTargetsManager(ctx context.Context, tgb *elbv2api.TargetGroupBinding) (TargetsManager, error)
+}
+
+// NewDefaultTargetsManagerProvider constructs new defaultTargetsManagerProvider.
+func NewDefaultTargetsManagerProvider(elbv2Client services.ELBV2, assumeRoleELBV2Provider AssumeRoleELBV2Provider, logger logr.Logger) *defaultTargetsManagerProvider {
+	return &defaultTargetsManagerProvider{
+		targetsManager:              NewCachedTargetsManager(elbv2Client, logger),
+		assumeRoleELBV2Provider:     assumeRoleELBV2Provider,
+		targetsManagerByAssumedRole: make(map[string]TargetsManager),
+		logger:                      logger,
+	}
+}
+
+var _ TargetsManagerProvider = &defaultTargetsManagerProvider{}
+
+// default implementation for TargetsManagerProvider.
+// TargetGroupBindings without assumeRoleARN share a single TargetsManager using controller's own credentials,
+// while TargetGroupBindings with assumeRoleARN share a TargetsManager per assumed role.
+type defaultTargetsManagerProvider struct {
+	targetsManager          TargetsManager
+	assumeRoleELBV2Provider AssumeRoleELBV2Provider
+
+	// targetsManagerByAssumedRole caches TargetsManager by assumed roleARN.
+	targetsManagerByAssumedRole map[string]TargetsManager
+	// targetsManagerMutex protects targetsManagerByAssumedRole
+	targetsManagerMutex sync.Mutex
+
+	logger logr.Logger
+}
+
+func (p *defaultTargetsManagerProvider) ProvideTargetsManager(_ context.Context, tgb *elbv2api.TargetGroupBinding) (TargetsManager, error) {
+	roleARN := tgb.Spec.AssumeRoleARN
+	if roleARN == "" {
+		return p.targetsManager, nil
+	}
+	for _, tgARN := range BuildTargetGroupARNs(tgb) {
+		if err := validateTargetGroupBelongsToRoleAccount(tgARN, roleARN); err != nil {
+			return nil, err
+		}
+	}
+
+	p.targetsManagerMutex.Lock()
+	defer p.targetsManagerMutex.Unlock()
+	if targetsManager, exists := p.targetsManagerByAssumedRole[roleARN]; exists {
+		return targetsManager, nil
+	}
+	elbv2Client, err := p.assumeRoleELBV2Provider.AssumeRoleELBV2(roleARN)
+	if err != nil {
+		return nil, err
+	}
+	targetsManager := NewCachedTargetsManager(elbv2Client, p.logger.WithValues("assumeRoleARN", roleARN))
+	p.targetsManagerByAssumedRole[roleARN] = targetsManager
+	return targetsManager, nil
+}
+
+// validateTargetGroupBelongsToRoleAccount validates the TargetGroup belongs to the AWS partition and account of the IAM role.
+func validateTargetGroupBelongsToRoleAccount(tgARN string, roleARN string) error {
+	parsedRoleARN, err := arn.Parse(roleARN)
+	if err != nil {
+		return errors.Wrapf(err, "invalid assumeRoleARN: %v", roleARN)
+	}
+	if err := aws.ValidateARNInPartitionAndAccount(tgARN, parsedRoleARN.Partition, parsedRoleARN.AccountID); err != nil {
+		return errors.Wrapf(err, "invalid targetGroup for assumed role %v", roleARN)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeAssumeRoleELBV2Provider struct {
+	elbv2ClientByRoleARN map[string]services.ELBV2
+	assumeRoleCalls      int
+}
+
+func (p *fakeAssumeRoleELBV2Provider) AssumeRoleELBV2(roleARN string) (services.ELBV2, error) {
+	p.assumeRoleCalls++
+	elbv2Client, exists := p.elbv2ClientByRoleARN[roleARN]
+	if !exists {
+		return nil, errors.Errorf("assume role %v is not allowed", roleARN)
+	}
+	return elbv2Client, nil
+}
+
+func Test_defaultTargetsManagerProvider_ProvideTargetsManager(t *testing.T) {
+	const (
+		localTGARN        = "arn:aws:elasticloadbalancing:us-west-2:111111111111:targetgroup/tg-1/1234567890abcdef"
+		crossAccountTGARN = "arn:aws:elasticloadbalancing:us-west-2:222222222222:targetgroup/tg-2/1234567890abcdef"
+		crossAccountRole  = "arn:aws:iam::222222222222:role/shared-services"
+		disallowedRole    = "arn:aws:iam::222222222222:role/disallowed"
+		otherAccountRole  = "arn:aws:iam::333333333333:role/shared-services"
+		chinaTGARN        = "arn:aws-cn:elasticloadbalancing:cn-north-1:222222222222:targetgroup/tg-2/1234567890abcdef"
+	)
+	tests := []struct {
+		name                 string
+		tgb                  *elbv2api.TargetGroupBinding
+		wantCrossAccountCall bool
+		wantErr              error
+	}{
+		{
+			name: "targetGroupBinding without assumeRoleARN",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: localTGARN,
+				},
+			},
+			wantCrossAccountCall: false,
+		},
+		{
+			name: "targetGroupBinding with allowed assumeRoleARN",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: crossAccountTGARN,
+					AssumeRoleARN:  crossAccountRole,
+				},
+			},
+			wantCrossAccountCall: true,
+		},
+		{
+			name: "targetGroupBinding with disallowed assumeRoleARN",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: crossAccountTGARN,
+					AssumeRoleARN:  disallowedRole,
+				},
+			},
+			wantErr: errors.New("assume role arn:aws:iam::222222222222:role/disallowed is not allowed"),
+		},
+		{
+			name: "targetGroupBinding with targetGroup outside of assumed role's account",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: crossAccountTGARN,
+					AssumeRoleARN:  otherAccountRole,
+				},
+			},
+			wantErr: errors.New("invalid targetGroup for assumed role arn:aws:iam::333333333333:role/shared-services: arn:aws:elasticloadbalancing:us-west-2:222222222222:targetgroup/tg-2/1234567890abcdef doesn't belong to account 333333333333"),
+		},
+		{
+			name: "targetGroupBinding with targetGroup outside of assumed role's partition",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: chinaTGARN,
+					AssumeRoleARN:  crossAccountRole,
+				},
+			},
+			wantErr: errors.New("invalid targetGroup for assumed role arn:aws:iam::222222222222:role/shared-services: arn:aws-cn:elasticloadbalancing:cn-north-1:222222222222:targetgroup/tg-2/1234567890abcdef doesn't belong to partition aws"),
+		},
+		{
+			name: "targetGroupBinding with invalid assumeRoleARN",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: crossAccountTGARN,
+					AssumeRoleARN:  "shared-services",
+				},
+			},
+			wantErr: errors.New("invalid assumeRoleARN: shared-services: arn: invalid prefix"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			crossAccountELBV2Client := services.NewMockELBV2(ctrl)
+			assumeRoleELBV2Provider := &fakeAssumeRoleELBV2Provider{
+				elbv2ClientByRoleARN: map[string]services.ELBV2{
+					crossAccountRole: crossAccountELBV2Client,
+					otherAccountRole: crossAccountELBV2Client,
+				},
+			}
+			wantReq := &elbv2sdk.RegisterTargetsInput{
+				TargetGroupArn: awssdk.String(tt.tgb.Spec.TargetGroupARN),
+				Targets: []*elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.1"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			}
+			if tt.wantCrossAccountCall {
+				crossAccountELBV2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), wantReq).Return(&elbv2sdk.RegisterTargetsOutput{}, nil)
+			} else if tt.wantErr == nil {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), wantReq).Return(&elbv2sdk.RegisterTargetsOutput{}, nil)
+			}
+
+			p := NewDefaultTargetsManagerProvider(elbv2Client, assumeRoleELBV2Provider, &log.NullLogger{})
+			ctx := context.Background()
+			got, err := p.ProvideTargetsManager(ctx, tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			err = got.RegisterTargets(ctx, tt.tgb.Spec.TargetGroupARN, []elbv2sdk.TargetDescription{
+				{
+					Id:   awssdk.String("192.168.1.1"),
+					Port: awssdk.Int64(8080),
+				},
+			})
+			assert.NoError(t, err)
+
+			// TargetsManager for the same assumed role should be reused.
+			gotAgain, err := p.ProvideTargetsManager(ctx, tt.tgb)
+			assert.NoError(t, err)
+			assert.Same(t, got, gotAgain)
+			if tt.wantCrossAccountCall {
+				assert.Equal(t, 1, assumeRoleELBV2Provider.assumeRoleCalls)
+			} else {
+				assert.Equal(t, 0, assumeRoleELBV2Provider.assumeRoleCalls)
+			}
+		})
+	}
+}
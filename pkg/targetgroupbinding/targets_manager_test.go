@@ -3,10 +3,13 @@ package targetgroupbinding
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sync"
@@ -36,6 +39,129 @@ func Test_cachedTargetsManager_RegisterTargets(t *testing.T) {
 		wantTargetsCache map[string][]TargetInfo
 		wantErr          error
 	}{
+		{
+			name: "register targets retries when TargetGroup isn't found yet",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.1"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("TargetGroupNotFound", "target group not found", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.1"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+				},
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.1"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantTargetsCache: map[string][]TargetInfo{},
+		},
+		{
+			name: "register targets fails when TargetGroup isn't found after retries",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.1"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("TargetGroupNotFound", "target group not found", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.1"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("TargetGroupNotFound", "target group not found", nil),
+					},
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.1"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("TargetGroupNotFound", "target group not found", nil),
+					},
+				},
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.1"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupNotFound: target group not found"),
+		},
+		{
+			name: "register targets doesn't retry on other errors",
+			fields: fields{
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-tg"),
+							Targets: []*elbv2sdk.TargetDescription{
+								{
+									Id:   awssdk.String("192.168.1.1"),
+									Port: awssdk.Int64(8080),
+								},
+							},
+						},
+						err: awserr.New("InvalidTarget", "invalid target", nil),
+					},
+				},
+			},
+			args: args{
+				tgARN: "my-tg",
+				targets: []elbv2sdk.TargetDescription{
+					{
+						Id:   awssdk.String("192.168.1.1"),
+						Port: awssdk.Int64(8080),
+					},
+				},
+			},
+			wantErr: errors.New("InvalidTarget: invalid target"),
+		},
 		{
 			name: "register targets and targets for TargetGroup already exists in cache",
 			fields: fields{
@@ -278,6 +404,7 @@ func Test_cachedTargetsManager_RegisterTargets(t *testing.T) {
 				targetsCache:             targetsCache,
 				targetsCacheTTL:          targetsCacheTTL,
 				registerTargetsChunkSize: 2,
+				registerTargetsBackoff:   wait.Backoff{Duration: 1 * time.Millisecond, Factor: 2, Steps: 3},
 				logger:                   log.Log,
 			}
 
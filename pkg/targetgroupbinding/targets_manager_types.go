@@ -24,6 +24,14 @@ func (t *TargetInfo) IsHealthy() bool {
 	return awssdk.StringValue(t.TargetHealth.State) == elbv2sdk.TargetHealthStateEnumHealthy
 }
 
+// IsUnhealthy returns whether target is unhealthy.
+func (t *TargetInfo) IsUnhealthy() bool {
+	if t.TargetHealth == nil {
+		return false
+	}
+	return awssdk.StringValue(t.TargetHealth.State) == elbv2sdk.TargetHealthStateEnumUnhealthy
+}
+
 // IsNotRegistered returns whether target is not registered.
 func (t *TargetInfo) IsNotRegistered() bool {
 	if t.TargetHealth == nil {
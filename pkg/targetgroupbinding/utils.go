@@ -6,6 +6,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
 )
 
 const (
@@ -23,12 +24,28 @@ func BuildTargetHealthPodConditionType(tgb *elbv2api.TargetGroupBinding) corev1.
 	return corev1.PodConditionType(fmt.Sprintf("%s/%s", TargetHealthPodConditionTypePrefix, tgb.Name))
 }
 
+// BuildTargetGroupARNs returns the ARNs of all TargetGroups bound by TargetGroupBinding, starting with spec.targetGroupARN.
+func BuildTargetGroupARNs(tgb *elbv2api.TargetGroupBinding) []string {
+	tgARNs := make([]string, 0, 1+len(tgb.Spec.AdditionalTargetGroupARNs))
+	tgARNs = append(tgARNs, tgb.Spec.TargetGroupARN)
+	tgARNs = append(tgARNs, tgb.Spec.AdditionalTargetGroupARNs...)
+	return tgARNs
+}
+
 // IndexFuncServiceRefName is IndexFunc for "ServiceReference" index.
 func IndexFuncServiceRefName(obj client.Object) []string {
 	tgb := obj.(*elbv2api.TargetGroupBinding)
 	return []string{tgb.Spec.ServiceRef.Name}
 }
 
+// buildHealthCheckGracePeriod returns the health check grace period configured for TargetGroupBinding.
+func buildHealthCheckGracePeriod(tgb *elbv2api.TargetGroupBinding) time.Duration {
+	if tgb.Spec.HealthCheckGracePeriodSeconds == nil {
+		return 0
+	}
+	return time.Duration(*tgb.Spec.HealthCheckGracePeriodSeconds) * time.Second
+}
+
 func buildServiceReferenceKey(tgb *elbv2api.TargetGroupBinding, svcRef elbv2api.ServiceReference) types.NamespacedName {
 	return types.NamespacedName{
 		Namespace: tgb.Namespace,
@@ -4,9 +4,11 @@ import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/aws-load-balancer-controller/test/framework"
+	awsresources "sigs.k8s.io/aws-load-balancer-controller/test/framework/resources/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/test/framework/utils"
 	"sort"
 	"strconv"
@@ -20,6 +22,7 @@ type TargetGroupHC struct {
 	Timeout            int64
 	HealthyThreshold   int64
 	UnhealthyThreshold int64
+	SuccessCodes       string
 }
 
 type LoadBalancerExpectation struct {
@@ -31,6 +34,10 @@ type LoadBalancerExpectation struct {
 	TargetGroups  map[string]string // target group port, protocol
 	NumTargets    int
 	TargetGroupHC *TargetGroupHC
+	// expected certificates for TLS/HTTPS listeners, the first one is the default certificate.
+	ExpectedCertARNs []string
+	// expected ALPN policy for TLS listeners.
+	ExpectedALPNPolicy []string
 }
 
 func verifyAWSLoadBalancerResources(ctx context.Context, f *framework.Framework, lbARN string, expected LoadBalancerExpectation) error {
@@ -42,6 +49,14 @@ func verifyAWSLoadBalancerResources(ctx context.Context, f *framework.Framework,
 	Expect(err).NotTo(HaveOccurred())
 	err = verifyLoadBalancerListeners(ctx, f, lbARN, expected.Listeners)
 	Expect(err).NotTo(HaveOccurred())
+	if len(expected.ExpectedCertARNs) != 0 {
+		err = verifyLoadBalancerListenerCertificates(ctx, f, lbARN, expected.ExpectedCertARNs)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	if len(expected.ExpectedALPNPolicy) != 0 {
+		err = verifyLoadBalancerListenerALPNPolicy(ctx, f, lbARN, expected.ExpectedALPNPolicy)
+		Expect(err).NotTo(HaveOccurred())
+	}
 	err = verifyLoadBalancerTargetGroups(ctx, f, lbARN, expected)
 	Expect(err).NotTo(HaveOccurred())
 	return nil
@@ -71,6 +86,14 @@ func verifyLoadBalancerAttributes(ctx context.Context, f *framework.Framework, l
 	return nil
 }
 
+// checkLoadBalancerAttributes waits until the load balancer attributes match the expected attributes.
+func checkLoadBalancerAttributes(ctx context.Context, f *framework.Framework, lbARN string, expectedAttrs map[string]string) error {
+	Eventually(func() error {
+		return verifyLoadBalancerAttributes(ctx, f, lbARN, expectedAttrs)
+	}, f.Options.PollTimeoutOrDefault(utils.PollTimeoutShort), f.Options.PollIntervalOrDefault(utils.PollIntervalMedium)).Should(Succeed())
+	return nil
+}
+
 func verifyLoadBalancerResourceTags(ctx context.Context, f *framework.Framework, lbARN string, expectedTags map[string]string,
 	unexpectedTags map[string]string) bool {
 	resARNs := []string{lbARN}
@@ -144,31 +167,61 @@ func verifyLoadBalancerListeners(ctx context.Context, f *framework.Framework, lb
 	return nil
 }
 
-func verifyLoadBalancerListenerCertificates(ctx context.Context, f *framework.Framework, lbARN string, expectedCertARNS []string) error {
+// verifyLoadBalancerListenerCertificates verifies the certificates on every TLS/HTTPS listener.
+// the default certificate must match expectedCertARNs[0], and the whole certificate set must match expectedCertARNs.
+func verifyLoadBalancerListenerCertificates(ctx context.Context, f *framework.Framework, lbARN string, expectedCertARNs []string) error {
 	listeners, err := f.LBManager.GetLoadBalancerListeners(ctx, lbARN)
 	Expect(err).ToNot(HaveOccurred())
 	Expect(len(listeners)).Should(BeNumerically(">", 0))
-	listenerCerts, err := f.LBManager.GetLoadBalancerListenerCertificates(ctx, awssdk.StringValue(listeners[0].ListenerArn))
-	Expect(err).ToNot(HaveOccurred())
+	Expect(len(expectedCertARNs)).Should(BeNumerically(">", 0))
 
-	var observedCertArns []string
-	var defaultCert string
-	for _, cert := range listenerCerts {
-		if awssdk.BoolValue(cert.IsDefault) {
-			defaultCert = awssdk.StringValue(cert.CertificateArn)
+	sortedExpectedCertARNs := append([]string(nil), expectedCertARNs...)
+	sort.Strings(sortedExpectedCertARNs)
+	for _, ls := range listeners {
+		protocol := awssdk.StringValue(ls.Protocol)
+		if protocol != elbv2sdk.ProtocolEnumTls && protocol != elbv2sdk.ProtocolEnumHttps {
+			continue
+		}
+		listenerCerts, err := f.LBManager.GetLoadBalancerListenerCertificates(ctx, awssdk.StringValue(ls.ListenerArn))
+		Expect(err).ToNot(HaveOccurred())
+
+		var observedCertARNs []string
+		var defaultCert string
+		for _, cert := range listenerCerts {
+			if awssdk.BoolValue(cert.IsDefault) {
+				defaultCert = awssdk.StringValue(cert.CertificateArn)
+			}
+			observedCertARNs = append(observedCertARNs, awssdk.StringValue(cert.CertificateArn))
+		}
+		if defaultCert != expectedCertARNs[0] {
+			return errors.Errorf("listener %v default cert mismatch, expected %v, actual %v",
+				awssdk.Int64Value(ls.Port), expectedCertARNs[0], defaultCert)
+		}
+		sort.Strings(observedCertARNs)
+		if !cmp.Equal(sortedExpectedCertARNs, observedCertARNs) {
+			return errors.Errorf("listener %v certs mismatch, expected %v, actual %v",
+				awssdk.Int64Value(ls.Port), sortedExpectedCertARNs, observedCertARNs)
 		}
-		observedCertArns = append(observedCertArns, awssdk.StringValue(cert.CertificateArn))
-	}
-	if defaultCert != expectedCertARNS[0] {
-		return errors.New("default cert does not match")
 	}
-	//Expect(defaultCert).To(Equal(expectedCertARNS[0]))
-	if len(expectedCertARNS) != len(observedCertArns) {
-		return errors.New("cert len mismatch")
+	return nil
+}
+
+// verifyLoadBalancerListenerALPNPolicy verifies the ALPN policy on every TLS listener.
+func verifyLoadBalancerListenerALPNPolicy(ctx context.Context, f *framework.Framework, lbARN string, expectedALPNPolicy []string) error {
+	listeners, err := f.LBManager.GetLoadBalancerListeners(ctx, lbARN)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(len(listeners)).Should(BeNumerically(">", 0))
+
+	for _, ls := range listeners {
+		if awssdk.StringValue(ls.Protocol) != elbv2sdk.ProtocolEnumTls {
+			continue
+		}
+		observedALPNPolicy := awssdk.StringValueSlice(ls.AlpnPolicy)
+		if !cmp.Equal(expectedALPNPolicy, observedALPNPolicy) {
+			return errors.Errorf("listener %v ALPN policy mismatch, expected %v, actual %v",
+				awssdk.Int64Value(ls.Port), expectedALPNPolicy, observedALPNPolicy)
+		}
 	}
-	sort.Strings(observedCertArns)
-	sort.Strings(expectedCertARNS)
-	Expect(expectedCertARNS).To(Equal(observedCertArns))
 	return nil
 }
 
@@ -196,6 +249,10 @@ func verifyTargetGroupHealthCheckConfig(tg *elbv2sdk.TargetGroup, hc *TargetGrou
 		Expect(awssdk.Int64Value(tg.HealthCheckTimeoutSeconds)).To(Equal(hc.Timeout))
 		Expect(awssdk.Int64Value(tg.HealthyThresholdCount)).To(Equal(hc.HealthyThreshold))
 		Expect(awssdk.Int64Value(tg.UnhealthyThresholdCount)).To(Equal(hc.UnhealthyThreshold))
+		if hc.SuccessCodes != "" {
+			Expect(tg.Matcher).NotTo(BeNil())
+			Expect(awssdk.StringValue(tg.Matcher.HttpCode)).To(Equal(hc.SuccessCodes))
+		}
 	}
 	return nil
 }
@@ -208,20 +265,40 @@ func verifyTargetGroupNumRegistered(ctx context.Context, f *framework.Framework,
 		numTargets, err := f.TGManager.GetCurrentTargetCount(ctx, tgARN)
 		Expect(err).ToNot(HaveOccurred())
 		return numTargets == expectedTargets
-	}, utils.PollTimeoutShort, utils.PollIntervalMedium).Should(BeTrue())
+	}, f.Options.PollTimeoutOrDefault(utils.PollTimeoutShort), f.Options.PollIntervalOrDefault(utils.PollIntervalMedium)).Should(BeTrue())
 	return nil
 }
 
 func waitUntilTargetsAreHealthy(ctx context.Context, f *framework.Framework, lbARN string, expectedTargetCount int) error {
+	if f.Options.IsAWSEmulated() {
+		f.Logger.Info("skip target health check under AWS emulation")
+		return nil
+	}
 	targetGroups, err := f.TGManager.GetTargetGroupsForLoadBalancer(ctx, lbARN)
 	Expect(err).ToNot(HaveOccurred())
 	Expect(len(targetGroups)).To(Not(BeZero()))
 	// Check the first target group
 	tgARN := awssdk.StringValue(targetGroups[0].TargetGroupArn)
 
-	Eventually(func() (bool, error) {
-		return f.TGManager.CheckTargetGroupHealthy(ctx, tgARN, expectedTargetCount)
-	}, utils.PollTimeoutLong, utils.PollIntervalLong).Should(BeTrue())
+	Eventually(func() error {
+		targetsHealth, err := f.TGManager.GetTargetGroupHealth(ctx, tgARN)
+		if err != nil {
+			return err
+		}
+		if len(targetsHealth) != expectedTargetCount {
+			return errors.Errorf("expected %v targets, actual %v: %v", expectedTargetCount, len(targetsHealth), targetsHealth)
+		}
+		var unhealthyTargets []awsresources.TargetHealth
+		for _, targetHealth := range targetsHealth {
+			if targetHealth.State != elbv2sdk.TargetHealthStateEnumHealthy {
+				unhealthyTargets = append(unhealthyTargets, targetHealth)
+			}
+		}
+		if len(unhealthyTargets) != 0 {
+			return errors.Errorf("unhealthy targets: %v", unhealthyTargets)
+		}
+		return nil
+	}, f.Options.PollTimeoutOrDefault(utils.PollTimeoutLong), f.Options.PollIntervalOrDefault(utils.PollIntervalLong)).Should(Succeed())
 	return nil
 }
 
@@ -231,6 +308,12 @@ func getTargetGroupHealthCheckProtocol(ctx context.Context, f *framework.Framewo
 	return awssdk.StringValue(targetGroups[0].HealthCheckProtocol)
 }
 
+func getTargetGroupHealthCheckPath(ctx context.Context, f *framework.Framework, lbARN string) string {
+	targetGroups, err := f.TGManager.GetTargetGroupsForLoadBalancer(ctx, lbARN)
+	Expect(err).ToNot(HaveOccurred())
+	return awssdk.StringValue(targetGroups[0].HealthCheckPath)
+}
+
 func verifyTargetGroupAttributes(ctx context.Context, f *framework.Framework, lbARN string, expectedAttributes map[string]string) bool {
 	targetGroups, err := f.TGManager.GetTargetGroupsForLoadBalancer(ctx, lbARN)
 	Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	acmsdk "github.com/aws/aws-sdk-go/service/acm"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/aws-load-balancer-controller/test/framework"
+	awsresources "sigs.k8s.io/aws-load-balancer-controller/test/framework/resources/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/test/framework/utils"
+	"strings"
+)
+
+// tlsCertCommonNames are the common names of self-signed certificates used by TLS listeners when --certificate-arns is unspecified.
+// the certificates are shared across specs to avoid importing a certificate per spec.
+var tlsCertCommonNames = []string{"tls-e2e-1.example.com", "tls-e2e-2.example.com"}
+
+// ensureTLSCertificateARNs returns the certificate ARNs for TLS listeners along with certificates imported into ACM.
+// certificates from --certificate-arns are used if specified, otherwise self-signed certificates are imported and
+// must be released by releaseTLSCertificates.
+func ensureTLSCertificateARNs(ctx context.Context, f *framework.Framework) ([]string, []string, error) {
+	if len(f.Options.CertificateARNs) != 0 {
+		return strings.Split(f.Options.CertificateARNs, ","), nil, nil
+	}
+	var importedCertARNs []string
+	for _, commonName := range tlsCertCommonNames {
+		certARN, err := f.CertManager.GenerateAndImportCertToACM(ctx, awsresources.CertificateConfig{
+			CommonName: commonName,
+			Shared:     true,
+		})
+		if err != nil {
+			return nil, importedCertARNs, err
+		}
+		importedCertARNs = append(importedCertARNs, certARN)
+	}
+	return importedCertARNs, importedCertARNs, nil
+}
+
+// releaseTLSCertificates releases certificates imported by ensureTLSCertificateARNs.
+// certificates remain in use for a while after the load balancer is deleted, thus the release is retried until timeout.
+func releaseTLSCertificates(ctx context.Context, f *framework.Framework, importedCertARNs []string) error {
+	for _, certARN := range importedCertARNs {
+		if err := wait.PollImmediate(utils.PollIntervalMedium, utils.PollTimeoutMedium, func() (bool, error) {
+			if err := f.CertManager.DeleteCertFromACM(ctx, certARN); err != nil {
+				if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == acmsdk.ErrCodeResourceInUseException {
+					return false, nil
+				}
+				return false, err
+			}
+			return true, nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
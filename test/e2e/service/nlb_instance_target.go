@@ -8,12 +8,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/test/framework"
+	k8sresources "sigs.k8s.io/aws-load-balancer-controller/test/framework/resources/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	defaultTestImage   = "kishorj/hello-multi:v1"
-	appContainerPort   = 80
+	defaultTestImage   = k8sresources.DefaultBackendImage
+	appContainerPort   = k8sresources.DefaultBackendContainerPort
 	defaultNumReplicas = 3
 	defaultName        = "instance-e2e"
 )
@@ -42,6 +43,10 @@ func (s *NLBInstanceTestStack) UpdateServiceTrafficPolicy(ctx context.Context, f
 	return s.resourceStack.UpdateServiceTrafficPolicy(ctx, f, trafficPolicy)
 }
 
+func (s *NLBInstanceTestStack) UpdateLoadBalancerAttributes(ctx context.Context, f *framework.Framework, lbAttributes map[string]string) error {
+	return s.resourceStack.UpdateLoadBalancerAttributes(ctx, f, lbAttributes)
+}
+
 func (s *NLBInstanceTestStack) ScaleDeployment(ctx context.Context, f *framework.Framework, numReplicas int32) error {
 	return s.resourceStack.ScaleDeployment(ctx, f, numReplicas)
 }
@@ -83,41 +88,16 @@ func (s *NLBInstanceTestStack) ApplyNodeLabels(ctx context.Context, f *framework
 }
 
 func (s *NLBInstanceTestStack) buildDeploymentSpec() *appsv1.Deployment {
-	numReplicas := int32(defaultNumReplicas)
-	labels := map[string]string{
-		"app.kubernetes.io/name":     "multi-port",
-		"app.kubernetes.io/instance": defaultName,
-	}
-	return &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: defaultName,
+	return k8sresources.BuildBackendDeployment(k8sresources.BackendConfig{
+		Name: defaultName,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":     "multi-port",
+			"app.kubernetes.io/instance": defaultName,
 		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &numReplicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: labels,
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:            "app",
-							ImagePullPolicy: corev1.PullAlways,
-							Image:           defaultTestImage,
-							Ports: []corev1.ContainerPort{
-								{
-									ContainerPort: appContainerPort,
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
+		Replicas:      defaultNumReplicas,
+		Image:         defaultTestImage,
+		ContainerPort: appContainerPort,
+	})
 }
 
 func (s *NLBInstanceTestStack) buildServiceSpec(ctx context.Context, annotations map[string]string) *corev1.Service {
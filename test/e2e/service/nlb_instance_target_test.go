@@ -18,14 +18,19 @@ var _ = Describe("test k8s service reconciled by the aws load balancer controlle
 		stack   NLBInstanceTestStack
 		dnsName string
 		lbARN   string
+
+		importedCertARNs []string
 	)
 	BeforeEach(func() {
 		ctx = context.Background()
 		stack = NLBInstanceTestStack{}
+		importedCertARNs = nil
 	})
 	AfterEach(func() {
 		err := stack.Cleanup(ctx, tf)
 		Expect(err).NotTo(HaveOccurred())
+		err = releaseTLSCertificates(ctx, tf, importedCertARNs)
+		Expect(err).NotTo(HaveOccurred())
 	})
 	Context("with NLB instance target configuration", func() {
 		It("should provision internet-facing load balancer resources", func() {
@@ -194,7 +199,7 @@ var _ = Describe("test k8s service reconciled by the aws load balancer controlle
 			})
 			By("specifying target group attributes annotation", func() {
 				err := stack.UpdateServiceAnnotations(ctx, tf, map[string]string{
-					"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": "preserve_client_ip.enabled=false, proxy_protocol_v2.enabled=true, deregistration_delay.timeout_seconds=120",
+					"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": "preserve_client_ip.enabled=false, proxy_protocol_v2.enabled=true, deregistration_delay.timeout_seconds=120, stickiness.enabled=true, stickiness.type=source_ip",
 				})
 				Expect(err).NotTo(HaveOccurred())
 
@@ -203,17 +208,22 @@ var _ = Describe("test k8s service reconciled by the aws load balancer controlle
 						"preserve_client_ip.enabled":           "false",
 						"proxy_protocol_v2.enabled":            "true",
 						"deregistration_delay.timeout_seconds": "120",
+						"stickiness.enabled":                   "true",
+						"stickiness.type":                      "source_ip",
 					})
 				}, utils.PollTimeoutShort, utils.PollIntervalMedium).Should(BeTrue())
 			})
 		})
 		It("should create TLS listeners", func() {
-			if len(tf.Options.CertificateARNs) == 0 {
-				Skip("Skipping tests, certificates not specified")
-			}
+			var certARNs []string
+			By("ensuring certificates", func() {
+				var err error
+				certARNs, importedCertARNs, err = ensureTLSCertificateARNs(ctx, tf)
+				Expect(err).NotTo(HaveOccurred())
+			})
 			By("deploying stack", func() {
 				err := stack.Deploy(ctx, tf, map[string]string{
-					"service.beta.kubernetes.io/aws-load-balancer-ssl-cert": tf.Options.CertificateARNs,
+					"service.beta.kubernetes.io/aws-load-balancer-ssl-cert": strings.Join(certARNs, ","),
 				})
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -246,17 +256,12 @@ var _ = Describe("test k8s service reconciled by the aws load balancer controlle
 						HealthyThreshold:   3,
 						UnhealthyThreshold: 3,
 					},
+					ExpectedCertARNs: certARNs,
 				})
 				Expect(err).NotTo(HaveOccurred())
 			})
-			By("verifying listener certificates", func() {
-				expectedARNs := strings.Split(tf.Options.CertificateARNs, ",")
-				Eventually(func() bool {
-					return verifyLoadBalancerListenerCertificates(ctx, tf, lbARN, expectedARNs) == nil
-				}, utils.PollTimeoutShort, utils.PollIntervalMedium).Should(BeTrue())
-			})
 			By("removing first certificate from annotation and updating the service", func() {
-				certs := strings.Split(tf.Options.CertificateARNs, ",")[1:]
+				certs := certARNs[1:]
 				if len(certs) == 0 {
 					return
 				}
@@ -268,6 +273,16 @@ var _ = Describe("test k8s service reconciled by the aws load balancer controlle
 					return verifyLoadBalancerListenerCertificates(ctx, tf, lbARN, certs) == nil
 				}, utils.PollTimeoutShort, utils.PollIntervalMedium).Should(BeTrue())
 			})
+			By("specifying TLS backend protocol and ALPN policy", func() {
+				err := stack.UpdateServiceAnnotations(ctx, tf, map[string]string{
+					"service.beta.kubernetes.io/aws-load-balancer-backend-protocol": "ssl",
+					"service.beta.kubernetes.io/aws-load-balancer-alpn-policy":      "HTTP2Preferred",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(func() bool {
+					return verifyLoadBalancerListenerALPNPolicy(ctx, tf, lbARN, []string{"HTTP2Preferred"}) == nil
+				}, utils.PollTimeoutShort, utils.PollIntervalMedium).Should(BeTrue())
+			})
 		})
 		It("should enable proxy protocol v2", func() {
 			By("deploying stack", func() {
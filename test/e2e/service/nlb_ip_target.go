@@ -6,10 +6,10 @@ import (
 	"fmt"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"net/http"
 	"sigs.k8s.io/aws-load-balancer-controller/test/framework"
 	"sigs.k8s.io/aws-load-balancer-controller/test/framework/utils"
-	"time"
 )
 
 const (
@@ -29,6 +29,14 @@ func (s *NLBIPTestStack) UpdateServiceAnnotations(ctx context.Context, f *framew
 	return s.resourceStack.UpdateServiceAnnotations(ctx, f, svcAnnotations)
 }
 
+func (s *NLBIPTestStack) DeleteServiceAnnotations(ctx context.Context, f *framework.Framework, annotationKeys []string) error {
+	return s.resourceStack.DeleteServiceAnnotations(ctx, f, annotationKeys)
+}
+
+func (s *NLBIPTestStack) UpdateLoadBalancerAttributes(ctx context.Context, f *framework.Framework, lbAttributes map[string]string) error {
+	return s.resourceStack.UpdateLoadBalancerAttributes(ctx, f, lbAttributes)
+}
+
 func (s *NLBIPTestStack) ScaleDeployment(ctx context.Context, f *framework.Framework, numReplicas int32) error {
 	return s.resourceStack.ScaleDeployment(ctx, f, numReplicas)
 }
@@ -42,6 +50,10 @@ func (s *NLBIPTestStack) GetLoadBalancerIngressHostName() string {
 }
 
 func (s *NLBIPTestStack) SendTrafficToLB(ctx context.Context, f *framework.Framework) error {
+	if f.Options.IsAWSEmulated() {
+		f.Logger.Info("skip sending traffic to load balancer under AWS emulation")
+		return nil
+	}
 	httpClient := http.Client{Timeout: utils.PollIntervalMedium}
 	protocol := "http"
 	if s.listenerTLS() {
@@ -54,25 +66,19 @@ func (s *NLBIPTestStack) SendTrafficToLB(ctx context.Context, f *framework.Frame
 	}
 	// Choose the first port for now, TODO: verify all listeners
 	port := s.resourceStack.svc.Spec.Ports[0].Port
-	noerr := false
-	for i := 0; i < 10; i++ {
+	pollInterval := f.Options.PollIntervalOrDefault(2 * utils.PollIntervalLong)
+	pollTimeout := f.Options.PollTimeoutOrDefault(10 * 2 * utils.PollIntervalLong)
+	return wait.PollImmediate(pollInterval, pollTimeout, func() (bool, error) {
 		resp, err := httpClient.Get(fmt.Sprintf("%s://%s:%v/from-tls-client", protocol, s.GetLoadBalancerIngressHostName(), port))
 		if err != nil {
-			time.Sleep(2 * utils.PollIntervalLong)
-			continue
+			return false, nil
 		}
-		noerr = true
+		defer resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("Unexpected HTTP status code %v", resp.StatusCode)
-		}
-		if resp.StatusCode == http.StatusOK {
-			break
+			return false, fmt.Errorf("Unexpected HTTP status code %v", resp.StatusCode)
 		}
-	}
-	if noerr {
-		return nil
-	}
-	return fmt.Errorf("Unsuccessful after 10 retries")
+		return true, nil
+	})
 }
 
 func (s *NLBIPTestStack) listenerTLS() bool {
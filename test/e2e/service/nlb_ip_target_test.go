@@ -8,7 +8,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	k8sresources "sigs.k8s.io/aws-load-balancer-controller/test/framework/resources/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/test/framework/utils"
+	"strings"
 )
 
 var _ = Describe("k8s service reconciled by the aws load balancer", func() {
@@ -21,52 +23,34 @@ var _ = Describe("k8s service reconciled by the aws load balancer", func() {
 		lbARN       string
 		labels      map[string]string
 		stack       NLBIPTestStack
+
+		importedCertARNs []string
 	)
 
 	BeforeEach(func() {
 		ctx = context.Background()
 		numReplicas = 3
 		stack = NLBIPTestStack{}
+		importedCertARNs = nil
 		name = "ip-e2e"
 		labels = map[string]string{
 			"app.kubernetes.io/name":     "multi-port",
 			"app.kubernetes.io/instance": name,
 		}
-		deployment = &appsv1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: name,
-			},
-			Spec: appsv1.DeploymentSpec{
-				Replicas: &numReplicas,
-				Selector: &metav1.LabelSelector{
-					MatchLabels: labels,
-				},
-				Template: corev1.PodTemplateSpec{
-					ObjectMeta: metav1.ObjectMeta{
-						Labels: labels,
-					},
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name:            "app",
-								ImagePullPolicy: corev1.PullAlways,
-								Image:           defaultTestImage,
-								Ports: []corev1.ContainerPort{
-									{
-										ContainerPort: appContainerPort,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		}
+		deployment = k8sresources.BuildBackendDeployment(k8sresources.BackendConfig{
+			Name:          name,
+			Labels:        labels,
+			Replicas:      numReplicas,
+			Image:         defaultTestImage,
+			ContainerPort: appContainerPort,
+		})
 	})
 
 	AfterEach(func() {
 		err := stack.Cleanup(ctx, tf)
 		Expect(err).NotTo(HaveOccurred())
+		err = releaseTLSCertificates(ctx, tf, importedCertARNs)
+		Expect(err).NotTo(HaveOccurred())
 	})
 
 	Context("NLB with IP target configuration", func() {
@@ -151,6 +135,7 @@ var _ = Describe("k8s service reconciled by the aws load balancer", func() {
 					"service.beta.kubernetes.io/aws-load-balancer-healthcheck-timeout":             "6",
 					"service.beta.kubernetes.io/aws-load-balancer-healthcheck-healthy-threshold":   "2",
 					"service.beta.kubernetes.io/aws-load-balancer-healthcheck-unhealthy-threshold": "2",
+					"service.beta.kubernetes.io/aws-load-balancer-healthcheck-success-codes":       "200-399",
 				})
 				Expect(err).ToNot(HaveOccurred())
 
@@ -177,10 +162,54 @@ var _ = Describe("k8s service reconciled by the aws load balancer", func() {
 						Timeout:            6,
 						HealthyThreshold:   2,
 						UnhealthyThreshold: 2,
+						SuccessCodes:       "200-399",
 					},
 				})
 				Expect(err).ToNot(HaveOccurred())
 			})
+			By("Removing Healthcheck path annotation", func() {
+				err := stack.DeleteServiceAnnotations(ctx, tf, []string{"service.beta.kubernetes.io/aws-load-balancer-healthcheck-path"})
+				Expect(err).ToNot(HaveOccurred())
+
+				// the controller is deployed with the default service-default-healthcheck-path flag
+				Eventually(func() bool {
+					return getTargetGroupHealthCheckPath(ctx, tf, lbARN) == "/"
+				}, utils.PollTimeoutShort, utils.PollIntervalMedium).Should(BeTrue())
+
+				err = verifyAWSLoadBalancerResources(ctx, tf, lbARN, LoadBalancerExpectation{
+					Type:       "network",
+					Scheme:     "internet-facing",
+					TargetType: "ip",
+					Listeners: map[string]string{
+						"80": "TCP",
+					},
+					TargetGroups: map[string]string{
+						"80": "TCP",
+					},
+					NumTargets: int(numReplicas),
+					TargetGroupHC: &TargetGroupHC{
+						Protocol:           "HTTP",
+						Port:               "80",
+						Path:               "/",
+						Interval:           30,
+						Timeout:            6,
+						HealthyThreshold:   2,
+						UnhealthyThreshold: 2,
+						SuccessCodes:       "200-399",
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+			By("Specifying load balancer attributes", func() {
+				lbAttributes := map[string]string{
+					"load_balancing.cross_zone.enabled": "true",
+					"deletion_protection.enabled":       "false",
+				}
+				err := stack.UpdateLoadBalancerAttributes(ctx, tf, lbAttributes)
+				Expect(err).ToNot(HaveOccurred())
+				err = checkLoadBalancerAttributes(ctx, tf, lbARN, lbAttributes)
+				Expect(err).ToNot(HaveOccurred())
+			})
 		})
 	})
 
@@ -189,13 +218,17 @@ var _ = Describe("k8s service reconciled by the aws load balancer", func() {
 			svc *corev1.Service
 		)
 		BeforeEach(func() {
+			var certARNs []string
+			var err error
+			certARNs, importedCertARNs, err = ensureTLSCertificateARNs(ctx, tf)
+			Expect(err).NotTo(HaveOccurred())
 			svc = &corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: name + "-tls",
 					Annotations: map[string]string{
 						"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb-ip",
 						"service.beta.kubernetes.io/aws-load-balancer-scheme":   "internet-facing",
-						"service.beta.kubernetes.io/aws-load-balancer-ssl-cert": tf.Options.CertificateARNs,
+						"service.beta.kubernetes.io/aws-load-balancer-ssl-cert": strings.Join(certARNs, ","),
 					},
 				},
 				Spec: corev1.ServiceSpec{
@@ -213,9 +246,6 @@ var _ = Describe("k8s service reconciled by the aws load balancer", func() {
 			}
 		})
 		It("Should create TLS listeners", func() {
-			if len(tf.Options.CertificateARNs) == 0 {
-				Skip("Skipping tests, certificates not specified")
-			}
 			By("deploying stack", func() {
 				err := stack.Deploy(ctx, tf, svc, deployment)
 				Expect(err).NotTo(HaveOccurred())
@@ -342,6 +372,154 @@ var _ = Describe("k8s service reconciled by the aws load balancer", func() {
 				Expect(dnsName).ToNot(BeEmpty())
 			})
 
+			By("querying AWS loadbalancer from the dns name", func() {
+				var err error
+				lbARN, err = tf.LBManager.FindLoadBalancerByDNSName(ctx, dnsName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lbARN).ToNot(BeEmpty())
+			})
+			By("Verify Service with AWS", func() {
+				err := verifyAWSLoadBalancerResources(ctx, tf, lbARN, LoadBalancerExpectation{
+					Name:       lbName,
+					Type:       "network",
+					Scheme:     "internet-facing",
+					TargetType: "ip",
+					Listeners: map[string]string{
+						"80": "TCP",
+					},
+					TargetGroups: map[string]string{
+						"80": "TCP",
+					},
+					NumTargets: int(numReplicas),
+					TargetGroupHC: &TargetGroupHC{
+						Protocol:           "TCP",
+						Port:               "traffic-port",
+						Interval:           10,
+						Timeout:            10,
+						HealthyThreshold:   3,
+						UnhealthyThreshold: 3,
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+	Context("NLB with external type and IP target configuration", func() {
+		var (
+			svc *corev1.Service
+		)
+		BeforeEach(func() {
+			svc = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":            "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "ip",
+						"service.beta.kubernetes.io/aws-load-balancer-scheme":          "internet-facing",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:     corev1.ServiceTypeLoadBalancer,
+					Selector: labels,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       80,
+							TargetPort: intstr.FromInt(80),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+				},
+			}
+		})
+		It("Should create and verify internet-facing NLB with IP targets", func() {
+			By("deploying stack", func() {
+				err := stack.Deploy(ctx, tf, svc, deployment)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			By("checking service status for lb dns name", func() {
+				dnsName = stack.GetLoadBalancerIngressHostName()
+				Expect(dnsName).ToNot(BeEmpty())
+			})
+
+			By("querying AWS loadbalancer from the dns name", func() {
+				var err error
+				lbARN, err = tf.LBManager.FindLoadBalancerByDNSName(ctx, dnsName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lbARN).ToNot(BeEmpty())
+			})
+			By("Verify Service with AWS", func() {
+				err := verifyAWSLoadBalancerResources(ctx, tf, lbARN, LoadBalancerExpectation{
+					Type:       "network",
+					Scheme:     "internet-facing",
+					TargetType: "ip",
+					Listeners: map[string]string{
+						"80": "TCP",
+					},
+					TargetGroups: map[string]string{
+						"80": "TCP",
+					},
+					NumTargets: int(numReplicas),
+					TargetGroupHC: &TargetGroupHC{
+						Protocol:           "TCP",
+						Port:               "traffic-port",
+						Interval:           10,
+						Timeout:            10,
+						HealthyThreshold:   3,
+						UnhealthyThreshold: 3,
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+			By("waiting for target group targets to be healthy", func() {
+				err := waitUntilTargetsAreHealthy(ctx, tf, lbARN, int(numReplicas))
+				Expect(err).NotTo(HaveOccurred())
+			})
+			By("Send traffic to LB", func() {
+				err := stack.SendTrafficToLB(ctx, tf)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+	Context("NLB with external type and IP target Load Balancer with name", func() {
+		var (
+			svc    *corev1.Service
+			lbName string
+		)
+		BeforeEach(func() {
+			lbName = utils.RandomDNS1123Label(20)
+			svc = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-name":            lbName,
+						"service.beta.kubernetes.io/aws-load-balancer-type":            "external",
+						"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "ip",
+						"service.beta.kubernetes.io/aws-load-balancer-scheme":          "internet-facing",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:     corev1.ServiceTypeLoadBalancer,
+					Selector: labels,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       80,
+							TargetPort: intstr.FromInt(80),
+							Protocol:   corev1.ProtocolTCP,
+						},
+					},
+				},
+			}
+		})
+		It("Should create and verify service", func() {
+			By("deploying stack", func() {
+				err := stack.Deploy(ctx, tf, svc, deployment)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			By("checking service status for lb dns name", func() {
+				dnsName = stack.GetLoadBalancerIngressHostName()
+				Expect(dnsName).ToNot(BeEmpty())
+			})
+
 			By("querying AWS loadbalancer from the dns name", func() {
 				var err error
 				lbARN, err = tf.LBManager.FindLoadBalancerByDNSName(ctx, dnsName)
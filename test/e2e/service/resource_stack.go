@@ -3,13 +3,19 @@ package service
 import (
 	"context"
 	"fmt"
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/test/framework"
+	awsresources "sigs.k8s.io/aws-load-balancer-controller/test/framework/resources/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/test/framework/utils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 func NewResourceStack(dp *appsv1.Deployment, svc *corev1.Service, baseName string, enablePodReadinessGate bool) *resourceStack {
@@ -66,6 +72,11 @@ func (s *resourceStack) UpdateServiceAnnotations(ctx context.Context, f *framewo
 	return nil
 }
 
+// UpdateLoadBalancerAttributes sets the load balancer attributes annotation of service to the specified attributes.
+func (s *resourceStack) UpdateLoadBalancerAttributes(ctx context.Context, f *framework.Framework, lbAttributes map[string]string) error {
+	return s.UpdateServiceAnnotations(ctx, f, buildLoadBalancerAttributesAnnotation(lbAttributes))
+}
+
 func (s *resourceStack) DeleteServiceAnnotations(ctx context.Context, f *framework.Framework, annotationKeys []string) error {
 	if err := s.removeServiceAnnotations(ctx, f, annotationKeys); err != nil {
 		return err
@@ -107,12 +118,54 @@ func (s *resourceStack) Cleanup(ctx context.Context, f *framework.Framework) err
 	if err := s.deleteService(ctx, f); err != nil {
 		return err
 	}
+	if err := s.CheckAwsResourcesDeleted(ctx, f); err != nil {
+		return err
+	}
 	if err := s.deleteNamespace(ctx, f); err != nil {
 		return err
 	}
 	return nil
 }
 
+// CheckAwsResourcesDeleted polls until no LoadBalancer or TargetGroup tagged for this stack remains.
+func (s *resourceStack) CheckAwsResourcesDeleted(ctx context.Context, f *framework.Framework) error {
+	stackTags := map[string]string{
+		"elbv2.k8s.aws/cluster": f.Options.ClusterName,
+		"service.k8s.aws/stack": s.GetStackName(),
+	}
+	resourceTypes := []string{awsresources.ResourceTypeELBLoadBalancer, awsresources.ResourceTypeELBTargetGroup}
+	f.Logger.Info("waiting until AWS resources are deleted", "stack", s.GetStackName())
+	var leakedResARNs []string
+	err := wait.PollImmediate(f.Options.PollIntervalOrDefault(utils.PollIntervalMedium), f.Options.PollTimeoutOrDefault(utils.PollTimeoutMedium), func() (bool, error) {
+		resARNs, err := f.RTManager.GetResourceARNsWithTags(ctx, stackTags, resourceTypes)
+		if err != nil {
+			return false, err
+		}
+		leakedResARNs = resARNs
+		return len(resARNs) == 0, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return errors.Errorf("AWS resources for stack %v are not deleted: %v", s.GetStackName(), leakedResARNs)
+	}
+	return err
+}
+
+// buildLoadBalancerAttributesAnnotation builds the load balancer attributes annotation for specified attributes.
+func buildLoadBalancerAttributesAnnotation(lbAttributes map[string]string) map[string]string {
+	attrKeys := make([]string, 0, len(lbAttributes))
+	for key := range lbAttributes {
+		attrKeys = append(attrKeys, key)
+	}
+	sort.Strings(attrKeys)
+	attrPairs := make([]string, 0, len(attrKeys))
+	for _, key := range attrKeys {
+		attrPairs = append(attrPairs, fmt.Sprintf("%v=%v", key, lbAttributes[key]))
+	}
+	return map[string]string{
+		"service.beta.kubernetes.io/aws-load-balancer-attributes": strings.Join(attrPairs, ","),
+	}
+}
+
 func (s *resourceStack) GetLoadBalancerIngressHostname() string {
 	return s.createdSVC.Status.LoadBalancer.Ingress[0].Hostname
 }
@@ -209,7 +262,8 @@ func (s *resourceStack) waitUntilServiceReady(ctx context.Context, f *framework.
 	f.Logger.Info("waiting until service becomes ready", "svc", k8s.NamespacedName(s.svc))
 	observedSVC, err := f.SVCManager.WaitUntilServiceActive(ctx, s.svc)
 	if err != nil {
-		f.Logger.Info("failed waiting for service")
+		f.Logger.Info("failed waiting for service", "svc", k8s.NamespacedName(s.svc))
+		return err
 	}
 	s.createdSVC = observedSVC
 	return nil
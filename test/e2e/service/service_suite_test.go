@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"sigs.k8s.io/aws-load-balancer-controller/test/framework"
+	"sigs.k8s.io/aws-load-balancer-controller/test/framework/controller"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
@@ -10,6 +12,9 @@ import (
 
 var tf *framework.Framework
 
+// reconcile errors scraped before each spec, nil if metrics are unavailable.
+var reconcileErrorsBeforeSpec map[string]float64
+
 func TestService(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Service Suite")
@@ -20,3 +25,25 @@ var _ = BeforeSuite(func() {
 	tf, err = framework.InitFramework()
 	Expect(err).NotTo(HaveOccurred())
 })
+
+var _ = BeforeEach(func() {
+	reconcileErrors, err := tf.CTRLMetricsScraper.ScrapeReconcileErrors(context.Background())
+	if err != nil {
+		tf.Logger.Info("skip reconcile errors check, failed to scrape controller metrics", "error", err.Error())
+		reconcileErrorsBeforeSpec = nil
+		return
+	}
+	reconcileErrorsBeforeSpec = reconcileErrors
+})
+
+var _ = AfterEach(func() {
+	if reconcileErrorsBeforeSpec == nil {
+		return
+	}
+	reconcileErrors, err := tf.CTRLMetricsScraper.ScrapeReconcileErrors(context.Background())
+	if err != nil {
+		tf.Logger.Info("skip reconcile errors check, failed to scrape controller metrics", "error", err.Error())
+		return
+	}
+	Expect(controller.DiffReconcileErrors(reconcileErrorsBeforeSpec, reconcileErrors)).To(BeEmpty(), "controller reconcile errors occurred during spec")
+})
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	metricReconcileErrors = "awslbc_reconcile_errors_total"
+	labelController       = "controller"
+	labelReason           = "reason"
+)
+
+// MetricsScraper is responsible for scraping metrics from controller pods.
+type MetricsScraper interface {
+	// ScrapeReconcileErrors returns the reconcile error counts summed across controller pods, keyed by "controller/reason".
+	ScrapeReconcileErrors(ctx context.Context) (map[string]float64, error)
+}
+
+// NewDefaultMetricsScraper constructs new defaultMetricsScraper.
+func NewDefaultMetricsScraper(clientSet kubernetes.Interface, logger logr.Logger) *defaultMetricsScraper {
+	return &defaultMetricsScraper{
+		clientSet:       clientSet,
+		namespace:       "kube-system",
+		podSelector:     "app.kubernetes.io/name=" + AWSLoadBalancerControllerHelmChart,
+		metricsPort:     "8080",
+		metricsEndpoint: "/metrics",
+		logger:          logger,
+	}
+}
+
+var _ MetricsScraper = &defaultMetricsScraper{}
+
+// default implementation for MetricsScraper, which scrapes controller pods via the apiserver proxy.
+type defaultMetricsScraper struct {
+	clientSet       kubernetes.Interface
+	namespace       string
+	podSelector     string
+	metricsPort     string
+	metricsEndpoint string
+	logger          logr.Logger
+}
+
+func (s *defaultMetricsScraper) ScrapeReconcileErrors(ctx context.Context) (map[string]float64, error) {
+	pods, err := s.clientSet.CoreV1().Pods(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: s.podSelector})
+	if err != nil {
+		return nil, err
+	}
+	reconcileErrors := make(map[string]float64)
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		rawMetrics, err := s.clientSet.CoreV1().Pods(s.namespace).
+			ProxyGet("http", pod.Name, s.metricsPort, s.metricsEndpoint, nil).DoRaw(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var parser expfmt.TextParser
+		metricFamilies, err := parser.TextToMetricFamilies(bytes.NewReader(rawMetrics))
+		if err != nil {
+			return nil, err
+		}
+		metricFamily, ok := metricFamilies[metricReconcileErrors]
+		if !ok {
+			continue
+		}
+		for _, metric := range metricFamily.Metric {
+			labels := make(map[string]string)
+			for _, label := range metric.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			key := fmt.Sprintf("%s/%s", labels[labelController], labels[labelReason])
+			reconcileErrors[key] += metric.GetCounter().GetValue()
+		}
+	}
+	return reconcileErrors, nil
+}
+
+// DiffReconcileErrors returns the reconcile errors that occurred between the before and after scrapes.
+func DiffReconcileErrors(before map[string]float64, after map[string]float64) map[string]float64 {
+	diff := make(map[string]float64)
+	for key, count := range after {
+		if delta := count - before[key]; delta > 0 {
+			diff[key] = delta
+		}
+	}
+	return diff
+}
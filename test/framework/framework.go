@@ -3,6 +3,7 @@ package framework
 import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
@@ -26,12 +27,15 @@ type Framework struct {
 	Cloud     aws.Cloud
 
 	CTRLInstallationManager controller.InstallationManager
+	CTRLMetricsScraper      controller.MetricsScraper
 	NSManager               k8sresources.NamespaceManager
 	DPManager               k8sresources.DeploymentManager
 	SVCManager              k8sresources.ServiceManager
 	INGManager              k8sresources.IngressManager
 	LBManager               awsresources.LoadBalancerManager
 	TGManager               awsresources.TargetGroupManager
+	RTManager               awsresources.ResourceTaggingManager
+	CertManager             awsresources.CertificateManager
 
 	HTTPVerifier http.Verifier
 
@@ -71,17 +75,29 @@ func InitFramework() (*Framework, error) {
 		return nil, err
 	}
 
+	clientSet, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	awsEndpoints, err := globalOptions.ParseAWSEndpoints()
+	if err != nil {
+		return nil, err
+	}
+	logger := utils.NewGinkgoLogger()
 	cloud, err := aws.NewCloud(aws.CloudConfig{
 		Region:         globalOptions.AWSRegion,
 		VpcID:          globalOptions.AWSVPCID,
 		MaxRetries:     3,
 		ThrottleConfig: throttle.NewDefaultServiceOperationsThrottleConfig(),
-	}, nil)
+		AWSEndpoints:   awsEndpoints,
+	}, globalOptions.ClusterName, nil, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	logger := utils.NewGinkgoLogger()
+	svcPollInterval := globalOptions.PollIntervalOrDefault(utils.PollIntervalShort)
+	svcPollTimeout := globalOptions.PollTimeoutOrDefault(utils.PollTimeoutLong)
 
 	f := &Framework{
 		Options:   globalOptions,
@@ -90,12 +106,15 @@ func InitFramework() (*Framework, error) {
 		Cloud:     cloud,
 
 		CTRLInstallationManager: buildControllerInstallationManager(globalOptions, logger),
+		CTRLMetricsScraper:      controller.NewDefaultMetricsScraper(clientSet, logger),
 		NSManager:               k8sresources.NewDefaultNamespaceManager(k8sClient, logger),
 		DPManager:               k8sresources.NewDefaultDeploymentManager(k8sClient, logger),
-		SVCManager:              k8sresources.NewDefaultServiceManager(k8sClient, logger),
+		SVCManager:              k8sresources.NewDefaultServiceManager(k8sClient, svcPollInterval, svcPollTimeout, logger),
 		INGManager:              k8sresources.NewDefaultIngressManager(k8sClient, logger),
 		LBManager:               awsresources.NewDefaultLoadBalancerManager(cloud.ELBV2(), logger),
 		TGManager:               awsresources.NewDefaultTargetGroupManager(cloud.ELBV2(), logger),
+		RTManager:               awsresources.NewDefaultResourceTaggingManager(cloud.RGT(), logger),
+		CertManager:             awsresources.NewDefaultCertificateManager(cloud.ACM(), globalOptions.AWSRegion, logger),
 
 		HTTPVerifier: http.NewDefaultVerifier(),
 
@@ -3,6 +3,8 @@ package framework
 import (
 	"flag"
 	"github.com/pkg/errors"
+	"strings"
+	"time"
 )
 
 var globalOptions Options
@@ -18,23 +20,51 @@ type Options struct {
 	AWSVPCID    string
 	KubeConfig  string
 
+	// Custom AWS endpoints in format of endpointsID1=url1,endpointsID2=url2, used to run against AWS emulators like localstack.
+	// credentials for the emulator are taken from the standard AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY environment variables.
+	AWSEndpoints string
+
 	// AWS Load Balancer Controller image. leave empty to use default one from helm chart.
 	ControllerImage string
 
 	// Additional parameters for e2e tests
 	S3BucketName    string
 	CertificateARNs string
+
+	// Poll interval and timeout for e2e waits. leave empty to use the default of each wait.
+	PollInterval time.Duration
+	PollTimeout  time.Duration
 }
 
 func (options *Options) BindFlags() {
 	flag.StringVar(&options.ClusterName, "cluster-name", "", `Kubernetes cluster name (required)`)
 	flag.StringVar(&options.AWSRegion, "aws-region", "", `AWS Region for the kubernetes cluster`)
 	flag.StringVar(&options.AWSVPCID, "aws-vpc-id", "", `AWS VPC ID for the kubernetes cluster`)
+	flag.StringVar(&options.AWSEndpoints, "aws-endpoints", "", `Custom AWS endpoints, format: endpointsID1=url1,endpointsID2=url2`)
 
 	flag.StringVar(&options.ControllerImage, "controller-image", "", `AWS Load Balancer Controller image`)
 
 	flag.StringVar(&options.S3BucketName, "s3-bucket-name", "", `S3 bucket to use for testing load balancer access logging feature`)
 	flag.StringVar(&options.CertificateARNs, "certificate-arns", "", `Certificate ARNs to use for TLS listeners`)
+
+	flag.DurationVar(&options.PollInterval, "poll-interval", 0, `Poll interval for e2e waits, overrides the default of each wait`)
+	flag.DurationVar(&options.PollTimeout, "poll-timeout", 0, `Poll timeout for e2e waits, overrides the default of each wait`)
+}
+
+// PollIntervalOrDefault returns the configured poll interval, or defaultInterval if not configured.
+func (options *Options) PollIntervalOrDefault(defaultInterval time.Duration) time.Duration {
+	if options.PollInterval > 0 {
+		return options.PollInterval
+	}
+	return defaultInterval
+}
+
+// PollTimeoutOrDefault returns the configured poll timeout, or defaultTimeout if not configured.
+func (options *Options) PollTimeoutOrDefault(defaultTimeout time.Duration) time.Duration {
+	if options.PollTimeout > 0 {
+		return options.PollTimeout
+	}
+	return defaultTimeout
 }
 
 func (options *Options) Validate() error {
@@ -50,9 +80,34 @@ func (options *Options) Validate() error {
 	if len(options.AWSVPCID) == 0 {
 		return errors.Errorf("%s must be set!", "aws-vpc-id")
 	}
+	if _, err := options.ParseAWSEndpoints(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// ParseAWSEndpoints parses the custom AWS endpoints keyed by endpointsID.
+func (options *Options) ParseAWSEndpoints() (map[string]string, error) {
+	if len(options.AWSEndpoints) == 0 {
+		return nil, nil
+	}
+	awsEndpoints := make(map[string]string)
+	for _, entry := range strings.Split(options.AWSEndpoints, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return nil, errors.Errorf("%s must be in format of endpointsID=url: %v", "aws-endpoints", entry)
+		}
+		awsEndpoints[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return awsEndpoints, nil
+}
+
+// IsAWSEmulated returns whether tests run against an AWS emulator instead of real AWS.
+// checks that rely on real data plane behavior, like target health and traffic to the load balancer, are skipped under emulation.
+func (options *Options) IsAWSEmulated() bool {
+	return len(options.AWSEndpoints) != 0
+}
+
 func (options *Options) rebindFlags() error {
 	// kubeconfig is already defined by controller-runtime. we rebind it to our KubeConfig variable.
 	kubeConfigFlag := flag.Lookup("kubeconfig")
@@ -0,0 +1,187 @@
+package aws
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	acmsdk "github.com/aws/aws-sdk-go/service/acm"
+	"github.com/go-logr/logr"
+	"math/big"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	certificateKeyBits  = 2048
+	certificateValidFor = 24 * time.Hour
+)
+
+// CertificateConfig is the configuration for self-signed certificates.
+type CertificateConfig struct {
+	// CommonName of the certificate.
+	CommonName string
+	// DNSNames are the SANs of the certificate, defaults to the wildcard ELB DNS name of the cluster region.
+	DNSNames []string
+	// Shared certificates are deduplicated by CommonName, concurrent requests with same CommonName reuse the imported certificate.
+	// the certificate is only deleted from ACM once every user has released it via DeleteCertFromACM.
+	Shared bool
+}
+
+// CertificateManager is responsible for ACM certificates.
+type CertificateManager interface {
+	// GenerateAndImportCertToACM generates a self-signed certificate and imports it into ACM, returns the certificate ARN.
+	GenerateAndImportCertToACM(ctx context.Context, cfg CertificateConfig) (string, error)
+	// DeleteCertFromACM deletes the certificate from ACM.
+	DeleteCertFromACM(ctx context.Context, certARN string) error
+}
+
+// NewDefaultCertificateManager constructs new defaultCertificateManager.
+func NewDefaultCertificateManager(acmClient services.ACM, region string, logger logr.Logger) *defaultCertificateManager {
+	return &defaultCertificateManager{
+		acmClient:        acmClient,
+		region:           region,
+		logger:           logger,
+		sharedCertsByCN:  make(map[string]*sharedCertificate),
+		sharedCertsByARN: make(map[string]*sharedCertificate),
+	}
+}
+
+// sharedCertificate is an imported certificate that is shared by multiple users.
+type sharedCertificate struct {
+	commonName string
+	arn        string
+	refCount   int
+}
+
+var _ CertificateManager = &defaultCertificateManager{}
+
+// default implementation for CertificateManager
+type defaultCertificateManager struct {
+	acmClient services.ACM
+	region    string
+	logger    logr.Logger
+
+	// sharedCertsMutex protects sharedCertsByCN and sharedCertsByARN.
+	sharedCertsMutex sync.Mutex
+	sharedCertsByCN  map[string]*sharedCertificate
+	sharedCertsByARN map[string]*sharedCertificate
+}
+
+func (m *defaultCertificateManager) GenerateAndImportCertToACM(ctx context.Context, cfg CertificateConfig) (string, error) {
+	if !cfg.Shared {
+		return m.generateAndImportCert(ctx, cfg)
+	}
+	m.sharedCertsMutex.Lock()
+	defer m.sharedCertsMutex.Unlock()
+	if sharedCert, exists := m.sharedCertsByCN[cfg.CommonName]; exists {
+		sharedCert.refCount++
+		return sharedCert.arn, nil
+	}
+	certARN, err := m.generateAndImportCert(ctx, cfg)
+	if err != nil {
+		return "", err
+	}
+	sharedCert := &sharedCertificate{
+		commonName: cfg.CommonName,
+		arn:        certARN,
+		refCount:   1,
+	}
+	m.sharedCertsByCN[cfg.CommonName] = sharedCert
+	m.sharedCertsByARN[certARN] = sharedCert
+	return certARN, nil
+}
+
+func (m *defaultCertificateManager) DeleteCertFromACM(ctx context.Context, certARN string) error {
+	m.sharedCertsMutex.Lock()
+	defer m.sharedCertsMutex.Unlock()
+	if sharedCert, exists := m.sharedCertsByARN[certARN]; exists {
+		if sharedCert.refCount > 1 {
+			sharedCert.refCount--
+			return nil
+		}
+		if err := m.deleteCert(ctx, certARN); err != nil {
+			return err
+		}
+		delete(m.sharedCertsByCN, sharedCert.commonName)
+		delete(m.sharedCertsByARN, certARN)
+		return nil
+	}
+	return m.deleteCert(ctx, certARN)
+}
+
+func (m *defaultCertificateManager) generateAndImportCert(ctx context.Context, cfg CertificateConfig) (string, error) {
+	dnsNames := cfg.DNSNames
+	if len(dnsNames) == 0 {
+		dnsNames = []string{buildELBWildcardDNSName(m.region)}
+	}
+	certPEM, keyPEM, err := generateSelfSignedCertificate(cfg.CommonName, dnsNames)
+	if err != nil {
+		return "", err
+	}
+	resp, err := m.acmClient.ImportCertificateWithContext(ctx, &acmsdk.ImportCertificateInput{
+		Certificate: certPEM,
+		PrivateKey:  keyPEM,
+	})
+	if err != nil {
+		return "", err
+	}
+	certARN := awssdk.StringValue(resp.CertificateArn)
+	m.logger.Info("imported certificate", "commonName", cfg.CommonName, "dnsNames", dnsNames, "arn", certARN)
+	return certARN, nil
+}
+
+func (m *defaultCertificateManager) deleteCert(ctx context.Context, certARN string) error {
+	if _, err := m.acmClient.DeleteCertificateWithContext(ctx, &acmsdk.DeleteCertificateInput{
+		CertificateArn: awssdk.String(certARN),
+	}); err != nil {
+		return err
+	}
+	m.logger.Info("deleted certificate", "arn", certARN)
+	return nil
+}
+
+// buildELBWildcardDNSName returns the wildcard DNS name that matches ELB DNS names in specified region.
+func buildELBWildcardDNSName(region string) string {
+	dnsSuffix := "amazonaws.com"
+	if strings.HasPrefix(region, "cn-") {
+		dnsSuffix = "amazonaws.com.cn"
+	}
+	return fmt.Sprintf("*.elb.%s.%s", region, dnsSuffix)
+}
+
+// generateSelfSignedCertificate generates PEM encoded self-signed certificate and private key.
+func generateSelfSignedCertificate(commonName string, dnsNames []string) ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, certificateKeyBits)
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	notBefore := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              dnsNames,
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(certificateValidFor),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM, nil
+}
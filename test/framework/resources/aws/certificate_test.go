@@ -0,0 +1,138 @@
+package aws
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	acmsdk "github.com/aws/aws-sdk-go/service/acm"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sync"
+	"testing"
+)
+
+// fakeACM records certificate imports and deletions.
+type fakeACM struct {
+	services.ACM
+
+	mutex          sync.Mutex
+	importedCerts  int
+	deletedCertARN []string
+}
+
+func (c *fakeACM) ImportCertificateWithContext(_ context.Context, _ *acmsdk.ImportCertificateInput, _ ...request.Option) (*acmsdk.ImportCertificateOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.importedCerts++
+	return &acmsdk.ImportCertificateOutput{
+		CertificateArn: awssdk.String(fmt.Sprintf("arn:aws:acm:us-west-2:123456789012:certificate/cert-%d", c.importedCerts)),
+	}, nil
+}
+
+func (c *fakeACM) DeleteCertificateWithContext(_ context.Context, input *acmsdk.DeleteCertificateInput, _ ...request.Option) (*acmsdk.DeleteCertificateOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.deletedCertARN = append(c.deletedCertARN, awssdk.StringValue(input.CertificateArn))
+	return &acmsdk.DeleteCertificateOutput{}, nil
+}
+
+func Test_buildELBWildcardDNSName(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{
+			name:   "us-west-2",
+			region: "us-west-2",
+			want:   "*.elb.us-west-2.amazonaws.com",
+		},
+		{
+			name:   "eu-central-1",
+			region: "eu-central-1",
+			want:   "*.elb.eu-central-1.amazonaws.com",
+		},
+		{
+			name:   "china region",
+			region: "cn-north-1",
+			want:   "*.elb.cn-north-1.amazonaws.com.cn",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildELBWildcardDNSName(tt.region)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_generateSelfSignedCertificate(t *testing.T) {
+	tests := []struct {
+		name       string
+		commonName string
+		dnsNames   []string
+	}{
+		{
+			name:       "SAN for configured region",
+			commonName: "e2e.example.com",
+			dnsNames:   []string{buildELBWildcardDNSName("ap-southeast-1")},
+		},
+		{
+			name:       "multiple SANs",
+			commonName: "e2e.example.com",
+			dnsNames:   []string{"e2e.example.com", "*.elb.us-east-1.amazonaws.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certPEM, keyPEM, err := generateSelfSignedCertificate(tt.commonName, tt.dnsNames)
+			assert.NoError(t, err)
+			keyBlock, _ := pem.Decode(keyPEM)
+			assert.NotNil(t, keyBlock)
+			certBlock, _ := pem.Decode(certPEM)
+			assert.NotNil(t, certBlock)
+			cert, err := x509.ParseCertificate(certBlock.Bytes)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.commonName, cert.Subject.CommonName)
+			assert.Equal(t, tt.dnsNames, cert.DNSNames)
+		})
+	}
+}
+
+func Test_defaultCertificateManager_sharedCertificates(t *testing.T) {
+	acmClient := &fakeACM{}
+	m := NewDefaultCertificateManager(acmClient, "us-west-2", &log.NullLogger{})
+	cfg := CertificateConfig{
+		CommonName: "e2e.example.com",
+		Shared:     true,
+	}
+
+	certARNs := make([]string, 2)
+	var wg sync.WaitGroup
+	for i := range certARNs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			certARN, err := m.GenerateAndImportCertToACM(context.Background(), cfg)
+			assert.NoError(t, err)
+			certARNs[i] = certARN
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, acmClient.importedCerts)
+	assert.Equal(t, certARNs[0], certARNs[1])
+
+	assert.NoError(t, m.DeleteCertFromACM(context.Background(), certARNs[0]))
+	assert.Empty(t, acmClient.deletedCertARN)
+	assert.NoError(t, m.DeleteCertFromACM(context.Background(), certARNs[1]))
+	assert.Equal(t, []string{certARNs[0]}, acmClient.deletedCertARN)
+
+	certARN, err := m.GenerateAndImportCertToACM(context.Background(), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, acmClient.importedCerts)
+	assert.NotEqual(t, certARNs[0], certARN)
+}
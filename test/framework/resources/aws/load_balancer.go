@@ -73,13 +73,9 @@ func (m *defaultLoadBalancerManager) GetLoadBalancerFromARN(ctx context.Context,
 }
 
 func (m *defaultLoadBalancerManager) GetLoadBalancerListeners(ctx context.Context, lbARN string) ([]*elbv2sdk.Listener, error) {
-	listeners, err := m.elbv2Client.DescribeListenersWithContext(ctx, &elbv2sdk.DescribeListenersInput{
+	return m.elbv2Client.DescribeListenersAsList(ctx, &elbv2sdk.DescribeListenersInput{
 		LoadBalancerArn: awssdk.String(lbARN),
 	})
-	if err != nil {
-		return nil, err
-	}
-	return listeners.Listeners, nil
 }
 
 func (m *defaultLoadBalancerManager) GetLoadBalancerListenerCertificates(ctx context.Context, listnerARN string) ([]*elbv2sdk.Certificate, error) {
@@ -109,11 +105,7 @@ func (m *defaultLoadBalancerManager) GetLoadBalancerResourceTags(ctx context.Con
 }
 
 func (m *defaultLoadBalancerManager) GetLoadBalancerListenerRules(ctx context.Context, lsARN string) ([]*elbv2sdk.Rule, error) {
-	listenersRules, err := m.elbv2Client.DescribeRulesWithContext(ctx, &elbv2sdk.DescribeRulesInput{
+	return m.elbv2Client.DescribeRulesAsList(ctx, &elbv2sdk.DescribeRulesInput{
 		ListenerArn: awssdk.String(lsARN),
 	})
-	if err != nil {
-		return nil, err
-	}
-	return listenersRules.Rules, nil
 }
@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	rgtsdk "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	ResourceTypeELBLoadBalancer = "elasticloadbalancing:loadbalancer"
+	ResourceTypeELBTargetGroup  = "elasticloadbalancing:targetgroup"
+)
+
+// ResourceTaggingManager is responsible for querying AWS resources by tags.
+type ResourceTaggingManager interface {
+	// GetResourceARNsWithTags returns the ARNs of resources of resourceTypes that have all specified tags.
+	GetResourceARNsWithTags(ctx context.Context, tags map[string]string, resourceTypes []string) ([]string, error)
+}
+
+// NewDefaultResourceTaggingManager constructs new defaultResourceTaggingManager.
+func NewDefaultResourceTaggingManager(rgtClient services.RGT, logger logr.Logger) *defaultResourceTaggingManager {
+	return &defaultResourceTaggingManager{
+		rgtClient: rgtClient,
+		logger:    logger,
+	}
+}
+
+var _ ResourceTaggingManager = &defaultResourceTaggingManager{}
+
+// default implementation for ResourceTaggingManager
+type defaultResourceTaggingManager struct {
+	rgtClient services.RGT
+	logger    logr.Logger
+}
+
+func (m *defaultResourceTaggingManager) GetResourceARNsWithTags(ctx context.Context, tags map[string]string, resourceTypes []string) ([]string, error) {
+	var tagFilters []*rgtsdk.TagFilter
+	for key, value := range tags {
+		tagFilters = append(tagFilters, &rgtsdk.TagFilter{
+			Key:    awssdk.String(key),
+			Values: awssdk.StringSlice([]string{value}),
+		})
+	}
+	req := &rgtsdk.GetResourcesInput{
+		TagFilters:          tagFilters,
+		ResourceTypeFilters: awssdk.StringSlice(resourceTypes),
+	}
+	mappings, err := m.rgtClient.GetResourcesAsList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resARNs := make([]string, 0, len(mappings))
+	for _, mapping := range mappings {
+		resARNs = append(resARNs, awssdk.StringValue(mapping.ResourceARN))
+	}
+	return resARNs, nil
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
@@ -12,10 +13,27 @@ import (
 type TargetGroupManager interface {
 	GetTargetGroupsForLoadBalancer(ctx context.Context, lbARN string) ([]*elbv2sdk.TargetGroup, error)
 	CheckTargetGroupHealthy(ctx context.Context, tgARN string, expectedTargetCount int) (bool, error)
+	GetTargetGroupHealth(ctx context.Context, tgARN string) ([]TargetHealth, error)
 	GetCurrentTargetCount(ctx context.Context, tgARN string) (int, error)
 	GetTargetGroupAttributes(ctx context.Context, tgARN string) ([]*elbv2sdk.TargetGroupAttribute, error)
 }
 
+// TargetHealth is the observed health of a single target within a target group.
+type TargetHealth struct {
+	TargetID    string
+	Port        int64
+	State       string
+	Reason      string
+	Description string
+}
+
+func (h TargetHealth) String() string {
+	if len(h.Reason) == 0 {
+		return fmt.Sprintf("%v:%v(%v)", h.TargetID, h.Port, h.State)
+	}
+	return fmt.Sprintf("%v:%v(%v, %v: %v)", h.TargetID, h.Port, h.State, h.Reason, h.Description)
+}
+
 // NewDefaultTargetGroupManager constructs new defaultTargetGroupManager.
 func NewDefaultTargetGroupManager(elbv2Client services.ELBV2, logger logr.Logger) *defaultTargetGroupManager {
 	return &defaultTargetGroupManager{
@@ -34,13 +52,9 @@ type defaultTargetGroupManager struct {
 
 // GetTargetGroupsForLoadBalancer returns all targetgroups configured for the load balancer
 func (m *defaultTargetGroupManager) GetTargetGroupsForLoadBalancer(ctx context.Context, lbARN string) ([]*elbv2sdk.TargetGroup, error) {
-	targetGroups, err := m.elbv2Client.DescribeTargetGroupsWithContext(ctx, &elbv2sdk.DescribeTargetGroupsInput{
+	return m.elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{
 		LoadBalancerArn: awssdk.String(lbARN),
 	})
-	if err != nil {
-		return nil, err
-	}
-	return targetGroups.TargetGroups, nil
 }
 
 // GetCurrentTargetCount returns the count of all the targets in the target group that are currently in initial, healthy or unhealthy state
@@ -75,19 +89,38 @@ func (m *defaultTargetGroupManager) GetTargetGroupAttributes(ctx context.Context
 
 // CheckTargetGroupHealthy returns true only if all of the targets in the target group are in healthy state
 func (m *defaultTargetGroupManager) CheckTargetGroupHealthy(ctx context.Context, tgARN string, expectedTargetCount int) (bool, error) {
-	resp, err := m.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
-		TargetGroupArn: awssdk.String(tgARN),
-	})
+	targetsHealth, err := m.GetTargetGroupHealth(ctx, tgARN)
 	if err != nil {
 		return false, err
 	}
-	if len(resp.TargetHealthDescriptions) != expectedTargetCount {
+	if len(targetsHealth) != expectedTargetCount {
 		return false, nil
 	}
-	for _, thd := range resp.TargetHealthDescriptions {
-		if awssdk.StringValue(thd.TargetHealth.State) != elbv2sdk.TargetHealthStateEnumHealthy {
+	for _, targetHealth := range targetsHealth {
+		if targetHealth.State != elbv2sdk.TargetHealthStateEnumHealthy {
 			return false, nil
 		}
 	}
 	return true, nil
 }
+
+// GetTargetGroupHealth returns the health state and reason of every target in the target group
+func (m *defaultTargetGroupManager) GetTargetGroupHealth(ctx context.Context, tgARN string) ([]TargetHealth, error) {
+	resp, err := m.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	})
+	if err != nil {
+		return nil, err
+	}
+	targetsHealth := make([]TargetHealth, 0, len(resp.TargetHealthDescriptions))
+	for _, thd := range resp.TargetHealthDescriptions {
+		targetsHealth = append(targetsHealth, TargetHealth{
+			TargetID:    awssdk.StringValue(thd.Target.Id),
+			Port:        awssdk.Int64Value(thd.Target.Port),
+			State:       awssdk.StringValue(thd.TargetHealth.State),
+			Reason:      awssdk.StringValue(thd.TargetHealth.Reason),
+			Description: awssdk.StringValue(thd.TargetHealth.Description),
+		})
+	}
+	return targetsHealth, nil
+}
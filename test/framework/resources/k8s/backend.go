@@ -0,0 +1,73 @@
+package k8s
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultBackendImage is an echo server that responds 200 on every path of every container port.
+	DefaultBackendImage = "kishorj/hello-multi:v1"
+	// DefaultBackendContainerPort is the container port of DefaultBackendImage.
+	DefaultBackendContainerPort = 80
+)
+
+// BackendConfig is the configuration for backend Deployment.
+type BackendConfig struct {
+	// Namespace and Name of backend Deployment.
+	Namespace string
+	Name      string
+	// Labels for backend pods, these are used as deployment selector as well.
+	Labels map[string]string
+	// Number of backend pods.
+	Replicas int32
+	// Image of backend container, defaults to DefaultBackendImage.
+	Image string
+	// Container port of backend container, defaults to DefaultBackendContainerPort.
+	ContainerPort int32
+}
+
+// BuildBackendDeployment builds the backend Deployment for specified config.
+func BuildBackendDeployment(cfg BackendConfig) *appsv1.Deployment {
+	image := cfg.Image
+	if len(image) == 0 {
+		image = DefaultBackendImage
+	}
+	containerPort := cfg.ContainerPort
+	if containerPort == 0 {
+		containerPort = DefaultBackendContainerPort
+	}
+	replicas := cfg.Replicas
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfg.Namespace,
+			Name:      cfg.Name,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cfg.Labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: cfg.Labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            "app",
+							ImagePullPolicy: corev1.PullAlways,
+							Image:           image,
+							Ports: []corev1.ContainerPort{
+								{
+									ContainerPort: containerPort,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -7,10 +7,13 @@ import (
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
-	"sigs.k8s.io/aws-load-balancer-controller/test/framework/utils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
 )
 
+// serviceGetMaxAttempts is the number of consecutive failed Get attempts tolerated while waiting for a Service.
+const serviceGetMaxAttempts = 5
+
 // ServiceManager is responsible for Service resources.
 type ServiceManager interface {
 	WaitUntilServiceActive(ctx context.Context, svc *corev1.Service) (*corev1.Service, error)
@@ -18,10 +21,12 @@ type ServiceManager interface {
 }
 
 // NewDefaultServiceManager constructs new ServiceManager.
-func NewDefaultServiceManager(k8sClient client.Client, logger logr.Logger) *defaultServiceManager {
+func NewDefaultServiceManager(k8sClient client.Client, pollInterval time.Duration, pollTimeout time.Duration, logger logr.Logger) *defaultServiceManager {
 	return &defaultServiceManager{
-		k8sClient: k8sClient,
-		logger:    logger,
+		k8sClient:    k8sClient,
+		pollInterval: pollInterval,
+		pollTimeout:  pollTimeout,
+		logger:       logger,
 	}
 }
 
@@ -29,27 +34,39 @@ var _ ServiceManager = &defaultServiceManager{}
 
 // default implementation for ServiceManager.
 type defaultServiceManager struct {
-	k8sClient client.Client
-	logger    logr.Logger
+	k8sClient    client.Client
+	pollInterval time.Duration
+	pollTimeout  time.Duration
+	logger       logr.Logger
 }
 
 func (m *defaultServiceManager) WaitUntilServiceActive(ctx context.Context, svc *corev1.Service) (*corev1.Service, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.pollTimeout)
+	defer cancel()
 	observedSvc := &corev1.Service{}
-	return observedSvc, wait.PollImmediateUntil(utils.PollIntervalShort, func() (bool, error) {
+	failedGetAttempts := 0
+	return observedSvc, wait.PollImmediateUntil(m.pollInterval, func() (bool, error) {
 		if err := m.k8sClient.Get(ctx, k8s.NamespacedName(svc), observedSvc); err != nil {
-			return false, err
+			failedGetAttempts++
+			if failedGetAttempts >= serviceGetMaxAttempts {
+				return false, err
+			}
+			m.logger.Info("failed to get service, will retry", "svc", k8s.NamespacedName(svc), "error", err)
+			return false, nil
 		}
+		failedGetAttempts = 0
 		if observedSvc.Status.LoadBalancer.Ingress != nil {
 			return true, nil
 		}
 		return false, nil
 	}, ctx.Done())
-
 }
 
 func (m *defaultServiceManager) WaitUntilServiceDeleted(ctx context.Context, svc *corev1.Service) error {
+	ctx, cancel := context.WithTimeout(ctx, m.pollTimeout)
+	defer cancel()
 	observedSVC := &corev1.Service{}
-	return wait.PollImmediateUntil(utils.PollIntervalShort, func() (bool, error) {
+	return wait.PollImmediateUntil(m.pollInterval, func() (bool, error) {
 		if err := m.k8sClient.Get(ctx, k8s.NamespacedName(svc), observedSVC); err != nil {
 			if apierrs.IsNotFound(err) {
 				return true, nil
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+// flakyGetClient fails the first numFailedGets Get calls with getErr before delegating to the wrapped client.
+type flakyGetClient struct {
+	client.Client
+	numFailedGets int
+	getErr        error
+	getCalls      int
+}
+
+func (c *flakyGetClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	c.getCalls++
+	if c.getCalls <= c.numFailedGets {
+		return c.getErr
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func Test_defaultServiceManager_WaitUntilServiceActive(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-svc",
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{
+					{
+						Hostname: "awesome-lb.elb.amazonaws.com",
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		numFailedGets int
+		wantGetCalls  int
+		wantErr       error
+	}{
+		{
+			name:          "service is active",
+			numFailedGets: 0,
+			wantGetCalls:  1,
+		},
+		{
+			name:          "transient Get errors are retried",
+			numFailedGets: serviceGetMaxAttempts - 1,
+			wantGetCalls:  serviceGetMaxAttempts,
+		},
+		{
+			name:          "persistent Get errors are surfaced",
+			numFailedGets: serviceGetMaxAttempts,
+			wantGetCalls:  serviceGetMaxAttempts,
+			wantErr:       errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := &flakyGetClient{
+				Client:        testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(svc.DeepCopy()).Build(),
+				numFailedGets: tt.numFailedGets,
+				getErr:        errors.New("some error"),
+			}
+			m := NewDefaultServiceManager(k8sClient, time.Millisecond, time.Minute, &log.NullLogger{})
+
+			got, err := m.WaitUntilServiceActive(context.Background(), svc)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, svc.Status.LoadBalancer, got.Status.LoadBalancer)
+			}
+			assert.Equal(t, tt.wantGetCalls, k8sClient.getCalls)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func Test_podMutator_MutateCreate(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "svc-1",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app": "app-1",
+			},
+		},
+	}
+	targetTypeIP := elbv2api.TargetTypeIP
+	targetTypeInstance := elbv2api.TargetTypeInstance
+	tgbIP := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "tgb-ip",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetType: &targetTypeIP,
+			ServiceRef: elbv2api.ServiceReference{
+				Name: "svc-1",
+			},
+		},
+	}
+	tgbInstance := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "tgb-instance",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetType: &targetTypeInstance,
+			ServiceRef: elbv2api.ServiceReference{
+				Name: "svc-1",
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		tgbs      []*elbv2api.TargetGroupBinding
+		podLabels map[string]string
+		want      []corev1.PodReadinessGate
+	}{
+		{
+			name:      "pod backing IP targetGroup",
+			tgbs:      []*elbv2api.TargetGroupBinding{tgbIP, tgbInstance},
+			podLabels: map[string]string{"app": "app-1"},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-ip",
+				},
+			},
+		},
+		{
+			name:      "pod backing instance targetGroup only",
+			tgbs:      []*elbv2api.TargetGroupBinding{tgbInstance},
+			podLabels: map[string]string{"app": "app-1"},
+			want:      nil,
+		},
+		{
+			name:      "pod not backing any targetGroup",
+			tgbs:      []*elbv2api.TargetGroupBinding{tgbIP},
+			podLabels: map[string]string{"app": "app-2"},
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			for _, tgb := range tt.tgbs {
+				assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+			}
+			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{Namespace: "ns"},
+			})
+			injector := inject.NewPodReadinessGate(inject.Config{EnablePodReadinessGateInject: true}, k8sClient, &log.NullLogger{})
+			m := NewPodMutator(injector)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Labels:    tt.podLabels,
+				},
+			}
+			got, err := m.MutateCreate(ctx, pod)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.(*corev1.Pod).Spec.ReadinessGates)
+
+			// mutation is idempotent
+			got, err = m.MutateCreate(ctx, got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.(*corev1.Pod).Spec.ReadinessGates)
+		})
+	}
+}
@@ -9,17 +9,20 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"strings"
 )
 
 const apiPathMutateELBv2TargetGroupBinding = "/mutate-elbv2-k8s-aws-v1beta1-targetgroupbinding"
 
 // NewTargetGroupBindingMutator returns a mutator for TargetGroupBinding CRD.
-func NewTargetGroupBindingMutator(elbv2Client services.ELBV2, logger logr.Logger) *targetGroupBindingMutator {
+func NewTargetGroupBindingMutator(elbv2Client services.ELBV2, vpcID string, logger logr.Logger) *targetGroupBindingMutator {
 	return &targetGroupBindingMutator{
 		elbv2Client: elbv2Client,
+		vpcID:       vpcID,
 		logger:      logger,
 	}
 }
@@ -28,6 +31,7 @@ var _ webhook.Mutator = &targetGroupBindingMutator{}
 
 type targetGroupBindingMutator struct {
 	elbv2Client services.ELBV2
+	vpcID       string
 	logger      logr.Logger
 }
 
@@ -37,9 +41,15 @@ func (m *targetGroupBindingMutator) Prototype(_ admission.Request) (runtime.Obje
 
 func (m *targetGroupBindingMutator) MutateCreate(ctx context.Context, obj runtime.Object) (runtime.Object, error) {
 	tgb := obj.(*elbv2api.TargetGroupBinding)
+	if err := m.adoptTargetGroup(ctx, tgb); err != nil {
+		return nil, err
+	}
 	if err := m.defaultingTargetType(ctx, tgb); err != nil {
 		return nil, err
 	}
+	if err := m.checkAdditionalTargetGroups(ctx, tgb); err != nil {
+		return nil, err
+	}
 	return tgb, nil
 }
 
@@ -51,25 +61,114 @@ func (m *targetGroupBindingMutator) defaultingTargetType(ctx context.Context, tg
 	if tgb.Spec.TargetType != nil {
 		return nil
 	}
+	// the TargetGroup of cross-account TargetGroupBinding isn't visible with controller's own credentials.
+	if tgb.Spec.AssumeRoleARN != "" {
+		return errors.New("couldn't determine TargetType, spec.targetType must be specified when spec.assumeRoleARN is specified")
+	}
 	tgARN := tgb.Spec.TargetGroupARN
 	sdkTargetType, err := m.obtainSDKTargetTypeFromAWS(ctx, tgARN)
 	if err != nil {
 		return errors.Wrap(err, "couldn't determine TargetType")
 	}
-	var targetType elbv2api.TargetType
-	switch sdkTargetType {
-	case elbv2sdk.TargetTypeEnumInstance:
-		targetType = elbv2api.TargetTypeInstance
-	case elbv2sdk.TargetTypeEnumIp:
-		targetType = elbv2api.TargetTypeIP
-	default:
-		return errors.Errorf("unsupported TargetType: %v", sdkTargetType)
+	targetType, err := convertSDKTargetType(sdkTargetType)
+	if err != nil {
+		return err
 	}
 
 	tgb.Spec.TargetType = &targetType
 	return nil
 }
 
+// adoptTargetGroup resolves the pre-existing TargetGroup specified by ARN or name via annotation.
+// the TargetGroup must be in the cluster's VPC, and its TargetType must match the TargetGroupBinding.
+func (m *targetGroupBindingMutator) adoptTargetGroup(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	tgNameOrARN, exists := tgb.Annotations[targetgroupbinding.AnnotationAdoptTargetGroup]
+	if !exists {
+		return nil
+	}
+	if tgb.Spec.AssumeRoleARN != "" {
+		return errors.New("adopting TargetGroup isn't supported when spec.assumeRoleARN is specified")
+	}
+	req := &elbv2sdk.DescribeTargetGroupsInput{}
+	if strings.HasPrefix(tgNameOrARN, "arn:") {
+		req.TargetGroupArns = awssdk.StringSlice([]string{tgNameOrARN})
+	} else {
+		req.Names = awssdk.StringSlice([]string{tgNameOrARN})
+	}
+	tgList, err := m.elbv2Client.DescribeTargetGroupsAsList(ctx, req)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't adopt TargetGroup %v", tgNameOrARN)
+	}
+	if len(tgList) != 1 {
+		return errors.Errorf("couldn't adopt TargetGroup %v, expecting a single targetGroup but got %v", tgNameOrARN, len(tgList))
+	}
+	sdkTG := tgList[0]
+	tgARN := awssdk.StringValue(sdkTG.TargetGroupArn)
+	if tgb.Spec.TargetGroupARN != "" && tgb.Spec.TargetGroupARN != tgARN {
+		return errors.Errorf("couldn't adopt TargetGroup %v, spec.targetGroupARN mismatch: %v", tgNameOrARN, tgb.Spec.TargetGroupARN)
+	}
+	if tgVPCID := awssdk.StringValue(sdkTG.VpcId); tgVPCID != m.vpcID {
+		return errors.Errorf("couldn't adopt TargetGroup %v, VPC mismatch: %v, expecting %v", tgNameOrARN, tgVPCID, m.vpcID)
+	}
+	targetType, err := convertSDKTargetType(awssdk.StringValue(sdkTG.TargetType))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't adopt TargetGroup %v", tgNameOrARN)
+	}
+	if tgb.Spec.TargetType != nil && *tgb.Spec.TargetType != targetType {
+		return errors.Errorf("couldn't adopt TargetGroup %v, TargetType mismatch: %v, expecting %v", tgNameOrARN, targetType, *tgb.Spec.TargetType)
+	}
+
+	tgb.Spec.TargetGroupARN = tgARN
+	tgb.Spec.TargetType = &targetType
+	return nil
+}
+
+// checkAdditionalTargetGroups ensures the additional TargetGroups are compatible with spec.targetGroupARN,
+// i.e. they have the same TargetType as TargetGroupBinding and are in the same VPC, so that they can receive the same targets.
+func (m *targetGroupBindingMutator) checkAdditionalTargetGroups(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if len(tgb.Spec.AdditionalTargetGroupARNs) == 0 {
+		return nil
+	}
+	// the TargetGroups of cross-account TargetGroupBinding aren't visible with controller's own credentials.
+	if tgb.Spec.AssumeRoleARN != "" {
+		return nil
+	}
+	tgARNs := targetgroupbinding.BuildTargetGroupARNs(tgb)
+	req := &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice(tgARNs),
+	}
+	tgList, err := m.elbv2Client.DescribeTargetGroupsAsList(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "couldn't check additional TargetGroups")
+	}
+	sdkTGByARN := make(map[string]*elbv2sdk.TargetGroup, len(tgList))
+	for _, sdkTG := range tgList {
+		sdkTGByARN[awssdk.StringValue(sdkTG.TargetGroupArn)] = sdkTG
+	}
+	primarySDKTG, exists := sdkTGByARN[tgb.Spec.TargetGroupARN]
+	if !exists {
+		return errors.Errorf("couldn't check additional TargetGroups, TargetGroup %v not found", tgb.Spec.TargetGroupARN)
+	}
+	tgVPCID := awssdk.StringValue(primarySDKTG.VpcId)
+	for _, tgARN := range tgb.Spec.AdditionalTargetGroupARNs {
+		sdkTG, exists := sdkTGByARN[tgARN]
+		if !exists {
+			return errors.Errorf("couldn't check additional TargetGroup %v, TargetGroup not found", tgARN)
+		}
+		targetType, err := convertSDKTargetType(awssdk.StringValue(sdkTG.TargetType))
+		if err != nil {
+			return errors.Wrapf(err, "incompatible additional TargetGroup %v", tgARN)
+		}
+		if *tgb.Spec.TargetType != targetType {
+			return errors.Errorf("incompatible additional TargetGroup %v, TargetType mismatch: %v, expecting %v", tgARN, targetType, *tgb.Spec.TargetType)
+		}
+		if additionalTGVPCID := awssdk.StringValue(sdkTG.VpcId); additionalTGVPCID != tgVPCID {
+			return errors.Errorf("incompatible additional TargetGroup %v, VPC mismatch: %v, expecting %v", tgARN, additionalTGVPCID, tgVPCID)
+		}
+	}
+	return nil
+}
+
 func (m *targetGroupBindingMutator) obtainSDKTargetTypeFromAWS(ctx context.Context, tgARN string) (string, error) {
 	req := &elbv2sdk.DescribeTargetGroupsInput{
 		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
@@ -84,6 +183,18 @@ func (m *targetGroupBindingMutator) obtainSDKTargetTypeFromAWS(ctx context.Conte
 	return awssdk.StringValue(tgList[0].TargetType), nil
 }
 
+// convertSDKTargetType converts the TargetType of AWS SDK into TargetType of TargetGroupBinding.
+func convertSDKTargetType(sdkTargetType string) (elbv2api.TargetType, error) {
+	switch sdkTargetType {
+	case elbv2sdk.TargetTypeEnumInstance:
+		return elbv2api.TargetTypeInstance, nil
+	case elbv2sdk.TargetTypeEnumIp:
+		return elbv2api.TargetTypeIP, nil
+	default:
+		return "", errors.Errorf("unsupported TargetType: %v", sdkTargetType)
+	}
+}
+
 // +kubebuilder:webhook:path=/mutate-elbv2-k8s-aws-v1beta1-targetgroupbinding,mutating=true,failurePolicy=fail,groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=create;update,versions=v1beta1,name=mtargetgroupbinding.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1,admissionReviewVersions=v1beta1
 
 func (m *targetGroupBindingMutator) SetupWithManager(mgr ctrl.Manager) {
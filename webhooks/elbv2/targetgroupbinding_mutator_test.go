@@ -7,6 +7,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -56,6 +57,22 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "targetGroupBinding with TargetType absent and AssumeRoleARN set",
+			fields: fields{
+				describeTargetGroupsAsListCalls: nil,
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     nil,
+						AssumeRoleARN:  "arn:aws:iam::123456789012:role/role-1",
+					},
+				},
+			},
+			wantErr: errors.New("couldn't determine TargetType, spec.targetType must be specified when spec.assumeRoleARN is specified"),
+		},
 		{
 			name: "targetGroupBinding with TargetType absent will be defaulted via AWS API - instance",
 			fields: fields{
@@ -147,6 +164,343 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 			},
 			wantErr: errors.New("unsupported TargetType: lambda"),
 		},
+		{
+			name: "targetGroupBinding adopts targetGroup by name",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"my-tg"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/adopt-target-group": "my-tg",
+						},
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "",
+						TargetType:     nil,
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"elbv2.k8s.aws/adopt-target-group": "my-tg",
+					},
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+					TargetType:     &ipTargetType,
+				},
+			},
+		},
+		{
+			name: "targetGroupBinding adopts targetGroup by ARN",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"),
+								TargetType:     awssdk.String("instance"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/adopt-target-group": "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+						},
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+						TargetType:     &instanceTargetType,
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"elbv2.k8s.aws/adopt-target-group": "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+					},
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef",
+					TargetType:     &instanceTargetType,
+				},
+			},
+		},
+		{
+			name: "targetGroupBinding adopts targetGroup in another VPC",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"my-tg"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-2"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/adopt-target-group": "my-tg",
+						},
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "",
+						TargetType:     nil,
+					},
+				},
+			},
+			wantErr: errors.New("couldn't adopt TargetGroup my-tg, VPC mismatch: vpc-2, expecting vpc-1"),
+		},
+		{
+			name: "targetGroupBinding adopts targetGroup with mismatched TargetType",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"my-tg"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/adopt-target-group": "my-tg",
+						},
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "",
+						TargetType:     &instanceTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("couldn't adopt TargetGroup my-tg, TargetType mismatch: ip, expecting instance"),
+		},
+		{
+			name: "targetGroupBinding adopts targetGroup with mismatched targetGroupARN",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"my-tg"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/1234567890abcdef"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/adopt-target-group": "my-tg",
+						},
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     nil,
+					},
+				},
+			},
+			wantErr: errors.New("couldn't adopt TargetGroup my-tg, spec.targetGroupARN mismatch: tg-1"),
+		},
+		{
+			name: "targetGroupBinding adopts targetGroup with AssumeRoleARN set",
+			fields: fields{
+				describeTargetGroupsAsListCalls: nil,
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/adopt-target-group": "my-tg",
+						},
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:    &ipTargetType,
+						AssumeRoleARN: "arn:aws:iam::123456789012:role/role-1",
+					},
+				},
+			},
+			wantErr: errors.New("adopting TargetGroup isn't supported when spec.assumeRoleARN is specified"),
+		},
+		{
+			name: "targetGroupBinding with compatible additional targetGroups",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1", "tg-2"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("tg-1"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+							{
+								TargetGroupArn: awssdk.String("tg-2"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2"},
+						TargetType:                &ipTargetType,
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN:            "tg-1",
+					AdditionalTargetGroupARNs: []string{"tg-2"},
+					TargetType:                &ipTargetType,
+				},
+			},
+		},
+		{
+			name: "targetGroupBinding with additional targetGroup of mismatched TargetType",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1", "tg-2"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("tg-1"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+							{
+								TargetGroupArn: awssdk.String("tg-2"),
+								TargetType:     awssdk.String("instance"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2"},
+						TargetType:                &ipTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("incompatible additional TargetGroup tg-2, TargetType mismatch: instance, expecting ip"),
+		},
+		{
+			name: "targetGroupBinding with additional targetGroup in another VPC",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1", "tg-2"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("tg-1"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-1"),
+							},
+							{
+								TargetGroupArn: awssdk.String("tg-2"),
+								TargetType:     awssdk.String("ip"),
+								VpcId:          awssdk.String("vpc-2"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2"},
+						TargetType:                &ipTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("incompatible additional TargetGroup tg-2, VPC mismatch: vpc-2, expecting vpc-1"),
+		},
+		{
+			name: "targetGroupBinding with additional targetGroups and AssumeRoleARN set",
+			fields: fields{
+				describeTargetGroupsAsListCalls: nil,
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2"},
+						TargetType:                &ipTargetType,
+						AssumeRoleARN:             "arn:aws:iam::123456789012:role/role-1",
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN:            "tg-1",
+					AdditionalTargetGroupARNs: []string{"tg-2"},
+					TargetType:                &ipTargetType,
+					AssumeRoleARN:             "arn:aws:iam::123456789012:role/role-1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -159,6 +513,7 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 
 			m := &targetGroupBindingMutator{
 				elbv2Client: elbv2Client,
+				vpcID:       "vpc-1",
 				logger:      &log.NullLogger{},
 			}
 			got, err := m.MutateCreate(context.Background(), tt.args.obj)
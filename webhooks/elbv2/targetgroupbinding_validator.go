@@ -7,8 +7,10 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,6 +46,15 @@ func (v *targetGroupBindingValidator) ValidateCreate(ctx context.Context, obj ru
 	if err := v.checkNodeSelector(tgb); err != nil {
 		return err
 	}
+	if err := v.checkRequiredPodConditionType(tgb); err != nil {
+		return err
+	}
+	if err := v.checkHealthCheckGracePeriodSeconds(tgb); err != nil {
+		return err
+	}
+	if err := v.checkDuplicateTargetGroups(tgb); err != nil {
+		return err
+	}
 	if err := v.checkExistingTargetGroups(tgb); err != nil {
 		return err
 	}
@@ -62,6 +73,12 @@ func (v *targetGroupBindingValidator) ValidateUpdate(ctx context.Context, obj ru
 	if err := v.checkNodeSelector(tgb); err != nil {
 		return err
 	}
+	if err := v.checkRequiredPodConditionType(tgb); err != nil {
+		return err
+	}
+	if err := v.checkHealthCheckGracePeriodSeconds(tgb); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -87,12 +104,18 @@ func (v *targetGroupBindingValidator) checkImmutableFields(tgb *elbv2api.TargetG
 	if tgb.Spec.TargetGroupARN != oldTGB.Spec.TargetGroupARN {
 		changedImmutableFields = append(changedImmutableFields, "spec.targetGroupARN")
 	}
+	if !sets.NewString(tgb.Spec.AdditionalTargetGroupARNs...).Equal(sets.NewString(oldTGB.Spec.AdditionalTargetGroupARNs...)) {
+		changedImmutableFields = append(changedImmutableFields, "spec.additionalTargetGroupARNs")
+	}
 	if (tgb.Spec.TargetType == nil) != (oldTGB.Spec.TargetType == nil) {
 		changedImmutableFields = append(changedImmutableFields, "spec.targetType")
 	}
 	if tgb.Spec.TargetType != nil && oldTGB.Spec.TargetType != nil && (*tgb.Spec.TargetType) != (*oldTGB.Spec.TargetType) {
 		changedImmutableFields = append(changedImmutableFields, "spec.targetType")
 	}
+	if tgb.Spec.AssumeRoleARN != oldTGB.Spec.AssumeRoleARN {
+		changedImmutableFields = append(changedImmutableFields, "spec.assumeRoleARN")
+	}
 
 	if len(changedImmutableFields) != 0 {
 		return errors.Errorf("%s update may not change these fields: %s", "TargetGroupBinding", strings.Join(changedImmutableFields, ","))
@@ -100,6 +123,18 @@ func (v *targetGroupBindingValidator) checkImmutableFields(tgb *elbv2api.TargetG
 	return nil
 }
 
+// checkDuplicateTargetGroups will check TargetGroups are not bound more than once by TargetGroupBinding.
+func (v *targetGroupBindingValidator) checkDuplicateTargetGroups(tgb *elbv2api.TargetGroupBinding) error {
+	tgARNs := sets.NewString()
+	for _, tgARN := range targetgroupbinding.BuildTargetGroupARNs(tgb) {
+		if tgARNs.Has(tgARN) {
+			return errors.Errorf("TargetGroup %v is specified more than once", tgARN)
+		}
+		tgARNs.Insert(tgARN)
+	}
+	return nil
+}
+
 // checkExistingTargetGroups will check for unique TargetGroup per TargetGroupBinding
 func (v *targetGroupBindingValidator) checkExistingTargetGroups(tgb *elbv2api.TargetGroupBinding) error {
 	ctx := context.Background()
@@ -107,9 +142,12 @@ func (v *targetGroupBindingValidator) checkExistingTargetGroups(tgb *elbv2api.Ta
 	if err := v.k8sClient.List(ctx, &tgbList); err != nil {
 		return errors.Wrap(err, "failed to list TargetGroupBindings in the cluster")
 	}
+	tgARNs := sets.NewString(targetgroupbinding.BuildTargetGroupARNs(tgb)...)
 	for _, tgbObj := range tgbList.Items {
-		if tgbObj.Spec.TargetGroupARN == tgb.Spec.TargetGroupARN {
-			return errors.Errorf("TargetGroup %v is already bound to TargetGroupBinding %v", tgb.Spec.TargetGroupARN, k8s.NamespacedName(&tgbObj).String())
+		for _, tgARN := range targetgroupbinding.BuildTargetGroupARNs(&tgbObj) {
+			if tgARNs.Has(tgARN) {
+				return errors.Errorf("TargetGroup %v is already bound to TargetGroupBinding %v", tgARN, k8s.NamespacedName(&tgbObj).String())
+			}
 		}
 	}
 	return nil
@@ -123,6 +161,22 @@ func (v *targetGroupBindingValidator) checkNodeSelector(tgb *elbv2api.TargetGrou
 	return nil
 }
 
+// checkRequiredPodConditionType ensures that RequiredPodConditionType is only set when TargetType is ip
+func (v *targetGroupBindingValidator) checkRequiredPodConditionType(tgb *elbv2api.TargetGroupBinding) error {
+	if (*tgb.Spec.TargetType == elbv2api.TargetTypeInstance) && (tgb.Spec.RequiredPodConditionType != nil) {
+		return errors.Errorf("TargetGroupBinding cannot set RequiredPodConditionType when TargetType is instance")
+	}
+	return nil
+}
+
+// checkHealthCheckGracePeriodSeconds ensures that HealthCheckGracePeriodSeconds is only set when TargetType is ip
+func (v *targetGroupBindingValidator) checkHealthCheckGracePeriodSeconds(tgb *elbv2api.TargetGroupBinding) error {
+	if (*tgb.Spec.TargetType == elbv2api.TargetTypeInstance) && (tgb.Spec.HealthCheckGracePeriodSeconds != nil) {
+		return errors.Errorf("TargetGroupBinding cannot set HealthCheckGracePeriodSeconds when TargetType is instance")
+	}
+	return nil
+}
+
 // +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-targetgroupbinding,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=create;update,versions=v1beta1,name=vtargetgroupbinding.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1,admissionReviewVersions=v1beta1
 
 func (v *targetGroupBindingValidator) SetupWithManager(mgr ctrl.Manager) {
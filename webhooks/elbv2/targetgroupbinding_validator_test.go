@@ -4,8 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
@@ -262,6 +264,46 @@ func Test_targetGroupBindingValidator_checkImmutableFields(t *testing.T) {
 			},
 			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.targetGroupARN"),
 		},
+		{
+			name: "additionalTargetGroupARNs is changed",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2", "tg-3"},
+						TargetType:                &instanceTargetType,
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2"},
+						TargetType:                &instanceTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.additionalTargetGroupARNs"),
+		},
+		{
+			name: "additionalTargetGroupARNs is reordered",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-3", "tg-2"},
+						TargetType:                &instanceTargetType,
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2", "tg-3"},
+						TargetType:                &instanceTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
 		{
 			name: "targetType is changed",
 			args: args{
@@ -334,6 +376,26 @@ func Test_targetGroupBindingValidator_checkImmutableFields(t *testing.T) {
 			},
 			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.targetGroupARN,spec.targetType"),
 		},
+		{
+			name: "assumeRoleARN is changed",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+						AssumeRoleARN:  "arn:aws:iam::123456789012:role/role-2",
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+						AssumeRoleARN:  "arn:aws:iam::123456789012:role/role-1",
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.assumeRoleARN"),
+		},
 		{
 			name: "both targetGroupARN and targetType are not changed",
 			args: args{
@@ -442,6 +504,142 @@ func Test_targetGroupBindingValidator_checkNodeSelector(t *testing.T) {
 	}
 }
 
+func Test_targetGroupBindingValidator_checkRequiredPodConditionType(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	requiredPodConditionType := corev1.PodConditionType("example.com/warmed-up")
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] targetType is ip, requiredPodConditionType is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is ip, requiredPodConditionType is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:               &ipTargetType,
+						RequiredPodConditionType: &requiredPodConditionType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] targetType is instance, requiredPodConditionType is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:               &instanceTargetType,
+						RequiredPodConditionType: &requiredPodConditionType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot set RequiredPodConditionType when TargetType is instance"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkRequiredPodConditionType(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkHealthCheckGracePeriodSeconds(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] targetType is ip, healthCheckGracePeriodSeconds is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is ip, healthCheckGracePeriodSeconds is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:                    &ipTargetType,
+						HealthCheckGracePeriodSeconds: aws.Int64(60),
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is instance, healthCheckGracePeriodSeconds is nil",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &instanceTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] targetType is instance, healthCheckGracePeriodSeconds is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType:                    &instanceTargetType,
+						HealthCheckGracePeriodSeconds: aws.Int64(60),
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot set HealthCheckGracePeriodSeconds when TargetType is instance"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkHealthCheckGracePeriodSeconds(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_targetGroupBindingValidator_checkExistingTargetGroups(t *testing.T) {
 
 	type env struct {
@@ -635,6 +833,38 @@ func Test_targetGroupBindingValidator_checkExistingTargetGroups(t *testing.T) {
 			},
 			wantErr: errors.New("TargetGroup tg-111 is already bound to TargetGroupBinding ns2/tgb2"),
 		},
+		{
+			name: "[err] duplicate target groups - additional target group bound by another target group binding",
+			env: env{
+				existingTGBs: []elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "tgb1",
+							Namespace: "ns1",
+						},
+						Spec: elbv2api.TargetGroupBindingSpec{
+							TargetGroupARN:            "tg-1",
+							AdditionalTargetGroupARNs: []string{"tg-2"},
+							TargetType:                nil,
+						},
+					},
+				},
+			},
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "tgb2",
+						Namespace: "ns1",
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-3",
+						AdditionalTargetGroupARNs: []string{"tg-2"},
+						TargetType:                nil,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroup tg-2 is already bound to TargetGroupBinding ns1/tgb1"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -658,3 +888,75 @@ func Test_targetGroupBindingValidator_checkExistingTargetGroups(t *testing.T) {
 		})
 	}
 }
+
+func Test_targetGroupBindingValidator_checkDuplicateTargetGroups(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] single target group",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] distinct additional target groups",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2", "tg-3"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] additional target group duplicates targetGroupARN",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-1"},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroup tg-1 is specified more than once"),
+		},
+		{
+			name: "[err] duplicate additional target groups",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:            "tg-1",
+						AdditionalTargetGroupARNs: []string{"tg-2", "tg-2"},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroup tg-2 is specified more than once"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkDuplicateTargetGroups(tt.args.tgb)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
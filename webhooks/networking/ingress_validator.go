@@ -2,14 +2,18 @@ package networking
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +32,7 @@ func NewIngressValidator(client client.Client, ingConfig config.IngressConfig, l
 		classLoader:                   ingress.NewDefaultClassLoader(client),
 		disableIngressClassAnnotation: ingConfig.DisableIngressClassAnnotation,
 		disableIngressGroupAnnotation: ingConfig.DisableIngressGroupNameAnnotation,
+		duplicateRulePolicy:           ingConfig.DuplicateRulePolicy,
 		logger:                        logger,
 	}
 }
@@ -40,6 +45,7 @@ type ingressValidator struct {
 	classLoader                   ingress.ClassLoader
 	disableIngressClassAnnotation bool
 	disableIngressGroupAnnotation bool
+	duplicateRulePolicy           string
 	logger                        logr.Logger
 }
 
@@ -58,6 +64,12 @@ func (v *ingressValidator) ValidateCreate(ctx context.Context, obj runtime.Objec
 	if err := v.checkIngressClassUsage(ctx, ing, nil); err != nil {
 		return err
 	}
+	if err := v.checkSSLRedirectUsage(ctx, ing, nil); err != nil {
+		return err
+	}
+	if err := v.checkDuplicateRules(ing, nil); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -73,6 +85,12 @@ func (v *ingressValidator) ValidateUpdate(ctx context.Context, obj runtime.Objec
 	if err := v.checkIngressClassUsage(ctx, ing, oldIng); err != nil {
 		return err
 	}
+	if err := v.checkSSLRedirectUsage(ctx, ing, oldIng); err != nil {
+		return err
+	}
+	if err := v.checkDuplicateRules(ing, oldIng); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -163,6 +181,139 @@ func (v *ingressValidator) checkIngressClassUsage(ctx context.Context, ing *netw
 	return nil
 }
 
+// checkSSLRedirectUsage checks the usage of "ssl-redirect" annotation.
+// when the sslRedirect port is one of the listen ports of Ingress, it must be an HTTPS port with certificates,
+// otherwise the HTTP listeners redirect to a broken HTTPS listener.
+// certificates are only checked for Ingresses that don't belong to an explicit IngressGroup, since other members can provide them.
+// on update, it's only checked when the related annotations changed, so that existing Ingresses are not blocked from unrelated updates.
+func (v *ingressValidator) checkSSLRedirectUsage(ctx context.Context, ing *networking.Ingress, oldIng *networking.Ingress) error {
+	if oldIng != nil && !v.isSSLRedirectAnnotationsChanged(ing, oldIng) {
+		return nil
+	}
+	var sslRedirectPort int64
+	exists, err := v.annotationParser.ParseInt64Annotation(annotations.IngressSuffixSSLRedirect, &sslRedirectPort, ing.Annotations)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	listenPorts, err := parseIngressListenPorts(v.annotationParser, ing)
+	if err != nil {
+		// invalid listen-ports configuration is reported during reconcile.
+		return nil
+	}
+	protocol, ok := listenPorts[sslRedirectPort]
+	if !ok {
+		return nil
+	}
+	if protocol != string(elbv2model.ProtocolHTTPS) {
+		return errors.Errorf("listener protocol non-SSL for SSLRedirect port: %v", sslRedirectPort)
+	}
+
+	if v.isIngressInExplicitGroup(ctx, ing) {
+		return nil
+	}
+	var certARNs []string
+	_ = v.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixCertificateARN, &certARNs, ing.Annotations)
+	if len(certARNs) != 0 || len(computeIngressHostsForCertDiscovery(ing)) != 0 {
+		return nil
+	}
+	return errors.Errorf("no certificate for SSLRedirect port: %v, either specify `%s/%s` annotation or hosts for certificate discovery",
+		sslRedirectPort, annotations.AnnotationPrefixIngress, annotations.IngressSuffixCertificateARN)
+}
+
+// isSSLRedirectAnnotationsChanged checks whether any annotation related to sslRedirect changed between Ingress and oldIngress.
+func (v *ingressValidator) isSSLRedirectAnnotationsChanged(ing *networking.Ingress, oldIng *networking.Ingress) bool {
+	for _, annotationSuffix := range []string{annotations.IngressSuffixSSLRedirect, annotations.IngressSuffixListenPorts, annotations.IngressSuffixCertificateARN} {
+		newValue := ""
+		oldValue := ""
+		newExists := v.annotationParser.ParseStringAnnotation(annotationSuffix, &newValue, ing.Annotations)
+		oldExists := v.annotationParser.ParseStringAnnotation(annotationSuffix, &oldValue, oldIng.Annotations)
+		if newExists != oldExists || newValue != oldValue {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDuplicateRules checks Ingress rules with duplicate host and path when reject policy is configured for duplicate rules.
+// on update, it's only checked when the rules changed, so that existing Ingresses are not blocked from unrelated updates.
+func (v *ingressValidator) checkDuplicateRules(ing *networking.Ingress, oldIng *networking.Ingress) error {
+	if v.duplicateRulePolicy != config.IngressDuplicateRulePolicyReject {
+		return nil
+	}
+	if oldIng != nil && equality.Semantic.DeepEqual(ing.Spec.Rules, oldIng.Spec.Rules) {
+		return nil
+	}
+	rulePathByKey := make(map[string]string)
+	for ruleIdx, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for pathIdx, path := range rule.HTTP.Paths {
+			pathType := networking.PathTypeImplementationSpecific
+			if path.PathType != nil {
+				pathType = *path.PathType
+			}
+			rulePath := fmt.Sprintf("spec.rules[%v].http.paths[%v]", ruleIdx, pathIdx)
+			key := fmt.Sprintf("%v %v %v", rule.Host, pathType, path.Path)
+			if duplicatedRulePath, exists := rulePathByKey[key]; exists {
+				return errors.Errorf("%v has duplicate host and path with %v: [host: %v, path: %v]",
+					rulePath, duplicatedRulePath, rule.Host, path.Path)
+			}
+			rulePathByKey[key] = rulePath
+		}
+	}
+	return nil
+}
+
+// isIngressInExplicitGroup checks whether Ingress belongs to an explicit IngressGroup via annotation or IngressClassParams.
+// Ingresses whose IngressClass cannot be loaded are considered in explicit IngressGroup conservatively.
+func (v *ingressValidator) isIngressInExplicitGroup(ctx context.Context, ing *networking.Ingress) bool {
+	groupName := ""
+	if exists := v.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &groupName, ing.Annotations); exists {
+		return true
+	}
+	if ing.Spec.IngressClassName == nil {
+		return false
+	}
+	classConfig, err := v.classLoader.Load(ctx, ing)
+	if err != nil {
+		return true
+	}
+	return classConfig.IngClassParams != nil && classConfig.IngClassParams.Spec.Group != nil
+}
+
+// parseIngressListenPorts parses the listen ports of Ingress from "listen-ports" annotation, keyed by port.
+func parseIngressListenPorts(annotationParser annotations.Parser, ing *networking.Ingress) (map[int64]string, error) {
+	var entries []map[string]int64
+	if _, err := annotationParser.ParseJSONAnnotation(annotations.IngressSuffixListenPorts, &entries, ing.Annotations); err != nil {
+		return nil, err
+	}
+	listenPorts := make(map[int64]string, len(entries))
+	for _, entry := range entries {
+		for protocol, port := range entry {
+			listenPorts[port] = protocol
+		}
+	}
+	return listenPorts, nil
+}
+
+// computeIngressHostsForCertDiscovery computes the hosts of Ingress that are used to discover certificates.
+func computeIngressHostsForCertDiscovery(ing *networking.Ingress) []string {
+	hosts := sets.NewString()
+	for _, r := range ing.Spec.Rules {
+		if len(r.Host) != 0 {
+			hosts.Insert(r.Host)
+		}
+	}
+	for _, t := range ing.Spec.TLS {
+		hosts.Insert(t.Hosts...)
+	}
+	return hosts.List()
+}
+
 // +kubebuilder:webhook:path=/validate-networking-v1beta1-ingress,mutating=false,failurePolicy=fail,groups=networking.k8s.io,resources=ingresses,verbs=create;update,versions=v1beta1,name=vingress.elbv2.k8s.aws,sideEffects=None,matchPolicy=Equivalent,webhookVersions=v1,admissionReviewVersions=v1beta1
 
 func (v *ingressValidator) SetupWithManager(mgr ctrl.Manager) {
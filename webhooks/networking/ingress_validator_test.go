@@ -785,3 +785,359 @@ func Test_ingressValidator_checkIngressClassUsage(t *testing.T) {
 		})
 	}
 }
+
+func Test_ingressValidator_checkSSLRedirectUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		ing     *networking.Ingress
+		oldIng  *networking.Ingress
+		wantErr error
+	}{
+		{
+			name: "ingress without ssl-redirect annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}]`,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with invalid ssl-redirect annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect": "https",
+					},
+				},
+			},
+			wantErr: errors.New("failed to parse int64 annotation, alb.ingress.kubernetes.io/ssl-redirect: https: strconv.ParseInt: parsing \"https\": invalid syntax"),
+		},
+		{
+			name: "ingress with ssl-redirect to port that isn't listen port",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect": "443",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}]`,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with ssl-redirect to HTTP listen port",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect": "8080",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTP": 8080}]`,
+					},
+				},
+			},
+			wantErr: errors.New("listener protocol non-SSL for SSLRedirect port: 8080"),
+		},
+		{
+			name: "ingress with ssl-redirect to HTTPS listen port with certificate-arn annotation",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect":    "443",
+						"alb.ingress.kubernetes.io/listen-ports":    `[{"HTTP": 80}, {"HTTPS": 443}]`,
+						"alb.ingress.kubernetes.io/certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/abcdefg",
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with ssl-redirect to HTTPS listen port with hosts for certificate discovery",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect": "443",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTPS": 443}]`,
+					},
+				},
+				Spec: networking.IngressSpec{
+					TLS: []networking.IngressTLS{
+						{
+							Hosts: []string{"app.example.com"},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with ssl-redirect to HTTPS listen port without certificate",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect": "443",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTPS": 443}]`,
+					},
+				},
+			},
+			wantErr: errors.New("no certificate for SSLRedirect port: 443, either specify `alb.ingress.kubernetes.io/certificate-arn` annotation or hosts for certificate discovery"),
+		},
+		{
+			name: "ingress updated without certificate - related annotations unchanged",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect": "443",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTPS": 443}]`,
+						"alb.ingress.kubernetes.io/scheme":       "internet-facing",
+					},
+				},
+			},
+			oldIng: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect": "443",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTPS": 443}]`,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress updated without certificate - ssl-redirect annotation added",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/ssl-redirect": "443",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTPS": 443}]`,
+					},
+				},
+			},
+			oldIng: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTPS": 443}]`,
+					},
+				},
+			},
+			wantErr: errors.New("no certificate for SSLRedirect port: 443, either specify `alb.ingress.kubernetes.io/certificate-arn` annotation or hosts for certificate discovery"),
+		},
+		{
+			name: "ingress with ssl-redirect to HTTPS listen port without certificate - in explicit IngressGroup",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/group.name":   "awesome-group",
+						"alb.ingress.kubernetes.io/ssl-redirect": "443",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 80}, {"HTTPS": 443}]`,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			v := &ingressValidator{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classLoader:      ingress.NewDefaultClassLoader(k8sClient),
+			}
+			err := v.checkSSLRedirectUsage(ctx, tt.ing, tt.oldIng)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ingressValidator_checkDuplicateRules(t *testing.T) {
+	pathTypePrefix := networking.PathTypePrefix
+	pathTypeExact := networking.PathTypeExact
+	buildIngress := func(rules ...networking.IngressRule) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns-1",
+				Name:      "ing-1",
+			},
+			Spec: networking.IngressSpec{
+				Rules: rules,
+			},
+		}
+	}
+	buildRule := func(host string, paths ...networking.HTTPIngressPath) networking.IngressRule {
+		return networking.IngressRule{
+			Host: host,
+			IngressRuleValue: networking.IngressRuleValue{
+				HTTP: &networking.HTTPIngressRuleValue{
+					Paths: paths,
+				},
+			},
+		}
+	}
+	buildPath := func(path string, pathType *networking.PathType, svcName string) networking.HTTPIngressPath {
+		return networking.HTTPIngressPath{
+			Path:     path,
+			PathType: pathType,
+			Backend: networking.IngressBackend{
+				ServiceName: svcName,
+			},
+		}
+	}
+	type fields struct {
+		duplicateRulePolicy string
+	}
+	type args struct {
+		ing    *networking.Ingress
+		oldIng *networking.Ingress
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ingress creation with duplicate rules - when reject policy",
+			fields: fields{
+				duplicateRulePolicy: "reject",
+			},
+			args: args{
+				ing: buildIngress(
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-1")),
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-2")),
+				),
+			},
+			wantErr: errors.New("spec.rules[1].http.paths[0] has duplicate host and path with spec.rules[0].http.paths[0]: [host: app.example.com, path: /foo]"),
+		},
+		{
+			name: "ingress creation with duplicate paths within a rule - when reject policy",
+			fields: fields{
+				duplicateRulePolicy: "reject",
+			},
+			args: args{
+				ing: buildIngress(
+					buildRule("", buildPath("/foo", nil, "svc-1"), buildPath("/foo", nil, "svc-2")),
+				),
+			},
+			wantErr: errors.New("spec.rules[0].http.paths[1] has duplicate host and path with spec.rules[0].http.paths[0]: [host: , path: /foo]"),
+		},
+		{
+			name: "ingress creation with same path of different path types - when reject policy",
+			fields: fields{
+				duplicateRulePolicy: "reject",
+			},
+			args: args{
+				ing: buildIngress(
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-1"), buildPath("/foo", &pathTypeExact, "svc-2")),
+				),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress creation with same path of different hosts - when reject policy",
+			fields: fields{
+				duplicateRulePolicy: "reject",
+			},
+			args: args{
+				ing: buildIngress(
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-1")),
+					buildRule("api.example.com", buildPath("/foo", &pathTypePrefix, "svc-2")),
+				),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress creation with duplicate rules - when first-wins policy",
+			fields: fields{
+				duplicateRulePolicy: "first-wins",
+			},
+			args: args{
+				ing: buildIngress(
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-1")),
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-2")),
+				),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress update that introduces duplicate rules - when reject policy",
+			fields: fields{
+				duplicateRulePolicy: "reject",
+			},
+			args: args{
+				ing: buildIngress(
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-1")),
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-2")),
+				),
+				oldIng: buildIngress(
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-1")),
+				),
+			},
+			wantErr: errors.New("spec.rules[1].http.paths[0] has duplicate host and path with spec.rules[0].http.paths[0]: [host: app.example.com, path: /foo]"),
+		},
+		{
+			name: "ingress update with unchanged duplicate rules - when reject policy",
+			fields: fields{
+				duplicateRulePolicy: "reject",
+			},
+			args: args{
+				ing: buildIngress(
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-1")),
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-2")),
+				),
+				oldIng: buildIngress(
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-1")),
+					buildRule("app.example.com", buildPath("/foo", &pathTypePrefix, "svc-2")),
+				),
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &ingressValidator{
+				duplicateRulePolicy: tt.fields.duplicateRulePolicy,
+				logger:              &log.NullLogger{},
+			}
+			err := v.checkDuplicateRules(tt.args.ing, tt.args.oldIng)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}